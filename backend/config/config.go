@@ -76,6 +76,9 @@ type Config struct {
 	} `mapstructure:"meta_server"`
 	Dataplane struct {
 		Address string `mapstructure:"address"`
+		// ExtendedServiceNameRule allows the regex conditions, capture group templates and priorities in the service name rules,
+		// enable it only when the dataplane consumers of /servicename/listRule support them
+		ExtendedServiceNameRule bool `mapstructure:"extended_service_name_rule"`
 	} `mapstructure:"dataplane"`
 	Jaeger struct {
 		Address string `mapstructure:"address"`
@@ -116,6 +119,73 @@ type Config struct {
 		InitLookBackDays int `mapstructure:"init_look_back_days"`
 		RefreshSeconds   int `mapstructure:"refresh_seconds"`
 	} `mapstructure:"data_group"`
+	Audit struct {
+		Enable        bool `mapstructure:"enable"`
+		RetentionDays int  `mapstructure:"retention_days"`
+	} `mapstructure:"audit"`
+	Anomaly struct {
+		Enable             bool `mapstructure:"enable"`
+		HistoryDays        int  `mapstructure:"history_days"`
+		LearnIntervalHours int  `mapstructure:"learn_interval_hours"`
+	} `mapstructure:"anomaly"`
+	RecordingRule struct {
+		Enable         bool   `mapstructure:"enable"`
+		RuleFile       string `mapstructure:"rule_file"`
+		RefreshMinutes int    `mapstructure:"refresh_minutes"`
+	} `mapstructure:"recording_rule"`
+	LogTail struct {
+		PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+		BatchSize           int `mapstructure:"batch_size"`
+		IdleTimeoutSeconds  int `mapstructure:"idle_timeout_seconds"`
+		MaxSessionMinutes   int `mapstructure:"max_session_minutes"`
+	} `mapstructure:"log_tail"`
+	LogMetric struct {
+		Enable bool `mapstructure:"enable"`
+		// DelaySeconds is the ingestion delay of logs, the evaluation window ends at now - delay
+		DelaySeconds int `mapstructure:"delay_seconds"`
+	} `mapstructure:"log_metric"`
+	LogParseRule struct {
+		// VerifySeconds after pushing the Vector config, it is rolled back if no logs are written since then
+		VerifySeconds int `mapstructure:"verify_seconds"`
+	} `mapstructure:"log_parse_rule"`
+	LogExport struct {
+		// Dir stores the exported files, default is the log-export directory under the temp directory
+		Dir string `mapstructure:"dir"`
+		// MaxRows is the upper limit of the logs in one export
+		MaxRows int `mapstructure:"max_rows"`
+		// RetentionHours after that the exported files are removed
+		RetentionHours int `mapstructure:"retention_hours"`
+	} `mapstructure:"log_export"`
+	LogArchive struct {
+		Enable bool `mapstructure:"enable"`
+		// the S3-compatible object storage
+		Endpoint        string `mapstructure:"endpoint"`
+		Region          string `mapstructure:"region"`
+		Bucket          string `mapstructure:"bucket"`
+		AccessKeyID     string `mapstructure:"access_key_id"`
+		SecretAccessKey string `mapstructure:"secret_access_key"`
+		// ForcePathStyle is required by most self-hosted storages like MinIO
+		ForcePathStyle bool `mapstructure:"force_path_style"`
+	} `mapstructure:"log_archive"`
+	ChangeEvent struct {
+		// Watch records the changes of the workloads and configMaps in the kubernetes clusters
+		Watch         bool `mapstructure:"watch"`
+		RetentionDays int  `mapstructure:"retention_days"`
+		// WebhookToken is compared with the X-APO-Token header of the change webhook, the webhook is disabled if empty
+		WebhookToken string `mapstructure:"webhook_token"`
+	} `mapstructure:"change_event"`
+	TopologySnapshot struct {
+		Enable bool `mapstructure:"enable"`
+		// IntervalMinutes between the snapshots, each covers the topology of the interval, e.g. 60 for hourly
+		IntervalMinutes int `mapstructure:"interval_minutes"`
+		RetentionDays   int `mapstructure:"retention_days"`
+	} `mapstructure:"topology_snapshot"`
+	CustomTopology struct {
+		// ReconcileMinutes between the checks of the custom edges against the realtime topology
+		ReconcileMinutes int `mapstructure:"reconcile_minutes"`
+		// ExpiredRetentionDays keeps the expired edges for the history topology, 0 to keep them forever
+		ExpiredRetentionDays int `mapstructure:"expired_retention_days"`
+	} `mapstructure:"custom_topology"`
 }
 
 type AnonymousUser struct {
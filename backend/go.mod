@@ -7,6 +7,7 @@ toolchain go1.23.8
 require (
 	github.com/ClickHouse/clickhouse-go/v2 v2.34.0
 	github.com/CloudDetail/metadata v0.0.0-20240903055919-f0487c96aa95
+	github.com/aws/aws-sdk-go v1.55.7
 	github.com/dave/dst v0.27.3
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-logr/zapr v1.3.0
@@ -18,6 +19,7 @@ require (
 	github.com/magiconair/properties v1.8.7
 	github.com/mitchellh/mapstructure v1.5.0
 	github.com/mwitkow/go-conntrack v0.0.0-20190716064945-2f068394615f
+	github.com/parquet-go/parquet-go v0.25.1
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/alertmanager v0.28.1
 	github.com/prometheus/client_golang v1.20.5
@@ -81,7 +83,6 @@ require (
 	github.com/ClickHouse/ch-go v0.65.1 // indirect
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/andybalholm/brotli v1.1.1 // indirect
-	github.com/aws/aws-sdk-go v1.55.7 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/bytedance/sonic v1.9.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetAnomalyAlertRule Generate an alert rule based on the seasonal baseline.
+// @Summary Generate an alert rule based on the seasonal baseline.
+// @Description The rule can be saved by /api/alerts/rule/add.
+// @Tags API.anomaly
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param serviceName query string true "Service name"
+// @Param endpoint query string false "Endpoint, empty for the whole service"
+// @Param metric query string true "latency / error / throughput"
+// @Param threshold query number false "Threshold of anomaly score, default 3"
+// @Param weeks query int false "Weeks of history used as baseline, default 4"
+// @Success 200 {object} request.AlertRule
+// @Failure 400 {object} code.Failure
+// @Router /api/anomaly/rule [get]
+func (h *handler) GetAnomalyAlertRule() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetAnomalyAlertRuleRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.anomalyService.GetAnomalyAlertRule(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetAnomalyAlertRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
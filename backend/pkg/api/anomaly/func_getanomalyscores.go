@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetAnomalyScores Get anomaly scores of RED metrics compared with the seasonal baseline.
+// @Summary Get anomaly scores of RED metrics compared with the seasonal baseline.
+// @Description Get anomaly scores of RED metrics compared with the seasonal baseline.
+// @Tags API.anomaly
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param startTime query int64 true "Start time (microseconds)"
+// @Param endTime query int64 true "End time (microseconds)"
+// @Param serviceName query string false "Service name"
+// @Param endpoint query string false "Endpoint"
+// @Param serviceLevel query bool false "Return scores of the whole service"
+// @Success 200 {object} response.GetAnomalyScoresResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/anomaly/scores [get]
+func (h *handler) GetAnomalyScores() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetAnomalyScoresRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.anomalyService.GetAnomalyScores(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetAnomalyScoresError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
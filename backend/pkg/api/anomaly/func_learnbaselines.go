@@ -0,0 +1,42 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// LearnBaselines Relearn the seasonal baselines immediately.
+// @Summary Relearn the seasonal baselines immediately.
+// @Description Relearn the seasonal baselines immediately.
+// @Tags API.anomaly
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/anomaly/learn [post]
+func (h *handler) LearnBaselines() core.HandlerFunc {
+	return func(c core.Context) {
+		historyDays := config.Get().Anomaly.HistoryDays
+		if historyDays <= 0 {
+			historyDays = 28
+		}
+
+		err := h.anomalyService.LearnBaselines(c, time.Duration(historyDays)*24*time.Hour)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.LearnAnomalyBaselineError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
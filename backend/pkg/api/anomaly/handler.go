@@ -0,0 +1,41 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/anomaly"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// GetAnomalyScores Get anomaly scores of RED metrics compared with the seasonal baseline.
+	// @Tags API.anomaly
+	// @Router /api/anomaly/scores [get]
+	GetAnomalyScores() core.HandlerFunc
+
+	// GetAnomalyAlertRule Generate an alert rule based on the seasonal baseline.
+	// @Tags API.anomaly
+	// @Router /api/anomaly/rule [get]
+	GetAnomalyAlertRule() core.HandlerFunc
+
+	// LearnBaselines Relearn the seasonal baselines immediately.
+	// @Tags API.anomaly
+	// @Router /api/anomaly/learn [post]
+	LearnBaselines() core.HandlerFunc
+}
+
+type handler struct {
+	logger         *zap.Logger
+	anomalyService anomaly.Service
+}
+
+func New(logger *zap.Logger, promRepo prometheus.Repo, dbRepo database.Repo) Handler {
+	return &handler{
+		logger:         logger,
+		anomalyService: anomaly.New(logger, promRepo, dbRepo),
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetAuditLogs Get audit logs of configuration changes and sensitive reads.
+// @Summary Get audit logs of configuration changes and sensitive reads.
+// @Description Get audit logs of configuration changes and sensitive reads, the user must be granted the audit log feature.
+// @Tags API.audit
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param userId query int false "User id"
+// @Param username query string false "Username"
+// @Param method query string false "HTTP method"
+// @Param path query string false "API path"
+// @Param success query bool false "Whether the request succeeded"
+// @Param startTime query int64 false "Start time (microseconds)"
+// @Param endTime query int64 false "End time (microseconds)"
+// @Param currentPage query int false "Current page"
+// @Param pageSize query int false "The size of page"
+// @Success 200 {object} response.GetAuditLogResponse
+// @Failure 400 {object} code.Failure
+// @Failure 403 {object} code.Failure
+// @Router /api/audit/logs [get]
+func (h *handler) GetAuditLogs() core.HandlerFunc {
+	return func(c core.Context) {
+		can, err := h.permissionService.CheckFeaturePermission(c, c.UserID(), model.FEATURE_AUDIT_LOG)
+		if err != nil {
+			c.AbortWithError(http.StatusForbidden, code.AuthError, err)
+			return
+		}
+		if !can {
+			c.AbortWithError(http.StatusForbidden, code.AuditLogNoPermissionError, nil)
+			return
+		}
+
+		req := new(request.GetAuditLogRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if req.PageParam == nil {
+			req.PageParam = &request.PageParam{
+				CurrentPage: 1,
+				PageSize:    10,
+			}
+		}
+
+		resp, err := h.auditService.GetAuditLogs(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetAuditLogError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
+	"github.com/CloudDetail/apo/backend/pkg/services/permission"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// GetAuditLogs Get audit logs of configuration changes and sensitive reads, granted by the audit log feature.
+	// @Tags API.audit
+	// @Router /api/audit/logs [get]
+	GetAuditLogs() core.HandlerFunc
+}
+
+type handler struct {
+	logger            *zap.Logger
+	auditService      audit.Service
+	permissionService permission.Service
+}
+
+func New(logger *zap.Logger, dbRepo database.Repo) Handler {
+	return &handler{
+		logger:            logger,
+		auditService:      audit.New(logger, dbRepo),
+		permissionService: permission.New(dbRepo),
+	}
+}
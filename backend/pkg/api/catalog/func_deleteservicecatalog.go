@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteServiceCatalog Delete the catalog of a service.
+// @Summary Delete the catalog of a service.
+// @Description Delete the catalog of a service.
+// @Tags API.catalog
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.DeleteServiceCatalogRequest true "Request information"
+// @Success 200 {object} string
+// @Failure 400 {object} code.Failure
+// @Router /api/catalog/delete [post]
+func (h *handler) DeleteServiceCatalog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteServiceCatalogRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		err := h.catalogService.DeleteServiceCatalog(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteServiceCatalogError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetServiceCatalog Get the catalog of a service.
+// @Summary Get the catalog of a service.
+// @Description Get the catalog of a service, null if the service is not in the catalog.
+// @Tags API.catalog
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param service query string true "Service name"
+// @Success 200 {object} database.ServiceCatalog
+// @Failure 400 {object} code.Failure
+// @Router /api/catalog/service [get]
+func (h *handler) GetServiceCatalog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetServiceCatalogRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.catalogService.GetServiceCatalog(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetServiceCatalogError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
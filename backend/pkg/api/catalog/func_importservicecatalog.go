@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ImportServiceCatalog Import the service catalogs from Backstage catalog-info.yaml.
+// @Summary Import the service catalogs from Backstage catalog-info.yaml.
+// @Description Import the service catalogs from Backstage catalog-info.yaml.
+// @Tags API.catalog
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.ImportServiceCatalogRequest true "Request information"
+// @Success 200 {object} response.ImportServiceCatalogResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/catalog/import [post]
+func (h *handler) ImportServiceCatalog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ImportServiceCatalogRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.catalogService.ImportServiceCatalog(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ImportServiceCatalogError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
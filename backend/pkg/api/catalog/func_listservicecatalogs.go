@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListServiceCatalogs List the service catalogs.
+// @Summary List the service catalogs.
+// @Description List the service catalogs, filtered by the services and the owner team.
+// @Tags API.catalog
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param services query []string false "Service names" collectionFormat(multi)
+// @Param teamId query int64 false "Owner team id"
+// @Success 200 {object} response.ListServiceCatalogsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/catalog/list [get]
+func (h *handler) ListServiceCatalogs() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ListServiceCatalogsRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.catalogService.ListServiceCatalogs(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListServiceCatalogsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// SaveServiceCatalog Create or update the catalog of a service.
+// @Summary Create or update the catalog of a service.
+// @Description Create or update the catalog of a service.
+// @Tags API.catalog
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.SaveServiceCatalogRequest true "Request information"
+// @Success 200 {object} string
+// @Failure 400 {object} code.Failure
+// @Router /api/catalog/save [post]
+func (h *handler) SaveServiceCatalog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.SaveServiceCatalogRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		err := h.catalogService.SaveServiceCatalog(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.SaveServiceCatalogError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/services/catalog"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// SaveServiceCatalog Create or update the catalog of a service.
+	// @Tags API.catalog
+	// @Router /api/catalog/save [post]
+	SaveServiceCatalog() core.HandlerFunc
+
+	// DeleteServiceCatalog Delete the catalog of a service.
+	// @Tags API.catalog
+	// @Router /api/catalog/delete [post]
+	DeleteServiceCatalog() core.HandlerFunc
+
+	// GetServiceCatalog Get the catalog of a service.
+	// @Tags API.catalog
+	// @Router /api/catalog/service [get]
+	GetServiceCatalog() core.HandlerFunc
+
+	// ListServiceCatalogs List the service catalogs.
+	// @Tags API.catalog
+	// @Router /api/catalog/list [get]
+	ListServiceCatalogs() core.HandlerFunc
+
+	// ImportServiceCatalog Import the service catalogs from Backstage catalog-info.yaml.
+	// @Tags API.catalog
+	// @Router /api/catalog/import [post]
+	ImportServiceCatalog() core.HandlerFunc
+}
+
+type handler struct {
+	logger         *zap.Logger
+	catalogService catalog.Service
+}
+
+func New(logger *zap.Logger, dbRepo database.Repo) Handler {
+	return &handler{
+		logger:         logger,
+		catalogService: catalog.New(dbRepo),
+	}
+}
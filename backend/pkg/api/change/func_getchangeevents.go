@@ -0,0 +1,53 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetChangeEvents Get the changes of the service or in the namespace.
+// @Summary Get the changes of the service or in the namespace.
+// @Description Get the deployments, config changes, scaling and rollouts of the service or in the namespace, used to annotate the charts and alerts.
+// @Tags API.change
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param service query string false "Service name, required if namespace is empty"
+// @Param namespace query string false "Namespace"
+// @Param clusterId query string false "Cluster id"
+// @Param types query []string false "Change types: deploy, config, scale, rollout" collectionFormat(multi)
+// @Param startTime query int64 true "Start time (microseconds)"
+// @Param endTime query int64 true "End time (microseconds)"
+// @Success 200 {object} response.GetChangeEventsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/change/events [get]
+func (h *handler) GetChangeEvents() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetChangeEventsRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.changeService.GetChangeEvents(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetChangeEventsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ReportChangeEvent Report a change from the CI/CD systems.
+// @Summary Report a change from the CI/CD systems.
+// @Description Report a deployment or other change with the X-APO-Token header, the webhook is disabled if change_event.webhook_token is not set.
+// @Tags API.change
+// @Accept json
+// @Produce json
+// @Param X-APO-Token header string true "Webhook token"
+// @Param Request body request.ReportChangeEventRequest true "Request information"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/change/webhook [post]
+func (h *handler) ReportChangeEvent() core.HandlerFunc {
+	return func(c core.Context) {
+		token := config.Get().ChangeEvent.WebhookToken
+		if len(token) == 0 {
+			c.AbortWithError(
+				http.StatusForbidden,
+				code.ChangeWebhookDisabledError,
+				errors.New("change_event.webhook_token is not set"),
+			)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-APO-Token")), []byte(token)) != 1 {
+			c.AbortWithError(
+				http.StatusUnauthorized,
+				code.InValidToken,
+				errors.New("invalid webhook token"),
+			)
+			return
+		}
+
+		req := new(request.ReportChangeEventRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if err := h.changeService.ReportChangeEvent(c, req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ReportChangeEventError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
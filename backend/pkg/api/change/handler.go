@@ -0,0 +1,36 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/change"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// GetChangeEvents Get the changes of the service or in the namespace.
+	// @Tags API.change
+	// @Router /api/change/events [get]
+	GetChangeEvents() core.HandlerFunc
+	// ReportChangeEvent Report a change from the CI/CD systems.
+	// @Tags API.change
+	// @Router /api/change/webhook [post]
+	ReportChangeEvent() core.HandlerFunc
+}
+
+type handler struct {
+	logger        *zap.Logger
+	changeService change.Service
+}
+
+func New(logger *zap.Logger, dbRepo database.Repo, promRepo prometheus.Repo, k8sRepo kubernetes.Repo) Handler {
+	return &handler{
+		logger:        logger,
+		changeService: change.New(logger, dbRepo, promRepo, k8sRepo),
+	}
+}
@@ -22,6 +22,12 @@ import (
 // @Param leftType query string true "parent node type"
 // @Param rightNode query string true "child node name"
 // @Param rightType query string true "child node type"
+// @Param protocol query string false "protocol of the call, e.g. http"
+// @Param port query int false "port of the child node"
+// @Param description query string false "description"
+// @Param sourceSystem query string false "system declaring the edge, e.g. cmdb"
+// @Param startTime query int64 false "time to show the edge from, 0 means unlimited. Unit: microseconds"
+// @Param expireTime query int64 false "time to hide the edge after, 0 means unlimited. Unit: microseconds"
 // @Success 200 {object} string "ok"
 // @Failure 400 {object} code.Failure
 // @Router /api/dataplane/customtopology/create [post]
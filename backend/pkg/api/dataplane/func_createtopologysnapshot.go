@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateTopologySnapshot Take a topology snapshot of the time range.
+// @Summary Take a topology snapshot of the time range.
+// @Description Take a topology snapshot of the time range.
+// @Tags API.dataplane
+// @Accept application/json
+// @Produce json
+// @Param Request body request.CreateTopologySnapshotRequest true "request"
+// @Success 200 {object} response.TopologySnapshotSummary
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/topology/snapshot/create [post]
+func (h *handler) CreateTopologySnapshot() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateTopologySnapshotRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.CreateTopologySnapshot(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateTopologySnapshotError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DiffTopology Compare the topology snapshots of two points in time.
+// @Summary Compare the topology snapshots of two points in time.
+// @Description Compare the topology snapshots of two points in time.
+// @Tags API.dataplane
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param cluster query string false "cluster id"
+// @Param baseId query int64 false "base snapshot id"
+// @Param targetId query int64 false "target snapshot id"
+// @Param baseTime query int64 false "use the latest snapshot before the time if baseId is not set"
+// @Param targetTime query int64 false "use the latest snapshot before the time if targetId is not set"
+// @Param changeRatio query number false "minimum relative change of the calls or latency, default 0.5"
+// @Success 200 {object} response.DiffTopologyResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/topology/diff [get]
+func (h *handler) DiffTopology() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DiffTopologyRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.DiffTopology(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DiffTopologyError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
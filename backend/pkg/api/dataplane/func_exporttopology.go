@@ -0,0 +1,61 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+package dataplane
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ExportTopology Export service's topology.
+// @Summary Export service's topology.
+// @Description Export service's topology as Graphviz DOT, Mermaid or JSON Graph Format, annotated with RED metrics.
+// @Tags API.dataplane
+// @Accept application/x-www-form-urlencoded
+// @Produce plain
+// @Param startTime query int64 true "query start time"
+// @Param endTime query int64 true "query end time"
+// @Param cluster query string false "Query cluster name"
+// @Param groupId query int64 false "data group id"
+// @Param format query string true "dot, mermaid or jgf"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {file} file
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/topology/export [get]
+func (h *handler) ExportTopology() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ExportTopologyRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if allow, err := h.dataService.CheckGroupPermission(c, req.GroupID); !allow || err != nil {
+			c.AbortWithPermissionError(err, code.AuthError, nil)
+			return
+		}
+
+		resp, err := h.dataplaneService.ExportServiceTopology(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ExportTopologyError,
+				err,
+			)
+			return
+		}
+		c.SetHeader("Access-Control-Expose-Headers", "Content-Disposition,Content-Type")
+		c.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", resp.FileName))
+		c.SetHeader("Content-Type", resp.ContentType)
+		c.Payload(resp.Content)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetTopologySnapshot Get the nodes and edges of a topology snapshot.
+// @Summary Get the nodes and edges of a topology snapshot.
+// @Description Get the nodes and edges of a topology snapshot.
+// @Tags API.dataplane
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param id query int64 true "snapshot id"
+// @Success 200 {object} response.GetTopologySnapshotResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/topology/snapshot [get]
+func (h *handler) GetTopologySnapshot() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetTopologySnapshotRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.GetTopologySnapshot(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetTopologySnapshotError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ImportCustomTopology Import custom topology from csv or yaml.
+// @Summary Import custom topology from csv or yaml.
+// @Description Import the custom topology edges from csv or yaml, the existing edges of the same nodes are replaced.
+// @Tags API.dataplane
+// @Accept json
+// @Produce json
+// @Param Request body request.ImportCustomTopologyRequest true "Import Custom Topology Request"
+// @Success 200 {object} response.ImportCustomTopologyResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/customtopology/import [post]
+func (h *handler) ImportCustomTopology() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ImportCustomTopologyRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.ImportCustomTopology(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ImportCustomTopologyError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
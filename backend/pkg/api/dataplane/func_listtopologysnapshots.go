@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListTopologySnapshots List topology snapshots.
+// @Summary List topology snapshots.
+// @Description List topology snapshots.
+// @Tags API.dataplane
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param cluster query string false "cluster id"
+// @Param startTime query int64 true "query start time"
+// @Param endTime query int64 true "query end time"
+// @Success 200 {object} response.ListTopologySnapshotsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/topology/snapshots [get]
+func (h *handler) ListTopologySnapshots() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ListTopologySnapshotsRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.ListTopologySnapshots(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListTopologySnapshotsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// PreviewServiceNameRules Preview servicename rules against the current instances.
+// @Summary Preview servicename rules against the current instances.
+// @Description Apply the rules, or the saved rules if not given, to the instances reported by the dataplane, and show the instances renamed, merged or left unmatched.
+// @Tags API.dataplane
+// @Accept json
+// @Produce json
+// @Param Request body request.PreviewServiceNameRulesRequest true "Preview ServiceName Rules Request"
+// @Success 200 {object} response.PreviewServiceNameRulesResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/servicename/previewRules [post]
+func (h *handler) PreviewServiceNameRules() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.PreviewServiceNameRulesRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.PreviewServiceNameRules(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.PreviewServiceNameRulesError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ReconcileCustomTopology Reconcile custom topology with realtime topology.
+// @Summary Reconcile custom topology with realtime topology.
+// @Description Flag the custom topology edges found in the realtime topology of the window as redundant.
+// @Tags API.dataplane
+// @Accept json
+// @Produce json
+// @Param Request body request.ReconcileCustomTopologyRequest true "Reconcile Custom Topology Request"
+// @Success 200 {object} response.ReconcileCustomTopologyResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/dataplane/customtopology/reconcile [post]
+func (h *handler) ReconcileCustomTopology() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ReconcileCustomTopologyRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.dataplaneService.ReconcileCustomTopology(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ReconcileCustomTopologyError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/data"
 	"github.com/CloudDetail/apo/backend/pkg/services/dataplane"
 	"go.uber.org/zap"
 )
@@ -37,6 +39,10 @@ type Handler interface {
 	// @Tags API.dataplane
 	// @Router /api/dataplane/topology [get]
 	QueryTopology() core.HandlerFunc
+	// ExportTopology Export service's topology.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/topology/export [get]
+	ExportTopology() core.HandlerFunc
 	// CreateCustomTopology Create custom topology.
 	// @Tags API.dataplane
 	// @Router /api/dataplane/customtopology/create [post]
@@ -49,6 +55,14 @@ type Handler interface {
 	// @Tags API.dataplane
 	// @Router /api/dataplane/customtopology/delete [post]
 	DeleteCustomTopology() core.HandlerFunc
+	// ImportCustomTopology Import custom topology from csv or yaml.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/customtopology/import [post]
+	ImportCustomTopology() core.HandlerFunc
+	// ReconcileCustomTopology Reconcile custom topology with realtime topology.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/customtopology/reconcile [post]
+	ReconcileCustomTopology() core.HandlerFunc
 	// CheckServiceNameRule Check servicename rule.
 	// @Tags API.dataplane
 	// @Router /api/dataplane/servicename/checkRule [post]
@@ -65,6 +79,10 @@ type Handler interface {
 	// @Tags API.dataplane
 	// @Router /api/dataplane/servicename/deleteRule [post]
 	DeleteServiceNameRule() core.HandlerFunc
+	// PreviewServiceNameRules Preview servicename rules against the current instances.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/servicename/previewRules [post]
+	PreviewServiceNameRules() core.HandlerFunc
 
 	// QueryAPPInfoTags Get app info tags.
 	// @Tags API.dataplane
@@ -75,16 +93,35 @@ type Handler interface {
 	// @Tags API.dataplane
 	// @Router /api/dataplane/appinfo/tags/values [get]
 	QueryAPPInfoTagValues() core.HandlerFunc
+
+	// CreateTopologySnapshot Take a topology snapshot.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/topology/snapshot/create [post]
+	CreateTopologySnapshot() core.HandlerFunc
+	// ListTopologySnapshots List topology snapshots.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/topology/snapshots [get]
+	ListTopologySnapshots() core.HandlerFunc
+	// GetTopologySnapshot Get a topology snapshot.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/topology/snapshot [get]
+	GetTopologySnapshot() core.HandlerFunc
+	// DiffTopology Compare two topology snapshots.
+	// @Tags API.dataplane
+	// @Router /api/dataplane/topology/diff [get]
+	DiffTopology() core.HandlerFunc
 }
 
 type handler struct {
 	logger           *zap.Logger
 	dataplaneService dataplane.Service
+	dataService      data.Service
 }
 
-func New(logger *zap.Logger, chRepo clickhouse.Repo, promRepo prometheus.Repo, dbRepo database.Repo) Handler {
+func New(logger *zap.Logger, chRepo clickhouse.Repo, promRepo prometheus.Repo, dbRepo database.Repo, k8sRepo kubernetes.Repo) Handler {
 	return &handler{
 		logger:           logger,
-		dataplaneService: dataplane.New(chRepo, promRepo, dbRepo),
+		dataplaneService: dataplane.New(logger, chRepo, promRepo, dbRepo),
+		dataService:      data.New(dbRepo, promRepo, chRepo, k8sRepo),
 	}
 }
@@ -6,6 +6,7 @@ package integration
 import (
 	"github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/services/integration"
 )
 
@@ -62,8 +63,8 @@ type handler struct {
 	integrationService integration.Service
 }
 
-func New(database database.Repo) Handler {
+func New(database database.Repo, k8sRepo kubernetes.Repo) Handler {
 	return &handler{
-		integrationService: integration.New(database),
+		integrationService: integration.New(database, k8sRepo),
 	}
 }
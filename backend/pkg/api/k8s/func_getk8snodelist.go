@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// GetK8sNodeList get the conditions and resources of the nodes
+// @Summary get the conditions and resources of the nodes
+// @Description get the conditions and resources of the nodes
+// @Tags API.k8s
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param clusterId query string false "cluster id, the cluster where APO is deployed if empty"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetK8sNodeListResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/k8s/nodes [get]
+func (h *handler) GetK8sNodeList() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetK8sNodeListRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.k8sService.GetK8sNodeList(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.K8sGetResourceError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
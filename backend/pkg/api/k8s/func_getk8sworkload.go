@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// GetK8sWorkload get the replicas, rollout, revisions, HPA and pods of the workload
+// @Summary get the replicas, rollout, revisions, HPA and pods of the workload
+// @Description get the replicas, rollout, revisions, HPA and pods of the workload
+// @Tags API.k8s
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param clusterId query string false "cluster id, the cluster where APO is deployed if empty"
+// @Param namespace query string true "namespace name"
+// @Param kind query string true "Deployment, StatefulSet or DaemonSet"
+// @Param name query string true "workload name"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetK8sWorkloadResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/k8s/workload [get]
+func (h *handler) GetK8sWorkload() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetK8sWorkloadRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.k8sService.GetK8sWorkload(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.K8sGetResourceError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -18,6 +18,7 @@ import (
 // @Tags API.k8s
 // @Accept application/x-www-form-urlencoded
 // @Produce json
+// @Param clusterId query string false "cluster id, the cluster where APO is deployed if empty"
 // @Param namespace query string true "namespace name"
 // @Success 200 {object} string
 // @Failure 400 {object} code.Failure
@@ -6,6 +6,8 @@ package k8s
 import (
 	"net/http"
 
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+
 	"github.com/CloudDetail/apo/backend/pkg/code"
 	"github.com/CloudDetail/apo/backend/pkg/core"
 )
@@ -16,13 +18,23 @@ import (
 // @Tags API.k8s
 // @Accept application/x-www-form-urlencoded
 // @Produce json
+// @Param clusterId query string false "cluster id, the cluster where APO is deployed if empty"
 // @Success 200 {object} string
 // @Failure 400 {object} code.Failure
 // @Router /api/k8s/namespaces [get]
 func (h *handler) GetNamespaceList() core.HandlerFunc {
 	return func(c core.Context) {
+		req := new(request.GetNamespaceListRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
 
-		resp, err := h.k8sService.GetNamespaceList(c)
+		resp, err := h.k8sService.GetNamespaceList(c, req)
 		if err != nil {
 			c.AbortWithError(
 				http.StatusBadRequest,
@@ -0,0 +1,51 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// GetServiceWorkloads get the workloads and nodes running the service
+// @Summary get the workloads and nodes running the service
+// @Description get the workloads and nodes running the service
+// @Tags API.k8s
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param service query string true "service name"
+// @Param startTime query int64 true "query start time"
+// @Param endTime query int64 true "query end time"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetServiceWorkloadsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/k8s/service/workloads [get]
+func (h *handler) GetServiceWorkloads() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetServiceWorkloadsRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.k8sService.GetServiceWorkloads(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.K8sGetResourceError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
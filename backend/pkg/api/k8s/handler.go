@@ -6,6 +6,7 @@ package k8s
 import (
 	"github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	"github.com/CloudDetail/apo/backend/pkg/services/k8s"
 )
 
@@ -26,14 +27,26 @@ type Handler interface {
 	// @Tags API.k8s
 	// @Router /api/k8s/pod/info [get]
 	GetPodInfo() core.HandlerFunc
+	// GetK8sWorkload get the replicas, rollout, revisions, HPA and pods of the workload
+	// @Tags API.k8s
+	// @Router /api/k8s/workload [get]
+	GetK8sWorkload() core.HandlerFunc
+	// GetK8sNodeList get the conditions and resources of the nodes
+	// @Tags API.k8s
+	// @Router /api/k8s/nodes [get]
+	GetK8sNodeList() core.HandlerFunc
+	// GetServiceWorkloads get the workloads and nodes running the service
+	// @Tags API.k8s
+	// @Router /api/k8s/service/workloads [get]
+	GetServiceWorkloads() core.HandlerFunc
 }
 
 type handler struct {
 	k8sService k8s.Service
 }
 
-func New(k8sRepo kubernetes.Repo) Handler {
+func New(k8sRepo kubernetes.Repo, promRepo prometheus.Repo) Handler {
 	return &handler{
-		k8sService: k8s.New(k8sRepo),
+		k8sService: k8s.New(k8sRepo, promRepo),
 	}
 }
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CorrelateLog get the trace, span, RED chart, k8s events and alerts related to a log
+// @Summary get the data related to a log
+// @Description The trace, pod and container are read from the columns of the log row, or the trace id in the content.
+// @Description The span is found by the span id, or by the pod and container at the time of the log.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.CorrelateLogRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.CorrelateLogResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/correlate [post]
+func (h *handler) CorrelateLog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CorrelateLogRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.CorrelateLog(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CorrelateLogError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateLogArchiveJob create a log archive job
+// @Summary create a log archive job
+// @Description Archive the partitions of the log table about to expire under TTL into the object storage on schedule.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.CreateLogArchiveJobRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/create [post]
+func (h *handler) CreateLogArchiveJob() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateLogArchiveJobRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.exportService.CreateLogArchiveJob(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateLogArchiveJobError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
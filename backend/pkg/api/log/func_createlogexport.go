@@ -0,0 +1,56 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateLogExport create a log export
+// @Summary create a log export
+// @Description Export the logs matching the query into a file in the background, the progress is returned by /api/log/export/progress.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.CreateLogExportRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} database.LogExportJob
+// @Failure 400 {object} code.Failure
+// @Router /api/log/export/create [post]
+func (h *handler) CreateLogExport() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateLogExportRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
+		}
+		if req.TimeField == "" {
+			req.TimeField = "timestamp"
+		}
+		if req.LogField == "" {
+			req.LogField = "content"
+		}
+		resp, err := h.exportService.CreateLogExport(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateLogExportError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateLogSavedQuery save a log query
+// @Summary save a log query
+// @Description Save a named log query owned by the current user, it can be shared with a team the user belongs to or a data group the user has permission of.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.CreateLogSavedQueryRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} database.LogSavedQuery
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/saved/create [post]
+func (h *handler) CreateLogSavedQuery() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateLogSavedQueryRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
+		}
+		if req.TimeField == "" {
+			req.TimeField = "timestamp"
+		}
+		if req.LogField == "" {
+			req.LogField = "content"
+		}
+		resp, err := h.logService.CreateLogSavedQuery(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateLogSavedQueryError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
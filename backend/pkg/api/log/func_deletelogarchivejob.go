@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogArchiveJob delete a log archive job
+// @Summary delete a log archive job
+// @Description Delete the log archive job, the archived objects are kept.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.LogArchiveJobRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/delete [post]
+func (h *handler) DeleteLogArchiveJob() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogArchiveJobRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.exportService.DeleteLogArchiveJob(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogArchiveJobError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
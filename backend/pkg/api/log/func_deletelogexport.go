@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogExport delete a log export
+// @Summary delete a log export
+// @Description Stop the log export if it is running and remove the exported file.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.LogExportRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/export/delete [post]
+func (h *handler) DeleteLogExport() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogExportRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.exportService.DeleteLogExport(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogExportError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogQueryHistory delete the recent log queries
+// @Summary delete the recent log queries
+// @Description Delete a query in the history of the current user, the whole history is cleared if id is 0.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.DeleteLogQueryHistoryRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/history/delete [post]
+func (h *handler) DeleteLogQueryHistory() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteLogQueryHistoryRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logService.DeleteLogQueryHistory(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogQueryHistoryError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogSavedQuery delete a saved log query
+// @Summary delete a saved log query
+// @Description Delete the saved log query, only the owner can delete it.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.LogSavedQueryRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/saved/delete [post]
+func (h *handler) DeleteLogSavedQuery() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogSavedQueryRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logService.DeleteLogSavedQuery(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogSavedQueryError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
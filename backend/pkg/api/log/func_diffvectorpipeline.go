@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DiffVectorPipeline diff the versions of the Vector pipeline
+// @Summary diff the versions of the Vector pipeline
+// @Description List the components added, removed or modified between two versions, or between a version and the current config.
+// @Tags API.log
+// @Produce json
+// @Param from query int64 true "version compared from"
+// @Param to query int64 false "version compared to, the current config if not set"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.DiffVectorPipelineResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline/diff [get]
+func (h *handler) DiffVectorPipeline() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DiffVectorPipelineRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.DiffVectorPipeline(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DiffVectorPipelineError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
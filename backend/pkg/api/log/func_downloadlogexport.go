@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DownloadLogExport download the exported logs
+// @Summary download the exported logs
+// @Description Download the file of a finished log export.
+// @Tags API.log
+// @Produce application/octet-stream
+// @Param id query int64 true "id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {file} file
+// @Failure 400 {object} code.Failure
+// @Router /api/log/export/download [get]
+func (h *handler) DownloadLogExport() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogExportRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		path, fileName, err := h.exportService.GetLogExportFile(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DownloadLogExportError,
+				err,
+			)
+			return
+		}
+		c.FileAttachment(path, fileName)
+	}
+}
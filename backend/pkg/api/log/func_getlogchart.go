@@ -33,8 +33,8 @@ func (h *handler) GetLogChart() core.HandlerFunc {
 			)
 			return
 		}
-		if req.Query == "" {
-			req.Query = "(1='1')"
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
 		}
 		if req.TimeField == "" {
 			req.TimeField = "timestamp"
@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetLogExport get the progress of a log export
+// @Summary get the progress of a log export
+// @Description Get the log export with its status and the number of exported logs.
+// @Tags API.log
+// @Produce json
+// @Param id query int64 true "id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} database.LogExportJob
+// @Failure 400 {object} code.Failure
+// @Router /api/log/export/progress [get]
+func (h *handler) GetLogExport() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogExportRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.exportService.GetLogExport(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetLogExportError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetLogPatterns cluster logs into templates
+// @Summary cluster logs into templates
+// @Description Cluster the content of the latest logs into templates, and mark the new or spiking ones compared with the base window.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.LogPatternRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.LogPatternResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pattern [post]
+func (h *handler) GetLogPatterns() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogPatternRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
+		}
+		if req.TimeField == "" {
+			req.TimeField = "timestamp"
+		}
+		if req.LogField == "" {
+			req.LogField = "content"
+		}
+		resp, err := h.logService.GetLogPatterns(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetLogPatternsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetLogSavedQuery get a saved log query
+// @Summary get a saved log query
+// @Description Get the saved log query owned by or shared with the current user.
+// @Tags API.log
+// @Produce json
+// @Param id query int64 true "id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.LogSavedQuery
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/saved/get [get]
+func (h *handler) GetLogSavedQuery() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogSavedQueryRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.GetLogSavedQuery(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetLogSavedQueryError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// GetVectorPipeline get the Vector pipeline
+// @Summary get the Vector pipeline
+// @Description Get the sources, transforms and sinks of the Vector aggregator and the version of them.
+// @Tags API.log
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetVectorPipelineResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline [get]
+func (h *handler) GetVectorPipeline() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.logService.GetVectorPipeline(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetVectorPipelineError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetVectorPipelineVersion get a version of the Vector pipeline
+// @Summary get a version of the Vector pipeline
+// @Description Get a version of the Vector pipeline pushed by APO.
+// @Tags API.log
+// @Produce json
+// @Param version query int64 true "version"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetVectorPipelineResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline/version [get]
+func (h *handler) GetVectorPipelineVersion() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetVectorPipelineVersionRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.GetVectorPipelineVersion(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetVectorPipelineError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
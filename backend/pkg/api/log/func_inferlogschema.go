@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// InferLogSchema infer the fields of structured logs
+// @Summary infer the fields of structured logs
+// @Description Propose the column types, nested fields, Map columns, materialized columns and skip indexes from the sample JSON logs or the latest logs in raw_logs.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.InferLogSchemaRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.InferLogSchemaResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/schema/infer [post]
+func (h *handler) InferLogSchema() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.InferLogSchemaRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.InferLogSchema(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.InferLogSchemaError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListLogArchiveJobs list the log archive jobs
+// @Summary list the log archive jobs
+// @Description List the log archive jobs with the results of their last runs.
+// @Tags API.log
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogArchiveJobsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/list [get]
+func (h *handler) ListLogArchiveJobs() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.exportService.ListLogArchiveJobs(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListLogArchiveJobsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
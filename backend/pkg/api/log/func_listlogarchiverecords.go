@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListLogArchiveRecords list the archived partitions
+// @Summary list the archived partitions
+// @Description List the partitions archived by the job, newest first.
+// @Tags API.log
+// @Produce json
+// @Param id query int64 true "id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogArchiveRecordsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/records [get]
+func (h *handler) ListLogArchiveRecords() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogArchiveJobRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.exportService.ListLogArchiveRecords(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListLogArchiveRecordsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
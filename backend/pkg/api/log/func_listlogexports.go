@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListLogExports list the log exports
+// @Summary list the log exports
+// @Description List the log exports of the current user, newest first.
+// @Tags API.log
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogExportsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/export/list [get]
+func (h *handler) ListLogExports() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.exportService.ListLogExports(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListLogExportsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
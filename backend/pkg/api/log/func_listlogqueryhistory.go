@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListLogQueryHistory list the recent log queries
+// @Summary list the recent log queries
+// @Description List the log queries recently executed by the current user, latest first.
+// @Tags API.log
+// @Produce json
+// @Param limit query int false "limit, default is 20"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogQueryHistoryResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/history [get]
+func (h *handler) ListLogQueryHistory() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ListLogQueryHistoryRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.ListLogQueryHistory(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetLogQueryHistoryError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
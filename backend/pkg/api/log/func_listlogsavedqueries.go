@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListLogSavedQueries list the saved log queries
+// @Summary list the saved log queries
+// @Description List the saved log queries owned by the current user or shared with the user through a team or data group, latest updated first.
+// @Tags API.log
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogSavedQueriesResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/saved/list [get]
+func (h *handler) ListLogSavedQueries() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.logService.ListLogSavedQueries(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetLogSavedQueryError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListVectorPipelineVersions list the versions of the Vector pipeline
+// @Summary list the versions of the Vector pipeline
+// @Description List the versions of the Vector pipeline, the latest first.
+// @Tags API.log
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListVectorPipelineVersionsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline/versions [get]
+func (h *handler) ListVectorPipelineVersions() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.logService.ListVectorPipelineVersions(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListVectorPipelineVersionsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
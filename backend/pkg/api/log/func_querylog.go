@@ -39,8 +39,8 @@ func (h *handler) QueryLog() core.HandlerFunc {
 		if req.PageSize == 0 {
 			req.PageSize = 10
 		}
-		if req.Query == "" {
-			req.Query = "(1='1')"
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
 		}
 		if req.TimeField == "" {
 			req.TimeField = "timestamp"
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// RollbackVectorPipeline roll back the Vector pipeline
+// @Summary roll back the Vector pipeline
+// @Description Push a previous version of the Vector pipeline as a new version.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.RollbackVectorPipelineRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline/rollback [post]
+func (h *handler) RollbackVectorPipeline() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.RollbackVectorPipelineRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logService.RollbackVectorPipeline(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.RollbackVectorPipelineError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// RunLogArchiveJob run a log archive job
+// @Summary run a log archive job
+// @Description Run the log archive job in the background now regardless of its schedule.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.LogArchiveJobRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/run [post]
+func (h *handler) RunLogArchiveJob() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogArchiveJobRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.exportService.RunLogArchiveJob(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.RunLogArchiveJobError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"go.uber.org/zap"
+)
+
+// TailLog stream new logs over Server-Sent Events
+// @Summary stream new logs over Server-Sent Events
+// @Description Push new logs matching the query as "logs" events, "heartbeat" is sent when there are no new logs for a while.
+// @Description The stream ends with an "end" event after the idle timeout or the maximum session length, or an "error" event.
+// @Tags API.log
+// @Produce text/event-stream
+// @Param dataBase query string true "database"
+// @Param tableName query string true "Table"
+// @Param query query string false "log query"
+// @Param rawSql query bool false "query is a SQL condition"
+// @Param timeField query string false "time field"
+// @Param logField query string false "log field"
+// @Param startTime query int64 false "push logs after startTime in microseconds, default is now"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.LogTailResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/tail [get]
+func (h *handler) TailLog() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.LogTailRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
+		}
+		if req.TimeField == "" {
+			req.TimeField = "timestamp"
+		}
+		if req.LogField == "" {
+			req.LogField = "content"
+		}
+		// the response is streamed, so errors are sent as events
+		if err := h.logService.TailLog(c, req, c.SSEvent); err != nil {
+			if errors.Is(err, context.Canceled) {
+				// client is gone
+				return
+			}
+			failure := &code.Failure{Code: code.TailLogError, Message: c.ErrMessage(code.TailLogError)}
+			var vErr core.BusinessError
+			if errors.As(err, &vErr) {
+				failure.Code, failure.Message = vErr.BusinessCode(), vErr.Message()
+			}
+			h.logger.Error("tail log failed", zap.Error(err))
+			_ = c.SSEvent(response.LogTailEventError, failure)
+		}
+	}
+}
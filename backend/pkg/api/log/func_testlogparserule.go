@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// TestLogParseRule test log parsing rules against sample logs
+// @Summary test log parsing rules against sample logs
+// @Description Validate the parse rule, run its regex or the structured field extraction against the samples or the latest logs in raw_logs, and return the extracted fields and type errors.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.TestLogParseRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.TestLogParseResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/rule/test [post]
+func (h *handler) TestLogParseRule() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.TestLogParseRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.TestLogParseRule(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.TestLogParseRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateLogArchiveJob update a log archive job
+// @Summary update a log archive job
+// @Description Update the settings of the log archive job.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateLogArchiveJobRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/archive/update [post]
+func (h *handler) UpdateLogArchiveJob() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateLogArchiveJobRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.exportService.UpdateLogArchiveJob(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateLogArchiveJobError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
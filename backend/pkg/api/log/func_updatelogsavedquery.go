@@ -0,0 +1,56 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateLogSavedQuery update a saved log query
+// @Summary update a saved log query
+// @Description Update the saved log query, only the owner can update it.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateLogSavedQueryRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/query/saved/update [post]
+func (h *handler) UpdateLogSavedQuery() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateLogSavedQueryRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		if !h.checkRawSQL(c, req.RawSQL) {
+			return
+		}
+		if req.TimeField == "" {
+			req.TimeField = "timestamp"
+		}
+		if req.LogField == "" {
+			req.LogField = "content"
+		}
+		err := h.logService.UpdateLogSavedQuery(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateLogSavedQueryError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
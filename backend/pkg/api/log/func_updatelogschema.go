@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateLogSchema update the fields of log table
+// @Summary update the fields of log table
+// @Description Alter the log table (the local and distributed tables on cluster) and recreate its view. With dryRun the DDL is returned without executing.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateLogSchemaRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.UpdateLogSchemaResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/log/schema/update [post]
+func (h *handler) UpdateLogSchema() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateLogSchemaRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logService.UpdateLogSchema(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateLogSchemaError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
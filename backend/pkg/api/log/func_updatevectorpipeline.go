@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateVectorPipeline update the Vector pipeline
+// @Summary update the Vector pipeline
+// @Description Validate the pipeline and push it to Vector as a new version.
+// @Tags API.log
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateVectorPipelineRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/log/pipeline/update [post]
+func (h *handler) UpdateVectorPipeline() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateVectorPipelineRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logService.UpdateVectorPipeline(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateVectorPipelineError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -4,7 +4,11 @@
 package log
 
 import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
 	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
@@ -12,6 +16,8 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	"github.com/CloudDetail/apo/backend/pkg/services/data"
 	"github.com/CloudDetail/apo/backend/pkg/services/log"
+	"github.com/CloudDetail/apo/backend/pkg/services/logexport"
+	"github.com/CloudDetail/apo/backend/pkg/services/permission"
 	"go.uber.org/zap"
 )
 
@@ -31,6 +37,41 @@ type Handler interface {
 	// @Router /api/log/query [post]
 	QueryLog() core.HandlerFunc
 
+	// CreateLogSavedQuery save a log query
+	// @Tags API.log
+	// @Router /api/log/query/saved/create [post]
+	CreateLogSavedQuery() core.HandlerFunc
+
+	// UpdateLogSavedQuery update a saved log query
+	// @Tags API.log
+	// @Router /api/log/query/saved/update [post]
+	UpdateLogSavedQuery() core.HandlerFunc
+
+	// DeleteLogSavedQuery delete a saved log query
+	// @Tags API.log
+	// @Router /api/log/query/saved/delete [post]
+	DeleteLogSavedQuery() core.HandlerFunc
+
+	// GetLogSavedQuery get a saved log query
+	// @Tags API.log
+	// @Router /api/log/query/saved/get [get]
+	GetLogSavedQuery() core.HandlerFunc
+
+	// ListLogSavedQueries list the saved log queries
+	// @Tags API.log
+	// @Router /api/log/query/saved/list [get]
+	ListLogSavedQueries() core.HandlerFunc
+
+	// ListLogQueryHistory list the recent log queries
+	// @Tags API.log
+	// @Router /api/log/query/history [get]
+	ListLogQueryHistory() core.HandlerFunc
+
+	// DeleteLogQueryHistory delete the recent log queries
+	// @Tags API.log
+	// @Router /api/log/query/history/delete [post]
+	DeleteLogQueryHistory() core.HandlerFunc
+
 	// GetLogChart get the log trend chart
 	// @Tags API.log
 	// @Router /api/log/chart [post]
@@ -41,6 +82,16 @@ type Handler interface {
 	// @Router /api/log/index [post]
 	GetLogIndex() core.HandlerFunc
 
+	// GetLogPatterns cluster logs into templates
+	// @Tags API.log
+	// @Router /api/log/pattern [post]
+	GetLogPatterns() core.HandlerFunc
+
+	// TailLog stream new logs over Server-Sent Events
+	// @Tags API.log
+	// @Router /api/log/tail [get]
+	TailLog() core.HandlerFunc
+
 	// GetLogTableInfo get log table information
 	// @Tags API.log
 	// @Router /api/log/table get
@@ -71,6 +122,96 @@ type Handler interface {
 	// @Router /api/log/rule/delete [delete]
 	DeleteLogParseRule() core.HandlerFunc
 
+	// TestLogParseRule test log parsing rules against sample logs
+	// @Tags API.log
+	// @Router /api/log/rule/test [post]
+	TestLogParseRule() core.HandlerFunc
+
+	// GetVectorPipeline get the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline [get]
+	GetVectorPipeline() core.HandlerFunc
+
+	// GetVectorPipelineVersion get a version of the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline/version [get]
+	GetVectorPipelineVersion() core.HandlerFunc
+
+	// UpdateVectorPipeline update the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline/update [post]
+	UpdateVectorPipeline() core.HandlerFunc
+
+	// ListVectorPipelineVersions list the versions of the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline/versions [get]
+	ListVectorPipelineVersions() core.HandlerFunc
+
+	// DiffVectorPipeline diff the versions of the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline/diff [get]
+	DiffVectorPipeline() core.HandlerFunc
+
+	// RollbackVectorPipeline roll back the Vector pipeline
+	// @Tags API.log
+	// @Router /api/log/pipeline/rollback [post]
+	RollbackVectorPipeline() core.HandlerFunc
+
+	// CreateLogExport create a log export
+	// @Tags API.log
+	// @Router /api/log/export/create [post]
+	CreateLogExport() core.HandlerFunc
+
+	// ListLogExports list the log exports
+	// @Tags API.log
+	// @Router /api/log/export/list [get]
+	ListLogExports() core.HandlerFunc
+
+	// GetLogExport get the progress of a log export
+	// @Tags API.log
+	// @Router /api/log/export/progress [get]
+	GetLogExport() core.HandlerFunc
+
+	// DownloadLogExport download the exported logs
+	// @Tags API.log
+	// @Router /api/log/export/download [get]
+	DownloadLogExport() core.HandlerFunc
+
+	// DeleteLogExport delete a log export
+	// @Tags API.log
+	// @Router /api/log/export/delete [post]
+	DeleteLogExport() core.HandlerFunc
+
+	// CreateLogArchiveJob create a log archive job
+	// @Tags API.log
+	// @Router /api/log/archive/create [post]
+	CreateLogArchiveJob() core.HandlerFunc
+
+	// UpdateLogArchiveJob update a log archive job
+	// @Tags API.log
+	// @Router /api/log/archive/update [post]
+	UpdateLogArchiveJob() core.HandlerFunc
+
+	// DeleteLogArchiveJob delete a log archive job
+	// @Tags API.log
+	// @Router /api/log/archive/delete [post]
+	DeleteLogArchiveJob() core.HandlerFunc
+
+	// ListLogArchiveJobs list the log archive jobs
+	// @Tags API.log
+	// @Router /api/log/archive/list [get]
+	ListLogArchiveJobs() core.HandlerFunc
+
+	// ListLogArchiveRecords list the archived partitions
+	// @Tags API.log
+	// @Router /api/log/archive/records [get]
+	ListLogArchiveRecords() core.HandlerFunc
+
+	// RunLogArchiveJob run a log archive job
+	// @Tags API.log
+	// @Router /api/log/archive/run [post]
+	RunLogArchiveJob() core.HandlerFunc
+
 	// OtherTable get the external log table
 	// @Tags API.log
 	// @Router /api/log/other get
@@ -95,16 +236,33 @@ type Handler interface {
 	// @Tags API.log
 	// @Router /api/log/context [post]
 	QueryLogContext() core.HandlerFunc
+
+	// InferLogSchema infer the fields of structured logs
+	// @Tags API.log
+	// @Router /api/log/schema/infer [post]
+	InferLogSchema() core.HandlerFunc
+
+	// UpdateLogSchema update the fields of log table
+	// @Tags API.log
+	// @Router /api/log/schema/update [post]
+	UpdateLogSchema() core.HandlerFunc
+
+	// CorrelateLog get the trace, span, RED chart, k8s events and alerts related to a log
+	// @Tags API.log
+	// @Router /api/log/correlate [post]
+	CorrelateLog() core.HandlerFunc
 }
 
 type handler struct {
-	logger      *zap.Logger
-	logService  log.Service
-	dataService data.Service
+	logger            *zap.Logger
+	logService        log.Service
+	exportService     logexport.Service
+	dataService       data.Service
+	permissionService permission.Service
 }
 
 func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo, promRepo prometheus.Repo) Handler {
-	logservice := log.New(chRepo, dbRepo, k8sApi, promRepo)
+	logservice := log.New(logger, chRepo, dbRepo, k8sApi, promRepo)
 	req := &request.LogTableRequest{}
 	req.FillerValue()
 
@@ -113,8 +271,27 @@ func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, k8sAp
 		logger.Error("create default log table failed", zap.Error(err))
 	}
 	return &handler{
-		logger:      logger,
-		logService:  logservice,
-		dataService: data.New(dbRepo, promRepo, chRepo, k8sApi),
+		logger:            logger,
+		logService:        logservice,
+		exportService:     logexport.New(logger, chRepo, dbRepo),
+		dataService:       data.New(dbRepo, promRepo, chRepo, k8sApi),
+		permissionService: permission.New(dbRepo),
+	}
+}
+
+// checkRawSQL aborts the request if the query is raw SQL and the user is not granted to use it.
+func (h *handler) checkRawSQL(c core.Context, rawSQL bool) bool {
+	if !rawSQL {
+		return true
+	}
+	can, err := h.permissionService.CheckFeaturePermission(c, c.UserID(), model.FEATURE_LOG_RAW_SQL)
+	if err != nil {
+		c.AbortWithError(http.StatusForbidden, code.AuthError, err)
+		return false
+	}
+	if !can {
+		c.AbortWithError(http.StatusForbidden, code.LogRawSQLNoPermissionError, nil)
+		return false
 	}
+	return true
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateLogAlertRule Create a log alert rule.
+// @Summary Create a log alert rule.
+// @Description Create an alert rule on a log metric, the alert events are sent to the alert source APO_LOG_ALERT.
+// @Tags API.logmetric
+// @Accept json
+// @Produce json
+// @Param Request body request.CreateLogAlertRuleRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/rule/create [post]
+func (h *handler) CreateLogAlertRule() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateLogAlertRuleRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logMetricService.CreateLogAlertRule(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateLogAlertRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
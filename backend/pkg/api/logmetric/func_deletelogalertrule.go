@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogAlertRule Delete a log alert rule.
+// @Summary Delete a log alert rule.
+// @Description Delete a log alert rule, its firing alerts are resolved.
+// @Tags API.logmetric
+// @Accept json
+// @Produce json
+// @Param Request body request.DeleteLogAlertRuleRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/rule/delete [post]
+func (h *handler) DeleteLogAlertRule() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteLogAlertRuleRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logMetricService.DeleteLogAlertRule(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogAlertRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteLogMetric Delete a log metric and its alert rules.
+// @Summary Delete a log metric and its alert rules.
+// @Description Delete a log metric and its alert rules.
+// @Tags API.logmetric
+// @Accept json
+// @Produce json
+// @Param Request body request.DeleteLogMetricRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/delete [post]
+func (h *handler) DeleteLogMetric() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteLogMetricRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logMetricService.DeleteLogMetric(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteLogMetricError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
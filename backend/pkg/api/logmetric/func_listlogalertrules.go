@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListLogAlertRules List log alert rules.
+// @Summary List log alert rules.
+// @Description List the alert rules of a log metric, or all of them if metricId is not set.
+// @Tags API.logmetric
+// @Produce json
+// @Param metricId query int64 false "id of the log metric"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogAlertRulesResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/rule/list [get]
+func (h *handler) ListLogAlertRules() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ListLogAlertRulesRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		resp, err := h.logMetricService.ListLogAlertRules(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListLogAlertRulesError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
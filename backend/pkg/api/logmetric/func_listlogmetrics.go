@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListLogMetrics List log metrics.
+// @Summary List log metrics.
+// @Description List the definitions of the log metrics.
+// @Tags API.logmetric
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListLogMetricsResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/list [get]
+func (h *handler) ListLogMetrics() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.logMetricService.ListLogMetrics(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListLogMetricsError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
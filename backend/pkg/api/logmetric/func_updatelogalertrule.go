@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateLogAlertRule Update a log alert rule.
+// @Summary Update a log alert rule.
+// @Description Update a log alert rule.
+// @Tags API.logmetric
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateLogAlertRuleRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/rule/update [post]
+func (h *handler) UpdateLogAlertRule() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateLogAlertRuleRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logMetricService.UpdateLogAlertRule(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateLogAlertRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateLogMetric Update a log metric.
+// @Summary Update a log metric.
+// @Description Update a log metric, it is evaluated once to validate the table, fields and query.
+// @Tags API.logmetric
+// @Accept json
+// @Produce json
+// @Param Request body request.UpdateLogMetricRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/logmetric/update [post]
+func (h *handler) UpdateLogMetric() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateLogMetricRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+		err := h.logMetricService.UpdateLogMetric(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateLogMetricError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/dify"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	alertinput "github.com/CloudDetail/apo/backend/pkg/services/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/services/logmetric"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// ListLogMetrics List log metrics.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/list [get]
+	ListLogMetrics() core.HandlerFunc
+
+	// CreateLogMetric Create a log metric.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/create [post]
+	CreateLogMetric() core.HandlerFunc
+
+	// UpdateLogMetric Update a log metric.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/update [post]
+	UpdateLogMetric() core.HandlerFunc
+
+	// DeleteLogMetric Delete a log metric and its alert rules.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/delete [post]
+	DeleteLogMetric() core.HandlerFunc
+
+	// ListLogAlertRules List log alert rules.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/rule/list [get]
+	ListLogAlertRules() core.HandlerFunc
+
+	// CreateLogAlertRule Create a log alert rule.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/rule/create [post]
+	CreateLogAlertRule() core.HandlerFunc
+
+	// UpdateLogAlertRule Update a log alert rule.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/rule/update [post]
+	UpdateLogAlertRule() core.HandlerFunc
+
+	// DeleteLogAlertRule Delete a log alert rule.
+	// @Tags API.logmetric
+	// @Router /api/logmetric/rule/delete [post]
+	DeleteLogAlertRule() core.HandlerFunc
+}
+
+type handler struct {
+	logger           *zap.Logger
+	logMetricService logmetric.Service
+}
+
+func New(logger *zap.Logger, chRepo clickhouse.Repo, promRepo prometheus.Repo, dbRepo database.Repo, difyRepo dify.DifyRepo) Handler {
+	return &handler{
+		logger:           logger,
+		logMetricService: logmetric.New(logger, chRepo, dbRepo, alertinput.New(promRepo, dbRepo, chRepo, difyRepo)),
+	}
+}
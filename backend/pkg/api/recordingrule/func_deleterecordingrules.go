@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// DeleteRecordingRules Delete recording rules from vmalert.
+// @Summary Delete recording rules from vmalert.
+// @Description Remove the recording rules from the vmalert rule configmap and stop rewriting queries.
+// @Tags API.recordingrule
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/recordingrule/delete [post]
+func (h *handler) DeleteRecordingRules() core.HandlerFunc {
+	return func(c core.Context) {
+		err := h.recordingRuleService.DeleteRecordingRules(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteRecordingRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// DeployRecordingRules Deploy recording rules to vmalert.
+// @Summary Deploy recording rules to vmalert.
+// @Description Write the recording rules into the vmalert rule configmap, queries are rewritten to use the recorded series once they exist.
+// @Tags API.recordingrule
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/recordingrule/deploy [post]
+func (h *handler) DeployRecordingRules() core.HandlerFunc {
+	return func(c core.Context) {
+		err := h.recordingRuleService.DeployRecordingRules(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeployRecordingRuleError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// ListRecordingRules List recording rules of the common PQL templates and their status.
+// @Summary List recording rules of the common PQL templates and their status.
+// @Description List recording rules of the common PQL templates and their status.
+// @Tags API.recordingrule
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ListRecordingRulesResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/recordingrule/list [get]
+func (h *handler) ListRecordingRules() core.HandlerFunc {
+	return func(c core.Context) {
+		resp, err := h.recordingRuleService.ListRecordingRules(c)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListRecordingRulesError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/recordingrule"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// ListRecordingRules List recording rules of the common PQL templates and their status.
+	// @Tags API.recordingrule
+	// @Router /api/recordingrule/list [get]
+	ListRecordingRules() core.HandlerFunc
+
+	// DeployRecordingRules Deploy recording rules to vmalert.
+	// @Tags API.recordingrule
+	// @Router /api/recordingrule/deploy [post]
+	DeployRecordingRules() core.HandlerFunc
+
+	// DeleteRecordingRules Delete recording rules from vmalert.
+	// @Tags API.recordingrule
+	// @Router /api/recordingrule/delete [post]
+	DeleteRecordingRules() core.HandlerFunc
+}
+
+type handler struct {
+	logger               *zap.Logger
+	recordingRuleService recordingrule.Service
+}
+
+func New(logger *zap.Logger, promRepo prometheus.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo) Handler {
+	return &handler{
+		logger:               logger,
+		recordingRuleService: recordingrule.New(logger, promRepo, dbRepo, k8sApi),
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DetectCycles detect the call cycles in the dependency graph
+// @Summary detect the call cycles in the dependency graph
+// @Description detect the call cycles in the dependency graph
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param startTime query uint64 true "query start time"
+// @Param endTime query uint64 true "query end time"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.DetectCyclesResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/graph/cycles [get]
+func (h *handler) DetectCycles() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetDependencyGraphRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.serviceInfoService.DetectCycles(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DetectCyclesError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
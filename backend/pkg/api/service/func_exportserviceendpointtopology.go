@@ -0,0 +1,64 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ExportServiceEndpointTopology export the upstream and downstream topology of a service
+// @Summary export the upstream and downstream topology of the service
+// @Description export the topology as Graphviz DOT, Mermaid or JSON Graph Format, annotated with RED metrics
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce plain
+// @Param startTime query uint64 true "query start time"
+// @Param endTime query uint64 true "query end time"
+// @Param service query string true "Query service name"
+// @Param endpoint query string true "Query Endpoint"
+// @Param entryService query string false "Ingress service name"
+// @Param entryEndpoint query string false "entry Endpoint"
+// @Param groupId query int64 false "data group id"
+// @Param format query string true "dot, mermaid or jgf"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {file} file
+// @Failure 400 {object} code.Failure
+// @Router /api/service/topology/export [get]
+func (h *handler) ExportServiceEndpointTopology() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ExportServiceEndpointTopologyRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if allow, err := h.dataService.CheckGroupPermission(c, req.GroupID); !allow || err != nil {
+			c.AbortWithPermissionError(err, code.AuthError, nil)
+			return
+		}
+
+		resp, err := h.serviceInfoService.ExportServiceEndpointTopology(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ExportTopologyError,
+				err,
+			)
+			return
+		}
+		c.SetHeader("Access-Control-Expose-Headers", "Content-Disposition,Content-Type")
+		c.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", resp.FileName))
+		c.SetHeader("Content-Type", resp.ContentType)
+		c.Payload(resp.Content)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetBlastRadius get the services and entry endpoints depending on a service or peer transitively
+// @Summary get the services and entry endpoints depending on a service or peer transitively
+// @Description get the services and entry endpoints depending on a service or peer transitively
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param startTime query uint64 true "query start time"
+// @Param endTime query uint64 true "query end time"
+// @Param service query string true "service name or db, mq and external peer"
+// @Param endpoint query string false "limit the entry endpoints to the ones calling the endpoint"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetBlastRadiusResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/graph/blastRadius [get]
+func (h *handler) GetBlastRadius() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetBlastRadiusRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.serviceInfoService.GetBlastRadius(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetBlastRadiusError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
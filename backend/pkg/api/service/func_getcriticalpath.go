@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetCriticalPath get the latency contribution of the nodes on the critical path of an entry endpoint
+// @Summary get the latency contribution of the nodes on the critical path of an entry endpoint
+// @Description get the latency contribution of the nodes on the critical path of an entry endpoint
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param startTime query uint64 true "query start time"
+// @Param endTime query uint64 true "query end time"
+// @Param service query string true "entry service name"
+// @Param endpoint query string true "entry Endpoint"
+// @Param clusterIds query []string false "cluster ids" collectionFormat(multi)
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetCriticalPathResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/graph/criticalPath [get]
+func (h *handler) GetCriticalPath() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetCriticalPathRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.serviceInfoService.GetCriticalPath(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetCriticalPathError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
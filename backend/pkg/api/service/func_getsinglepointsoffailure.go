@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetSinglePointsOfFailure get the nodes whose failure splits the dependency graph
+// @Summary get the nodes whose failure splits the dependency graph
+// @Description get the nodes whose failure splits the dependency graph
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param startTime query uint64 true "query start time"
+// @Param endTime query uint64 true "query end time"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetSinglePointsOfFailureResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/graph/spof [get]
+func (h *handler) GetSinglePointsOfFailure() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetDependencyGraphRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.serviceInfoService.GetSinglePointsOfFailure(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetSinglePointsOfFailureError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
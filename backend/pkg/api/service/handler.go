@@ -28,6 +28,31 @@ type Handler interface {
 	// @Router /api/service/topology [post]
 	GetServiceEndpointTopology() core.HandlerFunc
 
+	// ExportServiceEndpointTopology export the upstream and downstream topology of a service
+	// @Tags API.service
+	// @Router /api/service/topology/export [get]
+	ExportServiceEndpointTopology() core.HandlerFunc
+
+	// GetBlastRadius get the services and entry endpoints depending on a service or peer
+	// @Tags API.service
+	// @Router /api/service/graph/blastRadius [get]
+	GetBlastRadius() core.HandlerFunc
+
+	// GetCriticalPath get the latency contribution on the critical path of an entry endpoint
+	// @Tags API.service
+	// @Router /api/service/graph/criticalPath [get]
+	GetCriticalPath() core.HandlerFunc
+
+	// DetectCycles detect the call cycles in the dependency graph
+	// @Tags API.service
+	// @Router /api/service/graph/cycles [get]
+	DetectCycles() core.HandlerFunc
+
+	// GetSinglePointsOfFailure get the single points of failure in the dependency graph
+	// @Tags API.service
+	// @Router /api/service/graph/spof [get]
+	GetSinglePointsOfFailure() core.HandlerFunc
+
 	// GetDescendantMetrics get the delay curve data of all downstream services
 	// @Tags API.service
 	// @Router /api/service/descendant/metrics [post]
@@ -0,0 +1,56 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ExplainRYGLight show the contribution of each signal to the traffic light of a service
+// @Summary show the contribution of each signal to the traffic light of a service
+// @Description show the contribution of each signal to the traffic light of a service
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param startTime query int64 true "query start time"
+// @Param endTime query int64 true "query end time"
+// @Param serviceName query string true "Service name"
+// @Param groupId query int64 false "Data group id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.ExplainRYGLightResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/ryglight/explain [get]
+func (h *handler) ExplainRYGLight() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ExplainRygLightRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if allowed, err := h.dataService.CheckGroupPermission(c, req.GroupID); !allowed || err != nil {
+			c.AbortWithPermissionError(err, code.AuthError, nil)
+			return
+		}
+
+		resp, err := h.serviceoverview.ExplainRYGLight(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ExplainRYGLightError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetHealthScoreModel get the scoring model of traffic light of the data group
+// @Summary get the scoring model of traffic light of the data group
+// @Description the built-in model is returned if the data group has no model
+// @Tags API.service
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param groupId query int64 false "Data group id"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} response.GetHealthScoreModelResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/service/healthModel [get]
+func (h *handler) GetHealthScoreModel() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetHealthScoreModelRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.serviceoverview.GetHealthScoreModel(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetHealthScoreModelError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
+
+// SetHealthScoreModel set the scoring model of traffic light of the data group
+// @Summary set the scoring model of traffic light of the data group
+// @Description set the scoring model of traffic light of the data group
+// @Tags API.service
+// @Accept application/json
+// @Produce json
+// @Param Request body request.SetHealthScoreModelRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/service/healthModel/set [post]
+func (h *handler) SetHealthScoreModel() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.SetHealthScoreModelRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if allowed, err := h.dataService.CheckGroupPermission(c, req.GroupID); !allowed || err != nil {
+			c.AbortWithPermissionError(err, code.AuthError, nil)
+			return
+		}
+
+		err := h.serviceoverview.SetHealthScoreModel(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.SetHealthScoreModelError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
+
+// DeleteHealthScoreModel delete the scoring model of the data group, the default model is used then
+// @Summary delete the scoring model of the data group
+// @Description delete the scoring model of the data group, the default model is used then
+// @Tags API.service
+// @Accept application/json
+// @Produce json
+// @Param Request body request.DeleteHealthScoreModelRequest true "Request information"
+// @Param Authorization header string false "Bearer accessToken"
+// @Success 200 {object} string "ok"
+// @Failure 400 {object} code.Failure
+// @Router /api/service/healthModel/delete [post]
+func (h *handler) DeleteHealthScoreModel() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteHealthScoreModelRequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		if allowed, err := h.dataService.CheckGroupPermission(c, req.GroupID); !allowed || err != nil {
+			c.AbortWithPermissionError(err, code.AuthError, nil)
+			return
+		}
+
+		err := h.serviceoverview.DeleteHealthScoreModel(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteHealthScoreModelError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -41,6 +41,26 @@ type Handler interface {
 	// @Router /api/service/ryglight [get]
 	GetRYGLight() core.HandlerFunc
 
+	// ExplainRYGLight show the contribution of each signal to the traffic light of a service
+	// @Tags API.service
+	// @Router /api/service/ryglight/explain [get]
+	ExplainRYGLight() core.HandlerFunc
+
+	// GetHealthScoreModel get the scoring model of traffic light of the data group
+	// @Tags API.service
+	// @Router /api/service/healthModel [get]
+	GetHealthScoreModel() core.HandlerFunc
+
+	// SetHealthScoreModel set the scoring model of traffic light of the data group
+	// @Tags API.service
+	// @Router /api/service/healthModel/set [post]
+	SetHealthScoreModel() core.HandlerFunc
+
+	// DeleteHealthScoreModel delete the scoring model of the data group, the default model is used then
+	// @Tags API.service
+	// @Router /api/service/healthModel/delete [post]
+	DeleteHealthScoreModel() core.HandlerFunc
+
 	// GetMonitorStatus get the service status monitored by kuma
 	// @Tags API.service
 	// @Router /api/service/monitor/status [get]
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// CreateSLO Create a SLO of the service endpoint.
+// @Summary Create a SLO of the service endpoint.
+// @Description Create a SLO of the service endpoint.
+// @Tags API.slo
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.CreateSLORequest true "Request information"
+// @Success 200 {object} string
+// @Failure 400 {object} code.Failure
+// @Router /api/slo/create [post]
+func (h *handler) CreateSLO() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.CreateSLORequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		err := h.sloService.CreateSLO(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.CreateSLOError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// DeleteSLO Delete a SLO.
+// @Summary Delete a SLO.
+// @Description Delete a SLO.
+// @Tags API.slo
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.DeleteSLORequest true "Request information"
+// @Success 200 {object} string
+// @Failure 400 {object} code.Failure
+// @Router /api/slo/delete [post]
+func (h *handler) DeleteSLO() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.DeleteSLORequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		err := h.sloService.DeleteSLO(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.DeleteSLOError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
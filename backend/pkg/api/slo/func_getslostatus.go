@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// GetSLOStatus Get error budget and burn rates of a SLO.
+// @Summary Get error budget and burn rates of a SLO.
+// @Description Get error budget and burn rates of a SLO.
+// @Tags API.slo
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param id query int64 true "SLO id"
+// @Success 200 {object} response.GetSLOStatusResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/slo/status [get]
+func (h *handler) GetSLOStatus() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.GetSLOStatusRequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.sloService.GetSLOStatus(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.GetSLOStatusError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
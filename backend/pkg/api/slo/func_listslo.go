@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ListSLO List SLOs.
+// @Summary List SLOs.
+// @Description List SLOs.
+// @Tags API.slo
+// @Accept application/x-www-form-urlencoded
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param serviceName query string false "Service name"
+// @Param endpoint query string false "Endpoint"
+// @Success 200 {object} response.ListSLOResponse
+// @Failure 400 {object} code.Failure
+// @Router /api/slo/list [get]
+func (h *handler) ListSLO() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.ListSLORequest)
+		if err := c.ShouldBindQuery(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		resp, err := h.sloService.ListSLO(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ListSLOError,
+				err,
+			)
+			return
+		}
+		c.Payload(resp)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"net/http"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// UpdateSLO Update a SLO.
+// @Summary Update a SLO.
+// @Description Update a SLO.
+// @Tags API.slo
+// @Accept application/json
+// @Produce json
+// @Param Authorization header string false "Bearer accessToken"
+// @Param Request body request.UpdateSLORequest true "Request information"
+// @Success 200 {object} string
+// @Failure 400 {object} code.Failure
+// @Router /api/slo/update [post]
+func (h *handler) UpdateSLO() core.HandlerFunc {
+	return func(c core.Context) {
+		req := new(request.UpdateSLORequest)
+		if err := c.ShouldBindJSON(req); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.ParamBindError,
+				err,
+			)
+			return
+		}
+
+		err := h.sloService.UpdateSLO(c, req)
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				code.UpdateSLOError,
+				err,
+			)
+			return
+		}
+		c.Payload("ok")
+	}
+}
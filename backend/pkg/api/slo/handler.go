@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/slo"
+	"go.uber.org/zap"
+)
+
+type Handler interface {
+	// CreateSLO Create a SLO of the service endpoint.
+	// @Tags API.slo
+	// @Router /api/slo/create [post]
+	CreateSLO() core.HandlerFunc
+
+	// UpdateSLO Update a SLO.
+	// @Tags API.slo
+	// @Router /api/slo/update [post]
+	UpdateSLO() core.HandlerFunc
+
+	// DeleteSLO Delete a SLO.
+	// @Tags API.slo
+	// @Router /api/slo/delete [post]
+	DeleteSLO() core.HandlerFunc
+
+	// ListSLO List SLOs.
+	// @Tags API.slo
+	// @Router /api/slo/list [get]
+	ListSLO() core.HandlerFunc
+
+	// GetSLOStatus Get error budget and burn rates of a SLO.
+	// @Tags API.slo
+	// @Router /api/slo/status [get]
+	GetSLOStatus() core.HandlerFunc
+}
+
+type handler struct {
+	logger     *zap.Logger
+	sloService slo.Service
+}
+
+func New(logger *zap.Logger, promRepo prometheus.Repo, dbRepo database.Repo, k8sRepo kubernetes.Repo) Handler {
+	return &handler{
+		logger:     logger,
+		sloService: slo.New(promRepo, dbRepo, k8sRepo),
+	}
+}
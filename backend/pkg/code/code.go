@@ -235,6 +235,7 @@ const (
 	GetIntegrationInstallConfigFileFailed = "B1401"
 
 	GetClusterIntegrationFailed = "B1402"
+	K8sCredentialIllegal        = "B1403"
 
 	GetAlertEventListError     = "B1501"
 	GetAlertEventClassifyError = "B1502"
@@ -260,6 +261,131 @@ const (
 	ServiceNameRuleNotExistsError = "B1808"
 	QueryAPPInfoTagsError         = "B1809"
 	QueryAPPInfoValuesError       = "B1810"
+	PreviewServiceNameRulesError  = "B1811"
+	ServiceNameRuleConflictError  = "B1812"
+	ServiceNameRuleIllegalError   = "B1813"
+	ImportCustomTopologyError     = "B1814"
+	ReconcileCustomTopologyError  = "B1815"
+	CustomTopologyIllegalError    = "B1816"
+
+	// Audit
+	GetAuditLogError          = "B1901"
+	AuditLogNoPermissionError = "B1902"
+
+	// SLO
+	CreateSLOError    = "B2001"
+	UpdateSLOError    = "B2002"
+	DeleteSLOError    = "B2003"
+	ListSLOError      = "B2004"
+	GetSLOStatusError = "B2005"
+	SLOIllegalError   = "B2006"
+	SLONotExistError  = "B2007"
+
+	// Anomaly
+	GetAnomalyScoresError     = "B2101"
+	GetAnomalyAlertRuleError  = "B2102"
+	AnomalyMetricIllegalError = "B2103"
+	LearnAnomalyBaselineError = "B2104"
+
+	// Health score model
+	GetHealthScoreModelError     = "B2201"
+	SetHealthScoreModelError     = "B2202"
+	DeleteHealthScoreModelError  = "B2203"
+	HealthScoreModelIllegalError = "B2204"
+	ExplainRYGLightError         = "B2205"
+	RYGLightServiceNotFoundError = "B2206"
+
+	// Recording rule
+	ListRecordingRulesError  = "B2301"
+	DeployRecordingRuleError = "B2302"
+	DeleteRecordingRuleError = "B2303"
+
+	// Log query
+	LogQuerySyntaxError        = "B2401"
+	LogRawSQLNoPermissionError = "B2402"
+	TailLogError               = "B2403"
+	GetLogPatternsError        = "B2404"
+	TestLogParseRuleError      = "B2405"
+	LogParseRuleIllegalError   = "B2406"
+
+	// Vector pipeline
+	GetVectorPipelineError             = "B2407"
+	UpdateVectorPipelineError          = "B2408"
+	ListVectorPipelineVersionsError    = "B2409"
+	DiffVectorPipelineError            = "B2410"
+	RollbackVectorPipelineError        = "B2411"
+	VectorPipelineIllegalError         = "B2412"
+	VectorPipelineVersionNotExistError = "B2413"
+
+	// Log export and archive
+	CreateLogExportError       = "B2414"
+	ListLogExportsError        = "B2415"
+	GetLogExportError          = "B2416"
+	DownloadLogExportError     = "B2417"
+	DeleteLogExportError       = "B2418"
+	LogExportNotExistError     = "B2419"
+	LogExportNotReadyError     = "B2420"
+	CreateLogArchiveJobError   = "B2421"
+	UpdateLogArchiveJobError   = "B2422"
+	DeleteLogArchiveJobError   = "B2423"
+	ListLogArchiveJobsError    = "B2424"
+	ListLogArchiveRecordsError = "B2425"
+	RunLogArchiveJobError      = "B2426"
+	LogArchiveJobIllegalError  = "B2427"
+	LogArchiveJobNotExistError = "B2428"
+	CorrelateLogError          = "B2429"
+	InferLogSchemaError        = "B2430"
+	UpdateLogSchemaError       = "B2431"
+	LogSchemaIllegalError      = "B2432"
+	CreateLogSavedQueryError   = "B2433"
+	UpdateLogSavedQueryError   = "B2434"
+	DeleteLogSavedQueryError   = "B2435"
+	GetLogSavedQueryError      = "B2436"
+	LogSavedQueryIllegalError  = "B2437"
+	LogSavedQueryNotExistError = "B2438"
+	LogSavedQueryNotOwnerError = "B2439"
+	GetLogQueryHistoryError    = "B2440"
+	DeleteLogQueryHistoryError = "B2441"
+
+	// Log metric
+	CreateLogMetricError      = "B2501"
+	UpdateLogMetricError      = "B2502"
+	DeleteLogMetricError      = "B2503"
+	ListLogMetricsError       = "B2504"
+	LogMetricIllegalError     = "B2505"
+	LogMetricNotExistError    = "B2506"
+	CreateLogAlertRuleError   = "B2507"
+	UpdateLogAlertRuleError   = "B2508"
+	DeleteLogAlertRuleError   = "B2509"
+	ListLogAlertRulesError    = "B2510"
+	LogAlertRuleIllegalError  = "B2511"
+	LogAlertRuleNotExistError = "B2512"
+
+	// Change event
+	GetChangeEventsError       = "B2601"
+	ReportChangeEventError     = "B2602"
+	ChangeWebhookDisabledError = "B2603"
+
+	// Topology snapshot
+	CreateTopologySnapshotError = "B2701"
+	ListTopologySnapshotsError  = "B2702"
+	GetTopologySnapshotError    = "B2703"
+	DiffTopologyError           = "B2704"
+	TopologySnapshotNotExist    = "B2705"
+	ExportTopologyError         = "B2706"
+
+	// Dependency graph analysis
+	GetBlastRadiusError           = "B2801"
+	GetCriticalPathError          = "B2802"
+	DetectCyclesError             = "B2803"
+	GetSinglePointsOfFailureError = "B2804"
+
+	// Service catalog
+	SaveServiceCatalogError   = "B2901"
+	DeleteServiceCatalogError = "B2902"
+	GetServiceCatalogError    = "B2903"
+	ListServiceCatalogsError  = "B2904"
+	ImportServiceCatalogError = "B2905"
 )
 
 func Text(lang string, code string) string {
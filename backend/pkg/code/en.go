@@ -204,6 +204,7 @@ var enText = map[string]string{
 	GetIntegrationInstallDocFailed:        "Get integration install doc failed",
 	GetIntegrationInstallConfigFileFailed: "Get integration install config file failed",
 	GetClusterIntegrationFailed:           "Get cluster integration failed",
+	K8sCredentialIllegal:                  "Kubernetes credential of the cluster is illegal",
 
 	GetAlertEventListError:     "Failed to get alert event list",
 	GetAlertEventClassifyError: "Failed to classify alert event list",
@@ -227,4 +228,116 @@ var enText = map[string]string{
 	ServiceNameRuleNotExistsError: "service name rule not exists",
 	QueryAPPInfoTagsError:         "Failed to query APP info tags",
 	QueryAPPInfoValuesError:       "Failed to query APP info values",
+	PreviewServiceNameRulesError:  "Failed to preview service name rules",
+	ServiceNameRuleConflictError:  "Service name rule conflicts with another rule",
+	ServiceNameRuleIllegalError:   "Service name rule is illegal",
+	ImportCustomTopologyError:     "Failed to import custom topology",
+	ReconcileCustomTopologyError:  "Failed to reconcile custom topology",
+	CustomTopologyIllegalError:    "Custom topology is illegal",
+
+	GetAuditLogError:          "Failed to get audit log",
+	AuditLogNoPermissionError: "No permission to read audit logs",
+
+	CreateSLOError:    "Failed to create SLO",
+	UpdateSLOError:    "Failed to update SLO",
+	DeleteSLOError:    "Failed to delete SLO",
+	ListSLOError:      "Failed to list SLO",
+	GetSLOStatusError: "Failed to get SLO status",
+	SLOIllegalError:   "Illegal SLO definition",
+	SLONotExistError:  "SLO does not exist",
+
+	GetAnomalyScoresError:     "Failed to get anomaly scores",
+	GetAnomalyAlertRuleError:  "Failed to generate anomaly alert rule",
+	AnomalyMetricIllegalError: "Unsupported metric for anomaly detection",
+	LearnAnomalyBaselineError: "Failed to learn anomaly baseline",
+
+	GetHealthScoreModelError:     "Failed to get health score model",
+	SetHealthScoreModelError:     "Failed to set health score model",
+	DeleteHealthScoreModelError:  "Failed to delete health score model",
+	HealthScoreModelIllegalError: "Illegal health score model",
+	ExplainRYGLightError:         "Failed to explain service health",
+	RYGLightServiceNotFoundError: "No data of the service in the time range",
+
+	ListRecordingRulesError:  "Failed to list recording rules",
+	DeployRecordingRuleError: "Failed to deploy recording rules",
+	DeleteRecordingRuleError: "Failed to delete recording rules",
+
+	LogQuerySyntaxError:        "Illegal log query",
+	LogRawSQLNoPermissionError: "No permission to query logs with SQL",
+	TailLogError:               "Failed to tail logs",
+	GetLogPatternsError:        "Failed to get log patterns",
+	TestLogParseRuleError:      "Failed to test log parse rule",
+	LogParseRuleIllegalError:   "Illegal log parse rule",
+
+	GetVectorPipelineError:             "Failed to get Vector pipeline",
+	UpdateVectorPipelineError:          "Failed to update Vector pipeline",
+	ListVectorPipelineVersionsError:    "Failed to list Vector pipeline versions",
+	DiffVectorPipelineError:            "Failed to diff Vector pipeline versions",
+	RollbackVectorPipelineError:        "Failed to roll back Vector pipeline",
+	VectorPipelineIllegalError:         "Illegal Vector pipeline",
+	VectorPipelineVersionNotExistError: "Vector pipeline version not exists",
+
+	CreateLogExportError:       "Failed to create log export",
+	ListLogExportsError:        "Failed to list log exports",
+	GetLogExportError:          "Failed to get log export",
+	DownloadLogExportError:     "Failed to download exported logs",
+	DeleteLogExportError:       "Failed to delete log export",
+	LogExportNotExistError:     "Log export not exists",
+	LogExportNotReadyError:     "Log export is not finished",
+	CreateLogArchiveJobError:   "Failed to create log archive job",
+	UpdateLogArchiveJobError:   "Failed to update log archive job",
+	DeleteLogArchiveJobError:   "Failed to delete log archive job",
+	ListLogArchiveJobsError:    "Failed to list log archive jobs",
+	ListLogArchiveRecordsError: "Failed to list archived log partitions",
+	RunLogArchiveJobError:      "Failed to run log archive job",
+	LogArchiveJobIllegalError:  "Illegal log archive job",
+	LogArchiveJobNotExistError: "Log archive job not exists",
+	CorrelateLogError:          "Failed to correlate log",
+	InferLogSchemaError:        "Failed to infer log table schema",
+	UpdateLogSchemaError:       "Failed to update log table schema",
+	LogSchemaIllegalError:      "Illegal log table schema",
+	CreateLogSavedQueryError:   "Failed to save log query",
+	UpdateLogSavedQueryError:   "Failed to update saved log query",
+	DeleteLogSavedQueryError:   "Failed to delete saved log query",
+	GetLogSavedQueryError:      "Failed to get saved log queries",
+	LogSavedQueryIllegalError:  "Illegal saved log query",
+	LogSavedQueryNotExistError: "Saved log query does not exist",
+	LogSavedQueryNotOwnerError: "Only the owner can modify the saved log query",
+	GetLogQueryHistoryError:    "Failed to get log query history",
+	DeleteLogQueryHistoryError: "Failed to delete log query history",
+
+	CreateLogMetricError:      "Failed to create log metric",
+	UpdateLogMetricError:      "Failed to update log metric",
+	DeleteLogMetricError:      "Failed to delete log metric",
+	ListLogMetricsError:       "Failed to list log metrics",
+	LogMetricIllegalError:     "Illegal log metric",
+	LogMetricNotExistError:    "Log metric not exists",
+	CreateLogAlertRuleError:   "Failed to create log alert rule",
+	UpdateLogAlertRuleError:   "Failed to update log alert rule",
+	DeleteLogAlertRuleError:   "Failed to delete log alert rule",
+	ListLogAlertRulesError:    "Failed to list log alert rules",
+	LogAlertRuleIllegalError:  "Illegal log alert rule",
+	LogAlertRuleNotExistError: "Log alert rule not exists",
+
+	GetChangeEventsError:       "Failed to get change events",
+	ReportChangeEventError:     "Failed to report change event",
+	ChangeWebhookDisabledError: "Change webhook is disabled, change_event.webhook_token is not set",
+
+	CreateTopologySnapshotError: "Failed to create topology snapshot",
+	ListTopologySnapshotsError:  "Failed to list topology snapshots",
+	GetTopologySnapshotError:    "Failed to get topology snapshot",
+	DiffTopologyError:           "Failed to compare topology snapshots",
+	TopologySnapshotNotExist:    "Topology snapshot not exists",
+	ExportTopologyError:         "Failed to export topology",
+
+	GetBlastRadiusError:           "Failed to get blast radius",
+	GetCriticalPathError:          "Failed to get critical path",
+	DetectCyclesError:             "Failed to detect call cycles",
+	GetSinglePointsOfFailureError: "Failed to get single points of failure",
+
+	SaveServiceCatalogError:   "Failed to save service catalog",
+	DeleteServiceCatalogError: "Failed to delete service catalog",
+	GetServiceCatalogError:    "Failed to get service catalog",
+	ListServiceCatalogsError:  "Failed to list service catalogs",
+	ImportServiceCatalogError: "Failed to import service catalog",
 }
@@ -206,6 +206,7 @@ var zhCnText = map[string]string{
 	GetIntegrationInstallDocFailed:        "获取集群集成安装文档失败",
 	GetIntegrationInstallConfigFileFailed: "获取集群集成安装配置文件失败",
 	GetClusterIntegrationFailed:           "获取集群集成失败",
+	K8sCredentialIllegal:                  "集群的Kubernetes凭证不合法",
 
 	GetAlertEventListError:     "获取告警事件列表失败",
 	GetAlertEventClassifyError: "告警事件分类失败",
@@ -229,4 +230,116 @@ var zhCnText = map[string]string{
 	ServiceNameRuleNotExistsError: "服务名匹配规则不存在",
 	QueryAPPInfoTagsError:         "查询APP信息标签失败",
 	QueryAPPInfoValuesError:       "查询APP信息值失败",
+	PreviewServiceNameRulesError:  "预览服务名匹配规则失败",
+	ServiceNameRuleConflictError:  "服务名匹配规则与其他规则冲突",
+	ServiceNameRuleIllegalError:   "服务名匹配规则不合法",
+	ImportCustomTopologyError:     "导入自定义拓扑失败",
+	ReconcileCustomTopologyError:  "校对自定义拓扑失败",
+	CustomTopologyIllegalError:    "自定义拓扑不合法",
+
+	GetAuditLogError:          "获取审计日志失败",
+	AuditLogNoPermissionError: "没有查看审计日志的权限",
+
+	CreateSLOError:    "创建SLO失败",
+	UpdateSLOError:    "更新SLO失败",
+	DeleteSLOError:    "删除SLO失败",
+	ListSLOError:      "查询SLO列表失败",
+	GetSLOStatusError: "查询SLO状态失败",
+	SLOIllegalError:   "SLO定义不合法",
+	SLONotExistError:  "SLO不存在",
+
+	GetAnomalyScoresError:     "查询异常分数失败",
+	GetAnomalyAlertRuleError:  "生成异常检测告警规则失败",
+	AnomalyMetricIllegalError: "异常检测不支持该指标",
+	LearnAnomalyBaselineError: "学习异常检测基线失败",
+
+	GetHealthScoreModelError:     "查询健康评分模型失败",
+	SetHealthScoreModelError:     "设置健康评分模型失败",
+	DeleteHealthScoreModelError:  "删除健康评分模型失败",
+	HealthScoreModelIllegalError: "健康评分模型不合法",
+	ExplainRYGLightError:         "解释服务健康状态失败",
+	RYGLightServiceNotFoundError: "时间范围内未查询到该服务数据",
+
+	ListRecordingRulesError:  "查询记录规则失败",
+	DeployRecordingRuleError: "部署记录规则失败",
+	DeleteRecordingRuleError: "删除记录规则失败",
+
+	LogQuerySyntaxError:        "日志查询语句不合法",
+	LogRawSQLNoPermissionError: "没有使用SQL查询日志的权限",
+	TailLogError:               "实时日志查询失败",
+	GetLogPatternsError:        "日志模式聚类失败",
+	TestLogParseRuleError:      "测试日志解析规则失败",
+	LogParseRuleIllegalError:   "日志解析规则不合法",
+
+	GetVectorPipelineError:             "查询Vector配置失败",
+	UpdateVectorPipelineError:          "更新Vector配置失败",
+	ListVectorPipelineVersionsError:    "查询Vector配置历史版本失败",
+	DiffVectorPipelineError:            "对比Vector配置版本失败",
+	RollbackVectorPipelineError:        "回滚Vector配置失败",
+	VectorPipelineIllegalError:         "Vector配置不合法",
+	VectorPipelineVersionNotExistError: "Vector配置版本不存在",
+
+	CreateLogExportError:       "创建日志导出任务失败",
+	ListLogExportsError:        "查询日志导出任务失败",
+	GetLogExportError:          "查询日志导出进度失败",
+	DownloadLogExportError:     "下载导出日志失败",
+	DeleteLogExportError:       "删除日志导出任务失败",
+	LogExportNotExistError:     "日志导出任务不存在",
+	LogExportNotReadyError:     "日志导出尚未完成",
+	CreateLogArchiveJobError:   "创建日志归档任务失败",
+	UpdateLogArchiveJobError:   "更新日志归档任务失败",
+	DeleteLogArchiveJobError:   "删除日志归档任务失败",
+	ListLogArchiveJobsError:    "查询日志归档任务失败",
+	ListLogArchiveRecordsError: "查询日志归档记录失败",
+	RunLogArchiveJobError:      "执行日志归档任务失败",
+	LogArchiveJobIllegalError:  "日志归档任务不合法",
+	LogArchiveJobNotExistError: "日志归档任务不存在",
+	CorrelateLogError:          "日志关联查询失败",
+	InferLogSchemaError:        "推断日志表结构失败",
+	UpdateLogSchemaError:       "更新日志表结构失败",
+	LogSchemaIllegalError:      "日志表结构不合法",
+	CreateLogSavedQueryError:   "保存日志查询失败",
+	UpdateLogSavedQueryError:   "更新已保存的日志查询失败",
+	DeleteLogSavedQueryError:   "删除已保存的日志查询失败",
+	GetLogSavedQueryError:      "获取已保存的日志查询失败",
+	LogSavedQueryIllegalError:  "已保存的日志查询不合法",
+	LogSavedQueryNotExistError: "已保存的日志查询不存在",
+	LogSavedQueryNotOwnerError: "只有创建者可以修改已保存的日志查询",
+	GetLogQueryHistoryError:    "获取日志查询历史失败",
+	DeleteLogQueryHistoryError: "删除日志查询历史失败",
+
+	CreateLogMetricError:      "创建日志指标失败",
+	UpdateLogMetricError:      "更新日志指标失败",
+	DeleteLogMetricError:      "删除日志指标失败",
+	ListLogMetricsError:       "查询日志指标失败",
+	LogMetricIllegalError:     "日志指标不合法",
+	LogMetricNotExistError:    "日志指标不存在",
+	CreateLogAlertRuleError:   "创建日志告警规则失败",
+	UpdateLogAlertRuleError:   "更新日志告警规则失败",
+	DeleteLogAlertRuleError:   "删除日志告警规则失败",
+	ListLogAlertRulesError:    "查询日志告警规则失败",
+	LogAlertRuleIllegalError:  "日志告警规则不合法",
+	LogAlertRuleNotExistError: "日志告警规则不存在",
+
+	GetChangeEventsError:       "查询变更事件失败",
+	ReportChangeEventError:     "上报变更事件失败",
+	ChangeWebhookDisabledError: "未配置 change_event.webhook_token，变更上报接口不可用",
+
+	CreateTopologySnapshotError: "创建拓扑快照失败",
+	ListTopologySnapshotsError:  "查询拓扑快照列表失败",
+	GetTopologySnapshotError:    "查询拓扑快照失败",
+	DiffTopologyError:           "对比拓扑快照失败",
+	TopologySnapshotNotExist:    "拓扑快照不存在",
+	ExportTopologyError:         "导出拓扑失败",
+
+	GetBlastRadiusError:           "查询故障影响范围失败",
+	GetCriticalPathError:          "查询关键路径失败",
+	DetectCyclesError:             "检测循环调用失败",
+	GetSinglePointsOfFailureError: "查询单点故障失败",
+
+	SaveServiceCatalogError:   "保存服务目录失败",
+	DeleteServiceCatalogError: "删除服务目录失败",
+	GetServiceCatalogError:    "查询服务目录失败",
+	ListServiceCatalogsError:  "查询服务目录列表失败",
+	ImportServiceCatalogError: "导入服务目录失败",
 }
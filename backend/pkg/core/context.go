@@ -20,6 +20,7 @@ import (
 const (
 	_PayloadName    = "_payload_"
 	_AbortErrorName = "_abort_error_"
+	_StreamedName   = "_streamed_"
 )
 
 var contextPool = &sync.Pool{
@@ -70,6 +71,14 @@ type Context interface {
 	Payload(payload interface{})
 	getPayload() interface{}
 
+	// SSEvent writes a Server-Sent Event and flushes it to the client,
+	// the payload is ignored once an event is written.
+	// Error is returned when the client is gone.
+	SSEvent(name string, message any) error
+	// FileAttachment writes the file as an attachment with the filename, the payload is ignored.
+	FileAttachment(filepath string, filename string)
+	isStreamed() bool
+
 	// AbortWithError error return
 	AbortWithPermissionError(err error, expectCode string, emptyResp any)
 	// AbortWithError 错误返回
@@ -85,6 +94,9 @@ type Context interface {
 
 	abortError() BusinessError
 
+	// AbortedError returns the error set by AbortWithError, nil if the request is not aborted
+	AbortedError() BusinessError
+
 	// Header Gets the Header object
 	Header() http.Header
 	// Get the header GetHeader
@@ -157,6 +169,28 @@ func (c *context) Payload(payload interface{}) {
 	c.ctx.Set(_PayloadName, payload)
 }
 
+func (c *context) SSEvent(name string, message any) error {
+	if !c.isStreamed() {
+		c.ctx.Header("Cache-Control", "no-cache")
+		c.ctx.Header("Connection", "keep-alive")
+		// disable the buffering of nginx
+		c.ctx.Header("X-Accel-Buffering", "no")
+		c.ctx.Set(_StreamedName, true)
+	}
+	c.ctx.SSEvent(name, message)
+	c.ctx.Writer.Flush()
+	return c.ctx.Request.Context().Err()
+}
+
+func (c *context) FileAttachment(filepath string, filename string) {
+	c.ctx.Set(_StreamedName, true)
+	c.ctx.FileAttachment(filepath, filename)
+}
+
+func (c *context) isStreamed() bool {
+	return c.ctx.GetBool(_StreamedName)
+}
+
 func (c *context) Header() http.Header {
 	header := c.ctx.Request.Header
 
@@ -230,6 +264,18 @@ func (c *context) abortError() BusinessError {
 	return err.(BusinessError)
 }
 
+func (c *context) AbortedError() BusinessError {
+	if !c.ctx.IsAborted() {
+		return nil
+	}
+	err, ok := c.ctx.Get(_AbortErrorName)
+	if !ok {
+		return nil
+	}
+	vErr, _ := err.(BusinessError)
+	return vErr
+}
+
 func (c *context) GetContext() go_context.Context {
 	if c == nil {
 		return go_context.Background()
@@ -90,6 +90,8 @@ func New(logger *zap.Logger) (*Mux, error) {
 					Code:    businessCode,
 					Message: businessCodeMsg,
 				})
+			} else if context.isStreamed() {
+				// the response has been written by the handler, e.g. Server-Sent Events and files
 			} else if len(ctx.Writer.Header().Get("Content-Disposition")) > 0 {
 				content, ok := context.getPayload().([]byte)
 				if !ok {
@@ -174,6 +176,14 @@ func (r *Router) GET_Gin(relativePath string, handlers []gin.HandlerFunc) {
 	r.group.GET(relativePath, handlers...)
 }
 
+// WrapHTTPHandler serves the request by h, the response written by h is kept as is.
+func WrapHTTPHandler(h http.Handler) []gin.HandlerFunc {
+	return []gin.HandlerFunc{func(c *gin.Context) {
+		c.Set(_StreamedName, true)
+		h.ServeHTTP(c.Writer, c.Request)
+	}}
+}
+
 func (r *Router) POST(relativePath string, handlers ...HandlerFunc) {
 	r.group.POST(relativePath, wrapHandlers(handlers...)...)
 }
@@ -0,0 +1,76 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+// maxAuditBodySize is the max size of the request body kept in the audit log.
+const maxAuditBodySize = 64 * 1024
+
+// AuditMiddleware records who called the API, the redacted request body and the result.
+// It should be added to APIs which change configuration or read sensitive configuration.
+func (m *middleware) AuditMiddleware() core.HandlerFunc {
+	return func(c core.Context) {
+		if !config.Get().Audit.Enable {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		var truncated bool
+		req := c.Request()
+		if req.Body != nil {
+			// only the head of the body is read, the rest is streamed to the handler as is
+			body, _ = io.ReadAll(io.LimitReader(req.Body, maxAuditBodySize+1))
+			req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+			if len(body) > maxAuditBodySize {
+				body = body[:maxAuditBodySize]
+				truncated = true
+			}
+		}
+
+		ts := time.Now()
+		c.Next()
+
+		method, path := c.GetMethodPath()
+		auditLog := &database.AuditLog{
+			UserID:        c.UserID(),
+			Method:        method,
+			Path:          path,
+			Query:         req.URL.RawQuery,
+			BodyTruncated: truncated,
+			ClientIP:      c.ClientIP(),
+			HTTPCode:      http.StatusOK,
+			Success:       true,
+			Timestamp:     ts.UnixMicro(),
+		}
+		if err := c.AbortedError(); err != nil {
+			auditLog.HTTPCode = err.HTTPCode()
+			auditLog.BusinessCode = err.BusinessCode()
+			auditLog.Success = false
+		}
+		if auditLog.UserID > 0 {
+			if user, err := m.userService.GetUserInfo(c, auditLog.UserID); err == nil {
+				auditLog.Username = user.Username
+			}
+		}
+
+		if err := m.auditService.Record(c, auditLog, body); err != nil {
+			m.logger.Error("failed to record audit log",
+				zap.String("method", method),
+				zap.String("path", path),
+				zap.Error(err))
+		}
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/repository/cache"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/dify"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 	"github.com/CloudDetail/apo/backend/pkg/services/permission"
 	"github.com/CloudDetail/apo/backend/pkg/services/user"
+	"go.uber.org/zap"
 )
 
 var _ Middleware = (*middleware)(nil)
@@ -17,16 +19,21 @@ var _ Middleware = (*middleware)(nil)
 type Middleware interface {
 	AuthMiddleware() core.HandlerFunc
 	PermissionMiddleware() core.HandlerFunc
+	AuditMiddleware() core.HandlerFunc
 }
 
 type middleware struct {
+	logger            *zap.Logger
 	userService       user.Service
 	permissionService permission.Service
+	auditService      audit.Service
 }
 
-func New(cacheRepo cache.Repo, dbRepo database.Repo, difyRepo dify.DifyRepo) Middleware {
+func New(logger *zap.Logger, cacheRepo cache.Repo, dbRepo database.Repo, difyRepo dify.DifyRepo) Middleware {
 	return &middleware{
+		logger:            logger,
 		userService:       user.New(dbRepo, cacheRepo, difyRepo),
 		permissionService: permission.New(dbRepo),
+		auditService:      audit.New(logger, dbRepo),
 	}
 }
@@ -252,7 +252,7 @@ type DingTalkConfig struct {
 	AlertName  string `json:"-" gorm:"column:alert_name;type:varchar(50)"`
 	UUID       string `json:"-" gorm:"column:uuid;unique;type:varchar(50)"`
 	URL        string `json:"url,omitempty" gorm:"column:url;type:varchar(150)"`
-	Secret     string `json:"secret,omitempty" gorm:"secret"`
+	Secret     string `json:"secret,omitempty" gorm:"secret" secret:"true"`
 }
 
 func (t DingTalkConfig) TableName() string {
@@ -269,9 +269,9 @@ type EmailConfig struct {
 	Hello            string               `yaml:"hello,omitempty" json:"hello,omitempty"`
 	Smarthost        HostPort             `yaml:"smarthost,omitempty" json:"smarthost,omitempty"`
 	AuthUsername     string               `yaml:"auth_username,omitempty" json:"authUsername,omitempty"`
-	AuthPassword     Secret               `yaml:"auth_password,omitempty" json:"authPassword,omitempty"`
+	AuthPassword     Secret               `yaml:"auth_password,omitempty" json:"authPassword,omitempty" secret:"true"`
 	AuthPasswordFile string               `yaml:"auth_password_file,omitempty" json:"authPasswordFile,omitempty"`
-	AuthSecret       Secret               `yaml:"auth_secret,omitempty" json:"authSecret,omitempty"`
+	AuthSecret       Secret               `yaml:"auth_secret,omitempty" json:"authSecret,omitempty" secret:"true"`
 	AuthIdentity     string               `yaml:"auth_identity,omitempty" json:"authIdentity,omitempty"`
 	Headers          map[string]string    `yaml:"headers,omitempty" json:"headers,omitempty"`
 	HTML             string               `yaml:"html,omitempty" json:"html,omitempty"`
@@ -575,7 +575,7 @@ type WechatConfig struct {
 
 	HTTPConfig *httpconfig.HTTPClientConfig `yaml:"http_config,omitempty" json:"httpConfig,omitempty"`
 
-	APISecret   Secret `yaml:"api_secret,omitempty" json:"apiSecret,omitempty"`
+	APISecret   Secret `yaml:"api_secret,omitempty" json:"apiSecret,omitempty" secret:"true"`
 	CorpID      string `yaml:"corp_id,omitempty" json:"corpId,omitempty"`
 	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
 	APIURL      *URL   `yaml:"api_url,omitempty" json:"apiUrl,omitempty"`
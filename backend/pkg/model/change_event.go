@@ -0,0 +1,44 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+const (
+	ChangeSourceKubernetes = "kubernetes"
+	ChangeSourceWebhook    = "webhook"
+
+	// ChangeTypeDeploy is a new version, e.g. the images of a workload are changed
+	ChangeTypeDeploy = "deploy"
+	// ChangeTypeConfig is a configuration change, e.g. the data of a ConfigMap is changed
+	ChangeTypeConfig = "config"
+	// ChangeTypeScale is a change of the replicas
+	ChangeTypeScale = "scale"
+	// ChangeTypeRollout is a rollout without new images, e.g. restarted or the pod template is changed
+	ChangeTypeRollout = "rollout"
+)
+
+// ChangeEvent records a change which may affect the services, e.g. a deployment.
+type ChangeEvent struct {
+	ID     int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Source string `gorm:"column:source;type:varchar(20)" json:"source"`
+	Type   string `gorm:"column:type;type:varchar(20);index" json:"type"`
+
+	// the changed kubernetes object, empty if reported by webhook without them
+	ClusterID string `gorm:"column:cluster_id;type:varchar(100)" json:"clusterId"`
+	Namespace string `gorm:"column:namespace;type:varchar(100);index" json:"namespace"`
+	Kind      string `gorm:"column:kind;type:varchar(50)" json:"kind"`
+	Name      string `gorm:"column:name;type:varchar(255)" json:"name"`
+	// Service is the APO service reported by webhook
+	Service string `gorm:"column:service;type:varchar(255);index" json:"service"`
+
+	Version string `gorm:"column:version;type:varchar(255)" json:"version"`
+	Summary string `gorm:"column:summary;type:varchar(1000)" json:"summary"`
+	Author  string `gorm:"column:author;type:varchar(100)" json:"author"`
+	// URL links to the pipeline or the release note
+	URL       string `gorm:"column:url;type:varchar(1000)" json:"url"`
+	Timestamp int64  `gorm:"column:timestamp;index" json:"timestamp"` // microseconds
+}
+
+func (ChangeEvent) TableName() string {
+	return "change_event"
+}
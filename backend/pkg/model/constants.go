@@ -25,6 +25,14 @@ const (
 	PERMISSION_TYP_DATA    = "data"
 )
 
+// Features which are checked inside APIs instead of mapped to menus or APIs.
+const (
+	// FEATURE_LOG_RAW_SQL allows querying logs with ClickHouse SQL conditions
+	FEATURE_LOG_RAW_SQL = "日志SQL查询"
+	// FEATURE_AUDIT_LOG allows reading the audit logs
+	FEATURE_AUDIT_LOG = "审计日志"
+)
+
 const (
 	TRANSLATION_EN          = "en"
 	TRANSLATION_ZH          = "zh"
@@ -0,0 +1,71 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	HealthAggregationWorst    = "worst"
+	HealthAggregationWeighted = "weighted"
+)
+
+// Signals which can feed the red/yellow/green light of services
+const (
+	HealthSignalLatency          = "latency"          // average latency, ms
+	HealthSignalErrorRate        = "errorRate"        // average error rate, %
+	HealthSignalLogErrorCount    = "logErrorCount"    // average error logs
+	HealthSignalLatencyDoD       = "latencyDoD"       // latency Day-over-Day growth rate, %
+	HealthSignalErrorRateDoD     = "errorRateDoD"     // error rate Day-over-Day growth rate, %
+	HealthSignalLogErrorCountDoD = "logErrorCountDoD" // error logs Day-over-Day growth rate, %
+	HealthSignalLatencyAnomaly   = "latencyAnomaly"   // latency anomaly score compared with the seasonal baseline
+	HealthSignalErrorAnomaly     = "errorRateAnomaly" // error rate anomaly score compared with the seasonal baseline
+	HealthSignalAlert            = "alert"            // firing alerts, thresholds are not used
+	HealthSignalReplica          = "replica"          // instance count, thresholds are not used
+)
+
+// HealthSignal is one signal feeding the light.
+// Score is 3 if value < Thresholds[0], 2 if < Thresholds[1], 1 if < Thresholds[2], otherwise 0.
+type HealthSignal struct {
+	Key        string     `json:"key"`
+	Thresholds [3]float64 `json:"thresholds"`
+	Weight     float64    `json:"weight"`
+	// MissingScore is used when the signal has no data, the signal is skipped if nil
+	MissingScore *int `json:"missingScore,omitempty"`
+}
+
+type HealthSignals []HealthSignal
+
+func (s HealthSignals) Value() (driver.Value, error) {
+	if s == nil {
+		return "", nil
+	}
+	val, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(val), nil
+}
+
+func (s *HealthSignals) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	var val []byte
+	switch v := value.(type) {
+	case string:
+		val = []byte(v)
+	case []byte:
+		val = v
+	default:
+		return fmt.Errorf("failed to scan JSONField, expected string or []byte, got %T", value)
+	}
+	if len(val) == 0 {
+		return nil
+	}
+	return json.Unmarshal(val, s)
+}
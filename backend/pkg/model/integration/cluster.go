@@ -19,6 +19,38 @@ type Cluster struct {
 	Name         string       `form:"name" json:"name" gorm:"unique;type:varchar(255);column:name"`
 	ClusterType  string       `form:"clusterType" json:"clusterType" gorm:"type:varchar(255);column:cluster_type"`
 	APOCollector APOCollector `json:"apoCollector,omitempty" gorm:"type:json;column:apo_collector"`
+
+	// K8sCredential is used to access the kubernetes API of the cluster
+	K8sCredential *JSONField[K8sCredential] `json:"k8sCredential,omitempty" gorm:"type:json;column:k8s_credential"`
+	K8sStatus     *K8sClusterStatus         `json:"k8sStatus,omitempty" gorm:"-"`
+}
+
+const (
+	K8sAuthKubeConfig     = "kubeConfig"
+	K8sAuthServiceAccount = "serviceAccount"
+)
+
+type K8sCredential struct {
+	// kubeConfig / serviceAccount
+	AuthType string `json:"authType"`
+
+	// KubeConfig is the content of the kubeconfig file, the current context is used
+	KubeConfig string `json:"kubeConfig,omitempty" secret:"true"`
+
+	// Server, Token and CAData are used by serviceAccount
+	Server string `json:"server,omitempty"`
+	Token  string `json:"token,omitempty" secret:"true"`
+	// CAData is the PEM encoded certificate of the API server, the certificate is not verified if Insecure
+	CAData   string `json:"caData,omitempty"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+type K8sClusterStatus struct {
+	Healthy bool   `json:"healthy"`
+	Version string `json:"version,omitempty"`
+	Message string `json:"message,omitempty"`
+	// CheckedAt is the last time in seconds the API server was checked
+	CheckedAt int64 `json:"checkedAt"`
 }
 
 type ClusterIntegration struct {
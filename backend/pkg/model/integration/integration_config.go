@@ -11,6 +11,9 @@ func (ci *ClusterIntegration) RemoveSecret() *ClusterIntegration {
 	ci.Trace.TraceAPI.ReplaceSecret()
 	ci.Metric.MetricAPI.ReplaceSecret()
 	ci.Log.LogAPI.ReplaceSecret()
+	if ci.K8sCredential != nil {
+		ci.K8sCredential.ReplaceSecret()
+	}
 
 	return ci
 }
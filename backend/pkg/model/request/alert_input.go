@@ -132,10 +132,12 @@ type Alert struct {
 }
 
 type GetAlertRuleConfigRequest struct {
+	ClusterID     string `form:"clusterId" json:"clusterId"`
 	AlertRuleFile string `form:"alertRuleFile" json:"alertRuleFile"`
 }
 
 type GetAlertRuleRequest struct {
+	ClusterID     string `form:"clusterId" json:"clusterId"`
 	AlertRuleFile string `form:"alertRuleFile" json:"alertRuleFile"`
 	RefreshCache  bool   `form:"refreshCache" json:"refreshCache"`
 
@@ -144,6 +146,7 @@ type GetAlertRuleRequest struct {
 }
 
 type GetAlertManagerConfigReceverRequest struct {
+	ClusterID    string `form:"clusterId" json:"clusterId"`
 	AMConfigFile string `form:"amConfigFile" json:"amConfigFile"`
 	RefreshCache bool   `form:"refreshCache" json:"refreshCache"`
 
@@ -167,11 +170,13 @@ type AMConfigReceiverFilter struct {
 }
 
 type UpdateAlertRuleConfigRequest struct {
+	ClusterID     string `json:"clusterId"`
 	AlertRuleFile string `json:"alertRuleFile"`
 	Content       string `json:"content"`
 }
 
 type UpdateAlertRuleRequest struct {
+	ClusterID     string `json:"clusterId"`
 	AlertRuleFile string `json:"alertRuleFile"`
 
 	OldGroup  string    `json:"oldGroup" binding:"required"`
@@ -184,6 +189,7 @@ type UpdateAlertRuleRequest struct {
 type AddAlertManagerConfigReceiver UpdateAlertManagerConfigReceiver
 
 type UpdateAlertManagerConfigReceiver struct {
+	ClusterID    string `form:"clusterId" json:"clusterId"`
 	AMConfigFile string `form:"amConfigFile" json:"amConfigFile"`
 
 	Type             string            `form:"type" json:"type"` // receiver type
@@ -192,6 +198,7 @@ type UpdateAlertManagerConfigReceiver struct {
 }
 
 type DeleteAlertRuleRequest struct {
+	ClusterID     string `form:"clusterId" json:"clusterId"`
 	AlertRuleFile string `form:"alertRuleFile" json:"alertRuleFile"`
 
 	Group string `form:"group" json:"group" binding:"required"`
@@ -199,6 +206,7 @@ type DeleteAlertRuleRequest struct {
 }
 
 type DeleteAlertManagerConfigReceiverRequest struct {
+	ClusterID    string `form:"clusterId" json:"clusterId"`
 	AMConfigFile string `form:"amConfigFile" json:"amConfigFile"`
 	Type         string `form:"type" json:"type"`
 	Name         string `form:"name" json:"name" binding:"required"`
@@ -217,6 +225,7 @@ type AlertRule struct {
 }
 
 type AddAlertRuleRequest struct {
+	ClusterID     string `json:"clusterId"`
 	AlertRuleFile string `json:"alertRuleFile"`
 
 	AlertRule AlertRule `json:"alertRule"`
@@ -224,6 +233,7 @@ type AddAlertRuleRequest struct {
 }
 
 type CheckAlertRuleRequest struct {
+	ClusterID     string `form:"clusterId,omitempty"`
 	AlertRuleFile string `form:"alertRuleFile,omitempty"`
 	Group         string `form:"group" binding:"required"`
 	Alert         string `form:"alert" binding:"required"`
@@ -0,0 +1,24 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type GetAnomalyScoresRequest struct {
+	StartTime   int64  `json:"startTime" form:"startTime" binding:"required"` // microseconds
+	EndTime     int64  `json:"endTime" form:"endTime" binding:"required"`     // microseconds
+	ServiceName string `json:"serviceName" form:"serviceName"`
+	Endpoint    string `json:"endpoint" form:"endpoint"`
+	// ServiceLevel returns scores of the whole service instead of endpoints
+	ServiceLevel bool `json:"serviceLevel" form:"serviceLevel"`
+}
+
+type GetAnomalyAlertRuleRequest struct {
+	ServiceName string `json:"serviceName" form:"serviceName" binding:"required"`
+	Endpoint    string `json:"endpoint" form:"endpoint"`
+	// latency / error / throughput
+	Metric string `json:"metric" form:"metric" binding:"required"`
+	// Threshold of the anomaly score, default 3
+	Threshold float64 `json:"threshold" form:"threshold"`
+	// Weeks of history used as baseline, default 4
+	Weeks int `json:"weeks" form:"weeks"`
+}
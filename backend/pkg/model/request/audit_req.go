@@ -0,0 +1,15 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type GetAuditLogRequest struct {
+	UserID    int64  `json:"userId" form:"userId"`
+	Username  string `json:"username" form:"username"`
+	Method    string `json:"method" form:"method"`
+	Path      string `json:"path" form:"path"`
+	Success   *bool  `json:"success" form:"success"`
+	StartTime int64  `json:"startTime" form:"startTime"` // microseconds
+	EndTime   int64  `json:"endTime" form:"endTime"`     // microseconds
+	*PageParam
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+// GetChangeEventsRequest queries the changes of the service or in the namespace.
+type GetChangeEventsRequest struct {
+	Service   string   `form:"service" binding:"required_without=Namespace"`
+	Namespace string   `form:"namespace"`
+	ClusterID string   `form:"clusterId"`
+	Types     []string `form:"types" binding:"omitempty,dive,oneof=deploy config scale rollout"`
+	StartTime int64    `form:"startTime" binding:"required"`                 // microseconds
+	EndTime   int64    `form:"endTime" binding:"required,gtfield=StartTime"` // microseconds
+}
+
+// ReportChangeEventRequest is sent by the CI/CD systems after a deployment.
+type ReportChangeEventRequest struct {
+	Service   string `json:"service" binding:"required_without=Namespace"`
+	Namespace string `json:"namespace"`
+	ClusterID string `json:"clusterId"`
+	// Kind and Name of the deployed kubernetes object, optional
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Type is deploy if empty
+	Type    string `json:"type" binding:"omitempty,oneof=deploy config scale rollout"`
+	Version string `json:"version"`
+	Summary string `json:"summary"`
+	Author  string `json:"author"`
+	URL     string `json:"url"`
+	// Timestamp in microseconds, the time received if empty
+	Timestamp int64 `json:"timestamp"`
+}
@@ -52,11 +52,31 @@ type QueryServiceNameTag struct {
 }
 
 type CreateCustomTopologyRequest struct {
-	ClusterId string `form:"clusterId" binding:"required"`
-	LeftNode  string `form:"leftNode" binding:"required"`
-	LeftType  string `form:"leftType" binding:"required"`
-	RightNode string `form:"rightNode" binding:"required"`
-	RightType string `form:"rightType" binding:"required"`
+	ClusterId    string `form:"clusterId" binding:"required"`
+	LeftNode     string `form:"leftNode" binding:"required"`
+	LeftType     string `form:"leftType" binding:"required"`
+	RightNode    string `form:"rightNode" binding:"required"`
+	RightType    string `form:"rightType" binding:"required"`
+	Protocol     string `form:"protocol"`
+	Port         int    `form:"port" binding:"min=0,max=65535"`
+	Description  string `form:"description"`
+	SourceSystem string `form:"sourceSystem"`
+	// StartTime and ExpireTime limit when the edge is shown, 0 means unlimited. Unit: microseconds
+	StartTime  int64 `form:"startTime" binding:"min=0"`
+	ExpireTime int64 `form:"expireTime" binding:"min=0"`
+}
+
+type ImportCustomTopologyRequest struct {
+	// Format is csv or yaml
+	Format string `json:"format" binding:"required,oneof=csv yaml"`
+	// Content of the file, the csv requires the header line with the field names,
+	// e.g. clusterId,leftNode,leftType,rightNode,rightType,protocol,port,description,sourceSystem,startTime,expireTime
+	Content string `json:"content" binding:"required"`
+}
+
+type ReconcileCustomTopologyRequest struct {
+	StartTime int64 `json:"startTime" binding:"required"`
+	EndTime   int64 `json:"endTime" binding:"required,gtfield=StartTime"`
 }
 
 type ListCustomTopologyRequest struct {
@@ -69,19 +89,32 @@ type DeleteCustomTopologyRequest struct {
 }
 
 type SetServiceNameRuleRequest struct {
-	RuleId      int                                  `json:"ruleId"`
-	ServiceName string                               `json:"service"`
-	ClusterId   string                               `json:"clusterId"`
-	Conditions  []SetServiceNameRuleConditionRequest `json:"conditions"`
+	RuleId int `json:"ruleId"`
+	// ServiceName supports the capture groups of the regex conditions, e.g. ${app} or $1,
+	// templates, regex and Priority require dataplane.extended_service_name_rule
+	ServiceName string `json:"service"`
+	ClusterId   string `json:"clusterId"`
+	// Priority of the rule, the rule with higher priority is applied first
+	Priority   int                                  `json:"priority"`
+	Conditions []SetServiceNameRuleConditionRequest `json:"conditions"`
 }
 
 type SetServiceNameRuleConditionRequest struct {
 	CondtiondId int    `json:"conditionId"`
 	Key         string `json:"key"`
-	MatchType   string `json:"matchType"`
+	MatchType   string `json:"matchType"` // equals / startsWith / endsWith / contains / has / regex
 	Value       string `json:"value"`
 }
 
+type PreviewServiceNameRulesRequest struct {
+	// ClusterId limits the instances previewed, all clusters if empty
+	ClusterId string `json:"clusterId"`
+	StartTime int64  `json:"startTime" binding:"required"`
+	EndTime   int64  `json:"endTime" binding:"required,gtfield=StartTime"`
+	// Rules to preview, the saved rules are used if empty
+	Rules []SetServiceNameRuleRequest `json:"rules"`
+}
+
 type DeleteServiceNameRuleRequest struct {
 	RuleId int `form:"ruleId" json:"ruleId" binding:"required"`
 }
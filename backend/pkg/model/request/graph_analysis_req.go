@@ -0,0 +1,27 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type GetBlastRadiusRequest struct {
+	StartTime int64 `form:"startTime" binding:"min=0"`                    // query start time
+	EndTime   int64 `form:"endTime" binding:"required,gtfield=StartTime"` // query end time
+	// Service is the service or the db, mq and external peer, e.g. mysql:3306
+	Service string `form:"service" binding:"required"`
+	// Endpoint limits the entry endpoints to the ones calling the endpoint of the service
+	Endpoint string `form:"endpoint"`
+}
+
+type GetCriticalPathRequest struct {
+	StartTime int64  `form:"startTime" binding:"min=0"`                    // query start time
+	EndTime   int64  `form:"endTime" binding:"required,gtfield=StartTime"` // query end time
+	Service   string `form:"service" binding:"required"`                   // entry service name
+	Endpoint  string `form:"endpoint" binding:"required"`                  // entry Endpoint
+
+	ClusterIDs []string `form:"clusterIds"`
+}
+
+type GetDependencyGraphRequest struct {
+	StartTime int64 `form:"startTime" binding:"min=0"`                    // query start time
+	EndTime   int64 `form:"endTime" binding:"required,gtfield=StartTime"` // query end time
+}
@@ -3,15 +3,41 @@
 
 package request
 
+// ClusterID is empty for the cluster where APO is deployed
+type GetNamespaceListRequest struct {
+	ClusterID string `form:"clusterId"`
+}
+
 type GetNamespaceInfoRequest struct {
+	ClusterID string `form:"clusterId"`
 	Namespace string `form:"namespace" binding:"required"`
 }
 
 type GetPodListRequest struct {
+	ClusterID string `form:"clusterId"`
 	Namespace string `form:"namespace" binding:"required"`
 }
 
 type GetPodInfoRequest struct {
+	ClusterID string `form:"clusterId"`
 	Namespace string `form:"namespace" binding:"required"`
 	Pod       string `form:"pod" binding:"required"`
 }
+
+type GetK8sWorkloadRequest struct {
+	ClusterID string `form:"clusterId"`
+	Namespace string `form:"namespace" binding:"required"`
+	Kind      string `form:"kind" binding:"required,oneof=Deployment StatefulSet DaemonSet"`
+	Name      string `form:"name" binding:"required"`
+}
+
+type GetK8sNodeListRequest struct {
+	ClusterID string `form:"clusterId"`
+}
+
+// GetServiceWorkloadsRequest finds the workloads of the service by the pods of its instances
+type GetServiceWorkloadsRequest struct {
+	Service   string `form:"service" binding:"required"`
+	StartTime int64  `form:"startTime" binding:"required"`                 // query start time, microseconds
+	EndTime   int64  `form:"endTime" binding:"required,gtfield=StartTime"` // query end time, microseconds
+}
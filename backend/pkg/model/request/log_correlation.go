@@ -0,0 +1,25 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+// CorrelateLogRequest is a log row returned by the log query, the trace, pod and container
+// are read from the columns of the row, so it works for any structured log table.
+type CorrelateLogRequest struct {
+	// Time of the log in microseconds
+	Time      int64          `json:"timestamp" binding:"required,min=1"`
+	Content   string         `json:"content"`
+	Tags      map[string]any `json:"tags"`
+	LogFields map[string]any `json:"logFields"`
+
+	// Columns of the log row, detected by the common names if empty,
+	// e.g. trace_id, k8s_pod_name, container_id and k8s_namespace_name
+	TraceIDField   string `json:"traceIdField"`
+	PodField       string `json:"podField"`
+	ContainerField string `json:"containerField"`
+	NamespaceField string `json:"namespaceField"`
+	ServiceField   string `json:"serviceField"`
+
+	// Minutes before and after the log to search the related data, default is 15
+	WindowMinutes int `json:"windowMinutes" binding:"min=0,max=360"`
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type CreateLogExportRequest struct {
+	StartTime int64  `json:"startTime" binding:"min=0"`
+	EndTime   int64  `json:"endTime" binding:"required,gtfield=StartTime"`
+	DataBase  string `json:"dataBase" binding:"required"`
+	TableName string `json:"tableName" binding:"required"`
+	Query     string `json:"query"`
+	// RawSQL means Query is a ClickHouse SQL condition, which requires the permission of feature "日志SQL查询"
+	RawSQL    bool   `json:"rawSql"`
+	TimeField string `json:"timeField"`
+	LogField  string `json:"logField"`
+	// ndjson / csv / parquet
+	Format string `json:"format" binding:"required,oneof=ndjson csv parquet"`
+	// Limit of the exported logs, default and upper limit is log_export.max_rows in the config
+	Limit int `json:"limit" binding:"min=0"`
+}
+
+type LogExportRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
+
+type CreateLogArchiveJobRequest struct {
+	Name      string `json:"name" binding:"required"`
+	DataBase  string `json:"dataBase" binding:"required"`
+	TableName string `json:"tableName" binding:"required"`
+	// ndjson / csv / parquet, the text formats are compressed with gzip
+	Format string `json:"format" binding:"required,oneof=ndjson csv parquet"`
+	// Schedule is a standard cron expression, e.g. "0 1 * * *" runs at 01:00 every day
+	Schedule string `json:"schedule" binding:"required"`
+	// DaysBeforeExpire the partitions (days) expiring under TTL within the days are archived, default 1
+	DaysBeforeExpire int `json:"daysBeforeExpire" binding:"min=0"`
+	// Prefix of the object keys, the keys are <prefix>/<database>/<table>/<day>.<format>
+	Prefix  string `json:"prefix"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdateLogArchiveJobRequest struct {
+	ID int64 `json:"id" binding:"required"`
+	CreateLogArchiveJobRequest
+}
+
+type LogArchiveJobRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type CreateLogMetricRequest struct {
+	// Name is the Prometheus metric name
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	DataBase    string `json:"dataBase" binding:"required"`
+	TableName   string `json:"tableName" binding:"required"`
+	TimeField   string `json:"timeField"`
+	LogField    string `json:"logField"`
+	// Query in the log query language
+	Query string `json:"query"`
+	// count / sum / avg / min / max / p50 / p90 / p95 / p99
+	Aggregation string `json:"aggregation" binding:"required"`
+	// Field is the aggregated numeric field, required except count
+	Field string `json:"field"`
+	// GroupBy fields become the labels of the metric, "a.b" refers to key b of Map column a
+	GroupBy []string `json:"groupBy"`
+	// IntervalSeconds between evaluations, default 60
+	IntervalSeconds int `json:"intervalSeconds" binding:"min=0"`
+	// WindowSeconds of logs aggregated in each evaluation, default is IntervalSeconds
+	WindowSeconds int `json:"windowSeconds" binding:"min=0"`
+}
+
+type UpdateLogMetricRequest struct {
+	ID int64 `json:"id" binding:"required"`
+	CreateLogMetricRequest
+}
+
+type DeleteLogMetricRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
+
+type CreateLogAlertRuleRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	MetricID    int64  `json:"metricId" binding:"required"`
+	// one of > >= < <= == !=
+	Operator  string  `json:"operator" binding:"required"`
+	Threshold float64 `json:"threshold"`
+	// ForEvaluations is the number of consecutive evaluations matched before firing, default 1
+	ForEvaluations int `json:"forEvaluations" binding:"min=0"`
+	// critical / error / warning / info, default warning
+	Severity string            `json:"severity"`
+	Labels   map[string]string `json:"labels"`
+	Enabled  bool              `json:"enabled"`
+}
+
+type UpdateLogAlertRuleRequest struct {
+	ID int64 `json:"id" binding:"required"`
+	CreateLogAlertRuleRequest
+}
+
+type DeleteLogAlertRuleRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
+
+type ListLogAlertRulesRequest struct {
+	MetricID int64 `json:"metricId" form:"metricId"`
+}
@@ -46,3 +46,16 @@ type DeleteLogParseRequest struct {
 	TableName string `json:"tableName"`
 	ParseName string `json:"parseName"`
 }
+
+type TestLogParseRequest struct {
+	// DataBase of raw_logs, default apo
+	DataBase     string            `json:"dataBase"`
+	RouteRule    map[string]string `json:"routeRule"`
+	ParseRule    string            `json:"parseRule"`
+	TableFields  []Field           `json:"tableFields"`
+	IsStructured bool              `json:"isStructured"`
+	// Samples are tested instead of the latest logs in raw_logs matching RouteRule
+	Samples []string `json:"samples"`
+	// SampleSize of logs pulled from raw_logs, default 20
+	SampleSize int `json:"sampleSize" binding:"min=0,max=200"`
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type LogPatternRequest struct {
+	// StartTime, EndTime and the filters of the logs to cluster
+	LogQueryRequest
+	// SampleSize is the maximum number of latest logs clustered in each window, default 10000
+	SampleSize int `json:"sampleSize" binding:"min=0,max=100000"`
+	// SimilarityThreshold of merging a log into a template, in (0, 1], default 0.5
+	SimilarityThreshold float64 `json:"similarityThreshold" binding:"min=0,max=1"`
+
+	// BaseStartTime and BaseEndTime is the window compared with, in microseconds.
+	// Templates which are new or spiking in the current window are marked if set.
+	BaseStartTime int64 `json:"baseStartTime" binding:"min=0"`
+	BaseEndTime   int64 `json:"baseEndTime" binding:"omitempty,gtfield=BaseStartTime"`
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+import "github.com/CloudDetail/apo/backend/pkg/model/vector"
+
+type UpdateVectorPipelineRequest struct {
+	Pipeline vector.Pipeline `json:"pipeline" binding:"required"`
+	Comment  string          `json:"comment"`
+}
+
+type GetVectorPipelineVersionRequest struct {
+	Version int64 `form:"version" json:"version" binding:"required"`
+}
+
+type DiffVectorPipelineRequest struct {
+	From int64 `form:"from" json:"from" binding:"required"`
+	// To is the current config of Vector if not set
+	To int64 `form:"to" json:"to"`
+}
+
+type RollbackVectorPipelineRequest struct {
+	Version int64 `json:"version" binding:"required"`
+}
@@ -4,11 +4,13 @@
 package request
 
 type LogQueryRequest struct {
-	StartTime  int64  `json:"startTime" binding:"min=0"`
-	EndTime    int64  `json:"endTime" binding:"required,gtfield=StartTime"`
-	TableName  string `json:"tableName"`
-	DataBase   string `json:"dataBase"`
-	Query      string `json:"query"`
+	StartTime int64  `json:"startTime" binding:"min=0"`
+	EndTime   int64  `json:"endTime" binding:"required,gtfield=StartTime"`
+	TableName string `json:"tableName"`
+	DataBase  string `json:"dataBase"`
+	Query     string `json:"query"`
+	// RawSQL means Query is a ClickHouse SQL condition, which requires the permission of feature "日志SQL查询"
+	RawSQL     bool   `json:"rawSql"`
 	PageNum    int    `json:"pageNum"`
 	PageSize   int    `json:"pageSize"`
 	TimeField  string `json:"timeField"`
@@ -25,6 +27,21 @@ type LogIndexRequest struct {
 	TimeField string `json:"timeField"`
 	LogField  string `json:"logField"`
 	Query     string `json:"query"`
+	// RawSQL means Query is a ClickHouse SQL condition, which requires the permission of feature "日志SQL查询"
+	RawSQL bool `json:"rawSql"`
+}
+
+// LogTailRequest streams the logs written after StartTime, the filters are the same as LogQueryRequest.
+type LogTailRequest struct {
+	TableName string `form:"tableName" json:"tableName"`
+	DataBase  string `form:"dataBase" json:"dataBase"`
+	Query     string `form:"query" json:"query"`
+	// RawSQL means Query is a ClickHouse SQL condition, which requires the permission of feature "日志SQL查询"
+	RawSQL    bool   `form:"rawSql" json:"rawSql"`
+	TimeField string `form:"timeField" json:"timeField"`
+	LogField  string `form:"logField" json:"logField"`
+	// StartTime in microseconds, default is the time when the session starts
+	StartTime int64 `form:"startTime" json:"startTime" binding:"min=0"`
 }
 
 type LogQueryContextRequest struct {
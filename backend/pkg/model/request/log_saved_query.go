@@ -0,0 +1,45 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type CreateLogSavedQueryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	DataBase    string `json:"dataBase" binding:"required"`
+	TableName   string `json:"tableName" binding:"required"`
+	Query       string `json:"query"`
+	// RawSQL means Query is a ClickHouse SQL condition, which requires the permission of feature "日志SQL查询"
+	RawSQL    bool   `json:"rawSql"`
+	TimeField string `json:"timeField"`
+	LogField  string `json:"logField"`
+	// RelativeRange is the time range before now, e.g. 15m, 1h, 7d, StartTime and EndTime in microseconds are used if empty
+	RelativeRange string `json:"relativeRange"`
+	StartTime     int64  `json:"startTime" binding:"min=0"`
+	EndTime       int64  `json:"endTime" binding:"min=0"`
+	// Fields displayed in the log list
+	Fields []string `json:"fields"`
+	// private / team / datagroup, default is private
+	ShareType   string `json:"shareType" binding:"omitempty,oneof=private team datagroup"`
+	TeamID      int64  `json:"teamId"`
+	DataGroupID int64  `json:"dataGroupId"`
+}
+
+type UpdateLogSavedQueryRequest struct {
+	ID int64 `json:"id" binding:"required"`
+	CreateLogSavedQueryRequest
+}
+
+type LogSavedQueryRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
+
+type ListLogQueryHistoryRequest struct {
+	// Limit of the returned queries, default is 20
+	Limit int `form:"limit" binding:"min=0,max=100"`
+}
+
+type DeleteLogQueryHistoryRequest struct {
+	// ID of the query in the history, the whole history is cleared if 0
+	ID int64 `json:"id"`
+}
@@ -12,6 +12,21 @@ import (
 type Field struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// Path of the value in the structured log, e.g. ["http", "status"], default is [Name]
+	Path []string `json:"path,omitempty"`
+	// Materialized is the expression calculating the column from the other columns of the log table,
+	// e.g. attributes['user_id'], the column is not written by the view
+	Materialized string `json:"materialized,omitempty"`
+	// Index is the type of the skip index on the column, e.g. bloom_filter(0.01), set(100) or minmax
+	Index string `json:"index,omitempty"`
+}
+
+// JSONPath returns the keys of the value in the structured log.
+func (f *Field) JSONPath() []string {
+	if len(f.Path) > 0 {
+		return f.Path
+	}
+	return []string{f.Name}
 }
 
 type BufferEngineConfig struct {
@@ -87,3 +102,29 @@ func (q *LogTableRequest) FillerValue() {
 		}
 	}
 }
+
+// InferLogSchemaRequest infers the fields of the structured logs from the samples.
+type InferLogSchemaRequest struct {
+	// DataBase of raw_logs, default apo
+	DataBase  string            `json:"dataBase"`
+	RouteRule map[string]string `json:"routeRule"`
+	// Samples are inferred instead of the latest logs in raw_logs matching RouteRule
+	Samples []string `json:"samples"`
+	// SampleSize of logs pulled from raw_logs, default 100
+	SampleSize int `json:"sampleSize" binding:"min=0,max=1000"`
+	// FilteredFields are the fields frequently filtered, e.g. user_id or http.status, the skip indexes are proposed for them
+	FilteredFields []string `json:"filteredFields"`
+	// MaxDepth of the nested objects flattened into columns, default 3
+	MaxDepth int `json:"maxDepth" binding:"min=0,max=10"`
+	// MaxNestedKeys of the nested objects flattened into columns, the objects having more keys are stored as Map, default 20
+	MaxNestedKeys int `json:"maxNestedKeys" binding:"min=0,max=100"`
+}
+
+// UpdateLogSchemaRequest changes the fields of the log table to Fields.
+type UpdateLogSchemaRequest struct {
+	DataBase  string  `json:"dataBase" binding:"required"`
+	TableName string  `json:"tableName" binding:"required"`
+	Fields    []Field `json:"fields"`
+	// DryRun returns the statements without executing them
+	DryRun bool `json:"dryRun"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type SaveServiceCatalogRequest struct {
+	Service string `json:"service" binding:"required"`
+	// TeamID is the owner team, 0 if not assigned
+	TeamID        int64    `json:"teamId"`
+	Tier          string   `json:"tier"`
+	Description   string   `json:"description"`
+	RepositoryURL string   `json:"repositoryUrl"`
+	RunbookURL    string   `json:"runbookUrl"`
+	OnCall        string   `json:"onCall"`
+	Tags          []string `json:"tags"`
+}
+
+type DeleteServiceCatalogRequest struct {
+	Service string `json:"service" form:"service" binding:"required"`
+}
+
+type GetServiceCatalogRequest struct {
+	Service string `json:"service" form:"service" binding:"required"`
+}
+
+type ListServiceCatalogsRequest struct {
+	Services []string `json:"services" form:"services"`
+	TeamID   int64    `json:"teamId" form:"teamId"`
+}
+
+type ImportServiceCatalogRequest struct {
+	// Content is the catalog-info.yaml in Backstage format, multiple documents are supported
+	Content string `json:"content" binding:"required"`
+}
@@ -3,6 +3,8 @@
 
 package request
 
+import "github.com/CloudDetail/apo/backend/pkg/model"
+
 type GetServiceEndpointTopologyRequest struct {
 	StartTime     int64  `form:"startTime" json:"startTime" binding:"min=0"`                   // query start time
 	EndTime       int64  `form:"endTime" json:"endTime"  binding:"required,gtfield=StartTime"` // query end time
@@ -210,6 +212,30 @@ type GetRygLightRequest struct {
 	GroupID int64 `form:"groupId" json:"groupId"`
 }
 
+type ExplainRygLightRequest struct {
+	ServiceName string `form:"serviceName" binding:"required"` // application name, exact match
+
+	StartTime int64 `form:"startTime" binding:"required"`                 // query start time
+	EndTime   int64 `form:"endTime" binding:"required,gtfield=StartTime"` // query end time
+
+	GroupID int64 `form:"groupId" json:"groupId"`
+}
+
+type GetHealthScoreModelRequest struct {
+	GroupID int64 `form:"groupId" json:"groupId"`
+}
+
+type SetHealthScoreModelRequest struct {
+	GroupID int64 `json:"groupId"`
+	// worst / weighted
+	Aggregation string               `json:"aggregation" binding:"required"`
+	Signals     []model.HealthSignal `json:"signals" binding:"required"`
+}
+
+type DeleteHealthScoreModelRequest struct {
+	GroupID int64 `json:"groupId"`
+}
+
 type GetAlertEventsRequest struct {
 	StartTime int64 `form:"startTime" binding:"required" json:"startTime"`               // query start time
 	EndTime   int64 `form:"endTime" binding:"required,gtfield=StartTime" json:"endTime"` // query end time
@@ -0,0 +1,37 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type CreateSLORequest struct {
+	Name        string `json:"name" binding:"required"`
+	ServiceName string `json:"serviceName" binding:"required"`
+	Endpoint    string `json:"endpoint" binding:"required"`
+	// availability / latency
+	Type string `json:"type" binding:"required"`
+	// Target percentage of good requests, e.g. 99.9
+	Target float64 `json:"target" binding:"required"`
+	// LatencyThreshold is required by latency SLO. Unit: ms
+	LatencyThreshold float64 `json:"latencyThreshold"`
+	// WindowDays one of 7 / 28 / 30
+	WindowDays   int  `json:"windowDays" binding:"required"`
+	AlertEnabled bool `json:"alertEnabled"`
+}
+
+type UpdateSLORequest struct {
+	ID int64 `json:"id" binding:"required"`
+	CreateSLORequest
+}
+
+type DeleteSLORequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
+
+type ListSLORequest struct {
+	ServiceName string `json:"serviceName" form:"serviceName"`
+	Endpoint    string `json:"endpoint" form:"endpoint"`
+}
+
+type GetSLOStatusRequest struct {
+	ID int64 `json:"id" form:"id" binding:"required"`
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type ExportServiceEndpointTopologyRequest struct {
+	GetServiceEndpointTopologyRequest
+	Format string `form:"format" json:"format" binding:"required,oneof=dot mermaid jgf"`
+}
+
+type ExportTopologyRequest struct {
+	Cluster   string `form:"cluster"`
+	StartTime int64  `form:"startTime" binding:"min=0"`
+	EndTime   int64  `form:"endTime" binding:"required,gtfield=StartTime"`
+	GroupID   int64  `form:"groupId"`
+	Format    string `form:"format" binding:"required,oneof=dot mermaid jgf"`
+}
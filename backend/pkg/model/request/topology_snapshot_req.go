@@ -0,0 +1,31 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package request
+
+type CreateTopologySnapshotRequest struct {
+	Cluster   string `json:"cluster"`
+	StartTime int64  `json:"startTime" binding:"required"`                 // microseconds
+	EndTime   int64  `json:"endTime" binding:"required,gtfield=StartTime"` // microseconds
+}
+
+type ListTopologySnapshotsRequest struct {
+	Cluster   string `form:"cluster"`
+	StartTime int64  `form:"startTime" binding:"required"`                 // microseconds
+	EndTime   int64  `form:"endTime" binding:"required,gtfield=StartTime"` // microseconds
+}
+
+type GetTopologySnapshotRequest struct {
+	ID int64 `form:"id" binding:"required"`
+}
+
+// DiffTopologyRequest compares two snapshots, selected by id or the latest ones taken before the times.
+type DiffTopologyRequest struct {
+	Cluster    string `form:"cluster"`
+	BaseID     int64  `form:"baseId"`
+	TargetID   int64  `form:"targetId"`
+	BaseTime   int64  `form:"baseTime" binding:"required_without=BaseID"`     // microseconds
+	TargetTime int64  `form:"targetTime" binding:"required_without=TargetID"` // microseconds
+	// ChangeRatio is the minimum relative change of the calls or latency of an edge to report, default 0.5
+	ChangeRatio float64 `form:"changeRatio" binding:"min=0"`
+}
@@ -6,14 +6,14 @@ package request
 import "github.com/CloudDetail/apo/backend/pkg/model"
 
 type LoginRequest struct {
-	Username string `json:"username" form:"username" binding:"required"` // username
-	Password string `json:"password" form:"password" binding:"required"` // password
+	Username string `json:"username" form:"username" binding:"required"`               // username
+	Password string `json:"password" form:"password" binding:"required" secret:"true"` // password
 }
 
 type CreateUserRequest struct {
-	Username        string  `json:"username" form:"username" binding:"required"`               // 用户名
-	Password        string  `json:"password" form:"password" binding:"required"`               // 密码
-	ConfirmPassword string  `json:"confirmPassword" form:"confirmPassword" binding:"required"` // 确认密码
+	Username        string  `json:"username" form:"username" binding:"required"`                             // 用户名
+	Password        string  `json:"password" form:"password" binding:"required" secret:"true"`               // 密码
+	ConfirmPassword string  `json:"confirmPassword" form:"confirmPassword" binding:"required" secret:"true"` // 确认密码
 	Email           string  `json:"email" form:"email,omitempty"`
 	Phone           string  `json:"phone" form:"phone,omitempty"`
 	Corporation     string  `json:"corporation,omitempty" form:"corporation,omitempty"`
@@ -58,9 +58,9 @@ type UpdateUserEmailRequest struct {
 
 type UpdateUserPasswordRequest struct {
 	UserID          int64  `json:"userId" form:"userId" binding:"required"`
-	OldPassword     string `json:"oldPassword" form:"oldPassword" binding:"required"`
-	NewPassword     string `json:"newPassword" form:"newPassword" binding:"required"`
-	ConfirmPassword string `json:"confirmPassword" form:"confirmPassword" binding:"required"`
+	OldPassword     string `json:"oldPassword" form:"oldPassword" binding:"required" secret:"true"`
+	NewPassword     string `json:"newPassword" form:"newPassword" binding:"required" secret:"true"`
+	ConfirmPassword string `json:"confirmPassword" form:"confirmPassword" binding:"required" secret:"true"`
 }
 
 type GetUserListRequest struct {
@@ -77,8 +77,8 @@ type RemoveUserRequest struct {
 
 type ResetPasswordRequest struct {
 	UserID          int64  `json:"userId" form:"userId" binding:"required"`
-	NewPassword     string `json:"newPassword" form:"newPassword" binding:"required"`
-	ConfirmPassword string `json:"confirmPassword" form:"confirmPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" form:"newPassword" binding:"required" secret:"true"`
+	ConfirmPassword string `json:"confirmPassword" form:"confirmPassword" binding:"required" secret:"true"`
 }
 
 type GetUserConfigRequest struct {
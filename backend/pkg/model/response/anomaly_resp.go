@@ -0,0 +1,22 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+// AnomalyScore is the robust z-score of RED metrics compared with the seasonal baseline,
+// positive when the metric is higher than usual. Nil if there is no baseline.
+type AnomalyScore struct {
+	Latency   *float64 `json:"latency"`
+	ErrorRate *float64 `json:"errorRate"`
+	TPS       *float64 `json:"tps"`
+}
+
+type EndpointAnomalyScore struct {
+	ServiceName string `json:"serviceName"`
+	Endpoint    string `json:"endpoint"`
+	AnomalyScore
+}
+
+type GetAnomalyScoresResponse struct {
+	Scores []EndpointAnomalyScore `json:"scores"`
+}
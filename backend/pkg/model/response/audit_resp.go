@@ -0,0 +1,14 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+type GetAuditLogResponse struct {
+	AuditLogs        []database.AuditLog `json:"auditLogs"`
+	model.Pagination `json:",inline"`
+}
@@ -0,0 +1,11 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/model"
+
+type GetChangeEventsResponse struct {
+	// Events are sorted by timestamp
+	Events []model.ChangeEvent `json:"events"`
+}
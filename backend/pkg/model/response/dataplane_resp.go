@@ -55,6 +55,24 @@ type ListCustomTopologyResponse struct {
 	Topologies []*database.CustomServiceTopology `json:"topologies"`
 }
 
+type ImportCustomTopologyResponse struct {
+	Created int `json:"created"`
+	// Updated is the number of the existing edges replaced by the imported ones
+	Updated int                         `json:"updated"`
+	Failed  []ImportCustomTopologyError `json:"failed"`
+}
+
+type ImportCustomTopologyError struct {
+	// Line of the csv or index of the yaml list, starting from 1
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+type ReconcileCustomTopologyResponse struct {
+	// Redundant is the custom edges found in the realtime topology
+	Redundant []*database.CustomServiceTopology `json:"redundant"`
+}
+
 type ListServiceNameRuleResponse struct {
 	Rules []*ListServiceNameRule `json:"rules"`
 }
@@ -62,6 +80,7 @@ type ListServiceNameRuleResponse struct {
 type ListServiceNameRule struct {
 	Id          int                                  `json:"id"`
 	ServiceName string                               `json:"serviceName"`
+	Priority    int                                  `json:"priority"`
 	Conditions  []*database.ServiceNameRuleCondition `json:"conditions"`
 }
 
@@ -69,6 +88,41 @@ type CheckServiceNameRuleResponse struct {
 	Apps []*model.AppInfo `json:"apps"`
 }
 
+type PreviewServiceNameRulesResponse struct {
+	// Renamed is the instances which get a new service name
+	Renamed []ServiceNamePreview `json:"renamed"`
+	// Merged is the services whose instances come from different services
+	Merged []MergedServiceName `json:"merged"`
+	// Unmatched is the instances not matched by any rule
+	Unmatched []ServiceNamePreview `json:"unmatched"`
+	// Unchanged is the number of the instances matched without name changes
+	Unchanged int `json:"unchanged"`
+	// Conflicts is the instances matched by rules of the same priority with different names
+	Conflicts []ServiceNameRuleConflict `json:"conflicts"`
+}
+
+type ServiceNamePreview struct {
+	App            *model.AppInfo `json:"app"`
+	CurrentService string         `json:"currentService"`
+	NewService     string         `json:"newService"`
+	// RuleID is the rule naming the instance, 0 if unmatched
+	RuleID int `json:"ruleId"`
+	// ShadowedRuleIDs is the other matched rules with lower priority
+	ShadowedRuleIDs []int `json:"shadowedRuleIds,omitempty"`
+}
+
+type MergedServiceName struct {
+	Service       string   `json:"service"`
+	FromServices  []string `json:"fromServices"`
+	InstanceCount int      `json:"instanceCount"`
+}
+
+type ServiceNameRuleConflict struct {
+	App      *model.AppInfo `json:"app"`
+	RuleIDs  []int          `json:"ruleIds"`
+	Services []string       `json:"services"`
+}
+
 type QueryAPPInfoTagsResponse struct {
 	Labels []string `json:"labels"`
 }
@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+
+type GetBlastRadiusResponse struct {
+	// Services are the services and peers depending on the target transitively
+	Services       []DependentNode        `json:"services"`
+	EntryEndpoints []clickhouse.EntryNode `json:"entryEndpoints"`
+}
+
+type DependentNode struct {
+	Service  string `json:"service"`
+	Category string `json:"category"`
+	// Depth is the distance to the target, 1 for the direct callers
+	Depth int `json:"depth"`
+}
+
+type GetCriticalPathResponse struct {
+	Latency float64            `json:"latency"` // average latency of the entry endpoint in microseconds
+	Path    []CriticalPathNode `json:"path"`
+}
+
+type CriticalPathNode struct {
+	Service  string  `json:"service"`
+	Endpoint string  `json:"endpoint"`
+	Group    string  `json:"group"`
+	Latency  float64 `json:"latency"` // average latency in microseconds, 0 if not traced
+	// SelfLatency is the latency not spent in the next node of the path
+	SelfLatency float64 `json:"selfLatency"`
+	// Contribution is the ratio of the self latency to the entry latency
+	Contribution float64 `json:"contribution"`
+}
+
+type DetectCyclesResponse struct {
+	// Cycles are the groups of the services calling each other
+	Cycles [][]string `json:"cycles"`
+}
+
+type GetSinglePointsOfFailureResponse struct {
+	Nodes []SinglePointOfFailure `json:"nodes"`
+}
+
+// SinglePointOfFailure is a node whose failure splits the dependency graph.
+type SinglePointOfFailure struct {
+	Service  string `json:"service"`
+	Category string `json:"category"`
+	// Dependents is the number of the services and peers depending on the node transitively
+	Dependents int `json:"dependents"`
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+const (
+	RolloutComplete    = "complete"
+	RolloutProgressing = "progressing"
+	RolloutPaused      = "paused"
+	RolloutFailed      = "failed"
+)
+
+// K8sWorkload is a Deployment, StatefulSet or DaemonSet with its pods.
+type K8sWorkload struct {
+	ClusterID string   `json:"clusterId"`
+	Namespace string   `json:"namespace"`
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Images    []string `json:"images"`
+	CreatedAt int64    `json:"createdAt"`
+
+	Replicas   K8sReplicaStatus `json:"replicas"`
+	Rollout    K8sRolloutStatus `json:"rollout"`
+	Conditions []K8sCondition   `json:"conditions"`
+	// Revisions are the ReplicaSets of a Deployment, the latest first
+	Revisions []K8sRevision `json:"revisions"`
+	HPA       *K8sHPAStatus `json:"hpa,omitempty"`
+
+	Pods []K8sPod `json:"pods"`
+}
+
+type K8sReplicaStatus struct {
+	Desired   int32 `json:"desired"`
+	Current   int32 `json:"current"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+	Updated   int32 `json:"updated"`
+}
+
+type K8sRolloutStatus struct {
+	// complete / progressing / paused / failed
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	CurrentRevision string `json:"currentRevision,omitempty"`
+	UpdateRevision  string `json:"updateRevision,omitempty"`
+}
+
+type K8sCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime in seconds
+	LastTransitionTime int64 `json:"lastTransitionTime,omitempty"`
+}
+
+type K8sRevision struct {
+	Revision      int64    `json:"revision"`
+	Name          string   `json:"name"`
+	Images        []string `json:"images"`
+	Replicas      int32    `json:"replicas"`
+	ReadyReplicas int32    `json:"readyReplicas"`
+	ChangeCause   string   `json:"changeCause,omitempty"`
+	CreatedAt     int64    `json:"createdAt"`
+}
+
+type K8sHPAStatus struct {
+	Name            string         `json:"name"`
+	MinReplicas     int32          `json:"minReplicas"`
+	MaxReplicas     int32          `json:"maxReplicas"`
+	CurrentReplicas int32          `json:"currentReplicas"`
+	DesiredReplicas int32          `json:"desiredReplicas"`
+	Metrics         []K8sHPAMetric `json:"metrics"`
+	Conditions      []K8sCondition `json:"conditions"`
+}
+
+type K8sHPAMetric struct {
+	// e.g. resource/cpu, pods/http_requests
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Current string `json:"current"`
+}
+
+type K8sPod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	NodeName  string `json:"nodeName"`
+	Phase     string `json:"phase"`
+	Ready     bool   `json:"ready"`
+	Restarts  int32  `json:"restarts"`
+	CreatedAt int64  `json:"createdAt"`
+
+	Containers []K8sContainer `json:"containers"`
+}
+
+type K8sContainer struct {
+	Name     string `json:"name"`
+	Image    string `json:"image"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+
+	Requests K8sResources `json:"requests"`
+	Limits   K8sResources `json:"limits"`
+	// Usage is empty if metrics-server is not deployed
+	Usage *K8sResources `json:"usage,omitempty"`
+}
+
+// K8sResources are the amount of CPU in millicores and memory in bytes.
+type K8sResources struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+type K8sNode struct {
+	ClusterID      string         `json:"clusterId"`
+	Name           string         `json:"name"`
+	Ready          bool           `json:"ready"`
+	Unschedulable  bool           `json:"unschedulable"`
+	KubeletVersion string         `json:"kubeletVersion"`
+	Conditions     []K8sCondition `json:"conditions"`
+
+	Capacity    K8sResources  `json:"capacity"`
+	Allocatable K8sResources  `json:"allocatable"`
+	Usage       *K8sResources `json:"usage,omitempty"`
+}
+
+type GetK8sWorkloadResponse struct {
+	*K8sWorkload
+}
+
+type GetK8sNodeListResponse struct {
+	Nodes []K8sNode `json:"nodes"`
+}
+
+type GetServiceWorkloadsResponse struct {
+	Workloads []K8sWorkload `json:"workloads"`
+	// Nodes are the nodes running the pods of the service
+	Nodes []K8sNode `json:"nodes"`
+	// StandalonePods are the pods of the service not owned by any workload
+	StandalonePods []K8sPod `json:"standalonePods"`
+	// UnresolvedPods are the pods of the service in unregistered clusters, as "cluster/namespace/pod"
+	UnresolvedPods []string `json:"unresolvedPods"`
+}
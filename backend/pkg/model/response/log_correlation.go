@@ -0,0 +1,42 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+)
+
+// LogCorrelation is what the log row is identified as.
+type LogCorrelation struct {
+	TraceID     string `json:"traceId"`
+	SpanID      string `json:"spanId"`
+	PodName     string `json:"podName"`
+	Namespace   string `json:"namespace"`
+	ContainerID string `json:"containerId"`
+	NodeName    string `json:"nodeName"`
+	ServiceName string `json:"serviceName"`
+	EndPoint    string `json:"endpoint"`
+}
+
+type CorrelateLogResponse struct {
+	Correlation LogCorrelation `json:"correlation"`
+	// Time range of the charts, events and alerts in microseconds
+	StartTime int64 `json:"startTime"`
+	EndTime   int64 `json:"endTime"`
+
+	// Spans of the trace in order of start time
+	Trace []clickhouse.CorrelatedSpan `json:"trace"`
+	// Span which writes the log, nil if not found
+	Span *clickhouse.CorrelatedSpan `json:"span"`
+	// RED chart of the service endpoint
+	RedCharts *RedCharts `json:"redCharts"`
+	// K8s events of the pod
+	K8sEvents []clickhouse.K8sEvents `json:"k8sEvents"`
+	// Alerts of the service and the pod
+	Alerts     []alert.AlertEvent `json:"alerts"`
+	AlertTotal uint64             `json:"alertTotal"`
+	// Errors of the correlations which fail, the others are still returned
+	Errors map[string]string `json:"errors,omitempty"`
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type ListLogExportsResponse struct {
+	Exports []database.LogExportJob `json:"exports"`
+}
+
+type ListLogArchiveJobsResponse struct {
+	Jobs []database.LogArchiveJob `json:"jobs"`
+}
+
+type ListLogArchiveRecordsResponse struct {
+	Records []database.LogArchiveRecord `json:"records"`
+}
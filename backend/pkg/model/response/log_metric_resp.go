@@ -0,0 +1,14 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type ListLogMetricsResponse struct {
+	Metrics []database.LogMetric `json:"metrics"`
+}
+
+type ListLogAlertRulesResponse struct {
+	Rules []database.LogAlertRule `json:"rules"`
+}
@@ -18,3 +18,18 @@ type LogParseResponse struct {
 type GetServiceRouteResponse struct {
 	RouteRule map[string]string `json:"routeRule"`
 }
+
+type TestLogParseResponse struct {
+	// Error is the validation error of the parse rule, it would stop Vector from loading the config
+	Error   string                 `json:"error"`
+	Results []LogParseSampleResult `json:"results"`
+}
+
+type LogParseSampleResult struct {
+	Content string `json:"content"`
+	// Matched is false if the regex does not match or the structured log is not a JSON object
+	Matched bool              `json:"matched"`
+	Fields  map[string]string `json:"fields"`
+	// TypeErrors are the values can not be stored as the types of the table fields
+	TypeErrors []string `json:"typeErrors"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+type LogPatternResponse struct {
+	Patterns []LogPattern `json:"patterns"`
+	// Total is the number of clustered logs in the current window
+	Total int `json:"total"`
+	// Sampled means only the latest SampleSize logs are clustered
+	Sampled bool `json:"sampled"`
+	// BaseTotal is the number of clustered logs in the base window
+	BaseTotal   int  `json:"baseTotal,omitempty"`
+	BaseSampled bool `json:"baseSampled,omitempty"`
+}
+
+type LogPattern struct {
+	// Template of the logs, variable tokens are replaced with <*>
+	Template string   `json:"template"`
+	Count    int      `json:"count"`
+	Percent  float64  `json:"percent"`
+	Samples  []string `json:"samples"`
+	// FirstSeen and LastSeen in the current window, in microseconds
+	FirstSeen int64 `json:"firstSeen"`
+	LastSeen  int64 `json:"lastSeen"`
+
+	// Fields below are set when comparing with the base window
+	BaseCount   int     `json:"baseCount"`
+	BasePercent float64 `json:"basePercent"`
+	// IsNew means the template is not found in the base window
+	IsNew bool `json:"isNew"`
+	// IsSpiking means the percent of the template is at least twice of the base window
+	IsSpiking bool `json:"isSpiking"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/model/vector"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+type GetVectorPipelineResponse struct {
+	Pipeline *vector.Pipeline `json:"pipeline"`
+	// Content is the YAML of the pipeline
+	Content string `json:"content"`
+	// Version is 0 if the config is not pushed by APO
+	Version int64 `json:"version"`
+}
+
+type ListVectorPipelineVersionsResponse struct {
+	Versions []database.VectorConfigVersion `json:"versions"`
+}
+
+type DiffVectorPipelineResponse struct {
+	From  int64                  `json:"from"`
+	To    int64                  `json:"to"`
+	Diffs []vector.ComponentDiff `json:"diffs"`
+}
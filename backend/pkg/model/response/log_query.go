@@ -26,3 +26,28 @@ type LogQueryContextResponse struct {
 	Front []LogItem `json:"front"`
 	Back  []LogItem `json:"back"`
 }
+
+// Events of the log tail stream
+const (
+	LogTailEventLogs      = "logs"
+	LogTailEventHeartbeat = "heartbeat"
+	LogTailEventEnd       = "end"
+	LogTailEventError     = "error"
+)
+
+// Reasons of LogTailEventEnd
+const (
+	LogTailEndIdle    = "idle"
+	LogTailEndTimeout = "timeout"
+)
+
+type LogTailResponse struct {
+	Logs []LogItem `json:"logs"`
+	// Cursor is the timestamp of the latest pushed log in microseconds,
+	// can be used as startTime to resume the stream.
+	Cursor int64 `json:"cursor"`
+}
+
+type LogTailEndResponse struct {
+	Reason string `json:"reason"`
+}
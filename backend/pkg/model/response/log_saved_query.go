@@ -0,0 +1,20 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type LogSavedQuery struct {
+	database.LogSavedQuery
+	// Owned means the query is owned by the current user, only the owner can update or delete it
+	Owned bool `json:"owned"`
+}
+
+type ListLogSavedQueriesResponse struct {
+	Queries []LogSavedQuery `json:"queries"`
+}
+
+type ListLogQueryHistoryResponse struct {
+	Histories []database.LogQueryHistory `json:"histories"`
+}
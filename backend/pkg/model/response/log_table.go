@@ -3,7 +3,36 @@
 
 package response
 
+import "github.com/CloudDetail/apo/backend/pkg/model/request"
+
 type LogTableResponse struct {
 	Sqls []string `json:"sqls"`
 	Err  string   `json:"error"`
 }
+
+type InferLogSchemaResponse struct {
+	// Fields are the proposed columns, in order of frequency
+	Fields []InferredLogField `json:"fields"`
+	// Samples is the number of the samples which are JSON objects
+	Samples int `json:"samples"`
+	// Invalid is the number of the samples which are not JSON objects
+	Invalid  int      `json:"invalid"`
+	Warnings []string `json:"warnings"`
+}
+
+// InferredLogField is the proposed column of a field in the structured logs.
+type InferredLogField struct {
+	request.Field
+	// Frequency is the ratio of the samples containing the field
+	Frequency float64  `json:"frequency"`
+	Distinct  int      `json:"distinct"`
+	Examples  []string `json:"examples"`
+	// Hints explain the proposed type, materialized column and index
+	Hints []string `json:"hints,omitempty"`
+}
+
+type UpdateLogSchemaResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Sqls are the statements to execute if DryRun, or the executed statements
+	Sqls []string `json:"sqls"`
+}
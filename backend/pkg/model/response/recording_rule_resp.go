@@ -0,0 +1,25 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+type ListRecordingRulesResponse struct {
+	// RuleFile is the key in the vmalert rule configmap
+	RuleFile string                `json:"ruleFile"`
+	Rules    []RecordingRuleStatus `json:"rules"`
+}
+
+type RecordingRuleStatus struct {
+	Record string   `json:"record"`
+	Metric string   `json:"metric"`
+	Labels []string `json:"labels"`
+	Expr   string   `json:"expr"`
+
+	Deployed   bool  `json:"deployed"`
+	DeployedAt int64 `json:"deployedAt"` // unix seconds
+	// Outdated means the deployed expr differs from the current one
+	Outdated bool `json:"outdated"`
+	// Active means queries are rewritten to use the recorded series
+	Active      bool  `json:"active"`
+	ActiveSince int64 `json:"activeSince"` // unix seconds
+}
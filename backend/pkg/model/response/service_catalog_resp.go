@@ -0,0 +1,24 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type ListServiceCatalogsResponse struct {
+	Catalogs []database.ServiceCatalog `json:"catalogs"`
+}
+
+type ImportServiceCatalogResponse struct {
+	// Imported is the services saved in the catalog
+	Imported []string `json:"imported"`
+	// Skipped is the entities not imported and the reason
+	Skipped []SkippedCatalogEntity `json:"skipped"`
+	// UnknownTeams is the owners not found in the teams, the services are imported without owner
+	UnknownTeams []string `json:"unknownTeams"`
+}
+
+type SkippedCatalogEntity struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/polarisanalyzer"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	"github.com/CloudDetail/apo/backend/pkg/util"
@@ -123,6 +124,8 @@ type ServiceDetail struct {
 	Latency     TempChartObject `json:"latency"`
 	ErrorRate   TempChartObject `json:"errorRate"`
 	Tps         TempChartObject `json:"tps"` // FIXME name is tps, actual requests per minute
+	// Anomaly scores compared with the seasonal baseline, nil if no baseline learned
+	Anomaly *AnomalyScore `json:"anomaly,omitempty"`
 }
 
 type RedCharts struct {
@@ -158,6 +161,8 @@ type ServiceEndPointsRes struct {
 	Namespaces     []string        `json:"namespaces"` // The namespace of the application. It may be empty
 	EndpointCount  int             `json:"endpointCount"`
 	ServiceDetails []ServiceDetail `json:"serviceDetails"`
+	// Catalog is the metadata maintained by users, nil if the service is not in the catalog
+	Catalog *database.ServiceCatalog `json:"catalog,omitempty"`
 }
 
 type ServiceRYGLightRes struct {
@@ -173,7 +178,8 @@ const (
 )
 
 type ServiceRYGResult struct {
-	ServiceName string `json:"serviceName"`
+	ServiceName string                   `json:"serviceName"`
+	Catalog     *database.ServiceCatalog `json:"catalog,omitempty"`
 
 	RYGResult
 }
@@ -197,6 +203,36 @@ type RYGScoreDetail struct {
 	Detail string `json:"detail"`
 }
 
+type GetHealthScoreModelResponse struct {
+	// Custom is false when the built-in model is used
+	Custom      bool                 `json:"custom"`
+	Aggregation string               `json:"aggregation"`
+	Signals     []model.HealthSignal `json:"signals"`
+}
+
+type ExplainRYGLightResponse struct {
+	ServiceName string `json:"serviceName"`
+	RYGResult
+	Custom      bool   `json:"custom"`
+	Aggregation string `json:"aggregation"`
+	// DeterminedBy is the signal which decides the final color when aggregation is worst
+	DeterminedBy string                  `json:"determinedBy,omitempty"`
+	Signals      []RYGSignalContribution `json:"signals"`
+}
+
+// RYGSignalContribution shows how a signal contributes to the final color.
+type RYGSignalContribution struct {
+	Key        string     `json:"key"`
+	Value      *float64   `json:"value"`
+	Thresholds [3]float64 `json:"thresholds"`
+	Score      int        `json:"score"` // 0 - 3
+	Weight     float64    `json:"weight"`
+	// Contribution points of the signal in the final percent score
+	Contribution float64 `json:"contribution"`
+	Skipped      bool    `json:"skipped"` // no data and no missingScore
+	Detail       string  `json:"detail"`
+}
+
 type InstanceData struct {
 	Name      string          `json:"name"` // Instance name
 	Namespace string          `json:"namespace"`
@@ -0,0 +1,26 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type ListSLOResponse struct {
+	SLOs []database.SLO `json:"slos"`
+}
+
+type GetSLOStatusResponse struct {
+	SLO database.SLO `json:"slo"`
+	// SLI percentage of good requests in the compliance window, nil if no request
+	SLI *float64 `json:"sli"`
+	// ErrorBudgetRemaining ratio of the error budget not consumed yet, negative when the SLO is violated
+	ErrorBudgetRemaining *float64      `json:"errorBudgetRemaining"`
+	BurnRates            []SLOBurnRate `json:"burnRates"`
+}
+
+type SLOBurnRate struct {
+	// Window e.g. 5m / 1h / 6h
+	Window string `json:"window"`
+	// BurnRate how fast the error budget is consumed, 1 means the budget is exactly used up at the end of the window
+	BurnRate *float64 `json:"burnRate"`
+}
@@ -0,0 +1,11 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+// ExportTopologyResponse is the topology rendered as a file.
+type ExportTopologyResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package response
+
+import "github.com/CloudDetail/apo/backend/pkg/repository/database"
+
+type TopologySnapshotSummary struct {
+	ID        int64  `json:"id"`
+	ClusterID string `json:"clusterId"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+	NodeCount int    `json:"nodeCount"`
+	EdgeCount int    `json:"edgeCount"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type ListTopologySnapshotsResponse struct {
+	Snapshots []TopologySnapshotSummary `json:"snapshots"`
+}
+
+type GetTopologySnapshotResponse struct {
+	*database.TopologySnapshot
+}
+
+type DiffTopologyResponse struct {
+	Base   TopologySnapshotSummary `json:"base"`
+	Target TopologySnapshotSummary `json:"target"`
+
+	AddedNodes   []database.TopologySnapshotNode `json:"addedNodes"`
+	RemovedNodes []database.TopologySnapshotNode `json:"removedNodes"`
+	AddedEdges   []database.TopologySnapshotEdge `json:"addedEdges"`
+	RemovedEdges []database.TopologySnapshotEdge `json:"removedEdges"`
+	// ChangedEdges are the edges in both snapshots whose calls or latency changed more than the ratio
+	ChangedEdges []TopologyEdgeChange `json:"changedEdges"`
+}
+
+// TopologyEdgeChange compares an edge in two snapshots, the changes are relative, e.g. 1 means doubled.
+// The calls are compared per minute as the windows of the snapshots may differ.
+type TopologyEdgeChange struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+
+	BaseCallsPerMinute   float64 `json:"baseCallsPerMinute"`
+	TargetCallsPerMinute float64 `json:"targetCallsPerMinute"`
+	CallsChange          float64 `json:"callsChange"`
+
+	BaseAvgLatency   float64 `json:"baseAvgLatency"`
+	TargetAvgLatency float64 `json:"targetAvgLatency"`
+	LatencyChange    float64 `json:"latencyChange"`
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+// Types of the transforms managed by APO, the other types are kept as is.
+const (
+	TransformRemap    = "remap"
+	TransformRoute    = "route"
+	TransformFilter   = "filter"
+	TransformSample   = "sample"
+	TransformDedupe   = "dedupe"
+	TransformThrottle = "throttle"
+)
+
+// Kinds of the components
+const (
+	KindSource    = "source"
+	KindTransform = "transform"
+	KindSink      = "sink"
+)
+
+// Pipeline is the aggregator config of Vector.
+type Pipeline struct {
+	Sources    map[string]*Component `yaml:"sources" json:"sources"`
+	Transforms map[string]*Component `yaml:"transforms" json:"transforms"`
+	Sinks      map[string]*Component `yaml:"sinks" json:"sinks"`
+}
+
+// Component is a source, transform or sink of Vector.
+// The settings of the transforms managed by APO are typed, the others are kept in Options.
+type Component struct {
+	Type   string   `yaml:"type" json:"type"`
+	Inputs []string `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+
+	// remap: VRL program
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+	// route: route name -> condition, the outputs are referred as <transform>.<route>
+	Route map[string]any `yaml:"route,omitempty" json:"route,omitempty"`
+	// filter: condition to keep the events
+	Condition any `yaml:"condition,omitempty" json:"condition,omitempty"`
+	// sample: keep 1/rate of the events
+	Rate int `yaml:"rate,omitempty" json:"rate,omitempty"`
+	// sample and throttle: events with the same value of the field are sampled or throttled together
+	KeyField string `yaml:"key_field,omitempty" json:"keyField,omitempty"`
+	// sample: condition of the events never sampled
+	Exclude any `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	// dedupe: fields used to find the duplicated events
+	Fields *DedupeFields `yaml:"fields,omitempty" json:"fields,omitempty"`
+	// dedupe: number of events cached
+	Cache *DedupeCache `yaml:"cache,omitempty" json:"cache,omitempty"`
+	// throttle: number of events allowed in the window
+	Threshold int `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	// throttle: window in seconds
+	WindowSecs float64 `yaml:"window_secs,omitempty" json:"windowSecs,omitempty"`
+
+	// Options are the other settings, e.g. of the sources and sinks
+	Options map[string]any `yaml:",inline" json:"options,omitempty"`
+}
+
+type DedupeFields struct {
+	Match  []string `yaml:"match,omitempty" json:"match,omitempty"`
+	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+}
+
+type DedupeCache struct {
+	Num int `yaml:"num" json:"num"`
+}
+
+// Changes of the components between versions
+const (
+	ComponentAdded    = "added"
+	ComponentRemoved  = "removed"
+	ComponentModified = "modified"
+)
+
+type ComponentDiff struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Change string `json:"change"`
+	// Before and After are the YAML of the component
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
 	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
 
 	pmodel "github.com/prometheus/common/model"
 )
@@ -60,6 +61,8 @@ func (r *InnerReceivers) sendAlert(ctx core.Context, alert *alert.AlertEvent) er
 	var success []string
 	var errs error
 
+	catalogAnnos := r.catalogAnnotations(ctx, alert)
+
 	gCtx := context.Background()
 
 	gCtx = notify.WithGroupKey(gCtx, "alertName")
@@ -69,6 +72,7 @@ func (r *InnerReceivers) sendAlert(ctx core.Context, alert *alert.AlertEvent) er
 
 		for _, integration := range integrations {
 			alerts := alert.ToAMAlert(r.externalURL.String(), false)
+			addAbsentAnnotations(alerts, catalogAnnos)
 			var err error
 			var shouldRetry bool
 			for retry := 3; retry > 0; retry-- {
@@ -102,3 +106,45 @@ func (r *InnerReceivers) sendAlert(ctx core.Context, alert *alert.AlertEvent) er
 
 	return errs
 }
+
+// catalogAnnotations returns the owner, tier and runbook of the alerted service from the service catalog,
+// so the receivers can route and act on the notification.
+func (r *InnerReceivers) catalogAnnotations(ctx core.Context, alert *alert.AlertEvent) pmodel.LabelSet {
+	annos := make(pmodel.LabelSet)
+	serviceName := alert.GetServiceNameTag()
+	if r.database == nil || len(serviceName) == 0 {
+		return annos
+	}
+	catalog, err := r.database.GetServiceCatalog(ctx, serviceName)
+	if err != nil {
+		r.logger.Warn("failed to get service catalog", "service", serviceName, "err", err)
+		return annos
+	}
+	if catalog == nil {
+		return annos
+	}
+
+	values := map[pmodel.LabelName]string{
+		"owner_team":     catalog.TeamName,
+		"tier":           catalog.Tier,
+		"runbook_url":    catalog.RunbookURL,
+		"oncall":         catalog.OnCall,
+		"repository_url": catalog.RepositoryURL,
+	}
+	for k, v := range values {
+		if len(v) > 0 {
+			annos[k] = pmodel.LabelValue(v)
+		}
+	}
+	return annos
+}
+
+// addAbsentAnnotations adds the annotations which are not set by the alert,
+// e.g. the runbook_url of the alert rule is kept rather than the one of the service.
+func addAbsentAnnotations(amAlert *types.Alert, annos pmodel.LabelSet) {
+	for k, v := range annos {
+		if _, find := amAlert.Annotations[k]; !find {
+			amAlert.Annotations[k] = v
+		}
+	}
+}
@@ -77,3 +77,15 @@ func TestBuildInnerReceiver(t *testing.T) {
 		assert.False(t, ok)
 	}
 }
+
+func TestAddAbsentAnnotations(t *testing.T) {
+	amAlert := &types.Alert{}
+	amAlert.Annotations = model.LabelSet{"runbook_url": "https://runbook/rule"}
+
+	addAbsentAnnotations(amAlert, model.LabelSet{
+		"runbook_url": "https://runbook/service",
+		"owner_team":  "shop",
+	})
+	assert.Equal(t, model.LabelValue("https://runbook/rule"), amAlert.Annotations["runbook_url"])
+	assert.Equal(t, model.LabelValue("shop"), amAlert.Annotations["owner_team"])
+}
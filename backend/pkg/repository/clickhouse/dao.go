@@ -35,6 +35,8 @@ type Repo interface {
 	ListDescendantRelations(ctx core.Context, req *request.GetServiceEndpointTopologyRequest) ([]*model.TopologyRelation, error)
 	// Query the entry node list
 	ListEntryEndpoints(ctx core.Context, req *request.GetServiceEntryEndpointsRequest) ([]EntryNode, error)
+	// Query the list of entry nodes calling the service or peer
+	ListEntryEndpointsOfNode(ctx core.Context, startTime int64, endTime int64, node string) ([]EntryNode, error)
 
 	SearchEntryEndpointsByAlertService(ctx core.Context, endpoints []AlertService, startTime, endTime int64) ([]EntryRelationship, error)
 	// Query Service Topology
@@ -70,11 +72,27 @@ type Repo interface {
 	DropLogTable(ctx core.Context, req *request.LogTableRequest) ([]string, error)
 	UpdateLogTable(ctx core.Context, req *request.LogTableRequest, old []request.Field) ([]string, error)
 
-	queryRowsData(ctx core.Context, sql string) ([]map[string]any, error)
+	queryRowsData(ctx core.Context, sql string, args ...any) ([]map[string]any, error)
 
 	QueryAllLogs(ctx core.Context, req *request.LogQueryRequest) ([]map[string]any, string, error)
 	QueryLogContext(ctx core.Context, req *request.LogQueryContextRequest) ([]map[string]any, []map[string]any, error)
 	GetLogChart(ctx core.Context, req *request.LogQueryRequest) ([]map[string]any, int64, error)
+	// QueryLogContents returns the time and content of the latest logs, used by the log pattern mining
+	QueryLogContents(ctx core.Context, req *request.LogQueryRequest, limit int) ([]LogContentRow, error)
+	// QueryLogMetric aggregates logs into the samples of a log metric
+	QueryLogMetric(ctx core.Context, q *LogMetricQuery) ([]LogMetricSample, error)
+	// TailLogs returns the earliest logs written since from, used by the live log tail
+	TailLogs(ctx core.Context, req *request.LogTailRequest, from time.Time, limit int) ([]map[string]any, error)
+	// QuerySampleLogs returns the latest raw logs matching the route rule, used to test the parse rules
+	QuerySampleLogs(ctx core.Context, dataBase string, routeRule map[string]string, limit int) ([]string, error)
+	// CountLogs returns the number of logs written to the tables in [from, to)
+	CountLogs(ctx core.Context, dataBase string, tables []string, from, to time.Time) (uint64, error)
+	// ExportLogs scans the latest logs matching the query in batches, used by the log export
+	ExportLogs(ctx core.Context, req *request.LogQueryRequest, limit int, batchSize int, handle LogBatchHandler) error
+	// ExportLogsOfDay scans the logs of a day in batches, used by the log archive
+	ExportLogsOfDay(ctx core.Context, dataBase string, table string, day time.Time, batchSize int, handle LogBatchHandler) error
+	ListLogDays(ctx core.Context, dataBase string, table string, before time.Time) ([]LogDay, error)
+	GetLogTableTTLDays(ctx core.Context, dataBase string, table string) (int, error)
 	GetLogIndex(ctx core.Context, req *request.LogIndexRequest) (map[string]uint64, uint64, error)
 
 	OtherLogTable(ctx core.Context) ([]map[string]any, error)
@@ -102,6 +120,12 @@ type Repo interface {
 	// ========== k8s events ============
 	// SeverityNumber > 9 (warning)
 	GetK8sAlertEventsSample(ctx core.Context, startTime time.Time, endTime time.Time, instances []*model.ServiceInstance) ([]K8sEvents, error)
+	// All the k8s events of a pod, used by the log correlation
+	GetPodK8sEvents(ctx core.Context, startTime, endTime time.Time, namespace, podName string) ([]K8sEvents, error)
+
+	// ========== log correlation ============
+	ListTraceSpans(ctx core.Context, traceID string, startTime, endTime time.Time) ([]CorrelatedSpan, error)
+	FindSpanAt(ctx core.Context, podName, containerID string, at time.Time, startTime, endTime time.Time) (*CorrelatedSpan, error)
 
 	// profiling_event
 	// GetOnOffCPU get span execution consumption
@@ -136,10 +160,16 @@ type Repo interface {
 	QueryGroupServiceRedMetrics(ctx core.Context, startTime int64, endTime int64, clusterId string, serviceName string, endpoint string, step int64) ([]BucketRedMetric, error)
 	QueryGroupServiceRedMetricValue(ctx core.Context, startTime int64, endTime int64, clusterId string, serviceName string, endpoint string) (*GroupRedMetric, error)
 	QueryRealtimeServiceTopology(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]model.ServiceToplogy, error)
+	QueryServiceCallCounts(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]ServiceCallCount, error)
+	QueryServiceEdgeLatencies(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]ServiceEdgeLatency, error)
 	GetToResolveApps(ctx core.Context) ([]*model.AppInfo, error)
+	ListAppInfos(ctx core.Context, startTime int64, endTime int64) ([]*model.AppInfo, error)
 	ListAppInfoLabelKeys(ctx core.Context, startTime, endTime int64) ([]string, error)
 	ListAppInfoLabelValues(ctx core.Context, startTime, endTime int64, key string) ([]string, error)
 
+	// SetDataScopeProvider enables filtering logs, traces and alert events by the data scopes of the requesting user
+	SetDataScopeProvider(provider DataScopeProvider)
+
 	integration.Input
 }
 
@@ -148,6 +178,8 @@ type chRepo struct {
 
 	database string
 	availableFilters
+	// scopeProvider is nil if the data scopes are not enforced
+	scopeProvider DataScopeProvider
 
 	integration.Input
 }
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 )
@@ -173,9 +174,14 @@ func sortbyParam(sortBy string) ([]string, []bool) {
 }
 
 func (ch *chRepo) GetAlertEventWithWorkflowRecord(ctx core.Context, req *request.AlertEventSearchRequest, cacheMinutes int) ([]alert.AEventWithWRecord, int64, error) {
+	scope, err := ch.dataScopeCondition(ctx, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns)
+	if err != nil {
+		return nil, 0, err
+	}
 	alertFilter := NewQueryBuilder().
 		Between("update_time", req.StartTime/1e6, req.EndTime/1e6).
-		NotGreaterThan("end_time", req.EndTime/1e6)
+		NotGreaterThan("end_time", req.EndTime/1e6).
+		And(scope)
 
 	if req.GroupID > 0 {
 		alertFilter.Equals("ae.raw_tags['groupId']", strconv.FormatInt(req.GroupID, 10))
@@ -208,7 +214,7 @@ func (ch *chRepo) GetAlertEventWithWorkflowRecord(ctx core.Context, req *request
 		}
 	}
 
-	err := applyFilter(req.Filters, resultFilter, alertFilter)
+	err = applyFilter(req.Filters, resultFilter, alertFilter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -222,7 +228,7 @@ func (ch *chRepo) GetAlertEventWithWorkflowRecord(ctx core.Context, req *request
 		return nil, 0, err
 	}
 
-	sql, values, err := getSqlAndValueForSortedAlertEvent(req, cacheMinutes)
+	sql, values, err := getSqlAndValueForSortedAlertEvent(req, cacheMinutes, scope)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -244,10 +250,11 @@ func buildCountQuery(alertFilter *QueryBuilder, recordFilter *QueryBuilder, resu
 	)
 }
 
-func getSqlAndValueForSortedAlertEvent(req *request.AlertEventSearchRequest, cacheMinutes int) (string, []any, error) {
+func getSqlAndValueForSortedAlertEvent(req *request.AlertEventSearchRequest, cacheMinutes int, scope *whereSQL) (string, []any, error) {
 	alertFilter := NewQueryBuilder().
 		Between("update_time", req.StartTime/1e6, req.EndTime/1e6).
-		NotGreaterThan("end_time", req.EndTime/1e6)
+		NotGreaterThan("end_time", req.EndTime/1e6).
+		And(scope)
 
 	if req.GroupID > 0 {
 		alertFilter.Equals("ae.raw_tags['groupId']", strconv.FormatInt(req.GroupID, 10))
@@ -319,6 +326,9 @@ func (ch *chRepo) GetAlertEventCounts(ctx core.Context, req *request.AlertEventS
 	if req.GroupID > 0 {
 		alertFilter.Equals("ae.raw_tags['groupId']", strconv.FormatInt(req.GroupID, 10))
 	}
+	if err := ch.andDataScope(ctx, alertFilter, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns); err != nil {
+		return nil, err
+	}
 
 	var counts []_alertEventCount
 	intervalMicro := int64(cacheMinutes) * int64(time.Minute) / 1e3
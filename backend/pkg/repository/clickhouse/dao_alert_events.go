@@ -111,6 +111,10 @@ func (ch *chRepo) GetAlertEventCountGroupByInstance(ctx core.Context, startTime
 		builder.And(whereInstance)
 	}
 
+	if err := ch.andDataScope(ctx, builder, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns); err != nil {
+		return nil, err
+	}
+
 	groupByInstance := `group,severity,tags['svc_name'],tags['content_key'],tags['namespace'],tags['pod'],tags['src_namespace'], tags['src_pod'],tags['src_node'],tags['pid'],tags['instance_name']`
 
 	sql := fmt.Sprintf(SQL_GET_GROUP_COUNTS_ALERT_EVENT, groupByInstance, groupByInstance, builder.String())
@@ -142,6 +146,9 @@ func (ch *chRepo) GetAlertEventsSample(ctx core.Context, sampleCount int, startT
 	if len(filter.ClusterIDs) > 0 {
 		builder.InStrings("cluster_id", filter.ClusterIDs)
 	}
+	if err := ch.andDataScope(ctx, builder, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns); err != nil {
+		return nil, err
+	}
 
 	byBuilder := NewByLimitBuilder().
 		OrderBy("group", true).
@@ -202,6 +209,9 @@ func (ch *chRepo) GetAlertEvents(ctx core.Context, startTime time.Time, endTime
 	if len(filter.ClusterIDs) > 0 {
 		builder.InStrings("cluster_id", filter.ClusterIDs)
 	}
+	if err := ch.andDataScope(ctx, builder, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns); err != nil {
+		return nil, 0, err
+	}
 
 	var count uint64
 	countSql := buildAlertEventsCountQuery(GET_ALERT_EVENTS_COUNT, builder)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
 )
 
@@ -36,6 +37,9 @@ func (ch *chRepo) GetAlertsWithEventCount(ctx core.Context,
 	alertFilter := NewQueryBuilder().
 		Between("received_time", startTime.Unix(), endTime.Unix()).
 		And(whereSQL)
+	if err := ch.andDataScope(ctx, alertFilter, datagroup.DATASOURCE_CATEGORY_ALERT, alertScopeColumns); err != nil {
+		return nil, 0, err
+	}
 
 	var count uint64
 	countSql := buildAlertQuery(GET_ALERT_EVENTS_COUNT, alertFilter)
@@ -39,3 +39,29 @@ func (ch *chRepo) GetToResolveApps(ctx core.Context) ([]*model.AppInfo, error) {
 
 	return result, nil
 }
+
+// ListAppInfos returns the latest info of the processes reported in [startTime, endTime] (microseconds).
+func (ch *chRepo) ListAppInfos(ctx core.Context, startTime int64, endTime int64) ([]*model.AppInfo, error) {
+	qb := NewQueryBuilder().
+		Between("timestamp", startTime/1e6, endTime/1e6)
+	query := "SELECT * FROM originx_app_info " + qb.String() + " ORDER BY timestamp DESC"
+
+	apps := []model.AppInfo{}
+	err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &apps, query, qb.values...)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	result := make([]*model.AppInfo, 0)
+	for i := range apps {
+		app := &apps[i]
+		key := fmt.Sprintf("%s-%s-%s-%d-%d", app.Labels["node_ip"], app.Labels["node_name"], app.Labels["cluster_id"], app.StartTime, app.HostPid)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, app)
+	}
+	return result, nil
+}
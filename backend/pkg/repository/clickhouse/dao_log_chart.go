@@ -30,9 +30,8 @@ func calculateInterval(interval int64, timeField string) (string, int64) {
 
 const queryLogChart = "SELECT count(`%s`) as count, %s as timeline FROM `%s`.`%s` WHERE %s GROUP BY %s ORDER BY %s ASC"
 
-func chartSQL(baseQuery string, req *request.LogQueryRequest) (string, int64) {
+func chartSQL(baseQuery string, req *request.LogQueryRequest, condition string) (string, int64) {
 	group, interval := calculateInterval((req.EndTime-req.StartTime)/1000000, req.TimeField)
-	condition := NewQueryCondition(req.StartTime, req.EndTime, req.TimeField, req.Query)
 	sql := fmt.Sprintf(baseQuery,
 		req.TimeField,
 		group,
@@ -45,8 +44,12 @@ func chartSQL(baseQuery string, req *request.LogQueryRequest) (string, int64) {
 }
 
 func (ch *chRepo) GetLogChart(ctx core.Context, req *request.LogQueryRequest) ([]map[string]any, int64, error) {
-	sql, interval := chartSQL(queryLogChart, req)
-	results, err := ch.queryRowsData(ctx, sql)
+	condition, _, err := ch.newLogQueryCondition(ctx, logQueryParamsOf(req))
+	if err != nil {
+		return nil, 0, err
+	}
+	sql, interval := chartSQL(queryLogChart, req, condition.Wheres)
+	results, err := ch.queryRowsData(ctx, sql, condition.Values...)
 	if err != nil {
 		return nil, interval, err
 	}
@@ -0,0 +1,36 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// aliases are not the same as the columns, otherwise they replace the columns in WHERE
+const queryLogContentsSQL = "SELECT %s AS log_time, toString(%s) AS log_content FROM `%s`.`%s` WHERE %s ORDER BY %s DESC LIMIT %d"
+
+type LogContentRow struct {
+	Timestamp time.Time `ch:"log_time"`
+	Content   string    `ch:"log_content"`
+}
+
+// QueryLogContents returns the time and content of the latest logs matching req.
+func (ch *chRepo) QueryLogContents(ctx core.Context, req *request.LogQueryRequest, limit int) ([]LogContentRow, error) {
+	condition, _, err := ch.newLogQueryCondition(ctx, logQueryParamsOf(req))
+	if err != nil {
+		return nil, err
+	}
+	timeField, logField := quoteColumn(req.TimeField), quoteColumn(req.LogField)
+	sql := fmt.Sprintf(queryLogContentsSQL, timeField, logField, req.DataBase, req.TableName, condition.Wheres, timeField, limit)
+
+	var result []LogContentRow
+	if err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &result, sql, condition.Values...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
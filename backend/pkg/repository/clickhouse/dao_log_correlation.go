@@ -0,0 +1,122 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
+)
+
+const (
+	// maxTraceSpans limits the spans of a trace returned for correlation
+	maxTraceSpans = 500
+	// maxPodK8sEvents limits the k8s events of a pod returned for correlation
+	maxPodK8sEvents = 100
+
+	SQL_GET_POD_K8S_EVENTS = `SELECT Timestamp,SeverityText,Body,ResourceAttributes,LogAttributes
+		FROM k8s_events
+		%s %s`
+)
+
+// CorrelatedSpan is a span of span_trace related to a log.
+type CorrelatedSpan struct {
+	TraceId     string `ch:"trace_id" json:"traceId"`
+	SpanId      string `ch:"span_id" json:"spanId"`
+	StartTime   uint64 `ch:"start_time_us" json:"startTime"`
+	EndTime     uint64 `ch:"end_time_us" json:"endTime"`
+	Duration    uint64 `ch:"duration_us" json:"duration"`
+	ServiceName string `ch:"service_name" json:"serviceName"`
+	EndPoint    string `ch:"endpoint" json:"endpoint"`
+	InstanceId  string `ch:"instance_id" json:"instanceId"`
+	PodName     string `ch:"pod_name" json:"podName"`
+	Namespace   string `ch:"namespace" json:"namespace"`
+	ContainerId string `ch:"container_id" json:"containerId"`
+	NodeName    string `ch:"node_name" json:"nodeName"`
+	ClusterID   string `ch:"cluster_id" json:"clusterId"`
+	Pid         uint32 `ch:"pid" json:"pid"`
+	IsError     bool   `ch:"is_error" json:"isError"`
+	IsSlow      bool   `ch:"is_slow" json:"isSlow"`
+}
+
+// ListTraceSpans returns the spans of the trace in order of start time.
+func (ch *chRepo) ListTraceSpans(ctx core.Context, traceID string, startTime, endTime time.Time) ([]CorrelatedSpan, error) {
+	builder := NewQueryBuilder().
+		Between("timestamp", startTime.Unix(), endTime.Unix()).
+		Equals("trace_id", traceID)
+	bySql := NewByLimitBuilder().
+		OrderBy("start_time", true).
+		Limit(maxTraceSpans).
+		String()
+	return ch.queryCorrelatedSpans(ctx, builder, bySql)
+}
+
+// FindSpanAt returns the span running on the pod or the container at the time,
+// the nearest span within the time range is returned if there is no such span.
+func (ch *chRepo) FindSpanAt(ctx core.Context, podName, containerID string, at time.Time, startTime, endTime time.Time) (*CorrelatedSpan, error) {
+	builder := NewQueryBuilder().
+		Between("timestamp", startTime.Unix(), endTime.Unix()).
+		EqualsNotEmpty("labels['pod_name']", podName).
+		EqualsNotEmpty("labels['container_id']", containerID)
+	nanos := at.UnixNano()
+	bySql := fmt.Sprintf(
+		"ORDER BY if(start_time <= %d AND end_time >= %d, 0, least(abs(toInt64(start_time) - %d), abs(toInt64(end_time) - %d))) ASC, duration ASC LIMIT 1",
+		nanos, nanos, nanos, nanos)
+	spans, err := ch.queryCorrelatedSpans(ctx, builder, bySql)
+	if err != nil || len(spans) == 0 {
+		return nil, err
+	}
+	return &spans[0], nil
+}
+
+func (ch *chRepo) queryCorrelatedSpans(ctx core.Context, builder *QueryBuilder, bySql string) ([]CorrelatedSpan, error) {
+	if err := ch.andDataScope(ctx, builder, datagroup.DATASOURCE_CATEGORY_APM, apmScopeColumns); err != nil {
+		return nil, err
+	}
+	fieldSql := NewFieldBuilder().
+		Fields("trace_id", "pid").
+		Alias("apm_span_id", "span_id").
+		Alias("intDiv(start_time, 1000)", "start_time_us").
+		Alias("intDiv(end_time, 1000)", "end_time_us").
+		Alias("intDiv(duration, 1000)", "duration_us").
+		Alias("labels['service_name']", "service_name").
+		Alias("labels['content_key']", "endpoint").
+		Alias("labels['instance_id']", "instance_id").
+		Alias("labels['pod_name']", "pod_name").
+		Alias("labels['namespace']", "namespace").
+		Alias("labels['container_id']", "container_id").
+		Alias("labels['node_name']", "node_name").
+		Alias("labels['cluster_id']", "cluster_id").
+		Alias("flags['is_error']", "is_error").
+		Alias("flags['is_slow']", "is_slow").
+		String()
+	sql := buildSpanTraceQuery(TEMPLATE_QUERY_SPAN_TRACE, fieldSql, bySql, builder)
+	spans := []CorrelatedSpan{}
+	if err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &spans, sql, builder.values...); err != nil {
+		return nil, err
+	}
+	return spans, nil
+}
+
+// GetPodK8sEvents returns all the k8s events of the pod in the time range, the latest first.
+func (ch *chRepo) GetPodK8sEvents(ctx core.Context, startTime, endTime time.Time, namespace, podName string) ([]K8sEvents, error) {
+	builder := NewQueryBuilder().
+		Between("Timestamp", startTime.Unix(), endTime.Unix()).
+		Equals("ResourceAttributes['k8s.object.kind']", "Pod").
+		Equals("ResourceAttributes['k8s.object.name']", podName).
+		EqualsNotEmpty("ResourceAttributes['k8s.namespace.name']", namespace)
+	bySql := NewByLimitBuilder().
+		OrderBy("Timestamp", false).
+		Limit(maxPodK8sEvents).
+		String()
+
+	query := fmt.Sprintf(SQL_GET_POD_K8S_EVENTS, builder.String(), bySql)
+	events := []K8sEvents{}
+	if err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &events, query, builder.values...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
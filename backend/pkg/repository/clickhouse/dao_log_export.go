@@ -0,0 +1,127 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+const (
+	exportLogsSQL  = "SELECT * FROM `%s`.`%s` WHERE %s ORDER BY %s DESC LIMIT %d"
+	logsOfDaySQL   = "SELECT * FROM `%s`.`%s` WHERE timestamp >= ? AND timestamp < ?"
+	logDaysSQL     = "SELECT toDate(timestamp) AS day, count() FROM `%s`.`%s` WHERE timestamp < ? GROUP BY day ORDER BY day"
+	tableEngineSQL = "SELECT name, engine_full FROM system.tables WHERE database = ? AND name IN (?, ?)"
+)
+
+// ttlDaysRegex matches the TTL of the log tables created by APO, e.g. TTL toDateTime(timestamp) + toIntervalDay(7)
+var ttlDaysRegex = regexp.MustCompile(`TTL .*toIntervalDay\((\d+)\)`)
+
+// LogBatchHandler handles a batch of the scanned logs, columns are in the order of the table.
+type LogBatchHandler func(columns []string, logs []map[string]any) error
+
+type LogDay struct {
+	Day   time.Time
+	Count uint64
+}
+
+// ExportLogs scans the latest logs matching the query, handle is called for every batchSize logs.
+func (ch *chRepo) ExportLogs(ctx core.Context, req *request.LogQueryRequest, limit int, batchSize int, handle LogBatchHandler) error {
+	condition, _, err := ch.newLogQueryCondition(ctx, logQueryParamsOf(req))
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(exportLogsSQL, req.DataBase, req.TableName, condition.Wheres, quoteColumn(req.TimeField), limit)
+	return ch.scanLogs(ctx, sql, condition.Values, batchSize, handle)
+}
+
+// ExportLogsOfDay scans the logs of the day (UTC), which is a partition of the log tables.
+func (ch *chRepo) ExportLogsOfDay(ctx core.Context, dataBase string, table string, day time.Time, batchSize int, handle LogBatchHandler) error {
+	sql := fmt.Sprintf(logsOfDaySQL, dataBase, table)
+	return ch.scanLogs(ctx, sql, []any{day, day.AddDate(0, 0, 1)}, batchSize, handle)
+}
+
+// ListLogDays returns the days which have logs before the time, with the number of logs of each day.
+func (ch *chRepo) ListLogDays(ctx core.Context, dataBase string, table string, before time.Time) ([]LogDay, error) {
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), fmt.Sprintf(logDaysSQL, dataBase, table), before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var days []LogDay
+	for rows.Next() {
+		var day LogDay
+		if err := rows.Scan(&day.Day, &day.Count); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// GetLogTableTTLDays returns the TTL of the log table in days, 0 if no TTL is set.
+// For the distributed tables, the TTL is read from the local table.
+func (ch *chRepo) GetLogTableTTLDays(ctx core.Context, dataBase string, table string) (int, error) {
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), tableEngineSQL, dataBase, table, table+"_local")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var days int
+	for rows.Next() {
+		var name, engine string
+		if err := rows.Scan(&name, &engine); err != nil {
+			return 0, err
+		}
+		if ttl := parseTTLDays(engine); ttl > 0 {
+			days = ttl
+		}
+	}
+	return days, rows.Err()
+}
+
+func parseTTLDays(engine string) int {
+	matches := ttlDaysRegex.FindStringSubmatch(engine)
+	if len(matches) < 2 {
+		return 0
+	}
+	days, _ := strconv.Atoi(matches[1])
+	return days
+}
+
+func (ch *chRepo) scanLogs(ctx core.Context, sql string, args []any, batchSize int, handle LogBatchHandler) error {
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnNames := rows.Columns()
+	valuePtrs := scanTargets(rows.ColumnTypes())
+	batch := make([]map[string]any, 0, batchSize)
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return err
+		}
+		batch = append(batch, scannedRow(columnNames, valuePtrs))
+		if len(batch) >= batchSize {
+			if err := handle(columnNames, batch); err != nil {
+				return err
+			}
+			batch = make([]map[string]any, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return handle(columnNames, batch)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import "testing"
+
+func TestParseTTLDays(t *testing.T) {
+	tests := []struct {
+		engine string
+		want   int
+	}{
+		{"MergeTree PARTITION BY toDate(timestamp) ORDER BY (host_ip, timestamp) TTL toDateTime(timestamp) + toIntervalDay(7) SETTINGS index_granularity = 8192, ttl_only_drop_parts = 1", 7},
+		{"ReplicatedMergeTree('/clickhouse/tables/{uuid}/{shard}', '{replica}') PARTITION BY toDate(timestamp) ORDER BY (host_ip, timestamp) TTL toDateTime(timestamp) + toIntervalDay(30) SETTINGS index_granularity = 8192", 30},
+		{"MergeTree PARTITION BY toDate(timestamp) ORDER BY timestamp SETTINGS index_granularity = 8192", 0},
+		{"Distributed('cluster', 'apo', 'logs_local', rand())", 0},
+	}
+	for _, tt := range tests {
+		if got := parseTTLDays(tt.engine); got != tt.want {
+			t.Errorf("parseTTLDays(%s) = %d, want %d", tt.engine, got, tt.want)
+		}
+	}
+}
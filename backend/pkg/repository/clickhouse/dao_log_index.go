@@ -12,8 +12,7 @@ import (
 
 const groupLogIndexQuery = "SELECT count(*) as count, `%s` as f FROM `%s`.`%s` WHERE %s GROUP BY %s ORDER BY count DESC LIMIT 10"
 
-func groupBySQL(baseQuery string, req *request.LogIndexRequest) string {
-	condition := NewQueryCondition(req.StartTime, req.EndTime, req.TimeField, req.Query)
+func groupBySQL(baseQuery string, req *request.LogIndexRequest, condition string) string {
 	sql := fmt.Sprintf(baseQuery,
 		req.Column,
 		req.DataBase,
@@ -26,8 +25,7 @@ func groupBySQL(baseQuery string, req *request.LogIndexRequest) string {
 
 const countLogIndexQuery = "SELECT count(*) as count FROM `%s`.`%s` WHERE %s"
 
-func countSQL(baseQuery string, req *request.LogIndexRequest) string {
-	condition := NewQueryCondition(req.StartTime, req.EndTime, req.TimeField, req.Query)
+func countSQL(baseQuery string, req *request.LogIndexRequest, condition string) string {
 	sql := fmt.Sprintf(baseQuery,
 		req.DataBase,
 		req.TableName,
@@ -37,8 +35,25 @@ func countSQL(baseQuery string, req *request.LogIndexRequest) string {
 }
 
 func (ch *chRepo) GetLogIndex(ctx core.Context, req *request.LogIndexRequest) (map[string]uint64, uint64, error) {
-	groupSQL := groupBySQL(groupLogIndexQuery, req)
-	groupRows, err := ch.queryRowsData(ctx, groupSQL)
+	condition, columns, err := ch.newLogQueryCondition(ctx, logQueryParams{
+		DataBase:  req.DataBase,
+		TableName: req.TableName,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		TimeField: req.TimeField,
+		LogField:  req.LogField,
+		Query:     req.Query,
+		RawSQL:    req.RawSQL,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, find := columns[req.Column]; !find {
+		return nil, 0, fmt.Errorf("unknown column %s", req.Column)
+	}
+
+	groupSQL := groupBySQL(groupLogIndexQuery, req, condition.Wheres)
+	groupRows, err := ch.queryRowsData(ctx, groupSQL, condition.Values...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -78,8 +93,8 @@ func (ch *chRepo) GetLogIndex(ctx core.Context, req *request.LogIndexRequest) (m
 			res[key] = v["count"].(uint64)
 		}
 	}
-	countSQL := countSQL(countLogIndexQuery, req)
-	countRows, err := ch.queryRowsData(ctx, countSQL)
+	countSQL := countSQL(countLogIndexQuery, req, condition.Wheres)
+	countRows, err := ch.queryRowsData(ctx, countSQL, condition.Values...)
 	if err != nil {
 		return nil, 0, err
 	}
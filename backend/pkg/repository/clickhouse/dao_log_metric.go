@@ -0,0 +1,171 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+// Aggregations of log metrics
+const (
+	LogMetricCount = "count"
+	LogMetricSum   = "sum"
+	LogMetricAvg   = "avg"
+	LogMetricMin   = "min"
+	LogMetricMax   = "max"
+	LogMetricP50   = "p50"
+	LogMetricP90   = "p90"
+	LogMetricP95   = "p95"
+	LogMetricP99   = "p99"
+)
+
+var logMetricQuantiles = map[string]string{
+	LogMetricP50: "0.5",
+	LogMetricP90: "0.9",
+	LogMetricP95: "0.95",
+	LogMetricP99: "0.99",
+}
+
+// IsValidLogMetricAggregation checks whether aggregation is supported, all but count require a field.
+func IsValidLogMetricAggregation(aggregation string) bool {
+	switch aggregation {
+	case LogMetricCount, LogMetricSum, LogMetricAvg, LogMetricMin, LogMetricMax:
+		return true
+	}
+	_, find := logMetricQuantiles[aggregation]
+	return find
+}
+
+// LogMetricQuery aggregates the logs matching Query in [StartTime, EndTime) by GroupBy.
+type LogMetricQuery struct {
+	DataBase  string
+	TableName string
+	TimeField string
+	LogField  string
+	Query     string
+	// StartTime and EndTime in microseconds
+	StartTime int64
+	EndTime   int64
+
+	Aggregation string
+	// Field is the aggregated field, not used by count
+	Field   string
+	GroupBy []string
+}
+
+type LogMetricSample struct {
+	// Labels are the values of GroupBy
+	Labels []string
+	Value  float64
+}
+
+const queryLogMetricSQL = "SELECT %s FROM `%s`.`%s` WHERE %s %s"
+
+// QueryLogMetric evaluates the log metric, fields are validated against the columns of the table.
+func (ch *chRepo) QueryLogMetric(ctx core.Context, q *LogMetricQuery) ([]LogMetricSample, error) {
+	condition, columns, err := ch.newLogQueryCondition(ctx, logQueryParams{
+		DataBase:  q.DataBase,
+		TableName: q.TableName,
+		StartTime: q.StartTime,
+		EndTime:   q.EndTime,
+		TimeField: q.TimeField,
+		LogField:  q.LogField,
+		Query:     q.Query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	compiler := &logQueryCompiler{columns: columns, contentField: q.LogField}
+
+	var (
+		fields []string
+		args   []any
+	)
+	value, valueArgs, err := logMetricValueExpr(compiler, q.Aggregation, q.Field)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, value+" AS `__value`")
+	args = append(args, valueArgs...)
+
+	groups := make([]string, 0, len(q.GroupBy))
+	for i, field := range q.GroupBy {
+		col, err := compiler.resolve(field)
+		if err != nil {
+			return nil, err
+		}
+		alias := fmt.Sprintf("`__group_%d`", i)
+		expr := col.expr
+		if col.kind != logColumnOther {
+			expr = fmt.Sprintf("toString(%s)", expr)
+		}
+		fields = append(fields, expr+" AS "+alias)
+		args = append(args, col.args...)
+		groups = append(groups, alias)
+	}
+	args = append(args, condition.Values...)
+
+	var groupBy string
+	if len(groups) > 0 {
+		groupBy = "GROUP BY " + strings.Join(groups, ", ")
+	}
+	sql := fmt.Sprintf(queryLogMetricSQL, strings.Join(fields, ", "), q.DataBase, q.TableName, condition.Wheres, groupBy)
+
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []LogMetricSample
+	for rows.Next() {
+		sample := LogMetricSample{Labels: make([]string, len(groups))}
+		dest := make([]any, 0, len(groups)+1)
+		dest = append(dest, &sample.Value)
+		for i := range sample.Labels {
+			dest = append(dest, &sample.Labels[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+func logMetricValueExpr(compiler *logQueryCompiler, aggregation string, field string) (string, []any, error) {
+	if aggregation == LogMetricCount {
+		return "toFloat64(count())", nil, nil
+	}
+	if !IsValidLogMetricAggregation(aggregation) {
+		return "", nil, fmt.Errorf("unknown aggregation %s", aggregation)
+	}
+	col, err := compiler.resolve(field)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var value string
+	switch col.kind {
+	case logColumnNumber:
+		value = fmt.Sprintf("toFloat64(%s)", col.expr)
+	case logColumnString, logColumnOther:
+		// e.g. numeric values kept in a Map(String, String) by the parse rules
+		value = fmt.Sprintf("toFloat64OrNull(%s)", col.expr)
+	default:
+		return "", nil, fmt.Errorf("field %s is not numeric", field)
+	}
+
+	var agg string
+	if level, isQuantile := logMetricQuantiles[aggregation]; isQuantile {
+		agg = fmt.Sprintf("quantile(%s)(%s)", level, value)
+	} else {
+		agg = fmt.Sprintf("%s(%s)", aggregation, value)
+	}
+	// aggregating nothing returns NULL or nan, both of them are skipped by the caller
+	return fmt.Sprintf("toFloat64(ifNull(%s, nan))", agg), col.args, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+const (
+	querySampleLogsSQL = "SELECT toString(content) FROM %s.`raw_logs` %s ORDER BY timestamp DESC LIMIT %d"
+	countLogsSQL       = "SELECT count() FROM %s.%s WHERE timestamp >= fromUnixTimestamp64Micro(?) AND timestamp < fromUnixTimestamp64Micro(?)"
+)
+
+// QuerySampleLogs returns the content of the latest logs in raw_logs matching the route rule,
+// the keys of routeRule are the fields of Vector events, values are the prefixes separated by comma.
+func (ch *chRepo) QuerySampleLogs(ctx core.Context, dataBase string, routeRule map[string]string, limit int) ([]string, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	for field, value := range routeRule {
+		// e.g. k8s.pod.name in Vector is k8s_pod_name in the log tables
+		column := quoteColumn(strings.ReplaceAll(field, ".", "_"))
+		var prefixes []string
+		for _, prefix := range strings.Split(value, ",") {
+			prefixes = append(prefixes, fmt.Sprintf("startsWith(%s, ?)", column))
+			args = append(args, prefix)
+		}
+		conditions = append(conditions, "("+strings.Join(prefixes, " OR ")+")")
+	}
+	var where string
+	if len(conditions) > 0 {
+		// the same as getRouteRule, any of the fields matches
+		where = "WHERE " + strings.Join(conditions, " OR ")
+	}
+
+	sql := fmt.Sprintf(querySampleLogsSQL, quoteColumn(dataBase), where, limit)
+	var contents []string
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var content string
+		if err := rows.Scan(&content); err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	return contents, rows.Err()
+}
+
+// CountLogs returns the number of logs written to the tables in [from, to).
+func (ch *chRepo) CountLogs(ctx core.Context, dataBase string, tables []string, from, to time.Time) (uint64, error) {
+	var total uint64
+	for _, table := range tables {
+		var count uint64
+		sql := fmt.Sprintf(countLogsSQL, quoteColumn(dataBase), quoteColumn(table))
+		if err := ch.GetContextDB(ctx).QueryRow(ctx.GetContext(), sql, from.UnixMicro(), to.UnixMicro()).Scan(&count); err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
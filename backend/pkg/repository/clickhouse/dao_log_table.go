@@ -4,6 +4,10 @@
 package clickhouse
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse/factory"
@@ -31,13 +35,58 @@ func (ch *chRepo) DropLogTable(ctx core.Context, params *request.LogTableRequest
 	return sqls, nil
 }
 
+// PartialUpdateError is returned by UpdateLogTable when the local tables are altered but the distributed table
+// or the view is not. The fields of the local tables are the new ones, Failed are the statements left to execute.
+type PartialUpdateError struct {
+	Failed []string
+	Err    error
+}
+
+func (e *PartialUpdateError) Error() string {
+	return fmt.Sprintf("the log table is altered, but failed to execute %s: %v", strings.Join(e.Failed, ";"), e.Err)
+}
+
+func (e *PartialUpdateError) Unwrap() error {
+	return e.Err
+}
+
+// UpdateLogTable alters the log table and recreates the view, the executed statements are returned.
+// If the log table fails to alter, the view of the old fields is restored. If the distributed table or
+// the view fails after the local tables are altered, a *PartialUpdateError is returned.
 func (ch *chRepo) UpdateLogTable(ctx core.Context, req *request.LogTableRequest, old []request.Field) ([]string, error) {
-	sqls := factory.GetUpdateTableSQLByFields(req, old)
-	for _, sql := range sqls {
-		err := ch.GetContextDB(ctx).Exec(ctx.GetContext(), sql)
-		if err != nil {
-			return nil, err
+	plan := factory.GetUpdateTablePlan(req, old)
+	if err := ch.GetContextDB(ctx).Exec(ctx.GetContext(), plan.DropView); err != nil {
+		return nil, err
+	}
+	sqls := []string{plan.DropView}
+	if len(plan.AlterLocal) > 0 {
+		if err := ch.GetContextDB(ctx).Exec(ctx.GetContext(), plan.AlterLocal); err != nil {
+			if restoreErr := ch.GetContextDB(ctx).Exec(ctx.GetContext(), plan.RestoreView); restoreErr != nil {
+				return sqls, errors.Join(err, fmt.Errorf("failed to restore the view: %w", restoreErr))
+			}
+			return append(sqls, plan.RestoreView), err
 		}
+		sqls = append(sqls, plan.AlterLocal)
 	}
-	return sqls, nil
+	// the local tables are altered, the view of the new fields is created even if the distributed table fails
+	partial := &PartialUpdateError{}
+	if len(plan.AlterDistributed) > 0 {
+		if err := ch.GetContextDB(ctx).Exec(ctx.GetContext(), plan.AlterDistributed); err != nil {
+			partial.Failed, partial.Err = append(partial.Failed, plan.AlterDistributed), err
+		} else {
+			sqls = append(sqls, plan.AlterDistributed)
+		}
+	}
+	if err := ch.GetContextDB(ctx).Exec(ctx.GetContext(), plan.CreateView); err != nil {
+		partial.Failed, partial.Err = append(partial.Failed, plan.CreateView), errors.Join(partial.Err, err)
+	} else {
+		sqls = append(sqls, plan.CreateView)
+	}
+	if partial.Err == nil {
+		return sqls, nil
+	}
+	if len(plan.AlterLocal) == 0 {
+		return sqls, partial.Err
+	}
+	return sqls, partial
 }
@@ -22,7 +22,7 @@ SELECT
     name,type
 FROM
     system.columns
-WHERE database = '%s' And table = '%s';
+WHERE database = ? And table = ?;
 `
 
 func (ch *chRepo) OtherLogTable(ctx core.Context) ([]map[string]any, error) {
@@ -30,6 +30,26 @@ func (ch *chRepo) OtherLogTable(ctx core.Context) ([]map[string]any, error) {
 }
 
 func (ch *chRepo) OtherLogTableInfo(ctx core.Context, req *request.OtherTableInfoRequest) ([]map[string]any, error) {
-	sql := fmt.Sprintf(queryOtherTableInfoSQL, req.DataBase, req.TableName)
-	return ch.queryRowsData(ctx, sql)
+	return ch.queryRowsData(ctx, queryOtherTableInfoSQL, req.DataBase, req.TableName)
+}
+
+// logTableColumns returns the column types of the table keyed by column name.
+func (ch *chRepo) logTableColumns(ctx core.Context, dataBase string, tableName string) (map[string]string, error) {
+	rows, err := ch.OtherLogTableInfo(ctx, &request.OtherTableInfoRequest{
+		DataBase:  dataBase,
+		TableName: tableName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]string, len(rows))
+	for _, row := range rows {
+		name, _ := row["name"].(string)
+		typ, _ := row["type"].(string)
+		columns[name] = typ
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s.%s not found", dataBase, tableName)
+	}
+	return columns, nil
 }
@@ -15,15 +15,18 @@ const (
 )
 
 func (ch *chRepo) QueryAllLogs(ctx core.Context, req *request.LogQueryRequest) ([]map[string]any, string, error) {
-	condition := NewQueryCondition(req.StartTime, req.EndTime, req.TimeField, req.Query)
+	condition, _, err := ch.newLogQueryCondition(ctx, logQueryParamsOf(req))
+	if err != nil {
+		return nil, "", err
+	}
 	bySql := NewByLimitBuilder().
-		OrderBy(fmt.Sprintf("`%s`", req.TimeField), false).
+		OrderBy(quoteColumn(req.TimeField), false).
 		Limit(req.PageSize).
 		Offset(req.PageNum).
 		String()
-	sql := buildAllLogsQuery(logsBaseQuery, req, condition, bySql)
+	sql := buildAllLogsQuery(logsBaseQuery, req, condition.Wheres, bySql)
 
-	results, err := ch.queryRowsData(ctx, sql)
+	results, err := ch.queryRowsData(ctx, sql, condition.Values...)
 	if err != nil {
 		return nil, sql, err
 	}
@@ -31,6 +34,19 @@ func (ch *chRepo) QueryAllLogs(ctx core.Context, req *request.LogQueryRequest) (
 	return results, sql, nil
 }
 
+func logQueryParamsOf(req *request.LogQueryRequest) logQueryParams {
+	return logQueryParams{
+		DataBase:  req.DataBase,
+		TableName: req.TableName,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		TimeField: req.TimeField,
+		LogField:  req.LogField,
+		Query:     req.Query,
+		RawSQL:    req.RawSQL,
+	}
+}
+
 func buildAllLogsQuery(baseQuery string, req *request.LogQueryRequest, condition string, bySql string) string {
 	return fmt.Sprintf(baseQuery, req.DataBase, req.TableName, condition, bySql)
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 )
 
@@ -45,6 +46,15 @@ func (ch *chRepo) QueryLogContext(ctx core.Context, req *request.LogQueryContext
 	logtime := req.Time / 1000000
 	timefront := fmt.Sprintf("timestamp < toDateTime(%d) AND  timestamp > toDateTime(%d) ", logtime, logtime-60)
 	tags := tagsCondition(req.Tags)
+	columns, err := ch.logTableColumns(ctx, req.DataBase, req.TableName)
+	if err != nil {
+		return nil, nil, err
+	}
+	scope, err := ch.dataScopeCondition(ctx, datagroup.DATASOURCE_CATEGORY_LOG, logScopeColumns(columns))
+	if err != nil {
+		return nil, nil, err
+	}
+	tags += " AND " + scope.Wheres
 	// check the first 50, reverse
 	bySqlfront := NewByLimitBuilder().
 		OrderBy("timestamp", false).
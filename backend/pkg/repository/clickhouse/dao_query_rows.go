@@ -10,8 +10,8 @@ import (
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 )
 
-func (ch *chRepo) queryRowsData(ctx core.Context, sql string) ([]map[string]any, error) {
-	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), sql)
+func (ch *chRepo) queryRowsData(ctx core.Context, sql string, args ...any) ([]map[string]any, error) {
+	rows, err := ch.GetContextDB(ctx).Query(ctx.GetContext(), sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -21,10 +21,20 @@ func (ch *chRepo) queryRowsData(ctx core.Context, sql string) ([]map[string]any,
 
 func rowsToMapSlice(rows driver.Rows) ([]map[string]any, error) {
 	columnNames := rows.Columns()
-	columnTypes := rows.ColumnTypes()
+	valuePtrs := scanTargets(rows.ColumnTypes())
 	var result []map[string]any
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, err
+		}
+		result = append(result, scannedRow(columnNames, valuePtrs))
+	}
+	return result, nil
+}
 
-	valuePtrs := make([]any, len(columnNames))
+// scanTargets returns the pointers to scan a row into according to the column types.
+func scanTargets(columnTypes []driver.ColumnType) []any {
+	valuePtrs := make([]any, len(columnTypes))
 	for i, colType := range columnTypes {
 		switch colType.DatabaseTypeName() {
 		case "DateTime64", "DateTime", "DateTime64(9)":
@@ -50,36 +60,32 @@ func rowsToMapSlice(rows driver.Rows) ([]map[string]any, error) {
 			valuePtrs[i] = new(string)
 		}
 	}
+	return valuePtrs
+}
 
-	for rows.Next() {
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return nil, err
-		}
-
-		rowMap := make(map[string]any, len(columnNames))
-		for i, name := range columnNames {
-			switch val := valuePtrs[i].(type) {
-			case *time.Time:
-				rowMap[name] = *val
-			case *uint64:
-				rowMap[name] = *val
-			case *int64:
-				rowMap[name] = *val
-			case *uint32:
-				rowMap[name] = *val
-			case *int32:
-				rowMap[name] = *val
-			case *float64:
-				rowMap[name] = *val
-			case *float32:
-				rowMap[name] = *val
-			case *string:
-				rowMap[name] = *val
-			default:
-				rowMap[name] = ""
-			}
+func scannedRow(columnNames []string, valuePtrs []any) map[string]any {
+	rowMap := make(map[string]any, len(columnNames))
+	for i, name := range columnNames {
+		switch val := valuePtrs[i].(type) {
+		case *time.Time:
+			rowMap[name] = *val
+		case *uint64:
+			rowMap[name] = *val
+		case *int64:
+			rowMap[name] = *val
+		case *uint32:
+			rowMap[name] = *val
+		case *int32:
+			rowMap[name] = *val
+		case *float64:
+			rowMap[name] = *val
+		case *float32:
+			rowMap[name] = *val
+		case *string:
+			rowMap[name] = *val
+		default:
+			rowMap[name] = ""
 		}
-		result = append(result, rowMap)
 	}
-	return result, nil
+	return rowMap
 }
@@ -177,6 +177,20 @@ func (ch *chRepo) ListEntryEndpoints(ctx core.Context, req *request.GetServiceEn
 	return results, nil
 }
 
+// ListEntryEndpointsOfNode lists the entry endpoints of the traces calling the service or the db, mq and external peer.
+func (ch *chRepo) ListEntryEndpointsOfNode(ctx core.Context, startTime int64, endTime int64, node string) ([]EntryNode, error) {
+	queryBuilder := NewQueryBuilder().
+		Between("timestamp", startTime/1000000, endTime/1000000).
+		Equals("miss_top", false).
+		And(mergeWheres(OrSep, equals("service", node), equals("labels['client_peer']", node)))
+	results := []EntryNode{}
+	sql := fmt.Sprintf(SQL_GET_ENTRY_NODES, queryBuilder.String())
+	if err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &results, sql, queryBuilder.values...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // Query Service Topology
 func (ch *chRepo) ListServiceTopologys(ctx core.Context, req *request.QueryTopologyRequest) (*model.ServiceTopologyNodes, error) {
 	startTime := req.StartTime / 1000000
@@ -12,8 +12,32 @@ import (
 
 const (
 	TEMPLATE_QUERY_REALTIME_TOPOLOGY = "SELECT parent_service, parent_type, child_service, child_type FROM service_topology %s GROUP BY parent_service, parent_type, child_service, child_type"
+	TEMPLATE_QUERY_SERVICE_CALLS     = "SELECT parent_service, service, count() as calls FROM service_relationship %s GROUP BY parent_service, service"
+	// the spans of the callee are joined with the edges in the same trace, so the latency is of the calls from the parent
+	TEMPLATE_QUERY_SERVICE_EDGE_LATENCY = `SELECT relation.parent_service as parent_service, relation.service as service, avg(span.duration) / 1000 as avg_latency
+		FROM (SELECT trace_id, labels['service_name'] as service_name, labels['content_key'] as content_key, duration FROM span_trace %s) AS span
+		GLOBAL INNER JOIN (SELECT DISTINCT trace_id, parent_service, service, url FROM service_relationship %s) AS relation
+		ON span.trace_id = relation.trace_id AND span.service_name = relation.service AND span.content_key = relation.url
+		GROUP BY parent_service, service`
+	// the callee of the edge is in the cluster
+	SQL_SERVICE_IN_CLUSTER = "(trace_id, service) GLOBAL IN (SELECT trace_id, labels['service_name'] FROM span_trace WHERE timestamp BETWEEN ? AND ? AND labels['cluster_id'] = ?)"
 )
 
+// ServiceCallCount is the number of the traced calls between two services.
+type ServiceCallCount struct {
+	ParentService string `ch:"parent_service"`
+	Service       string `ch:"service"`
+	Calls         uint64 `ch:"calls"`
+}
+
+// ServiceEdgeLatency is the average latency of the calls between two services.
+type ServiceEdgeLatency struct {
+	ParentService string `ch:"parent_service"`
+	Service       string `ch:"service"`
+	// AvgLatency in microseconds
+	AvgLatency float64 `ch:"avg_latency"`
+}
+
 func (ch *chRepo) QueryRealtimeServiceTopology(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]model.ServiceToplogy, error) {
 	queryBuilder := NewQueryBuilder().
 		Between("toUnixTimestamp(timestamp)", startTime/1000000-3600, endTime/1000000+3600).
@@ -28,3 +52,45 @@ func (ch *chRepo) QueryRealtimeServiceTopology(ctx core.Context, startTime int64
 	}
 	return result, nil
 }
+
+// QueryServiceCallCounts counts the traced calls of each edge in the service call graph,
+// only the calls to the services in the cluster are counted if clusterId is not empty.
+func (ch *chRepo) QueryServiceCallCounts(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]ServiceCallCount, error) {
+	queryBuilder := NewQueryBuilder().
+		Between("timestamp", startTime/1000000, endTime/1000000).
+		NotEquals("parent_service", "")
+	if len(clusterId) > 0 {
+		queryBuilder.And(&whereSQL{
+			Wheres: SQL_SERVICE_IN_CLUSTER,
+			Values: []any{startTime / 1000000, endTime / 1000000, clusterId},
+		})
+	}
+	query := fmt.Sprintf(TEMPLATE_QUERY_SERVICE_CALLS, queryBuilder.String())
+
+	result := []ServiceCallCount{}
+	err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &result, query, queryBuilder.values...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryServiceEdgeLatencies returns the average latency of each edge in the service call graph,
+// only the calls to the services in the cluster are used if clusterId is not empty.
+func (ch *chRepo) QueryServiceEdgeLatencies(ctx core.Context, startTime int64, endTime int64, clusterId string) ([]ServiceEdgeLatency, error) {
+	spanBuilder := NewQueryBuilder().
+		Between("timestamp", startTime/1000000, endTime/1000000).
+		EqualsNotEmpty("labels['cluster_id']", clusterId)
+	relationBuilder := NewQueryBuilder().
+		Between("timestamp", startTime/1000000, endTime/1000000).
+		NotEquals("parent_service", "")
+	query := fmt.Sprintf(TEMPLATE_QUERY_SERVICE_EDGE_LATENCY, spanBuilder.String(), relationBuilder.String())
+	values := append(spanBuilder.values, relationBuilder.values...)
+
+	result := []ServiceEdgeLatency{}
+	err := ch.GetContextDB(ctx).Select(ctx.GetContext(), &result, query, values...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
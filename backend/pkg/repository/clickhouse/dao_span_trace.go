@@ -10,6 +10,7 @@ import (
 	"time"
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 )
 
@@ -59,6 +60,9 @@ func (ch *chRepo) GetFaultLogPageList(ctx core.Context, query *FaultLogQuery) ([
 	} else if query.Pid > 0 {
 		queryBuilder.Equals("pid", query.Pid)
 	}
+	if err := ch.andDataScope(ctx, queryBuilder, datagroup.DATASOURCE_CATEGORY_APM, apmScopeColumns); err != nil {
+		return nil, 0, err
+	}
 	if query.Type == 1 {
 		queryBuilder.Statement("flags['is_error'] = true")
 	} else if query.Type == 2 {
@@ -164,6 +168,9 @@ func (ch *chRepo) GetTracePageList(ctx core.Context, req *request.GetTracePageLi
 	for _, filter := range req.Filters {
 		queryBuilder.And(ch.extractSpanFilter(filter))
 	}
+	if err := ch.andDataScope(ctx, queryBuilder, datagroup.DATASOURCE_CATEGORY_APM, apmScopeColumns); err != nil {
+		return nil, 0, err
+	}
 
 	query := buildTraceCountQuery(TEMPLATE_COUNT_SPAN_TRACE, queryBuilder)
 	var count uint64
@@ -447,6 +454,9 @@ func (ch *chRepo) GetFieldValues(ctx core.Context, searchText string, filter *re
 	if filter.DataType == request.StringColumn && len(searchText) > 0 {
 		builder.Like(field, searchText+"%")
 	}
+	if err := ch.andDataScope(ctx, builder, datagroup.DATASOURCE_CATEGORY_APM, apmScopeColumns); err != nil {
+		return nil, err
+	}
 
 	byLimits := NewByLimitBuilder().
 		Limit(100).
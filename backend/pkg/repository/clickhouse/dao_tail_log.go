@@ -0,0 +1,36 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+const tailLogsQuery = "SELECT * FROM `%s`.`%s` WHERE %s ORDER BY %s ASC LIMIT %d"
+
+// TailLogs returns the earliest logs whose time is not before from.
+func (ch *chRepo) TailLogs(ctx core.Context, req *request.LogTailRequest, from time.Time, limit int) ([]map[string]any, error) {
+	condition, _, err := ch.newLogQueryCondition(ctx, logQueryParams{
+		DataBase:  req.DataBase,
+		TableName: req.TableName,
+		StartTime: from.UnixMicro(),
+		// allow a little clock skew of the log producers
+		EndTime:   time.Now().Add(time.Hour).UnixMicro(),
+		TimeField: req.TimeField,
+		LogField:  req.LogField,
+		Query:     req.Query,
+		RawSQL:    req.RawSQL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the time range of the condition is in seconds, narrow it down to nanoseconds
+	wheres := fmt.Sprintf("%s AND %s >= fromUnixTimestamp64Nano(%d)", condition.Wheres, quoteColumn(req.TimeField), from.UnixNano())
+	sql := fmt.Sprintf(tailLogsQuery, req.DataBase, req.TableName, wheres, quoteColumn(req.TimeField), limit)
+	return ch.queryRowsData(ctx, sql, condition.Values...)
+}
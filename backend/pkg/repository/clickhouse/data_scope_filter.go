@@ -0,0 +1,178 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
+)
+
+// DataScopeProvider resolves the data scopes the users have permission of.
+type DataScopeProvider interface {
+	// UserDataScope returns the scope tree of the category visible to the user,
+	// restricted is false if the user can read all data, e.g. admins.
+	UserDataScope(ctx core.Context, userID int64, category string) (scope *datagroup.DataScopeTreeNode, restricted bool, err error)
+}
+
+// scopeColumns are the columns matched with the labels of the data scopes,
+// the conditions on an empty column are skipped for cluster and never match for namespace and service.
+type scopeColumns struct {
+	Cluster   string
+	Namespace string
+	Service   string
+	// Pod is matched with the prefix "<service>-" if there is no service column
+	Pod string
+}
+
+var (
+	apmScopeColumns = scopeColumns{
+		Cluster:   "labels['cluster_id']",
+		Namespace: "labels['namespace']",
+		Service:   "labels['service_name']",
+	}
+	alertScopeColumns = scopeColumns{
+		Cluster:   "tags['cluster_id']",
+		Namespace: "tags['namespace']",
+		Service:   "if(tags['svc_name'] != '', tags['svc_name'], tags['serviceName'])",
+	}
+)
+
+// vmNamespace is the namespace of the scopes outside kubernetes, whose namespace labels are empty
+const vmNamespace = "VM_NS"
+
+// logScopeColumns returns the scope columns found in the log table.
+func logScopeColumns(columns map[string]string) scopeColumns {
+	var res scopeColumns
+	pick := func(names ...string) string {
+		for _, name := range names {
+			if _, find := columns[name]; find {
+				return quoteColumn(name)
+			}
+		}
+		return ""
+	}
+	res.Cluster = pick("cluster_id", "k8s_cluster_id")
+	res.Namespace = pick("k8s_namespace_name", "namespace")
+	res.Service = pick("service_name", "svc_name")
+	res.Pod = pick("k8s_pod_name", "pod_name")
+	return res
+}
+
+type userDataScope struct {
+	scope      *datagroup.DataScopeTreeNode
+	restricted bool
+}
+
+// SetDataScopeProvider enables filtering logs, traces and alert events by the data scopes of the requesting user.
+func (ch *chRepo) SetDataScopeProvider(provider DataScopeProvider) {
+	ch.scopeProvider = provider
+}
+
+// dataScopeCondition returns the condition limiting the rows to the data scopes of the requesting user.
+// The requests without user, e.g. the background tasks, are not limited.
+func (ch *chRepo) dataScopeCondition(ctx core.Context, category string, columns scopeColumns) (*whereSQL, error) {
+	if ch.scopeProvider == nil {
+		return ALWAYS_TRUE, nil
+	}
+	userID := ctx.UserID()
+	if userID == 0 {
+		return ALWAYS_TRUE, nil
+	}
+
+	// resolved once for each request
+	key := "_data_scope_" + category
+	var userScope *userDataScope
+	if cached, find := ctx.Get(key); find {
+		userScope, _ = cached.(*userDataScope)
+	}
+	if userScope == nil {
+		scope, restricted, err := ch.scopeProvider.UserDataScope(ctx, userID, category)
+		if err != nil {
+			return nil, err
+		}
+		userScope = &userDataScope{scope: scope, restricted: restricted}
+		ctx.Set(key, userScope)
+	}
+	if !userScope.restricted {
+		return ALWAYS_TRUE, nil
+	}
+	return scopeCondition(userScope.scope, columns), nil
+}
+
+// andDataScope adds the data scope condition of the requesting user to the builder.
+func (ch *chRepo) andDataScope(ctx core.Context, builder *QueryBuilder, category string, columns scopeColumns) error {
+	condition, err := ch.dataScopeCondition(ctx, category, columns)
+	if err != nil {
+		return err
+	}
+	builder.And(condition)
+	return nil
+}
+
+// scopeCondition converts the scope tree into the condition, a checked node allows all data under it.
+// The values are quoted in the condition so that it can be put into the raw SQL.
+func scopeCondition(node *datagroup.DataScopeTreeNode, columns scopeColumns) *whereSQL {
+	if node == nil {
+		return ALWAYS_FALSE
+	}
+	if node.IsChecked {
+		return scopeNodeCondition(node, columns)
+	}
+	if len(node.Children) == 0 {
+		return ALWAYS_FALSE
+	}
+	if len(node.Children) == 1 {
+		return scopeCondition(node.Children[0], columns)
+	}
+	children := make([]*whereSQL, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, scopeCondition(child, columns))
+	}
+	return mergeWheres(OrSep, children...)
+}
+
+func scopeNodeCondition(node *datagroup.DataScopeTreeNode, columns scopeColumns) *whereSQL {
+	cluster := ALWAYS_TRUE
+	if len(columns.Cluster) > 0 {
+		cluster = literalEquals(columns.Cluster, node.ClusterID)
+	}
+	namespace := ALWAYS_FALSE
+	if len(columns.Namespace) > 0 {
+		value := node.Namespace
+		if value == vmNamespace {
+			value = ""
+		}
+		namespace = literalEquals(columns.Namespace, value)
+	}
+
+	switch node.Type {
+	case datagroup.DATASOURCE_TYP_SYSTEM:
+		return ALWAYS_TRUE
+	case datagroup.DATASOURCE_TYP_CLUSTER:
+		return cluster
+	case datagroup.DATASOURCE_TYP_NAMESPACE:
+		return mergeWheres(AndSep, cluster, namespace)
+	case datagroup.DATASOURCE_TYP_SERVICE:
+		service := ALWAYS_FALSE
+		if len(columns.Service) > 0 {
+			service = literalEquals(columns.Service, node.Service)
+		} else if len(columns.Pod) > 0 {
+			service = &whereSQL{Wheres: fmt.Sprintf("startsWith(%s, %s)", columns.Pod, quoteValue(node.Service+"-"))}
+		}
+		return mergeWheres(AndSep, cluster, namespace, service)
+	default:
+		return ALWAYS_FALSE
+	}
+}
+
+func literalEquals(column string, value string) *whereSQL {
+	return &whereSQL{Wheres: fmt.Sprintf("%s = %s", column, quoteValue(value))}
+}
+
+func quoteValue(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
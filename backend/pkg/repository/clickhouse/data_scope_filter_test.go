@@ -0,0 +1,81 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
+)
+
+func scopeNode(typ string, clusterID, namespace, service string, checked bool, children ...*datagroup.DataScopeTreeNode) *datagroup.DataScopeTreeNode {
+	return &datagroup.DataScopeTreeNode{
+		DataScope: datagroup.DataScope{
+			Type:        typ,
+			ScopeLabels: datagroup.ScopeLabels{ClusterID: clusterID, Namespace: namespace, Service: service},
+		},
+		IsChecked: checked,
+		Children:  children,
+	}
+}
+
+func TestScopeCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    *datagroup.DataScopeTreeNode
+		columns scopeColumns
+		want    string
+	}{
+		{"no scope", nil, apmScopeColumns, "FALSE"},
+		{"system", scopeNode(datagroup.DATASOURCE_TYP_SYSTEM, "", "", "", true), apmScopeColumns, "TRUE"},
+		{"nothing checked", scopeNode(datagroup.DATASOURCE_TYP_SYSTEM, "", "", "", false), apmScopeColumns, "FALSE"},
+		{
+			"checked cluster and service",
+			scopeNode(datagroup.DATASOURCE_TYP_SYSTEM, "", "", "", false,
+				scopeNode(datagroup.DATASOURCE_TYP_CLUSTER, "c1", "", "", true),
+				scopeNode(datagroup.DATASOURCE_TYP_CLUSTER, "c2", "", "", false,
+					scopeNode(datagroup.DATASOURCE_TYP_NAMESPACE, "c2", "ns", "", false,
+						scopeNode(datagroup.DATASOURCE_TYP_SERVICE, "c2", "ns", "svc", true)))),
+			apmScopeColumns,
+			"(labels['cluster_id'] = 'c1' OR (labels['cluster_id'] = 'c2' AND labels['namespace'] = 'ns' AND labels['service_name'] = 'svc'))",
+		},
+		{
+			"vm namespace",
+			scopeNode(datagroup.DATASOURCE_TYP_NAMESPACE, "c1", vmNamespace, "", true),
+			alertScopeColumns,
+			"(tags['cluster_id'] = 'c1' AND tags['namespace'] = '')",
+		},
+		{
+			"service by pod",
+			scopeNode(datagroup.DATASOURCE_TYP_SERVICE, "c1", "ns", "svc", true),
+			scopeColumns{Namespace: "`k8s_namespace_name`", Pod: "`k8s_pod_name`"},
+			"(`k8s_namespace_name` = 'ns' AND startsWith(`k8s_pod_name`, 'svc-'))",
+		},
+		{
+			"no namespace column",
+			scopeNode(datagroup.DATASOURCE_TYP_NAMESPACE, "c1", "ns", "", true),
+			scopeColumns{Cluster: "`cluster_id`"},
+			"FALSE",
+		},
+		{
+			"quoted value",
+			scopeNode(datagroup.DATASOURCE_TYP_CLUSTER, "c1' OR '1'='1", "", "", true),
+			apmScopeColumns,
+			`labels['cluster_id'] = 'c1\' OR \'1\'=\'1'`,
+		},
+	}
+	for _, tt := range tests {
+		if got := scopeCondition(tt.node, tt.columns); got.Wheres != tt.want || len(got.Values) > 0 {
+			t.Errorf("%s: scopeCondition() = %s %v, want %s", tt.name, got.Wheres, got.Values, tt.want)
+		}
+	}
+}
+
+func TestLogScopeColumns(t *testing.T) {
+	columns := map[string]string{"timestamp": "DateTime64(9)", "k8s_namespace_name": "String", "k8s_pod_name": "String", "cluster_id": "String"}
+	want := scopeColumns{Cluster: "`cluster_id`", Namespace: "`k8s_namespace_name`", Pod: "`k8s_pod_name`"}
+	if got := logScopeColumns(columns); got != want {
+		t.Errorf("logScopeColumns() = %+v, want %+v", got, want)
+	}
+}
@@ -45,20 +45,50 @@ func GetDropTableSQL(params *request.LogTableRequest) []string {
 	return sqls
 }
 
+// UpdateTablePlan is the statements updating the fields of the log tables.
+// The Null and Buffer tables only have the raw columns, so they are not changed.
+type UpdateTablePlan struct {
+	DropView string
+	// AlterLocal alters the log table, or the local tables on the cluster
+	AlterLocal       string
+	AlterDistributed string
+	CreateView       string
+	// RestoreView recreates the view of the old fields if AlterLocal fails,
+	// so that the logs keep flowing into the unchanged table
+	RestoreView string
+}
+
+// SQLs returns the statements in order of execution.
+func (p *UpdateTablePlan) SQLs() []string {
+	var sqls []string
+	for _, sql := range []string{p.DropView, p.AlterLocal, p.AlterDistributed, p.CreateView} {
+		if len(sql) > 0 {
+			sqls = append(sqls, sql)
+		}
+	}
+	return sqls
+}
+
 // Delete view first, then adjust log, and then create view
 // The distributed table adjusts the local table first, and then the distributed table.
-func GetUpdateTableSQLByFields(params *request.LogTableRequest, old []request.Field) []string {
-	var sqls []string
+func GetUpdateTablePlan(params *request.LogTableRequest, old []request.Field) *UpdateTablePlan {
 	viewfactory := &ViewTableFactory{}
 	logfactory := &LogTableFactory{}
-	sqls = append(sqls, viewfactory.DropTableSQL(params))
-	logSql := logfactory.UpdateTableSQL(params, old, false)
-	if len(logSql) > 0 {
-		sqls = append(sqls, logSql)
+	oldParams := *params
+	oldParams.Fields = old
+
+	plan := &UpdateTablePlan{
+		DropView:    viewfactory.DropTableSQL(params),
+		AlterLocal:  logfactory.UpdateTableSQL(params, old, false),
+		CreateView:  viewfactory.CreateTableSQL(params),
+		RestoreView: viewfactory.CreateTableSQL(&oldParams),
 	}
-	if params.Cluster != "" && len(logSql) > 0 {
-		sqls = append(sqls, logfactory.UpdateTableSQL(params, old, true))
+	if params.Cluster != "" {
+		plan.AlterDistributed = logfactory.UpdateTableSQL(params, old, true)
 	}
-	sqls = append(sqls, viewfactory.CreateTableSQL(params))
-	return sqls
+	return plan
+}
+
+func GetUpdateTableSQLByFields(params *request.LogTableRequest, old []request.Field) []string {
+	return GetUpdateTablePlan(params, old).SQLs()
 }
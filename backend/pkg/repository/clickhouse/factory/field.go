@@ -0,0 +1,116 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package factory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// ColumnType returns the type of the field column. The values missing in the logs are NULL,
+// except the composite types which can not be inside Nullable, they keep the default values.
+func ColumnType(typ string) string {
+	for _, prefix := range []string{"Nullable(", "Map(", "Array(", "Tuple(", "Nested(", "JSON", "Object("} {
+		if strings.HasPrefix(typ, prefix) {
+			return typ
+		}
+	}
+	if inner, find := strings.CutPrefix(typ, "LowCardinality("); find && strings.HasSuffix(inner, ")") {
+		return "LowCardinality(" + ColumnType(strings.TrimSuffix(inner, ")")) + ")"
+	}
+	return fmt.Sprintf("Nullable(%s)", typ)
+}
+
+// columnSQL returns the definition of the field column, the distributed table reads
+// the materialized columns of the local tables as the ordinary columns.
+func columnSQL(field *request.Field, distributed bool) string {
+	sql := fmt.Sprintf("`%s` %s", field.Name, ColumnType(field.Type))
+	if len(field.Materialized) > 0 && !distributed {
+		sql += " MATERIALIZED " + field.Materialized
+	}
+	return sql
+}
+
+func indexName(field *request.Field) string {
+	return "idx_" + field.Name
+}
+
+func indexSQL(field *request.Field) string {
+	return fmt.Sprintf("INDEX %s `%s` TYPE %s GRANULARITY 1", indexName(field), field.Name, field.Index)
+}
+
+// extractSQL returns the expression of the view extracting the field from the structured log.
+func extractSQL(field *request.Field) string {
+	var keys []string
+	for _, key := range field.JSONPath() {
+		keys = append(keys, quoteString(key))
+	}
+	return fmt.Sprintf("JSONExtract(content, %s, %s) AS `%s`", strings.Join(keys, ", "), quoteString(ColumnType(field.Type)), field.Name)
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// viewFields returns the fields written by the view.
+func viewFields(fields []request.Field) []request.Field {
+	var written []request.Field
+	for _, field := range fields {
+		if len(field.Materialized) == 0 {
+			written = append(written, field)
+		}
+	}
+	return written
+}
+
+// alterColumnsSQL returns the ALTER commands changing the columns and skip indexes from old to fields.
+// The indexes are dropped before their columns are dropped or modified, and added after.
+func alterColumnsSQL(fields []request.Field, old []request.Field, distributed bool) []string {
+	oldFields := make(map[string]*request.Field, len(old))
+	for i := range old {
+		oldFields[old[i].Name] = &old[i]
+	}
+	newFields := make(map[string]*request.Field, len(fields))
+	for i := range fields {
+		newFields[fields[i].Name] = &fields[i]
+	}
+
+	var dropIndexes, dropColumns, addColumns, modifyColumns, addIndexes []string
+	for i := range old {
+		field := &old[i]
+		updated, find := newFields[field.Name]
+		columnChanged := !find || columnSQL(field, distributed) != columnSQL(updated, distributed)
+		if !distributed && len(field.Index) > 0 && (columnChanged || updated.Index != field.Index) {
+			dropIndexes = append(dropIndexes, fmt.Sprintf("DROP INDEX IF EXISTS %s", indexName(field)))
+		}
+		if !find {
+			dropColumns = append(dropColumns, fmt.Sprintf("DROP COLUMN IF EXISTS `%s`", field.Name))
+		}
+	}
+	for i := range fields {
+		field := &fields[i]
+		previous, find := oldFields[field.Name]
+		columnChanged := !find || columnSQL(field, distributed) != columnSQL(previous, distributed)
+		if !find {
+			addColumns = append(addColumns, "ADD COLUMN IF NOT EXISTS "+columnSQL(field, distributed))
+		} else if columnChanged {
+			if !distributed && len(previous.Materialized) > 0 && len(field.Materialized) == 0 {
+				// the values are kept as the ordinary column
+				modifyColumns = append(modifyColumns, fmt.Sprintf("MODIFY COLUMN `%s` REMOVE MATERIALIZED", field.Name))
+			}
+			modifyColumns = append(modifyColumns, "MODIFY COLUMN "+columnSQL(field, distributed))
+		}
+		if !distributed && len(field.Index) > 0 && (columnChanged || previous.Index != field.Index) {
+			addIndexes = append(addIndexes, "ADD "+indexSQL(field))
+		}
+	}
+
+	var commands []string
+	for _, group := range [][]string{dropIndexes, dropColumns, addColumns, modifyColumns, addIndexes} {
+		commands = append(commands, group...)
+	}
+	return commands
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package factory
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+func TestColumnType(t *testing.T) {
+	tests := map[string]string{
+		"String":                 "Nullable(String)",
+		"Nullable(Int64)":        "Nullable(Int64)",
+		"LowCardinality(String)": "LowCardinality(Nullable(String))",
+		"Map(String, String)":    "Map(String, String)",
+		"Array(Int64)":           "Array(Int64)",
+	}
+	for typ, want := range tests {
+		if got := ColumnType(typ); got != want {
+			t.Errorf("ColumnType(%s) = %s, want %s", typ, got, want)
+		}
+	}
+}
+
+func TestAlterColumnsSQL(t *testing.T) {
+	old := []request.Field{
+		{Name: "level", Type: "String", Index: "set(100)"},
+		{Name: "cost", Type: "Int64"},
+		{Name: "user", Type: "String", Index: "bloom_filter(0.01)"},
+		{Name: "attrs", Type: "Map(String, String)"},
+	}
+	fields := []request.Field{
+		{Name: "level", Type: "LowCardinality(String)", Index: "set(100)"},
+		{Name: "cost", Type: "Int64", Index: "minmax"},
+		{Name: "attrs", Type: "Map(String, String)"},
+		{Name: "attrs_user", Type: "String", Materialized: "`attrs`['user']"},
+	}
+	want := []string{
+		"DROP INDEX IF EXISTS idx_level",
+		"DROP INDEX IF EXISTS idx_user",
+		"DROP COLUMN IF EXISTS `user`",
+		"ADD COLUMN IF NOT EXISTS `attrs_user` Nullable(String) MATERIALIZED `attrs`['user']",
+		"MODIFY COLUMN `level` LowCardinality(Nullable(String))",
+		"ADD INDEX idx_level `level` TYPE set(100) GRANULARITY 1",
+		"ADD INDEX idx_cost `cost` TYPE minmax GRANULARITY 1",
+	}
+	if got := alterColumnsSQL(fields, old, false); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got:\n%s", strings.Join(got, "\n"))
+	}
+
+	// no indexes and materialized columns on the distributed table
+	want = []string{
+		"DROP COLUMN IF EXISTS `user`",
+		"ADD COLUMN IF NOT EXISTS `attrs_user` Nullable(String)",
+		"MODIFY COLUMN `level` LowCardinality(Nullable(String))",
+	}
+	if got := alterColumnsSQL(fields, old, true); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestUpdateTablePlan(t *testing.T) {
+	params := &request.LogTableRequest{
+		DataBase:     "apo",
+		TableName:    "logs_order",
+		Cluster:      "apo",
+		IsStructured: true,
+		Fields: []request.Field{
+			{Name: "http_status", Type: "Int64", Path: []string{"http", "status"}},
+			{Name: "attrs_user", Type: "String", Materialized: "`attrs`['user']"},
+		},
+	}
+	plan := GetUpdateTablePlan(params, nil)
+	if !strings.Contains(plan.AlterLocal, "ALTER TABLE apo.logs_order_local ON CLUSTER apo") ||
+		!strings.Contains(plan.AlterDistributed, "ALTER TABLE apo.logs_order ON CLUSTER apo") {
+		t.Fatalf("plan = %+v", plan)
+	}
+	if !strings.Contains(plan.CreateView, "JSONExtract(content, 'http', 'status', 'Nullable(Int64)') AS `http_status`") ||
+		strings.Contains(plan.CreateView, "attrs_user") {
+		t.Fatalf("view = %s", plan.CreateView)
+	}
+	if strings.Contains(plan.RestoreView, "http_status") {
+		t.Fatalf("restore view = %s", plan.RestoreView)
+	}
+	if sqls := plan.SQLs(); len(sqls) != 4 || sqls[0] != plan.DropView || sqls[3] != plan.CreateView {
+		t.Fatalf("sqls = %v", sqls)
+	}
+}
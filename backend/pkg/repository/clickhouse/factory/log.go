@@ -76,8 +76,13 @@ func (l *LogTableFactory) CreateTableSQL(params *request.LogTableRequest) string
 		ttlExpr = fmt.Sprintf(`TTL toDateTime(timestamp) + toIntervalDay(%d)`, params.TTL)
 	}
 	var AnalyzerFiles string
-	for _, field := range params.Fields {
-		AnalyzerFiles += fmt.Sprintf("`%s` Nullable(%s),\n", field.Name, field.Type)
+	for i := range params.Fields {
+		AnalyzerFiles += columnSQL(&params.Fields[i], false) + ",\n"
+	}
+	for i := range params.Fields {
+		if len(params.Fields[i].Index) > 0 {
+			AnalyzerFiles += indexSQL(&params.Fields[i]) + ",\n"
+		}
 	}
 	cluster := params.ClusterString()
 	var engine string
@@ -109,8 +114,8 @@ func (l *LogTableFactory) DropTableSQL(params *request.LogTableRequest) string {
 
 func (l *LogTableFactory) CreateDistributedTableSQL(params *request.LogTableRequest) string {
 	var AnalyzerFiles string
-	for _, field := range params.Fields {
-		AnalyzerFiles += fmt.Sprintf(",\n`%s` Nullable(%s)", field.Name, field.Type)
+	for i := range params.Fields {
+		AnalyzerFiles += ",\n" + columnSQL(&params.Fields[i], true)
 	}
 	return fmt.Sprintf(distributedlogSQL, params.DataBase, params.TableName, params.ClusterString(),
 		AnalyzerFiles, params.Cluster, params.DataBase, params.TableName)
@@ -121,40 +126,11 @@ func (l *LogTableFactory) DropDistributedTableSQL(params *request.LogTableReques
 }
 
 func (l *LogTableFactory) UpdateTableSQL(params *request.LogTableRequest, old []request.Field, distributed bool) string {
-	var updateFields []string
-	for i := range params.Fields {
-		exists := false
-		for j := range old {
-			if params.Fields[i].Name == old[j].Name && params.Fields[i].Type == old[j].Type {
-				exists = true
-				break
-			} else if params.Fields[i].Name == old[j].Name && params.Fields[i].Type != old[j].Type {
-				exists = true
-				updateFields = append(updateFields, fmt.Sprintf("MODIFY COLUMN `%s` Nullable(%s)", params.Fields[i].Name, params.Fields[i].Type))
-				break
-			}
-		}
-		if !exists {
-			updateFields = append(updateFields, fmt.Sprintf("ADD COLUMN `%s` Nullable(%s)", params.Fields[i].Name, params.Fields[i].Type))
-		}
-	}
-
-	for i := range old {
-		exists := false
-		for j := range params.Fields {
-			if old[i].Name == params.Fields[j].Name {
-				exists = true
-			}
-		}
-		if !exists {
-			updateFields = append(updateFields, fmt.Sprintf("DROP COLUMN `%s`", old[i].Name))
-		}
-	}
-
+	updateFields := alterColumnsSQL(params.Fields, old, distributed)
 	if len(updateFields) == 0 {
 		return ""
 	}
-	updateFieldSql := strings.Join(updateFields, ",")
+	updateFieldSql := strings.Join(updateFields, ",\n")
 	tablename := params.TableName
 	cluster := params.ClusterString()
 	if cluster != "" && !distributed {
@@ -49,11 +49,11 @@ type ViewTableFactory struct {
 
 func (v *ViewTableFactory) CreateTableSQL(params *request.LogTableRequest) string {
 	var logFields string
-	var viewFields string
+	var extractFields string
 	tablename := params.TableName
-	for _, field := range params.Fields {
-		logFields += fmt.Sprintf(",\n`%s` Nullable(%s)", field.Name, field.Type)
-		viewFields += fmt.Sprintf(",\nJSONExtract(content, '%s', 'Nullable(%s)') AS `%s`", field.Name, field.Type, field.Name)
+	for _, field := range viewFields(params.Fields) {
+		logFields += ",\n" + columnSQL(&field, true)
+		extractFields += ",\n" + extractSQL(&field)
 	}
 	cluster := params.ClusterString()
 	if cluster != "" {
@@ -64,7 +64,7 @@ func (v *ViewTableFactory) CreateTableSQL(params *request.LogTableRequest) strin
 		selectContent = "content AS content,"
 	}
 	return fmt.Sprintf(viewSQL, params.DataBase, params.TableName, cluster, params.DataBase, tablename,
-		logFields, selectContent, viewFields, params.DataBase, params.TableName)
+		logFields, selectContent, extractFields, params.DataBase, params.TableName)
 }
 
 func (v *ViewTableFactory) DropTableSQL(params *request.LogTableRequest) string {
@@ -0,0 +1,319 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+type logColumnKind int
+
+const (
+	logColumnString logColumnKind = iota
+	logColumnNumber
+	logColumnTime
+	logColumnOther
+)
+
+// logColumn is a resolved field of the log query.
+type logColumn struct {
+	// expr is the SQL expression of the field, args are the parameters used in it
+	expr     string
+	args     []any
+	kind     logColumnKind
+	nullable bool
+	// mapKey is set when the field is a key of a Map column
+	mapKey  string
+	mapExpr string
+}
+
+type logQueryCompiler struct {
+	// columns maps the column name to its ClickHouse type
+	columns      map[string]string
+	contentField string
+}
+
+// compileLogQuery compiles the log query into parameterized SQL conditions.
+// All fields are validated against columns, contentField is used by full-text search.
+func compileLogQuery(query string, columns map[string]string, contentField string) (*whereSQL, error) {
+	node, err := parseLogQuery(query)
+	if err != nil {
+		return nil, core.Error(code.LogQuerySyntaxError, err.Error())
+	}
+	if node == nil {
+		return ALWAYS_TRUE, nil
+	}
+	c := &logQueryCompiler{columns: columns, contentField: contentField}
+	where, err := c.compile(node)
+	if err != nil {
+		return nil, core.Error(code.LogQuerySyntaxError, err.Error())
+	}
+	return where, nil
+}
+
+// checkRawSQLCondition checks the raw SQL condition stays inside the parentheses it is wrapped in,
+// so that it is always ANDed with the time range and the data scopes. Quoted strings and identifiers
+// are skipped, comments and statement separators are refused.
+func checkRawSQLCondition(query string) error {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch ch := query[i]; ch {
+		case '\'', '"', '`':
+			end := skipQuoted(query, i)
+			if end < 0 {
+				return core.Error(code.LogQuerySyntaxError, "unterminated quote in SQL condition")
+			}
+			i = end
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth < 0 {
+				return core.Error(code.LogQuerySyntaxError, "unbalanced parentheses in SQL condition")
+			}
+		case ';', '#', '$':
+			return core.Error(code.LogQuerySyntaxError, fmt.Sprintf("%q is not allowed in SQL condition", ch))
+		case '-', '/':
+			if strings.HasPrefix(query[i:], "--") || strings.HasPrefix(query[i:], "/*") {
+				return core.Error(code.LogQuerySyntaxError, "comments are not allowed in SQL condition")
+			}
+		}
+	}
+	if depth != 0 {
+		return core.Error(code.LogQuerySyntaxError, "unbalanced parentheses in SQL condition")
+	}
+	return nil
+}
+
+// skipQuoted returns the index of the quote closing the one at start, -1 if not closed.
+// Backslash escapes the next character, a doubled quote is read as two adjacent strings.
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *logQueryCompiler) compile(node *logQueryNode) (*whereSQL, error) {
+	switch node.Type {
+	case logQueryAnd, logQueryOr:
+		children := make([]*whereSQL, 0, len(node.Children))
+		for _, child := range node.Children {
+			where, err := c.compile(child)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, where)
+		}
+		if node.Type == logQueryAnd {
+			return mergeWheres(AndSep, children...), nil
+		}
+		return mergeWheres(OrSep, children...), nil
+	case logQueryNot:
+		child, err := c.compile(node.Children[0])
+		if err != nil {
+			return nil, err
+		}
+		switch child {
+		case ALWAYS_TRUE:
+			return ALWAYS_FALSE, nil
+		case ALWAYS_FALSE:
+			return ALWAYS_TRUE, nil
+		}
+		return &whereSQL{Wheres: fmt.Sprintf("NOT (%s)", child.Wheres), Values: child.Values}, nil
+	case logQueryTerm:
+		return c.compileTerm(node)
+	case logQueryRange:
+		return c.compileRange(node)
+	case logQueryCompare:
+		col, err := c.resolve(node.Field)
+		if err != nil {
+			return nil, err
+		}
+		return c.compare(col, node.Op, node.Value)
+	case logQueryExists:
+		return c.compileExists(node)
+	}
+	return nil, fmt.Errorf("unknown query node")
+}
+
+func (c *logQueryCompiler) compileTerm(node *logQueryNode) (*whereSQL, error) {
+	col, err := c.resolve(node.Field)
+	if err != nil {
+		return nil, err
+	}
+
+	// full-text search on the log content
+	if len(node.Field) == 0 {
+		if node.Wildcard {
+			return cmpColumn(col, "%s ILIKE ?", "%"+wildcardToLike(node.Value)+"%"), nil
+		}
+		return cmpColumn(col, "positionCaseInsensitiveUTF8(%s, ?) > 0", node.Value), nil
+	}
+
+	if node.Wildcard {
+		if col.kind == logColumnNumber || col.kind == logColumnTime {
+			return nil, fmt.Errorf("wildcard is not supported by field %s", node.Field)
+		}
+		return cmpColumn(col, "%s LIKE ?", wildcardToLike(node.Value)), nil
+	}
+	return c.compare(col, "=", node.Value)
+}
+
+func (c *logQueryCompiler) compileRange(node *logQueryNode) (*whereSQL, error) {
+	col, err := c.resolve(node.Field)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []*whereSQL
+	if node.From != "*" {
+		op := ">"
+		if node.IncludeFrom {
+			op = ">="
+		}
+		where, err := c.compare(col, op, node.From)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, where)
+	}
+	if node.To != "*" {
+		op := "<"
+		if node.IncludeTo {
+			op = "<="
+		}
+		where, err := c.compare(col, op, node.To)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, where)
+	}
+	return mergeWheres(AndSep, conditions...), nil
+}
+
+func (c *logQueryCompiler) compileExists(node *logQueryNode) (*whereSQL, error) {
+	col, err := c.resolve(node.Field)
+	if err != nil {
+		return nil, err
+	}
+	if len(col.mapKey) > 0 {
+		return &whereSQL{Wheres: fmt.Sprintf("mapContains(%s, ?)", col.mapExpr), Values: []any{col.mapKey}}, nil
+	}
+	if col.nullable {
+		return &whereSQL{Wheres: fmt.Sprintf("isNotNull(%s)", col.expr), Values: col.args}, nil
+	}
+	if col.kind == logColumnString {
+		return &whereSQL{Wheres: fmt.Sprintf("notEmpty(%s)", col.expr), Values: col.args}, nil
+	}
+	return ALWAYS_TRUE, nil
+}
+
+func (c *logQueryCompiler) compare(col *logColumn, op string, value string) (*whereSQL, error) {
+	switch col.kind {
+	case logColumnTime:
+		return cmpColumn(col, "%s "+op+" parseDateTime64BestEffort(?, 9)", value), nil
+	case logColumnString, logColumnOther:
+		return cmpColumn(col, "%s "+op+" ?", value), nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return cmpColumn(col, "%s "+op+" ?", i), nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("value %q is not a number", value)
+	}
+	return cmpColumn(col, "%s "+op+" ?", f), nil
+}
+
+func cmpColumn(col *logColumn, format string, value any) *whereSQL {
+	values := make([]any, 0, len(col.args)+1)
+	values = append(values, col.args...)
+	values = append(values, value)
+	return &whereSQL{Wheres: fmt.Sprintf(format, col.expr), Values: values}
+}
+
+// resolve validates the field against the table columns, "a.b" refers to key b of the Map column a.
+func (c *logQueryCompiler) resolve(field string) (*logColumn, error) {
+	if len(field) == 0 {
+		field = c.contentField
+	}
+	if typ, find := c.columns[field]; find {
+		kind, nullable := logColumnKindOf(typ)
+		expr := quoteColumn(field)
+		if kind == logColumnOther {
+			expr = fmt.Sprintf("toString(%s)", expr)
+		}
+		return &logColumn{expr: expr, kind: kind, nullable: nullable}, nil
+	}
+
+	if parent, key, found := strings.Cut(field, "."); found {
+		if typ, find := c.columns[parent]; find {
+			if valueType, isMap := mapValueType(typ); isMap {
+				kind, _ := logColumnKindOf(valueType)
+				expr := quoteColumn(parent) + "[?]"
+				if kind == logColumnOther {
+					expr = fmt.Sprintf("toString(%s)", expr)
+				}
+				return &logColumn{
+					expr:    expr,
+					args:    []any{key},
+					kind:    kind,
+					mapKey:  key,
+					mapExpr: quoteColumn(parent),
+				}, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown field %s", field)
+}
+
+var identifierEscaper = strings.NewReplacer("\\", "\\\\", "`", "\\`")
+
+func quoteColumn(name string) string {
+	return "`" + identifierEscaper.Replace(name) + "`"
+}
+
+func unwrapType(typ string, wrapper string) (string, bool) {
+	if strings.HasPrefix(typ, wrapper+"(") && strings.HasSuffix(typ, ")") {
+		return typ[len(wrapper)+1 : len(typ)-1], true
+	}
+	return typ, false
+}
+
+func logColumnKindOf(typ string) (logColumnKind, bool) {
+	typ, _ = unwrapType(typ, "LowCardinality")
+	typ, nullable := unwrapType(typ, "Nullable")
+	switch {
+	case typ == "String" || strings.HasPrefix(typ, "FixedString") || strings.HasPrefix(typ, "Enum"):
+		return logColumnString, nullable
+	case strings.HasPrefix(typ, "Int") || strings.HasPrefix(typ, "UInt") ||
+		strings.HasPrefix(typ, "Float") || strings.HasPrefix(typ, "Decimal"):
+		return logColumnNumber, nullable
+	case strings.HasPrefix(typ, "Date"):
+		return logColumnTime, nullable
+	}
+	return logColumnOther, nullable
+}
+
+func mapValueType(typ string) (string, bool) {
+	inner, isMap := unwrapType(typ, "Map")
+	if !isMap {
+		return "", false
+	}
+	_, valueType, found := strings.Cut(inner, ",")
+	if !found {
+		return "", false
+	}
+	return strings.TrimSpace(valueType), true
+}
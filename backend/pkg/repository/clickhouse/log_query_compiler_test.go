@@ -0,0 +1,119 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompileLogQuery(t *testing.T) {
+	columns := map[string]string{
+		"timestamp": "DateTime64(9)",
+		"content":   "String",
+		"level":     "LowCardinality(String)",
+		"pod":       "String",
+		"pid":       "UInt32",
+		"duration":  "Nullable(Float64)",
+		"labels":    "Map(String, String)",
+	}
+
+	tests := []struct {
+		query   string
+		wheres  string
+		values  []any
+		wantErr bool
+	}{
+		{query: "", wheres: "TRUE"},
+		{query: "error", wheres: "positionCaseInsensitiveUTF8(`content`, ?) > 0", values: []any{"error"}},
+		{query: `"connection refused"`, wheres: "positionCaseInsensitiveUTF8(`content`, ?) > 0", values: []any{"connection refused"}},
+		{query: "level:error", wheres: "`level` = ?", values: []any{"error"}},
+		{query: "pod:order-*", wheres: "`pod` LIKE ?", values: []any{"order-%"}},
+		{query: `pod:order\*`, wheres: "`pod` = ?", values: []any{"order*"}},
+		{query: "pod:a_b*", wheres: "`pod` LIKE ?", values: []any{`a\_b%`}},
+		{query: "pid:[100 TO 200}", wheres: "(`pid` >= ? AND `pid` < ?)", values: []any{int64(100), int64(200)}},
+		{query: "duration:>=1.5", wheres: "`duration` >= ?", values: []any{1.5}},
+		{query: "duration:[* TO *]", wheres: "TRUE"},
+		{query: "duration:*", wheres: "isNotNull(`duration`)"},
+		{query: "labels.app:nginx", wheres: "`labels`[?] = ?", values: []any{"app", "nginx"}},
+		{query: "labels.app:*", wheres: "mapContains(`labels`, ?)", values: []any{"app"}},
+		{query: "timestamp:>2024-01-01", wheres: "`timestamp` > parseDateTime64BestEffort(?, 9)", values: []any{"2024-01-01"}},
+		{
+			query:  "level:error AND (pod:a OR NOT pod:b) -timeout",
+			wheres: "(`level` = ? AND (`pod` = ? OR NOT (`pod` = ?)) AND NOT (positionCaseInsensitiveUTF8(`content`, ?) > 0))",
+			values: []any{"error", "a", "b", "timeout"},
+		},
+		{query: "level:error pod:a", wheres: "(`level` = ? AND `pod` = ?)", values: []any{"error", "a"}},
+		{query: "unknown:1", wantErr: true},
+		{query: "pid:abc", wantErr: true},
+		{query: "pid:1*", wantErr: true},
+		{query: "level:error AND", wantErr: true},
+		{query: "(level:error", wantErr: true},
+		{query: `"unclosed`, wantErr: true},
+		{query: "`level` = 'error' AND `pod` = 'a'", wantErr: true},
+		{query: "\"`level` = 'error'\"", wheres: "positionCaseInsensitiveUTF8(`content`, ?) > 0", values: []any{"`level` = 'error'"}},
+		{query: `level:"x' OR 1=1"`, wheres: "`level` = ?", values: []any{"x' OR 1=1"}},
+		{query: "1=1; DROP", wheres: "(positionCaseInsensitiveUTF8(`content`, ?) > 0 AND positionCaseInsensitiveUTF8(`content`, ?) > 0)", values: []any{"1=1;", "DROP"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			where, err := compileLogQuery(tt.query, columns, "content")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expect error, got %s", where.Wheres)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if where.Wheres != tt.wheres {
+				t.Errorf("wheres = %s, want %s", where.Wheres, tt.wheres)
+			}
+			if len(where.Values) != 0 || len(tt.values) != 0 {
+				if !reflect.DeepEqual(where.Values, tt.values) {
+					t.Errorf("values = %v, want %v", where.Values, tt.values)
+				}
+			}
+		})
+	}
+}
+
+func TestQuoteColumn(t *testing.T) {
+	tests := map[string]string{
+		"apo":                  "`apo`",
+		"apo`.logs; DROP x --": "`apo\\`.logs; DROP x --`",
+		"apo\\`; DROP x --":    "`apo\\\\\\`; DROP x --`",
+	}
+	for name, want := range tests {
+		if got := quoteColumn(name); got != want {
+			t.Errorf("quoteColumn(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestCheckRawSQLCondition(t *testing.T) {
+	tests := []struct {
+		query string
+		ok    bool
+	}{
+		{"level = 'error' AND (status >= 500 OR status = 0)", true},
+		{"content LIKE '%)%' OR `weird)name` = \"(\"", true},
+		{`content = 'it\'s ) fine'`, true},
+		{"1) OR (1", false},
+		{"1) OR 1 = 1 OR (1", false},
+		{"level = 'a' OR (1", false},
+		{`content = '\') OR (1`, false},
+		{"1 = 1 -- )", false},
+		{"1 = 1 /* ) */", false},
+		{"1 = 1; DROP TABLE logs", false},
+		{"content = 'unterminated", false},
+	}
+	for _, tt := range tests {
+		if err := checkRawSQLCondition(tt.query); (err == nil) != tt.ok {
+			t.Errorf("checkRawSQLCondition(%s) = %v", tt.query, err)
+		}
+	}
+}
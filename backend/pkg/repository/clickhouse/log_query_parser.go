@@ -0,0 +1,438 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Log query language, similar to Lucene query syntax:
+//
+//	error                          full-text search on the log content
+//	"connection refused"           phrase search on the log content
+//	level:error                    field equals value
+//	pod:"order-7d9f"               field equals phrase
+//	pod:order-*                    wildcard, * matches any characters and ? matches one character
+//	duration:[100 TO 500]          range, [] is inclusive, {} is exclusive, * is unbounded
+//	duration:>100                  comparison, one of > >= < <=
+//	trace_id:*                     field exists
+//	labels.app:nginx               key of a Map column
+//	a AND (b OR NOT c), -c         boolean operators, adjacent terms are joined with AND
+type logQueryNodeType int
+
+const (
+	logQueryAnd logQueryNodeType = iota
+	logQueryOr
+	logQueryNot
+	logQueryTerm
+	logQueryRange
+	logQueryCompare
+	logQueryExists
+)
+
+type logQueryNode struct {
+	Type     logQueryNodeType
+	Children []*logQueryNode
+
+	// Field is empty when searching the log content
+	Field    string
+	Value    string
+	Phrase   bool
+	Wildcard bool
+
+	// Op of logQueryCompare
+	Op string
+
+	// From and To of logQueryRange, "*" means unbounded
+	From, To               string
+	IncludeFrom, IncludeTo bool
+}
+
+type logQueryTokenType int
+
+const (
+	tokEOF logQueryTokenType = iota
+	tokWord
+	tokPhrase
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokLBrace
+	tokRBrace
+	tokColon
+	tokCompare
+	tokAnd
+	tokOr
+	tokNot
+	tokTo
+)
+
+type logQueryToken struct {
+	typ logQueryTokenType
+	val string
+	pos int
+}
+
+func tokenizeLogQuery(query string) ([]logQueryToken, error) {
+	var tokens []logQueryToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, logQueryToken{tokLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, logQueryToken{tokRParen, ")", i})
+			i++
+		case r == '[':
+			tokens = append(tokens, logQueryToken{tokLBracket, "[", i})
+			i++
+		case r == ']':
+			tokens = append(tokens, logQueryToken{tokRBracket, "]", i})
+			i++
+		case r == '{':
+			tokens = append(tokens, logQueryToken{tokLBrace, "{", i})
+			i++
+		case r == '}':
+			tokens = append(tokens, logQueryToken{tokRBrace, "}", i})
+			i++
+		case r == ':':
+			tokens = append(tokens, logQueryToken{tokColon, ":", i})
+			i++
+		case r == '>' || r == '<':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			tokens = append(tokens, logQueryToken{tokCompare, op, i})
+			i += len(op)
+		case (r == '-' || r == '!') && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, logQueryToken{tokNot, string(r), i})
+			i++
+		case r == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unclosed quote at position %d", start)
+			}
+			tokens = append(tokens, logQueryToken{tokPhrase, sb.String(), start})
+		default:
+			start := i
+			var sb strings.Builder
+			for i < len(runes) {
+				c := runes[i]
+				if c == '\\' && i+1 < len(runes) {
+					// keep the escape so that escaped wildcards are not expanded
+					sb.WriteRune(c)
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if unicode.IsSpace(c) || strings.ContainsRune(`()[]{}:"`, c) {
+					break
+				}
+				sb.WriteRune(c)
+				i++
+			}
+			word := sb.String()
+			if strings.HasPrefix(word, "`") {
+				// e.g. `level` = 'error' sent by the SQL search before the query language
+				return nil, fmt.Errorf("SQL condition at position %d is not supported, use field:value or set rawSql", start)
+			}
+			tok := logQueryToken{tokWord, word, start}
+			switch word {
+			case "AND", "&&":
+				tok.typ = tokAnd
+			case "OR", "||":
+				tok.typ = tokOr
+			case "NOT":
+				tok.typ = tokNot
+			case "TO":
+				tok.typ = tokTo
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+	tokens = append(tokens, logQueryToken{tokEOF, "", len(runes)})
+	return tokens, nil
+}
+
+type logQueryParser struct {
+	tokens []logQueryToken
+	pos    int
+}
+
+// parseLogQuery parses the query into an AST, nil is returned for an empty query.
+func parseLogQuery(query string) (*logQueryNode, error) {
+	tokens, err := tokenizeLogQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &logQueryParser{tokens: tokens}
+	if p.peek().typ == tokEOF {
+		return nil, nil
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.typ != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.val, tok.pos)
+	}
+	return node, nil
+}
+
+func (p *logQueryParser) peek() logQueryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *logQueryParser) next() logQueryToken {
+	tok := p.tokens[p.pos]
+	if tok.typ != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *logQueryParser) expect(typ logQueryTokenType, desc string) (logQueryToken, error) {
+	tok := p.next()
+	if tok.typ != typ {
+		return tok, fmt.Errorf("expect %s at position %d, got %q", desc, tok.pos, tok.val)
+	}
+	return tok, nil
+}
+
+func (p *logQueryParser) parseOr() (*logQueryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []*logQueryNode{left}
+	for p.peek().typ == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+	if len(children) == 1 {
+		return left, nil
+	}
+	return &logQueryNode{Type: logQueryOr, Children: children}, nil
+}
+
+func (p *logQueryParser) parseAnd() (*logQueryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	children := []*logQueryNode{left}
+	for {
+		switch p.peek().typ {
+		case tokAnd:
+			p.next()
+		case tokWord, tokPhrase, tokLParen, tokNot:
+			// implicit AND
+		default:
+			if len(children) == 1 {
+				return left, nil
+			}
+			return &logQueryNode{Type: logQueryAnd, Children: children}, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, right)
+	}
+}
+
+func (p *logQueryParser) parseNot() (*logQueryNode, error) {
+	if p.peek().typ == tokNot {
+		p.next()
+		child, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &logQueryNode{Type: logQueryNot, Children: []*logQueryNode{child}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *logQueryParser) parsePrimary() (*logQueryNode, error) {
+	tok := p.next()
+	switch tok.typ {
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case tokPhrase:
+		return &logQueryNode{Type: logQueryTerm, Value: tok.val, Phrase: true}, nil
+	case tokWord:
+		if p.peek().typ == tokColon {
+			p.next()
+			return p.parseFieldValue(tok.val)
+		}
+		return newWordTerm("", tok.val), nil
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of query")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.val, tok.pos)
+	}
+}
+
+func (p *logQueryParser) parseFieldValue(field string) (*logQueryNode, error) {
+	tok := p.next()
+	switch tok.typ {
+	case tokPhrase:
+		return &logQueryNode{Type: logQueryTerm, Field: field, Value: tok.val, Phrase: true}, nil
+	case tokWord:
+		if tok.val == "*" {
+			return &logQueryNode{Type: logQueryExists, Field: field}, nil
+		}
+		return newWordTerm(field, tok.val), nil
+	case tokCompare:
+		value, err := p.parseRangeValue()
+		if err != nil {
+			return nil, err
+		}
+		return &logQueryNode{Type: logQueryCompare, Field: field, Op: tok.val, Value: value}, nil
+	case tokLBracket, tokLBrace:
+		from, err := p.parseRangeValue()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokTo, "TO"); err != nil {
+			return nil, err
+		}
+		to, err := p.parseRangeValue()
+		if err != nil {
+			return nil, err
+		}
+		end := p.next()
+		if end.typ != tokRBracket && end.typ != tokRBrace {
+			return nil, fmt.Errorf("expect ] or } at position %d, got %q", end.pos, end.val)
+		}
+		return &logQueryNode{
+			Type:        logQueryRange,
+			Field:       field,
+			From:        from,
+			To:          to,
+			IncludeFrom: tok.typ == tokLBracket,
+			IncludeTo:   end.typ == tokRBracket,
+		}, nil
+	default:
+		return nil, fmt.Errorf("expect value of %s at position %d", field, tok.pos)
+	}
+}
+
+func (p *logQueryParser) parseRangeValue() (string, error) {
+	tok := p.next()
+	// "-" before a number is lexed as NOT
+	if tok.typ == tokNot && tok.val == "-" && p.peek().typ == tokWord {
+		return "-" + unescapeLogQuery(p.next().val), nil
+	}
+	switch tok.typ {
+	case tokWord:
+		return unescapeLogQuery(tok.val), nil
+	case tokPhrase:
+		return tok.val, nil
+	default:
+		return "", fmt.Errorf("expect value at position %d, got %q", tok.pos, tok.val)
+	}
+}
+
+func newWordTerm(field string, word string) *logQueryNode {
+	node := &logQueryNode{Type: logQueryTerm, Field: field}
+	if hasUnescapedWildcard(word) {
+		node.Wildcard = true
+		node.Value = word
+	} else {
+		node.Value = unescapeLogQuery(word)
+	}
+	return node
+}
+
+func hasUnescapedWildcard(word string) bool {
+	for i := 0; i < len(word); i++ {
+		switch word[i] {
+		case '\\':
+			i++
+		case '*', '?':
+			return true
+		}
+	}
+	return false
+}
+
+func unescapeLogQuery(word string) string {
+	if !strings.Contains(word, `\`) {
+		return word
+	}
+	var sb strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] == '\\' && i+1 < len(word) {
+			i++
+		}
+		sb.WriteByte(word[i])
+	}
+	return sb.String()
+}
+
+// wildcardToLike converts the wildcard word into a LIKE pattern.
+func wildcardToLike(word string) string {
+	var sb strings.Builder
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		switch c {
+		case '\\':
+			if i+1 < len(word) {
+				i++
+				c = word[i]
+			}
+			if c == '%' || c == '_' || c == '\\' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(c)
+		case '*':
+			sb.WriteByte('%')
+		case '?':
+			sb.WriteByte('_')
+		case '%', '_':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -5,6 +5,10 @@ package clickhouse
 
 import (
 	"fmt"
+	"strings"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 )
 
 type FieldBuilder struct {
@@ -255,6 +259,60 @@ func (builder *ByLimitBuilder) String() string {
 	return sql
 }
 
-func NewQueryCondition(st, et int64, timeField, query string) string {
-	return fmt.Sprintf("`%s` >= toDateTime(%d) AND `%s` < toDateTime(%d) AND %s", timeField, st/1000000, timeField, et/1000000, query)
+type logQueryParams struct {
+	DataBase  string
+	TableName string
+	// StartTime and EndTime in microseconds
+	StartTime int64
+	EndTime   int64
+	TimeField string
+	LogField  string
+	Query     string
+	// RawSQL means Query is a ClickHouse SQL condition instead of the log query language
+	RawSQL bool
+}
+
+// newLogQueryCondition returns the conditions of time range and query, together with the columns of the log table.
+// Table and fields are validated against the columns before used in SQL.
+func (ch *chRepo) newLogQueryCondition(ctx core.Context, params logQueryParams) (*whereSQL, map[string]string, error) {
+	if strings.Contains(params.DataBase, "`") || strings.Contains(params.TableName, "`") {
+		return nil, nil, fmt.Errorf("illegal table %s.%s", params.DataBase, params.TableName)
+	}
+	columns, err := ch.logTableColumns(ctx, params.DataBase, params.TableName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, find := columns[params.TimeField]; !find {
+		return nil, nil, fmt.Errorf("unknown time field %s", params.TimeField)
+	}
+
+	timeRange := &whereSQL{
+		Wheres: fmt.Sprintf("%s >= toDateTime(%d) AND %s < toDateTime(%d)",
+			quoteColumn(params.TimeField), params.StartTime/1000000, quoteColumn(params.TimeField), params.EndTime/1000000),
+	}
+	// the logs out of the data scopes of the user are never returned, even by raw SQL
+	scope, err := ch.dataScopeCondition(ctx, datagroup.DATASOURCE_CATEGORY_LOG, logScopeColumns(columns))
+	if err != nil {
+		return nil, nil, err
+	}
+	timeRange = mergeWheres(AndSep, timeRange, scope)
+	if params.RawSQL {
+		if len(strings.TrimSpace(params.Query)) == 0 {
+			return timeRange, columns, nil
+		}
+		if err := checkRawSQLCondition(params.Query); err != nil {
+			return nil, nil, err
+		}
+		// raw SQL is sent without parameters, so "?" in it is kept as is
+		return &whereSQL{Wheres: fmt.Sprintf("%s AND (%s)", timeRange.Wheres, params.Query)}, columns, nil
+	}
+
+	if _, find := columns[params.LogField]; !find {
+		return nil, nil, fmt.Errorf("unknown log field %s", params.LogField)
+	}
+	condition, err := compileLogQuery(params.Query, columns, params.LogField)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mergeWheres(AndSep, timeRange, condition), columns, nil
 }
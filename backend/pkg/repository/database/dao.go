@@ -114,6 +114,7 @@ type Repo interface {
 	RemoveFromTeamByTeam(ctx core.Context, teamID int64, userIDs []int64) error
 	DeleteAllUserTeam(ctx core.Context, id int64, by string) error
 	GetAssignedTeam(ctx core.Context, userID int64) ([]profile.Team, error)
+	GetTeamsByName(ctx core.Context, teamNames []string) ([]profile.Team, error)
 
 	CreateRole(ctx core.Context, role *profile.Role) error
 	DeleteRole(ctx core.Context, roleID int) error
@@ -148,16 +149,98 @@ type Repo interface {
 	MigrateAMReceiver(ctx core.Context, receivers []amconfig.Receiver) ([]amconfig.Receiver, error)
 
 	CreateCustomServiceTopology(ctx core.Context, topology *CustomServiceTopology) error
+	UpdateCustomServiceTopology(ctx core.Context, topology *CustomServiceTopology) error
+	UpdateCustomServiceTopologyReconciliation(ctx core.Context, topology *CustomServiceTopology) error
 	ListCustomServiceTopology(ctx core.Context) ([]CustomServiceTopology, error)
+	ListActiveCustomServiceTopology(ctx core.Context, ts int64) ([]CustomServiceTopology, error)
 	DeleteCustomServiceTopology(ctx core.Context, id int) error
+	DeleteCustomServiceTopologyExpiredBefore(ctx core.Context, timestamp int64) (int64, error)
+
+	CreateTopologySnapshot(ctx core.Context, snapshot *TopologySnapshot) error
+	GetTopologySnapshot(ctx core.Context, id int64) (*TopologySnapshot, error)
+	GetTopologySnapshotAt(ctx core.Context, clusterID string, at int64) (*TopologySnapshot, error)
+	ListTopologySnapshots(ctx core.Context, clusterID string, startTime int64, endTime int64) ([]TopologySnapshot, error)
+	DeleteTopologySnapshotBefore(ctx core.Context, timestamp int64) (int64, error)
 
 	CreateServiceNameRule(ctx core.Context, serviceNameRule *ServiceNameRule) error
+	UpdateServiceNameRule(ctx core.Context, rule *ServiceNameRule) error
 	ListAllServiceNameRule(ctx core.Context) ([]ServiceNameRule, error)
 	UpsertServiceNameRuleCondition(ctx core.Context, condition *ServiceNameRuleCondition) error
 	ListAllServiceNameRuleCondition(ctx core.Context) ([]ServiceNameRuleCondition, error)
 	ServiceNameRuleExists(ctx core.Context, ruleId int) (bool, error)
 	DeleteServiceNameRule(ctx core.Context, ruleId int) error
 
+	CreateAuditLog(ctx core.Context, auditLog *AuditLog) error
+	GetAuditLogs(ctx core.Context, req *request.GetAuditLogRequest) ([]AuditLog, int64, error)
+	DeleteAuditLogBefore(ctx core.Context, timestamp int64) (int64, error)
+
+	CreateChangeEvent(ctx core.Context, event *model.ChangeEvent) error
+	ListChangeEvents(ctx core.Context, filter *ChangeEventFilter) ([]model.ChangeEvent, error)
+	DeleteChangeEventBefore(ctx core.Context, timestamp int64) (int64, error)
+
+	CreateSLO(ctx core.Context, slo *SLO) error
+	UpdateSLO(ctx core.Context, slo *SLO) error
+	DeleteSLO(ctx core.Context, id int64) error
+	GetSLO(ctx core.Context, id int64) (*SLO, error)
+	ListSLO(ctx core.Context, serviceName string, endpoint string) ([]SLO, error)
+
+	SaveServiceCatalogs(ctx core.Context, catalogs []ServiceCatalog) error
+	DeleteServiceCatalog(ctx core.Context, service string) error
+	GetServiceCatalog(ctx core.Context, service string) (*ServiceCatalog, error)
+	ListServiceCatalogs(ctx core.Context, services []string, teamID int64) ([]ServiceCatalog, error)
+
+	SaveAnomalyBaselines(ctx core.Context, baselines []AnomalyBaseline) error
+	ListAnomalyBaselines(ctx core.Context, serviceNames []string, metric string) ([]AnomalyBaseline, error)
+
+	GetHealthScoreModel(ctx core.Context, groupID int64) (*HealthScoreModel, error)
+	SaveHealthScoreModel(ctx core.Context, scoreModel *HealthScoreModel) error
+	DeleteHealthScoreModel(ctx core.Context, groupID int64) error
+
+	ListRecordingRules(ctx core.Context) ([]RecordingRule, error)
+	SaveRecordingRules(ctx core.Context, rules []RecordingRule) error
+	DeleteAllRecordingRules(ctx core.Context) error
+
+	CreateLogMetric(ctx core.Context, metric *LogMetric) error
+	UpdateLogMetric(ctx core.Context, metric *LogMetric) error
+	DeleteLogMetric(ctx core.Context, id int64) error
+	GetLogMetric(ctx core.Context, id int64) (*LogMetric, error)
+	ListLogMetrics(ctx core.Context) ([]LogMetric, error)
+
+	CreateLogAlertRule(ctx core.Context, rule *LogAlertRule) error
+	UpdateLogAlertRule(ctx core.Context, rule *LogAlertRule) error
+	DeleteLogAlertRule(ctx core.Context, id int64) error
+	GetLogAlertRule(ctx core.Context, id int64) (*LogAlertRule, error)
+	ListLogAlertRules(ctx core.Context, metricID int64) ([]LogAlertRule, error)
+
+	CreateVectorConfigVersion(ctx core.Context, version *VectorConfigVersion) error
+	ListVectorConfigVersions(ctx core.Context) ([]VectorConfigVersion, error)
+	GetVectorConfigVersion(ctx core.Context, version int64) (*VectorConfigVersion, error)
+
+	CreateLogExportJob(ctx core.Context, job *LogExportJob) error
+	UpdateLogExportJob(ctx core.Context, job *LogExportJob) error
+	DeleteLogExportJob(ctx core.Context, id int64) error
+	GetLogExportJob(ctx core.Context, id int64) (*LogExportJob, error)
+	ListLogExportJobs(ctx core.Context, userID int64) ([]LogExportJob, error)
+	ListLogExportJobsBefore(ctx core.Context, createdBefore int64) ([]LogExportJob, error)
+
+	CreateLogArchiveJob(ctx core.Context, job *LogArchiveJob) error
+	UpdateLogArchiveJob(ctx core.Context, job *LogArchiveJob) error
+	UpdateLogArchiveJobRun(ctx core.Context, id int64, runAt int64, runError string) error
+	DeleteLogArchiveJob(ctx core.Context, id int64) error
+	GetLogArchiveJob(ctx core.Context, id int64) (*LogArchiveJob, error)
+	ListLogArchiveJobs(ctx core.Context) ([]LogArchiveJob, error)
+	CreateLogArchiveRecord(ctx core.Context, record *LogArchiveRecord) error
+	ListLogArchiveRecords(ctx core.Context, jobID int64) ([]LogArchiveRecord, error)
+
+	CreateLogSavedQuery(ctx core.Context, query *LogSavedQuery) error
+	UpdateLogSavedQuery(ctx core.Context, query *LogSavedQuery) error
+	DeleteLogSavedQuery(ctx core.Context, id int64) error
+	GetLogSavedQuery(ctx core.Context, id int64) (*LogSavedQuery, error)
+	ListLogSavedQueries(ctx core.Context, userID int64, teamIDs []int64) ([]LogSavedQuery, error)
+	AddLogQueryHistory(ctx core.Context, history *LogQueryHistory, keep int) error
+	ListLogQueryHistory(ctx core.Context, userID int64, limit int) ([]LogQueryHistory, error)
+	DeleteLogQueryHistory(ctx core.Context, userID int64, id int64) error
+
 	integration.ObservabilityInputManage
 	DaoDataScope
 	DaoDataGroupNew
@@ -309,5 +392,21 @@ func migrateTable(db *gorm.DB) error {
 		&ServiceNameRule{},
 		&ServiceNameRuleCondition{},
 		&CustomServiceTopology{},
+		&AuditLog{},
+		&SLO{},
+		&AnomalyBaseline{},
+		&HealthScoreModel{},
+		&RecordingRule{},
+		&LogMetric{},
+		&LogAlertRule{},
+		&VectorConfigVersion{},
+		&LogExportJob{},
+		&LogArchiveJob{},
+		&LogArchiveRecord{},
+		&LogSavedQuery{},
+		&LogQueryHistory{},
+		&model.ChangeEvent{},
+		&TopologySnapshot{},
+		&ServiceCatalog{},
 	)
 }
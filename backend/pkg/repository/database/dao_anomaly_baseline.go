@@ -0,0 +1,51 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"gorm.io/gorm/clause"
+)
+
+// AnomalyBaseline is the seasonal baseline of a RED metric learned from history.
+// Endpoint is empty for the baseline of the whole service.
+type AnomalyBaseline struct {
+	ID          int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ServiceName string `gorm:"column:service_name;type:varchar(255);uniqueIndex:idx_anomaly_baseline" json:"serviceName"`
+	Endpoint    string `gorm:"column:endpoint;type:varchar(255);uniqueIndex:idx_anomaly_baseline" json:"endpoint"`
+	// latency / error / throughput
+	Metric string `gorm:"column:metric;type:varchar(50);uniqueIndex:idx_anomaly_baseline" json:"metric"`
+	// Baseline JSON of the seasonal buckets
+	Baseline string `gorm:"column:baseline;type:text" json:"baseline"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (AnomalyBaseline) TableName() string {
+	return "anomaly_baseline"
+}
+
+func (repo *daoRepo) SaveAnomalyBaselines(ctx core.Context, baselines []AnomalyBaseline) error {
+	if len(baselines) == 0 {
+		return nil
+	}
+	return repo.GetContextDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "service_name"}, {Name: "endpoint"}, {Name: "metric"}},
+		DoUpdates: clause.AssignmentColumns([]string{"baseline", "updated_at"}),
+	}).CreateInBatches(baselines, 100).Error
+}
+
+// ListAnomalyBaselines returns baselines of the services, all services are returned if serviceNames is empty.
+func (repo *daoRepo) ListAnomalyBaselines(ctx core.Context, serviceNames []string, metric string) ([]AnomalyBaseline, error) {
+	var baselines []AnomalyBaseline
+	query := repo.GetContextDB(ctx).Model(&AnomalyBaseline{})
+	if len(serviceNames) > 0 {
+		query = query.Where("service_name IN ?", serviceNames)
+	}
+	if len(metric) > 0 {
+		query = query.Where("metric = ?", metric)
+	}
+	err := query.Find(&baselines).Error
+	return baselines, err
+}
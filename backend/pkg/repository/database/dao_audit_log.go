@@ -0,0 +1,79 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+// AuditLog records a configuration change or a sensitive read made through the API.
+type AuditLog struct {
+	ID            int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID        int64  `gorm:"column:user_id;index" json:"userId"`
+	Username      string `gorm:"column:username;type:varchar(100)" json:"username"`
+	Method        string `gorm:"column:method;type:varchar(10)" json:"method"`
+	Path          string `gorm:"column:path;type:varchar(200);index" json:"path"`
+	Query         string `gorm:"column:query;type:text" json:"query"`
+	Body          string `gorm:"column:body;type:text" json:"body"`          // secrets redacted
+	BodyTruncated bool   `gorm:"column:body_truncated" json:"bodyTruncated"` // only the head of a large body is kept
+	Diff          string `gorm:"column:diff;type:text" json:"diff"`          // changes compared with the stored configuration
+	ClientIP      string `gorm:"column:client_ip;type:varchar(50)" json:"clientIp"`
+	HTTPCode      int    `gorm:"column:http_code" json:"httpCode"`
+	BusinessCode  string `gorm:"column:business_code;type:varchar(20)" json:"businessCode"`
+	Success       bool   `gorm:"column:success" json:"success"`
+	Timestamp     int64  `gorm:"column:timestamp;index" json:"timestamp"` // microseconds
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+func (repo *daoRepo) CreateAuditLog(ctx core.Context, auditLog *AuditLog) error {
+	return repo.GetContextDB(ctx).Create(auditLog).Error
+}
+
+func (repo *daoRepo) GetAuditLogs(ctx core.Context, req *request.GetAuditLogRequest) ([]AuditLog, int64, error) {
+	var auditLogs []AuditLog
+	var count int64
+
+	query := repo.GetContextDB(ctx).Model(&AuditLog{})
+	if req.UserID > 0 {
+		query = query.Where("user_id = ?", req.UserID)
+	}
+	if len(req.Username) > 0 {
+		query = query.Where("username LIKE ?", "%"+req.Username+"%")
+	}
+	if len(req.Method) > 0 {
+		query = query.Where("method = ?", req.Method)
+	}
+	if len(req.Path) > 0 {
+		query = query.Where("path LIKE ?", "%"+req.Path+"%")
+	}
+	if req.Success != nil {
+		query = query.Where("success = ?", *req.Success)
+	}
+	if req.StartTime > 0 {
+		query = query.Where("timestamp >= ?", req.StartTime)
+	}
+	if req.EndTime > 0 {
+		query = query.Where("timestamp <= ?", req.EndTime)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if req.PageParam != nil {
+		query = query.Limit(req.PageSize).Offset((req.CurrentPage - 1) * req.PageSize)
+	}
+	err = query.Order("timestamp DESC").Find(&auditLogs).Error
+	return auditLogs, count, err
+}
+
+// DeleteAuditLogBefore removes records older than the timestamp (microseconds).
+func (repo *daoRepo) DeleteAuditLogBefore(ctx core.Context, timestamp int64) (int64, error) {
+	result := repo.GetContextDB(ctx).Where("timestamp < ?", timestamp).Delete(&AuditLog{})
+	return result.RowsAffected, result.Error
+}
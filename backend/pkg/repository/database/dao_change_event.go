@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+)
+
+// ChangeEventFilter selects the changes in [StartTime, EndTime] of the services or in the namespaces.
+type ChangeEventFilter struct {
+	StartTime  int64 // microseconds
+	EndTime    int64 // microseconds
+	Types      []string
+	ClusterID  string
+	Services   []string
+	Namespaces []string
+}
+
+func (repo *daoRepo) CreateChangeEvent(ctx core.Context, event *model.ChangeEvent) error {
+	return repo.GetContextDB(ctx).Create(event).Error
+}
+
+func (repo *daoRepo) ListChangeEvents(ctx core.Context, filter *ChangeEventFilter) ([]model.ChangeEvent, error) {
+	query := repo.GetContextDB(ctx).
+		Where("timestamp >= ? AND timestamp <= ?", filter.StartTime, filter.EndTime)
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+	if len(filter.ClusterID) > 0 {
+		query = query.Where("cluster_id = ?", filter.ClusterID)
+	}
+	switch {
+	case len(filter.Services) > 0 && len(filter.Namespaces) > 0:
+		query = query.Where("service IN ? OR namespace IN ?", filter.Services, filter.Namespaces)
+	case len(filter.Services) > 0:
+		query = query.Where("service IN ?", filter.Services)
+	case len(filter.Namespaces) > 0:
+		query = query.Where("namespace IN ?", filter.Namespaces)
+	}
+
+	var events []model.ChangeEvent
+	err := query.Order("timestamp ASC").Find(&events).Error
+	return events, err
+}
+
+// DeleteChangeEventBefore removes the changes older than the timestamp (microseconds).
+func (repo *daoRepo) DeleteChangeEventBefore(ctx core.Context, timestamp int64) (int64, error) {
+	result := repo.GetContextDB(ctx).Where("timestamp < ?", timestamp).Delete(&model.ChangeEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -10,20 +10,38 @@ import (
 )
 
 type CustomServiceTopology struct {
-	ID         int    `gorm:"column:id;primary_key;auto_increment" json:"id"`
-	ClusterId  string `gorm:"column:cluster_id;type:varchar(100)" json:"clusterId"`
-	LeftNode   string `gorm:"column:left_node;type:varchar(200)" json:"leftNode"`
-	LeftType   string `gorm:"column:left_type;type:varchar(20)" json:"leftType"`
-	RightNode  string `gorm:"column:right_node;type:varchar(200)" json:"rightNode"`
-	RightType  string `gorm:"column:right_type;type:varchar(20)" json:"rightType"`
-	StartTime  int64  `gorm:"column:start_time" json:"startTime"`
-	ExpireTime int64  `gorm:"column:expire_time" json:"expireTime"`
+	ID        int    `gorm:"column:id;primary_key;auto_increment" json:"id"`
+	ClusterId string `gorm:"column:cluster_id;type:varchar(100)" json:"clusterId"`
+	LeftNode  string `gorm:"column:left_node;type:varchar(200)" json:"leftNode"`
+	LeftType  string `gorm:"column:left_type;type:varchar(20)" json:"leftType"`
+	RightNode string `gorm:"column:right_node;type:varchar(200)" json:"rightNode"`
+	RightType string `gorm:"column:right_type;type:varchar(20)" json:"rightType"`
+	// StartTime and ExpireTime limit when the edge is shown in the topology, 0 means unlimited. Unit: microseconds
+	StartTime  int64 `gorm:"column:start_time" json:"startTime"`
+	ExpireTime int64 `gorm:"column:expire_time" json:"expireTime"`
+
+	Protocol    string `gorm:"column:protocol;type:varchar(20)" json:"protocol"`
+	Port        int    `gorm:"column:port" json:"port"`
+	Description string `gorm:"column:description;type:varchar(500)" json:"description"`
+	// SourceSystem is where the edge is declared, e.g. cmdb
+	SourceSystem string `gorm:"column:source_system;type:varchar(100)" json:"sourceSystem"`
+
+	// Redundant is set when the edge is found in the realtime topology, so the custom edge is not needed anymore
+	Redundant      bool  `gorm:"column:redundant" json:"redundant"`
+	RedundantSince int64 `gorm:"column:redundant_since" json:"redundantSince"`
+	ReconciledAt   int64 `gorm:"column:reconciled_at" json:"reconciledAt"`
 }
 
 func (CustomServiceTopology) TableName() string {
 	return "custom_service_topology"
 }
 
+// ActiveAt checks whether the edge is shown in the topology at the time (microseconds).
+func (topology *CustomServiceTopology) ActiveAt(ts int64) bool {
+	return (topology.StartTime == 0 || topology.StartTime <= ts) &&
+		(topology.ExpireTime == 0 || topology.ExpireTime >= ts)
+}
+
 func (repo *daoRepo) CreateCustomServiceTopology(ctx core.Context, topology *CustomServiceTopology) error {
 	var count int64
 	repo.GetContextDB(ctx).Model(&CustomServiceTopology{}).Where("cluster_id = ? AND left_node = ? AND right_node = ?", topology.ClusterId, topology.LeftNode, topology.RightNode).Count(&count)
@@ -33,6 +51,21 @@ func (repo *daoRepo) CreateCustomServiceTopology(ctx core.Context, topology *Cus
 	return repo.GetContextDB(ctx).Create(topology).Error
 }
 
+func (repo *daoRepo) UpdateCustomServiceTopology(ctx core.Context, topology *CustomServiceTopology) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id").Where("id = ?", topology.ID).Updates(topology).Error
+}
+
+// UpdateCustomServiceTopologyReconciliation saves the reconciliation result only,
+// so that the edge edited by users during the reconciliation is not overwritten.
+func (repo *daoRepo) UpdateCustomServiceTopologyReconciliation(ctx core.Context, topology *CustomServiceTopology) error {
+	return repo.GetContextDB(ctx).Model(&CustomServiceTopology{}).Where("id = ?", topology.ID).
+		UpdateColumns(map[string]any{
+			"redundant":       topology.Redundant,
+			"redundant_since": topology.RedundantSince,
+			"reconciled_at":   topology.ReconciledAt,
+		}).Error
+}
+
 func (repo *daoRepo) ListCustomServiceTopology(ctx core.Context) ([]CustomServiceTopology, error) {
 	var topologies []CustomServiceTopology
 	err := repo.GetContextDB(ctx).
@@ -42,6 +75,23 @@ func (repo *daoRepo) ListCustomServiceTopology(ctx core.Context) ([]CustomServic
 	return topologies, err
 }
 
+// ListActiveCustomServiceTopology returns the edges shown in the topology at the time (microseconds).
+func (repo *daoRepo) ListActiveCustomServiceTopology(ctx core.Context, ts int64) ([]CustomServiceTopology, error) {
+	var topologies []CustomServiceTopology
+	err := repo.GetContextDB(ctx).
+		Model(&CustomServiceTopology{}).
+		Where("(start_time = 0 OR start_time <= ?) AND (expire_time = 0 OR expire_time >= ?)", ts, ts).
+		Order("cluster_id ASC, left_node ASC").
+		Scan(&topologies).Error
+	return topologies, err
+}
+
 func (repo *daoRepo) DeleteCustomServiceTopology(ctx core.Context, id int) error {
 	return repo.GetContextDB(ctx).Model(&CustomServiceTopology{}).Where("id = ?", id).Delete(nil).Error
 }
+
+// DeleteCustomServiceTopologyExpiredBefore removes the edges expired before the timestamp (microseconds).
+func (repo *daoRepo) DeleteCustomServiceTopologyExpiredBefore(ctx core.Context, timestamp int64) (int64, error) {
+	result := repo.GetContextDB(ctx).Where("expire_time > 0 AND expire_time < ?", timestamp).Delete(&CustomServiceTopology{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+func TestUpdateCustomServiceTopologyReconciliation(t *testing.T) {
+	repo := newSqliteRepo(t, &CustomServiceTopology{})
+	topology := &CustomServiceTopology{ClusterId: "c1", LeftNode: "a", RightNode: "b", Description: "old"}
+	if err := repo.CreateCustomServiceTopology(core.EmptyCtx(), topology); err != nil {
+		t.Fatal(err)
+	}
+
+	// the reconciler works on the edge read before the user edits it
+	reconciled := *topology
+	edited := *topology
+	edited.Description, edited.ExpireTime = "new", 100
+	if err := repo.UpdateCustomServiceTopology(core.EmptyCtx(), &edited); err != nil {
+		t.Fatal(err)
+	}
+	reconciled.Redundant, reconciled.RedundantSince, reconciled.ReconciledAt = true, 10, 10
+	if err := repo.UpdateCustomServiceTopologyReconciliation(core.EmptyCtx(), &reconciled); err != nil {
+		t.Fatal(err)
+	}
+
+	topologies, err := repo.ListCustomServiceTopology(core.EmptyCtx())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := topologies[0]
+	if got.Description != "new" || got.ExpireTime != 100 || !got.Redundant || got.ReconciledAt != 10 {
+		t.Errorf("topology = %+v", got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"gorm.io/gorm"
+)
+
+// HealthScoreModel decides how the red/yellow/green light of services in a data group is scored.
+type HealthScoreModel struct {
+	ID      int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	GroupID int64 `gorm:"column:group_id;uniqueIndex" json:"groupId"`
+	// worst / weighted
+	Aggregation string              `gorm:"column:aggregation;type:varchar(20)" json:"aggregation"`
+	Signals     model.HealthSignals `gorm:"column:signals;type:text" json:"signals"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (HealthScoreModel) TableName() string {
+	return "health_score_model"
+}
+
+// GetHealthScoreModel returns nil if the data group has no model.
+func (repo *daoRepo) GetHealthScoreModel(ctx core.Context, groupID int64) (*HealthScoreModel, error) {
+	var scoreModel HealthScoreModel
+	err := repo.GetContextDB(ctx).Where("group_id = ?", groupID).First(&scoreModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scoreModel, nil
+}
+
+func (repo *daoRepo) SaveHealthScoreModel(ctx core.Context, scoreModel *HealthScoreModel) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&HealthScoreModel{}).Where("group_id = ?", scoreModel.GroupID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return repo.GetContextDB(ctx).Select("aggregation", "signals", "updated_at").
+			Where("group_id = ?", scoreModel.GroupID).Updates(scoreModel).Error
+	}
+	return repo.GetContextDB(ctx).Create(scoreModel).Error
+}
+
+func (repo *daoRepo) DeleteHealthScoreModel(ctx core.Context, groupID int64) error {
+	return repo.GetContextDB(ctx).Where("group_id = ?", groupID).Delete(&HealthScoreModel{}).Error
+}
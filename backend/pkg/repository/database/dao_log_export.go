@@ -0,0 +1,195 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"gorm.io/gorm"
+)
+
+const (
+	LogExportRunning   = "running"
+	LogExportSucceeded = "succeeded"
+	LogExportFailed    = "failed"
+)
+
+// LogExportJob exports the result of a log query into a file asynchronously.
+type LogExportJob struct {
+	ID     int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID int64 `gorm:"column:user_id;index" json:"userId"`
+
+	DataBase  string `gorm:"column:data_base;type:varchar(100)" json:"dataBase"`
+	Table     string `gorm:"column:table_name;type:varchar(100)" json:"tableName"`
+	Query     string `gorm:"column:query;type:text" json:"query"`
+	RawSQL    bool   `gorm:"column:raw_sql" json:"rawSql"`
+	TimeField string `gorm:"column:time_field;type:varchar(100)" json:"timeField"`
+	LogField  string `gorm:"column:log_field;type:varchar(100)" json:"logField"`
+	StartTime int64  `gorm:"column:start_time" json:"startTime"`
+	EndTime   int64  `gorm:"column:end_time" json:"endTime"`
+
+	// ndjson / csv / parquet
+	Format string `gorm:"column:format;type:varchar(20)" json:"format"`
+	Limit  int    `gorm:"column:row_limit" json:"limit"`
+
+	// running / succeeded / failed
+	Status string `gorm:"column:status;type:varchar(20)" json:"status"`
+	// Rows exported so far
+	Rows int64 `gorm:"column:rows_exported" json:"rows"`
+	// Total is the number of logs to export, no more than Limit
+	Total    int64  `gorm:"column:total" json:"total"`
+	FileName string `gorm:"column:file_name;type:varchar(255)" json:"fileName"`
+	Size     int64  `gorm:"column:size" json:"size"`
+	Error    string `gorm:"column:error;type:text" json:"error"`
+
+	CreatedAt  int64 `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	FinishedAt int64 `gorm:"column:finished_at" json:"finishedAt"`
+}
+
+func (LogExportJob) TableName() string {
+	return "log_export_job"
+}
+
+// LogArchiveJob copies the partitions of a log table about to expire into the object storage.
+type LogArchiveJob struct {
+	ID       int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name     string `gorm:"column:name;type:varchar(200);uniqueIndex" json:"name"`
+	DataBase string `gorm:"column:data_base;type:varchar(100)" json:"dataBase"`
+	Table    string `gorm:"column:table_name;type:varchar(100)" json:"tableName"`
+	Format   string `gorm:"column:format;type:varchar(20)" json:"format"`
+	// Schedule is a standard cron expression
+	Schedule string `gorm:"column:schedule;type:varchar(100)" json:"schedule"`
+	// DaysBeforeExpire the partitions expiring within the days are archived
+	DaysBeforeExpire int `gorm:"column:days_before_expire" json:"daysBeforeExpire"`
+	// Prefix of the object keys
+	Prefix  string `gorm:"column:prefix;type:varchar(255)" json:"prefix"`
+	Enabled bool   `gorm:"column:enabled" json:"enabled"`
+
+	LastRunAt int64  `gorm:"column:last_run_at" json:"lastRunAt"`
+	LastError string `gorm:"column:last_error;type:text" json:"lastError"`
+	UpdatedAt int64  `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (LogArchiveJob) TableName() string {
+	return "log_archive_job"
+}
+
+// LogArchiveRecord is a partition archived by the job.
+type LogArchiveRecord struct {
+	ID    int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	JobID int64 `gorm:"column:job_id;uniqueIndex:idx_job_day" json:"jobId"`
+	// Day of the partition, e.g. 2025-01-02
+	Day       string `gorm:"column:day;type:varchar(20);uniqueIndex:idx_job_day" json:"day"`
+	ObjectKey string `gorm:"column:object_key;type:varchar(500)" json:"objectKey"`
+	Rows      int64  `gorm:"column:rows_archived" json:"rows"`
+	Size      int64  `gorm:"column:size" json:"size"`
+	CreatedAt int64  `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+func (LogArchiveRecord) TableName() string {
+	return "log_archive_record"
+}
+
+func (repo *daoRepo) CreateLogExportJob(ctx core.Context, job *LogExportJob) error {
+	return repo.GetContextDB(ctx).Create(job).Error
+}
+
+func (repo *daoRepo) UpdateLogExportJob(ctx core.Context, job *LogExportJob) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id", "created_at").Where("id = ?", job.ID).Updates(job).Error
+}
+
+func (repo *daoRepo) DeleteLogExportJob(ctx core.Context, id int64) error {
+	return repo.GetContextDB(ctx).Where("id = ?", id).Delete(&LogExportJob{}).Error
+}
+
+// GetLogExportJob returns nil if the job is not found.
+func (repo *daoRepo) GetLogExportJob(ctx core.Context, id int64) (*LogExportJob, error) {
+	var job LogExportJob
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListLogExportJobs returns the jobs of the user, newest first.
+func (repo *daoRepo) ListLogExportJobs(ctx core.Context, userID int64) ([]LogExportJob, error) {
+	var jobs []LogExportJob
+	err := repo.GetContextDB(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ListLogExportJobsBefore returns the jobs created before the time in seconds.
+func (repo *daoRepo) ListLogExportJobsBefore(ctx core.Context, createdBefore int64) ([]LogExportJob, error) {
+	var jobs []LogExportJob
+	err := repo.GetContextDB(ctx).Where("created_at < ?", createdBefore).Find(&jobs).Error
+	return jobs, err
+}
+
+func (repo *daoRepo) CreateLogArchiveJob(ctx core.Context, job *LogArchiveJob) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&LogArchiveJob{}).Where("name = ?", job.Name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("log archive job already exists")
+	}
+	return repo.GetContextDB(ctx).Create(job).Error
+}
+
+// UpdateLogArchiveJob updates the settings of the job, the result of the last run is kept.
+func (repo *daoRepo) UpdateLogArchiveJob(ctx core.Context, job *LogArchiveJob) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id", "last_run_at", "last_error").Where("id = ?", job.ID).Updates(job).Error
+}
+
+// UpdateLogArchiveJobRun records the result of the last run.
+func (repo *daoRepo) UpdateLogArchiveJobRun(ctx core.Context, id int64, runAt int64, runError string) error {
+	return repo.GetContextDB(ctx).Model(&LogArchiveJob{}).Where("id = ?", id).
+		UpdateColumns(map[string]any{"last_run_at": runAt, "last_error": runError}).Error
+}
+
+// DeleteLogArchiveJob deletes the job together with its records, the archived objects are kept.
+func (repo *daoRepo) DeleteLogArchiveJob(ctx core.Context, id int64) error {
+	return repo.Transaction(ctx, func(txCtx core.Context) error {
+		if err := repo.GetContextDB(txCtx).Where("job_id = ?", id).Delete(&LogArchiveRecord{}).Error; err != nil {
+			return err
+		}
+		return repo.GetContextDB(txCtx).Where("id = ?", id).Delete(&LogArchiveJob{}).Error
+	})
+}
+
+// GetLogArchiveJob returns nil if the job is not found.
+func (repo *daoRepo) GetLogArchiveJob(ctx core.Context, id int64) (*LogArchiveJob, error) {
+	var job LogArchiveJob
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&job).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (repo *daoRepo) ListLogArchiveJobs(ctx core.Context) ([]LogArchiveJob, error) {
+	var jobs []LogArchiveJob
+	err := repo.GetContextDB(ctx).Order("id").Find(&jobs).Error
+	return jobs, err
+}
+
+func (repo *daoRepo) CreateLogArchiveRecord(ctx core.Context, record *LogArchiveRecord) error {
+	return repo.GetContextDB(ctx).Create(record).Error
+}
+
+// ListLogArchiveRecords returns the archived partitions of the job, newest first.
+func (repo *daoRepo) ListLogArchiveRecords(ctx core.Context, jobID int64) ([]LogArchiveRecord, error) {
+	var records []LogArchiveRecord
+	err := repo.GetContextDB(ctx).Where("job_id = ?", jobID).Order("day DESC").Find(&records).Error
+	return records, err
+}
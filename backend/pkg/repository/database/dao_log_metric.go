@@ -0,0 +1,158 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"gorm.io/gorm"
+)
+
+// LogMetric aggregates the logs of a log table periodically, the latest values are exposed as Prometheus metrics.
+type LogMetric struct {
+	ID int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	// Name is the Prometheus metric name
+	Name        string `gorm:"column:name;type:varchar(200);uniqueIndex" json:"name"`
+	Description string `gorm:"column:description;type:varchar(500)" json:"description"`
+
+	DataBase  string `gorm:"column:data_base;type:varchar(100)" json:"dataBase"`
+	Table     string `gorm:"column:table_name;type:varchar(100)" json:"tableName"`
+	TimeField string `gorm:"column:time_field;type:varchar(100)" json:"timeField"`
+	LogField  string `gorm:"column:log_field;type:varchar(100)" json:"logField"`
+	// Query in the log query language
+	Query string `gorm:"column:query;type:text" json:"query"`
+
+	// count / sum / avg / min / max / p50 / p90 / p95 / p99
+	Aggregation string `gorm:"column:aggregation;type:varchar(20)" json:"aggregation"`
+	// Field is the aggregated numeric field, not used by count
+	Field   string                          `gorm:"column:field;type:varchar(200)" json:"field"`
+	GroupBy integration.JSONField[[]string] `gorm:"column:group_by;type:json" json:"groupBy"`
+
+	// IntervalSeconds between evaluations
+	IntervalSeconds int `gorm:"column:interval_seconds" json:"intervalSeconds"`
+	// WindowSeconds of logs aggregated in each evaluation, default is IntervalSeconds
+	WindowSeconds int `gorm:"column:window_seconds" json:"windowSeconds"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (LogMetric) TableName() string {
+	return "log_metric"
+}
+
+// LogAlertRule fires when the value of a log metric matches the condition.
+type LogAlertRule struct {
+	ID          int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name        string `gorm:"column:name;type:varchar(200);uniqueIndex" json:"name"`
+	Description string `gorm:"column:description;type:varchar(500)" json:"description"`
+	MetricID    int64  `gorm:"column:metric_id;index" json:"metricId"`
+
+	// one of > >= < <= == !=
+	Operator  string  `gorm:"column:operator;type:varchar(5)" json:"operator"`
+	Threshold float64 `gorm:"column:threshold" json:"threshold"`
+	// ForEvaluations is the number of consecutive evaluations matched before firing
+	ForEvaluations int `gorm:"column:for_evaluations" json:"forEvaluations"`
+	// critical / error / warning / info
+	Severity string `gorm:"column:severity;type:varchar(20)" json:"severity"`
+	// Labels are added to the tags of the alert events
+	Labels  integration.JSONField[map[string]string] `gorm:"column:labels;type:json" json:"labels"`
+	Enabled bool                                     `gorm:"column:enabled" json:"enabled"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (LogAlertRule) TableName() string {
+	return "log_alert_rule"
+}
+
+func (repo *daoRepo) CreateLogMetric(ctx core.Context, metric *LogMetric) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&LogMetric{}).Where("name = ?", metric.Name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("log metric already exists")
+	}
+	return repo.GetContextDB(ctx).Create(metric).Error
+}
+
+func (repo *daoRepo) UpdateLogMetric(ctx core.Context, metric *LogMetric) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id").Where("id = ?", metric.ID).Updates(metric).Error
+}
+
+// DeleteLogMetric deletes the log metric together with its alert rules.
+func (repo *daoRepo) DeleteLogMetric(ctx core.Context, id int64) error {
+	return repo.Transaction(ctx, func(txCtx core.Context) error {
+		if err := repo.GetContextDB(txCtx).Where("metric_id = ?", id).Delete(&LogAlertRule{}).Error; err != nil {
+			return err
+		}
+		return repo.GetContextDB(txCtx).Where("id = ?", id).Delete(&LogMetric{}).Error
+	})
+}
+
+// GetLogMetric returns nil if the log metric is not found.
+func (repo *daoRepo) GetLogMetric(ctx core.Context, id int64) (*LogMetric, error) {
+	var metric LogMetric
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&metric).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &metric, nil
+}
+
+func (repo *daoRepo) ListLogMetrics(ctx core.Context) ([]LogMetric, error) {
+	var metrics []LogMetric
+	err := repo.GetContextDB(ctx).Order("id").Find(&metrics).Error
+	return metrics, err
+}
+
+func (repo *daoRepo) CreateLogAlertRule(ctx core.Context, rule *LogAlertRule) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&LogAlertRule{}).Where("name = ?", rule.Name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("log alert rule already exists")
+	}
+	return repo.GetContextDB(ctx).Create(rule).Error
+}
+
+func (repo *daoRepo) UpdateLogAlertRule(ctx core.Context, rule *LogAlertRule) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id").Where("id = ?", rule.ID).Updates(rule).Error
+}
+
+func (repo *daoRepo) DeleteLogAlertRule(ctx core.Context, id int64) error {
+	return repo.GetContextDB(ctx).Where("id = ?", id).Delete(&LogAlertRule{}).Error
+}
+
+// GetLogAlertRule returns nil if the log alert rule is not found.
+func (repo *daoRepo) GetLogAlertRule(ctx core.Context, id int64) (*LogAlertRule, error) {
+	var rule LogAlertRule
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ListLogAlertRules returns the rules of the log metric, all rules are returned if metricID is 0.
+func (repo *daoRepo) ListLogAlertRules(ctx core.Context, metricID int64) ([]LogAlertRule, error) {
+	var rules []LogAlertRule
+	query := repo.GetContextDB(ctx).Order("id")
+	if metricID > 0 {
+		query = query.Where("metric_id = ?", metricID)
+	}
+	err := query.Find(&rules).Error
+	return rules, err
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"gorm.io/gorm"
+)
+
+const (
+	LogQuerySharePrivate   = "private"
+	LogQueryShareTeam      = "team"
+	LogQueryShareDataGroup = "datagroup"
+)
+
+// LogSavedQuery is a named log query owned by a user, it can be shared with a team or a data group.
+type LogSavedQuery struct {
+	ID          int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID      int64  `gorm:"column:user_id;index;uniqueIndex:idx_user_name" json:"userId"`
+	Name        string `gorm:"column:name;type:varchar(200);uniqueIndex:idx_user_name" json:"name"`
+	Description string `gorm:"column:description;type:varchar(500)" json:"description"`
+
+	DataBase  string `gorm:"column:data_base;type:varchar(100)" json:"dataBase"`
+	Table     string `gorm:"column:table_name;type:varchar(100)" json:"tableName"`
+	Query     string `gorm:"column:query;type:text" json:"query"`
+	RawSQL    bool   `gorm:"column:raw_sql" json:"rawSql"`
+	TimeField string `gorm:"column:time_field;type:varchar(100)" json:"timeField"`
+	LogField  string `gorm:"column:log_field;type:varchar(100)" json:"logField"`
+
+	// RelativeRange is the time range before now, e.g. 15m, 1h, 7d, StartTime and EndTime are used if empty
+	RelativeRange string `gorm:"column:relative_range;type:varchar(20)" json:"relativeRange"`
+	StartTime     int64  `gorm:"column:start_time" json:"startTime"`
+	EndTime       int64  `gorm:"column:end_time" json:"endTime"`
+	// Fields displayed in the log list
+	Fields integration.JSONField[[]string] `gorm:"column:fields;type:json" json:"fields"`
+
+	// private / team / datagroup
+	ShareType   string `gorm:"column:share_type;type:varchar(20)" json:"shareType"`
+	TeamID      int64  `gorm:"column:team_id" json:"teamId"`
+	DataGroupID int64  `gorm:"column:data_group_id" json:"dataGroupId"`
+
+	CreatedAt int64 `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (LogSavedQuery) TableName() string {
+	return "log_saved_query"
+}
+
+// LogQueryHistory is a log query recently executed by a user.
+type LogQueryHistory struct {
+	ID     int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserID int64 `gorm:"column:user_id;index" json:"userId"`
+
+	DataBase  string `gorm:"column:data_base;type:varchar(100)" json:"dataBase"`
+	Table     string `gorm:"column:table_name;type:varchar(100)" json:"tableName"`
+	Query     string `gorm:"column:query;type:text" json:"query"`
+	RawSQL    bool   `gorm:"column:raw_sql" json:"rawSql"`
+	TimeField string `gorm:"column:time_field;type:varchar(100)" json:"timeField"`
+	LogField  string `gorm:"column:log_field;type:varchar(100)" json:"logField"`
+	StartTime int64  `gorm:"column:start_time" json:"startTime"`
+	EndTime   int64  `gorm:"column:end_time" json:"endTime"`
+
+	// QueriedAt is the last time in seconds the query was executed
+	QueriedAt int64 `gorm:"column:queried_at;index" json:"queriedAt"`
+}
+
+func (LogQueryHistory) TableName() string {
+	return "log_query_history"
+}
+
+func (repo *daoRepo) CreateLogSavedQuery(ctx core.Context, query *LogSavedQuery) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&LogSavedQuery{}).Where("user_id = ? AND name = ?", query.UserID, query.Name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("saved log query already exists")
+	}
+	return repo.GetContextDB(ctx).Create(query).Error
+}
+
+// UpdateLogSavedQuery updates the query, the owner is kept.
+func (repo *daoRepo) UpdateLogSavedQuery(ctx core.Context, query *LogSavedQuery) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&LogSavedQuery{}).
+		Where("user_id = ? AND name = ? AND id <> ?", query.UserID, query.Name, query.ID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("saved log query already exists")
+	}
+	return repo.GetContextDB(ctx).Select("*").Omit("id", "user_id", "created_at").Where("id = ?", query.ID).Updates(query).Error
+}
+
+func (repo *daoRepo) DeleteLogSavedQuery(ctx core.Context, id int64) error {
+	return repo.GetContextDB(ctx).Where("id = ?", id).Delete(&LogSavedQuery{}).Error
+}
+
+// GetLogSavedQuery returns nil if the query is not found.
+func (repo *daoRepo) GetLogSavedQuery(ctx core.Context, id int64) (*LogSavedQuery, error) {
+	var query LogSavedQuery
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&query).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// ListLogSavedQueries returns the queries owned by the user, shared with the teams
+// and shared with any data group, the data groups are checked by the caller.
+func (repo *daoRepo) ListLogSavedQueries(ctx core.Context, userID int64, teamIDs []int64) ([]LogSavedQuery, error) {
+	var queries []LogSavedQuery
+	db := repo.GetContextDB(ctx)
+	where := db.Where("user_id = ?", userID).Or("share_type = ?", LogQueryShareDataGroup)
+	if len(teamIDs) > 0 {
+		where = where.Or("share_type = ? AND team_id IN ?", LogQueryShareTeam, teamIDs)
+	}
+	err := db.Where(where).Order("updated_at DESC").Find(&queries).Error
+	return queries, err
+}
+
+// AddLogQueryHistory records the query of the user, the same query executed again is moved
+// to the top, only the latest keep queries of the user are kept.
+func (repo *daoRepo) AddLogQueryHistory(ctx core.Context, history *LogQueryHistory, keep int) error {
+	return repo.Transaction(ctx, func(txCtx core.Context) error {
+		db := repo.GetContextDB(txCtx)
+		var latest LogQueryHistory
+		err := db.Where("user_id = ?", history.UserID).Order("queried_at DESC, id DESC").First(&latest).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && latest.DataBase == history.DataBase && latest.Table == history.Table &&
+			latest.Query == history.Query && latest.RawSQL == history.RawSQL {
+			history.ID = latest.ID
+			return db.Model(&LogQueryHistory{}).Where("id = ?", latest.ID).
+				UpdateColumns(map[string]any{"start_time": history.StartTime, "end_time": history.EndTime, "queried_at": history.QueriedAt}).Error
+		}
+		if err := db.Create(history).Error; err != nil {
+			return err
+		}
+
+		// OFFSET without LIMIT is rejected by MySQL, so the expired ones are picked here
+		var ids []int64
+		err = db.Model(&LogQueryHistory{}).Where("user_id = ?", history.UserID).
+			Order("queried_at DESC, id DESC").Pluck("id", &ids).Error
+		if err != nil || len(ids) <= keep {
+			return err
+		}
+		return db.Where("id IN ?", ids[keep:]).Delete(&LogQueryHistory{}).Error
+	})
+}
+
+// ListLogQueryHistory returns the latest queries of the user.
+func (repo *daoRepo) ListLogQueryHistory(ctx core.Context, userID int64, limit int) ([]LogQueryHistory, error) {
+	var histories []LogQueryHistory
+	err := repo.GetContextDB(ctx).Where("user_id = ?", userID).Order("queried_at DESC, id DESC").Limit(limit).Find(&histories).Error
+	return histories, err
+}
+
+// DeleteLogQueryHistory deletes a query in the history of the user, all queries are deleted if id is 0.
+func (repo *daoRepo) DeleteLogQueryHistory(ctx core.Context, userID int64, id int64) error {
+	db := repo.GetContextDB(ctx).Where("user_id = ?", userID)
+	if id > 0 {
+		db = db.Where("id = ?", id)
+	}
+	return db.Delete(&LogQueryHistory{}).Error
+}
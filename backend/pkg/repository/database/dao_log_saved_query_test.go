@@ -0,0 +1,57 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database/driver"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newSqliteRepo returns a repo on an in-memory sqlite database with the tables of models.
+func newSqliteRepo(t *testing.T, models ...any) *daoRepo {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection has its own in-memory database
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	return &daoRepo{DB: &driver.DB{DB: db}, sqlDB: sqlDB}
+}
+
+func TestAddLogQueryHistory(t *testing.T) {
+	repo := newSqliteRepo(t, &LogQueryHistory{})
+	for i, query := range []string{"a", "b", "c", "c", "d"} {
+		history := &LogQueryHistory{UserID: 1, DataBase: "apo", Table: "logs", Query: query, QueriedAt: int64(i)}
+		if err := repo.AddLogQueryHistory(core.EmptyCtx(), history, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other := &LogQueryHistory{UserID: 2, Query: "x", QueriedAt: 10}
+	if err := repo.AddLogQueryHistory(core.EmptyCtx(), other, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	histories, err := repo.ListLogQueryHistory(core.EmptyCtx(), 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries []string
+	for _, history := range histories {
+		queries = append(queries, history.Query)
+	}
+	if len(queries) != 3 || queries[0] != "d" || queries[1] != "c" || queries[2] != "b" {
+		t.Errorf("histories = %v", queries)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"gorm.io/gorm/clause"
+)
+
+// RecordingRule is a recording rule deployed to vmalert.
+type RecordingRule struct {
+	Record string `gorm:"column:record;type:varchar(255);primaryKey" json:"record"`
+	Metric string `gorm:"column:metric;type:varchar(255)" json:"metric"`
+	Expr   string `gorm:"column:expr;type:text" json:"expr"`
+	// DeployedAt is the unix seconds when the expr is deployed, kept unchanged when redeployed with the same expr.
+	DeployedAt int64 `gorm:"column:deployed_at" json:"deployedAt"`
+}
+
+func (RecordingRule) TableName() string {
+	return "recording_rule"
+}
+
+func (repo *daoRepo) ListRecordingRules(ctx core.Context) ([]RecordingRule, error) {
+	var rules []RecordingRule
+	err := repo.GetContextDB(ctx).Order("record ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (repo *daoRepo) SaveRecordingRules(ctx core.Context, rules []RecordingRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	return repo.GetContextDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "record"}},
+		DoUpdates: clause.AssignmentColumns([]string{"metric", "expr", "deployed_at"}),
+	}).Create(&rules).Error
+}
+
+func (repo *daoRepo) DeleteAllRecordingRules(ctx core.Context) error {
+	return repo.GetContextDB(ctx).Where("1 = 1").Delete(&RecordingRule{}).Error
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ServiceCatalog is the metadata of a service maintained by users, e.g. the owner and the runbook.
+type ServiceCatalog struct {
+	ID      int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Service string `gorm:"column:service;type:varchar(255);uniqueIndex" json:"service"`
+	// TeamID is the owner team, 0 if not assigned
+	TeamID   int64  `gorm:"column:team_id" json:"teamId"`
+	TeamName string `gorm:"column:team_name;->;-:migration" json:"teamName,omitempty"`
+	// Tier is the business criticality, e.g. tier-1
+	Tier          string `gorm:"column:tier;type:varchar(50)" json:"tier"`
+	Description   string `gorm:"column:description;type:varchar(1000)" json:"description"`
+	RepositoryURL string `gorm:"column:repository_url;type:varchar(500)" json:"repositoryUrl"`
+	RunbookURL    string `gorm:"column:runbook_url;type:varchar(500)" json:"runbookUrl"`
+	OnCall        string `gorm:"column:on_call;type:varchar(255)" json:"onCall"`
+
+	Tags integration.JSONField[[]string] `gorm:"column:tags;type:json" json:"tags"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (ServiceCatalog) TableName() string {
+	return "service_catalog"
+}
+
+// serviceCatalogQuery selects the catalogs with the name of the owner team.
+func (repo *daoRepo) serviceCatalogQuery(ctx core.Context) *gorm.DB {
+	return repo.GetContextDB(ctx).Model(&ServiceCatalog{}).
+		Select("service_catalog.*, team.team_name").
+		Joins("LEFT JOIN team ON team.team_id = service_catalog.team_id")
+}
+
+// SaveServiceCatalogs creates the catalogs or replaces the existing ones of the same services.
+func (repo *daoRepo) SaveServiceCatalogs(ctx core.Context, catalogs []ServiceCatalog) error {
+	if len(catalogs) == 0 {
+		return nil
+	}
+	return repo.GetContextDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "service"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"team_id", "tier", "description", "repository_url", "runbook_url", "on_call", "tags", "updated_at",
+		}),
+	}).Omit("team_name").CreateInBatches(catalogs, 100).Error
+}
+
+func (repo *daoRepo) DeleteServiceCatalog(ctx core.Context, service string) error {
+	return repo.GetContextDB(ctx).Where("service = ?", service).Delete(&ServiceCatalog{}).Error
+}
+
+// GetServiceCatalog returns nil if the service is not in the catalog.
+func (repo *daoRepo) GetServiceCatalog(ctx core.Context, service string) (*ServiceCatalog, error) {
+	var catalog ServiceCatalog
+	err := repo.serviceCatalogQuery(ctx).Where("service_catalog.service = ?", service).First(&catalog).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// ListServiceCatalogs returns the catalogs of the services, all catalogs are returned if services is empty.
+func (repo *daoRepo) ListServiceCatalogs(ctx core.Context, services []string, teamID int64) ([]ServiceCatalog, error) {
+	var catalogs []ServiceCatalog
+	query := repo.serviceCatalogQuery(ctx)
+	if len(services) > 0 {
+		query = query.Where("service_catalog.service IN ?", services)
+	}
+	if teamID > 0 {
+		query = query.Where("service_catalog.team_id = ?", teamID)
+	}
+	err := query.Order("service_catalog.service ASC").Find(&catalogs).Error
+	return catalogs, err
+}
@@ -8,9 +8,13 @@ import (
 )
 
 type ServiceNameRule struct {
-	ID          int    `gorm:"column:id;primary_key;auto_increment" json:"id"`
-	Service     string `gorm:"column:service_name;type:varchar(100)" json:"serviceName"`
-	ClusterId   string `gorm:"column:cluster_id;type:varchar(100)" json:"clusterId"`
+	ID int `gorm:"column:id;primary_key;auto_increment" json:"id"`
+	// Service is the name or the template of the name, e.g. ${app}-svc,
+	// which is expanded with the capture groups of the regex conditions
+	Service   string `gorm:"column:service_name;type:varchar(100)" json:"serviceName"`
+	ClusterId string `gorm:"column:cluster_id;type:varchar(100)" json:"clusterId"`
+	// Priority orders the rules of a cluster, the rule with higher priority is applied first
+	Priority int `gorm:"column:priority;default:0" json:"priority"`
 }
 
 func (ServiceNameRule) TableName() string {
@@ -25,11 +29,18 @@ func (repo *daoRepo) ListAllServiceNameRule(ctx core.Context) ([]ServiceNameRule
 	var nameRules []ServiceNameRule
 	err := repo.GetContextDB(ctx).
 		Model(&ServiceNameRule{}).
-		Order("cluster_id, service_name").
+		Order("cluster_id, priority DESC, id").
 		Scan(&nameRules).Error
 	return nameRules, err
 }
 
+func (repo *daoRepo) UpdateServiceNameRule(ctx core.Context, rule *ServiceNameRule) error {
+	return repo.GetContextDB(ctx).
+		Model(&ServiceNameRule{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]any{"service_name": rule.Service, "priority": rule.Priority}).Error
+}
+
 func (repo *daoRepo) ServiceNameRuleExists(ctx core.Context, ruleId int) (bool, error) {
 	var count int64
 
@@ -4,6 +4,7 @@
 package database
 
 import (
+	"regexp"
 	"slices"
 	"strings"
 
@@ -34,6 +35,9 @@ func (condition *ServiceNameRuleCondition) Match(labels map[string]string) bool
 			return strings.Contains(labelValue, condition.Value)
 		case "has":
 			return slices.Contains(strings.Split(labelValue, ","), condition.Value)
+		case "regex":
+			matched, err := regexp.MatchString(condition.Value, labelValue)
+			return err == nil && matched
 		default:
 			return false
 	}
@@ -54,7 +58,7 @@ func (repo *daoRepo) ListAllServiceNameRuleCondition(ctx core.Context) ([]Servic
 	var conditions []ServiceNameRuleCondition
 	err := repo.GetContextDB(ctx).
 		Model(&ServiceNameRuleCondition{}).
-		Order("rule_id ASC").
+		Order("rule_id ASC, id ASC").
 		Scan(&conditions).Error
 	return conditions, err
 }
@@ -0,0 +1,85 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"gorm.io/gorm"
+)
+
+const (
+	SLOTypeAvailability = "availability"
+	SLOTypeLatency      = "latency"
+)
+
+// SLO defines the service level objective of a service endpoint.
+type SLO struct {
+	ID          int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name        string `gorm:"column:name;type:varchar(100);uniqueIndex" json:"name"`
+	ServiceName string `gorm:"column:service_name;type:varchar(255)" json:"serviceName"`
+	Endpoint    string `gorm:"column:endpoint;type:varchar(255)" json:"endpoint"`
+	// availability / latency
+	Type string `gorm:"column:type;type:varchar(20)" json:"type"`
+	// Target percentage of good requests, e.g. 99.9
+	Target float64 `gorm:"column:target" json:"target"`
+	// LatencyThreshold for latency SLO, requests slower than it are bad requests. Unit: ms
+	LatencyThreshold float64 `gorm:"column:latency_threshold" json:"latencyThreshold"`
+	// WindowDays of the compliance period, one of 7 / 28 / 30
+	WindowDays   int  `gorm:"column:window_days" json:"windowDays"`
+	AlertEnabled bool `gorm:"column:alert_enabled" json:"alertEnabled"`
+
+	UpdatedAt int64 `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (SLO) TableName() string {
+	return "slo"
+}
+
+func (repo *daoRepo) CreateSLO(ctx core.Context, slo *SLO) error {
+	var count int64
+	err := repo.GetContextDB(ctx).Model(&SLO{}).Where("name = ?", slo.Name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("slo already exists")
+	}
+	return repo.GetContextDB(ctx).Create(slo).Error
+}
+
+func (repo *daoRepo) UpdateSLO(ctx core.Context, slo *SLO) error {
+	return repo.GetContextDB(ctx).Select("*").Omit("id").Where("id = ?", slo.ID).Updates(slo).Error
+}
+
+func (repo *daoRepo) DeleteSLO(ctx core.Context, id int64) error {
+	return repo.GetContextDB(ctx).Where("id = ?", id).Delete(&SLO{}).Error
+}
+
+// GetSLO returns nil if the slo is not found.
+func (repo *daoRepo) GetSLO(ctx core.Context, id int64) (*SLO, error) {
+	var slo SLO
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&slo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &slo, nil
+}
+
+func (repo *daoRepo) ListSLO(ctx core.Context, serviceName string, endpoint string) ([]SLO, error) {
+	var slos []SLO
+	query := repo.GetContextDB(ctx).Model(&SLO{})
+	if len(serviceName) > 0 {
+		query = query.Where("service_name = ?", serviceName)
+	}
+	if len(endpoint) > 0 {
+		query = query.Where("endpoint = ?", endpoint)
+	}
+	err := query.Order("id ASC").Find(&slos).Error
+	return slos, err
+}
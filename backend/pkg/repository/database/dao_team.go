@@ -310,3 +310,9 @@ func (repo *daoRepo) getUsersIDNameByTeamIDs(ctx core.Context, teamIDs ...int64)
 
 	return userMap, nil
 }
+
+func (repo *daoRepo) GetTeamsByName(ctx core.Context, teamNames []string) ([]profile.Team, error) {
+	var teams []profile.Team
+	err := repo.GetContextDB(ctx).Where("team_name IN ?", teamNames).Find(&teams).Error
+	return teams, err
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"gorm.io/gorm"
+)
+
+// TopologySnapshot persists the service call graph of a time window, used to compare the topology over time.
+type TopologySnapshot struct {
+	ID        int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	ClusterID string `gorm:"column:cluster_id;type:varchar(100);index:idx_cluster_time" json:"clusterId"`
+	// the window of the topology in microseconds, the snapshot is taken at EndTime
+	StartTime int64 `gorm:"column:start_time" json:"startTime"`
+	EndTime   int64 `gorm:"column:end_time;index:idx_cluster_time" json:"endTime"`
+
+	Nodes integration.JSONField[[]TopologySnapshotNode] `gorm:"column:nodes;type:json" json:"nodes"`
+	Edges integration.JSONField[[]TopologySnapshotEdge] `gorm:"column:edges;type:json" json:"edges"`
+
+	CreatedAt int64 `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+func (TopologySnapshot) TableName() string {
+	return "topology_snapshot"
+}
+
+type TopologySnapshotNode struct {
+	Service  string `json:"service"`
+	Category string `json:"category"`
+	IsCustom bool   `json:"isCustom"`
+}
+
+type TopologySnapshotEdge struct {
+	Parent   string `json:"parent"`
+	Child    string `json:"child"`
+	IsCustom bool   `json:"isCustom"`
+	// Calls is the number of the traced calls in the window
+	Calls uint64 `json:"calls"`
+	// AvgLatency is the average latency of the calls from the parent to the child in microseconds, 0 if unknown
+	AvgLatency float64 `json:"avgLatency"`
+}
+
+func (repo *daoRepo) CreateTopologySnapshot(ctx core.Context, snapshot *TopologySnapshot) error {
+	return repo.GetContextDB(ctx).Create(snapshot).Error
+}
+
+// GetTopologySnapshot returns nil if not found.
+func (repo *daoRepo) GetTopologySnapshot(ctx core.Context, id int64) (*TopologySnapshot, error) {
+	var snapshot TopologySnapshot
+	err := repo.GetContextDB(ctx).Where("id = ?", id).First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &snapshot, err
+}
+
+// GetTopologySnapshotAt returns the latest snapshot of the cluster taken before or at the time, nil if not found.
+func (repo *daoRepo) GetTopologySnapshotAt(ctx core.Context, clusterID string, at int64) (*TopologySnapshot, error) {
+	var snapshot TopologySnapshot
+	err := repo.GetContextDB(ctx).
+		Where("cluster_id = ? AND end_time <= ?", clusterID, at).
+		Order("end_time DESC").
+		First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &snapshot, err
+}
+
+func (repo *daoRepo) ListTopologySnapshots(ctx core.Context, clusterID string, startTime int64, endTime int64) ([]TopologySnapshot, error) {
+	var snapshots []TopologySnapshot
+	err := repo.GetContextDB(ctx).
+		Where("cluster_id = ? AND end_time >= ? AND end_time <= ?", clusterID, startTime, endTime).
+		Order("end_time ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+// DeleteTopologySnapshotBefore removes the snapshots taken before the timestamp (microseconds).
+func (repo *daoRepo) DeleteTopologySnapshotBefore(ctx core.Context, timestamp int64) (int64, error) {
+	result := repo.GetContextDB(ctx).Where("end_time < ?", timestamp).Delete(&TopologySnapshot{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package database
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"gorm.io/gorm"
+)
+
+// VectorConfigVersion is a version of the aggregator config of Vector pushed to the ConfigMap.
+type VectorConfigVersion struct {
+	Version int64  `gorm:"column:version;primaryKey;autoIncrement" json:"version"`
+	Content string `gorm:"column:content;type:text" json:"content,omitempty"`
+	Comment string `gorm:"column:comment;type:varchar(500)" json:"comment"`
+	// UserID who pushed the version, 0 for the versions pushed by the backend, e.g. rollback
+	UserID    int64 `gorm:"column:user_id" json:"userId"`
+	CreatedAt int64 `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+func (VectorConfigVersion) TableName() string {
+	return "vector_config_version"
+}
+
+func (repo *daoRepo) CreateVectorConfigVersion(ctx core.Context, version *VectorConfigVersion) error {
+	return repo.GetContextDB(ctx).Create(version).Error
+}
+
+// ListVectorConfigVersions returns the versions without content, the latest first.
+func (repo *daoRepo) ListVectorConfigVersions(ctx core.Context) ([]VectorConfigVersion, error) {
+	var versions []VectorConfigVersion
+	err := repo.GetContextDB(ctx).Omit("content").Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+// GetVectorConfigVersion returns nil if the version is not found.
+func (repo *daoRepo) GetVectorConfigVersion(ctx core.Context, version int64) (*VectorConfigVersion, error) {
+	var result VectorConfigVersion
+	err := repo.GetContextDB(ctx).Where("version = ?", version).First(&result).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -5,6 +5,7 @@ package database
 
 import (
 	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/profile"
 	"gorm.io/gorm"
 )
@@ -12,7 +13,7 @@ import (
 var validFeatures = []profile.Feature{
 	{FeatureName: "服务概览"},
 	{FeatureName: "工作流"},
-	{FeatureName: "日志检索"}, {FeatureName: "故障现场日志"}, {FeatureName: "全量日志"},
+	{FeatureName: "日志检索"}, {FeatureName: "故障现场日志"}, {FeatureName: "全量日志"}, {FeatureName: model.FEATURE_LOG_RAW_SQL},
 	{FeatureName: "链路追踪"}, {FeatureName: "故障现场链路"}, {FeatureName: "全量链路"},
 	{FeatureName: "全局资源大盘"},
 	{FeatureName: "应用基础设施大盘"},
@@ -22,7 +23,7 @@ var validFeatures = []profile.Feature{
 	{FeatureName: "接入中心"}, {FeatureName: "数据接入"}, {FeatureName: "告警接入"},
 	{FeatureName: "配置中心"},
 	{FeatureName: "系统管理"}, {FeatureName: "用户管理"}, {FeatureName: "菜单管理"},
-	{FeatureName: "团队管理"}, {FeatureName: "角色管理"},
+	{FeatureName: "团队管理"}, {FeatureName: "角色管理"}, {FeatureName: model.FEATURE_AUDIT_LOG},
 }
 
 func (repo *daoRepo) initFeature(ctx core.Context) error {
@@ -63,9 +64,9 @@ func (repo *daoRepo) initFeature(ctx core.Context) error {
 
 		// Add parent_id relationships
 		parentChildMapping := map[string][]string{
-			"日志检索": {"故障现场日志", "全量日志"},
+			"日志检索": {"故障现场日志", "全量日志", model.FEATURE_LOG_RAW_SQL},
 			"链路追踪": {"故障现场链路", "全量链路"},
-			"系统管理": {"用户管理", "菜单管理", "团队管理", "角色管理"},
+			"系统管理": {"用户管理", "菜单管理", "团队管理", "角色管理", model.FEATURE_AUDIT_LOG},
 			"告警管理": {"告警规则", "告警通知", "告警事件", "告警事件详情"},
 			"接入中心": {"数据接入", "告警接入"},
 		}
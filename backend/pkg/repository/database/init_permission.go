@@ -23,10 +23,10 @@ func isValidRoleName(roleName string) bool {
 func (repo *daoRepo) initPermissions(ctx core.Context) error {
 	roleFeatures := map[string][]string{
 		model.ROLE_ADMIN: {
-			"服务概览", "工作流", "日志检索", "故障现场日志", "全量日志", "链路追踪",
+			"服务概览", "工作流", "日志检索", "故障现场日志", "全量日志", model.FEATURE_LOG_RAW_SQL, "链路追踪",
 			"故障现场链路", "全量链路", "全局资源大盘", "应用基础设施大盘",
 			"应用指标大盘", "中间件大盘", "告警规则", "告警通知", "配置中心", "数据接入", "告警接入", "告警事件", "告警事件详情",
-			"系统管理", "用户管理", "菜单管理", "团队管理", "角色管理",
+			"系统管理", "用户管理", "菜单管理", "团队管理", "角色管理", model.FEATURE_AUDIT_LOG,
 		},
 		// model.ROLE_MANAGER: {
 		// 	"服务概览", "工作流", "日志检索", "故障现场日志", "全量日志", "链路追踪",
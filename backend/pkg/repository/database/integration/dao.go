@@ -25,6 +25,7 @@ type ObservabilityInputManage interface {
 	UpdateCluster(ctx core.Context, cluster *integration.Cluster) error
 	DeleteCluster(ctx core.Context, cluster *integration.Cluster) error
 	ListCluster(ctx core.Context) ([]integration.Cluster, error)
+	ListClusterWithCredential(ctx core.Context) ([]integration.Cluster, error)
 	GetCluster(ctx core.Context, clusterID string) (integration.Cluster, error)
 	CheckClusterNameExisted(ctx core.Context, clusterName string) (bool, error)
 
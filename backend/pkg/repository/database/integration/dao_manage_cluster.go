@@ -34,7 +34,22 @@ func (repo *subRepos) DeleteCluster(ctx core.Context, cluster *integration.Clust
 	return repo.GetContextDB(ctx).Delete(&integration.Cluster{}, "id = ?", cluster.ID).Error
 }
 
+// ListCluster returns the clusters with the secrets of the kubernetes credentials hidden.
 func (repo *subRepos) ListCluster(ctx core.Context) ([]integration.Cluster, error) {
+	clusters, err := repo.ListClusterWithCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters {
+		if clusters[i].K8sCredential != nil {
+			clusters[i].K8sCredential.ReplaceSecret()
+		}
+	}
+	return clusters, nil
+}
+
+// ListClusterWithCredential returns the clusters with the kubernetes credentials, never return it to users.
+func (repo *subRepos) ListClusterWithCredential(ctx core.Context) ([]integration.Cluster, error) {
 	var clusters []integration.Cluster
 	err := repo.GetContextDB(ctx).Find(&clusters).Error
 	return clusters, err
@@ -0,0 +1,68 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database/driver"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// TestListClusterHidesCredential covers both /api/alertinput/cluster/list and /api/integration/cluster/list,
+// which return the clusters of ListCluster.
+func TestListClusterHidesCredential(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&integration.Cluster{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := &subRepos{DB: &driver.DB{DB: db}}
+
+	clusters := []integration.Cluster{
+		{ID: "c1", Name: "sa", K8sCredential: &integration.JSONField[integration.K8sCredential]{Obj: integration.K8sCredential{
+			AuthType: integration.K8sAuthServiceAccount, Server: "https://10.0.0.1:6443", Token: "sa-token-value",
+		}}},
+		{ID: "c2", Name: "kubeconfig", K8sCredential: &integration.JSONField[integration.K8sCredential]{Obj: integration.K8sCredential{
+			AuthType: integration.K8sAuthKubeConfig, KubeConfig: "kubeconfig-content",
+		}}},
+		{ID: "c3", Name: "vm"},
+	}
+	for i := range clusters {
+		if err := repo.CreateCluster(core.EmptyCtx(), &clusters[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listed, err := repo.ListCluster(core.EmptyCtx())
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(listed)
+	for _, secret := range []string{"sa-token-value", "kubeconfig-content"} {
+		if strings.Contains(string(body), secret) {
+			t.Errorf("listed clusters leak the credential: %s", body)
+		}
+	}
+	for _, cluster := range listed {
+		if cluster.ID != "c3" && len(cluster.K8sCredential.Obj.AuthType) == 0 {
+			t.Errorf("expected the auth type of %s kept", cluster.ID)
+		}
+	}
+
+	withCredential, err := repo.ListClusterWithCredential(core.EmptyCtx())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withCredential[0].K8sCredential.Obj.Token != "sa-token-value" {
+		t.Error("expected the credential loaded for the kubernetes clients")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/amconfig"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"go.uber.org/zap"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultClusterID refers to the cluster set in the config file, where APO itself is deployed.
+const DefaultClusterID = ""
+
+const (
+	// the status of a cluster is checked again after the interval
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+)
+
+// clusterClient accesses the kubernetes API of a cluster.
+type clusterClient struct {
+	restConfig *rest.Config
+	cli        client.Client
+	discovery  discovery.ServerVersionInterface
+	stopWatch  context.CancelFunc
+
+	// the alert rules and alertmanager config are synced before first used
+	syncOnce sync.Once
+	Metadata
+
+	statusLock sync.Mutex
+	status     integration.K8sClusterStatus
+}
+
+func newClusterClient(restConfig *rest.Config) (*clusterClient, error) {
+	cli, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	checkConfig := rest.CopyConfig(restConfig)
+	checkConfig.Timeout = healthCheckTimeout
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(checkConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterClient{
+		restConfig: restConfig,
+		cli:        cli,
+		discovery:  discoveryClient,
+		Metadata: Metadata{
+			AlertRulesMap: map[string]*AlertRules{},
+			AMConfigMap:   map[string]*amconfig.Config{},
+		},
+	}, nil
+}
+
+// checkHealth returns the status of the API server, which is cached for healthCheckInterval.
+func (c *clusterClient) checkHealth(now time.Time) integration.K8sClusterStatus {
+	c.statusLock.Lock()
+	defer c.statusLock.Unlock()
+	if c.status.CheckedAt > 0 && now.Sub(time.Unix(c.status.CheckedAt, 0)) < healthCheckInterval {
+		return c.status
+	}
+
+	c.status = integration.K8sClusterStatus{CheckedAt: now.Unix()}
+	version, err := c.discovery.ServerVersion()
+	if err != nil {
+		c.status.Message = err.Error()
+	} else {
+		c.status.Healthy = true
+		c.status.Version = version.GitVersion
+	}
+	return c.status
+}
+
+// restConfigFromCredential creates the API config from the credential stored with the cluster.
+func restConfigFromCredential(cred *integration.K8sCredential) (*rest.Config, error) {
+	if cred == nil {
+		return nil, fmt.Errorf("kubernetes credential is empty")
+	}
+
+	switch cred.AuthType {
+	case integration.K8sAuthKubeConfig:
+		if len(cred.KubeConfig) == 0 {
+			return nil, fmt.Errorf("kubeconfig is empty")
+		}
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig([]byte(cred.KubeConfig))
+		if err != nil {
+			return nil, fmt.Errorf("illegal kubeconfig: %w", err)
+		}
+		return restConfig, nil
+	case integration.K8sAuthServiceAccount:
+		if len(cred.Server) == 0 || len(cred.Token) == 0 {
+			return nil, fmt.Errorf("server and token are required by %s", integration.K8sAuthServiceAccount)
+		}
+		restConfig := &rest.Config{
+			Host:        cred.Server,
+			BearerToken: cred.Token,
+		}
+		if cred.Insecure {
+			restConfig.Insecure = true
+		} else if len(cred.CAData) > 0 {
+			restConfig.CAData = caData(cred.CAData)
+		}
+		return restConfig, nil
+	default:
+		return nil, fmt.Errorf("unsupported auth type %q", cred.AuthType)
+	}
+}
+
+// caData accepts both the PEM certificate and the base64 encoded one copied from kubeconfig.
+func caData(data string) []byte {
+	data = strings.TrimSpace(data)
+	if strings.HasPrefix(data, "-----BEGIN") {
+		return []byte(data)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(data); err == nil {
+		return decoded
+	}
+	return []byte(data)
+}
+
+// RegisterCluster creates the client of the cluster with its credential, the previous client is replaced.
+func (k *k8sApi) RegisterCluster(clusterID string, cred *integration.K8sCredential) error {
+	if clusterID == DefaultClusterID {
+		return fmt.Errorf("can not replace the default cluster")
+	}
+	restConfig, err := restConfigFromCredential(cred)
+	if err != nil {
+		return err
+	}
+	c, err := newClusterClient(restConfig)
+	if err != nil {
+		return err
+	}
+
+	k.clustersLock.Lock()
+	defer k.clustersLock.Unlock()
+	if old, find := k.clusters[clusterID]; find {
+		old.stopWatching()
+	}
+	k.clusters[clusterID] = c
+	k.startWatching(clusterID, c)
+	k.logger.Info("kubernetes cluster registered", zap.String("clusterId", clusterID), zap.String("host", restConfig.Host))
+	return nil
+}
+
+func (k *k8sApi) RemoveCluster(clusterID string) {
+	if clusterID == DefaultClusterID {
+		return
+	}
+	k.clustersLock.Lock()
+	defer k.clustersLock.Unlock()
+	if c, find := k.clusters[clusterID]; find {
+		c.stopWatching()
+	}
+	delete(k.clusters, clusterID)
+}
+
+// GetClusterStatus checks whether the API server of the cluster is accessible.
+func (k *k8sApi) GetClusterStatus(clusterID string) (*integration.K8sClusterStatus, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	status := c.checkHealth(time.Now())
+	return &status, nil
+}
+
+func (k *k8sApi) HasCluster(clusterID string) bool {
+	k.clustersLock.RLock()
+	defer k.clustersLock.RUnlock()
+	_, find := k.clusters[clusterID]
+	return find
+}
+
+func (k *k8sApi) cluster(clusterID string) (*clusterClient, error) {
+	k.clustersLock.RLock()
+	defer k.clustersLock.RUnlock()
+	c, find := k.clusters[clusterID]
+	if find {
+		return c, nil
+	}
+	if clusterID == DefaultClusterID {
+		return nil, ErrKubernetesRepoNotReady
+	}
+	return nil, fmt.Errorf("%w: %s", ErrClusterNotRegistered, clusterID)
+}
+
+// metadataCluster returns the cluster whose alert rules and alertmanager config are synced.
+func (k *k8sApi) metadataCluster(clusterID string) (*clusterClient, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	c.syncOnce.Do(func() {
+		k.syncCluster(clusterID, c)
+	})
+	return c, nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const testAlertRules = `groups:
+- name: app
+  rules:
+  - alert: ServiceDown
+    expr: up == 0
+    for: 1m
+`
+
+// fakeAPIServer serves the namespaces and the configMaps in the apo namespace.
+type fakeAPIServer struct {
+	*httptest.Server
+
+	lock       sync.Mutex
+	token      string
+	configMaps map[string]*v1.ConfigMap
+}
+
+func newFakeAPIServer(t *testing.T, token string) *fakeAPIServer {
+	s := &fakeAPIServer{
+		token: token,
+		configMaps: map[string]*v1.ConfigMap{
+			DefaultCMNAME: {
+				TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: DefaultCMNAME, Namespace: DefaultAPONS, ResourceVersion: "1"},
+				Data:       map[string]string{DefaultAlertRuleFile: testAlertRules},
+			},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAPIServer) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	configMapPrefix := "/api/v1/namespaces/" + DefaultAPONS + "/configmaps/"
+	switch {
+	case r.URL.Path == "/version":
+		writeJSON(w, map[string]string{"gitVersion": "v1.29.3"})
+	case r.URL.Path == "/api":
+		writeJSON(w, metav1.APIVersions{TypeMeta: metav1.TypeMeta{Kind: "APIVersions"}, Versions: []string{"v1"}})
+	case r.URL.Path == "/apis":
+		writeJSON(w, metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}})
+	case r.URL.Path == "/api/v1":
+		writeJSON(w, metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList"},
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "pods", Namespaced: true, Kind: "Pod", Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: metav1.Verbs{"get", "list", "create", "update"}},
+			},
+		})
+	case r.URL.Path == "/api/v1/namespaces":
+		writeJSON(w, v1.NamespaceList{
+			TypeMeta: metav1.TypeMeta{Kind: "NamespaceList", APIVersion: "v1"},
+			Items: []v1.Namespace{
+				{TypeMeta: metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: DefaultAPONS}},
+			},
+		})
+	case strings.HasPrefix(r.URL.Path, configMapPrefix):
+		name := strings.TrimPrefix(r.URL.Path, configMapPrefix)
+		if r.Method == http.MethodPut {
+			// the built-in types are sent in protobuf
+			body, _ := io.ReadAll(r.Body)
+			obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+			cm, ok := obj.(*v1.ConfigMap)
+			if err != nil || !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.configMaps[name] = cm
+		}
+		cm, find := s.configMaps[name]
+		if !find {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(w, metav1.Status{TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}, Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound})
+			return
+		}
+		writeJSON(w, cm)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeAPIServer) configMapData(name string, key string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.configMaps[name].Data[key]
+}
+
+func writeJSON(w http.ResponseWriter, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(obj)
+}
+
+func newTestClusterRepo(t *testing.T) Repo {
+	// the default cluster is not available outside kubernetes
+	repo, err := New(zap.NewNop(), AuthTypeServiceAccount, "", config.MetadataSettings{})
+	if err != nil {
+		t.Fatalf("failed to create repo: %v", err)
+	}
+	return repo
+}
+
+func TestMultiClusterAccess(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	repo := newTestClusterRepo(t)
+	if _, err := repo.GetNamespaceList(DefaultClusterID); !errors.Is(err, ErrKubernetesRepoNotReady) {
+		t.Errorf("default cluster: err = %v, want %v", err, ErrKubernetesRepoNotReady)
+	}
+	if _, err := repo.GetNamespaceList("c1"); !errors.Is(err, ErrClusterNotRegistered) {
+		t.Errorf("unregistered cluster: err = %v, want %v", err, ErrClusterNotRegistered)
+	}
+
+	server := newFakeAPIServer(t, "token-c1")
+	err := repo.RegisterCluster("c1", &integration.K8sCredential{
+		AuthType: integration.K8sAuthServiceAccount,
+		Server:   server.URL,
+		Token:    "token-c1",
+	})
+	if err != nil {
+		t.Fatalf("failed to register cluster: %v", err)
+	}
+
+	namespaces, err := repo.GetNamespaceList("c1")
+	if err != nil {
+		t.Fatalf("failed to list namespaces: %v", err)
+	}
+	if len(namespaces.Items) != 1 || namespaces.Items[0].Name != DefaultAPONS {
+		t.Errorf("namespaces = %+v", namespaces.Items)
+	}
+
+	status, err := repo.GetClusterStatus("c1")
+	if err != nil || !status.Healthy || status.Version != "v1.29.3" {
+		t.Errorf("status = %+v, err = %v", status, err)
+	}
+
+	rules, total := repo.GetAlertRules("c1", "", nil, nil, false)
+	if total != 1 || rules[0].Alert != "ServiceDown" {
+		t.Errorf("alert rules = %+v", rules)
+	}
+	err = repo.AddAlertRule("c1", "", request.AlertRule{Group: "app", Alert: "HighErrorRate", Expr: "rate(errors_total[1m]) > 1", For: "5m"})
+	if err != nil {
+		t.Fatalf("failed to add alert rule: %v", err)
+	}
+	if content := server.configMapData(DefaultCMNAME, DefaultAlertRuleFile); !strings.Contains(content, "HighErrorRate") {
+		t.Errorf("alert rule is not written to configMap: %s", content)
+	}
+
+	repo.RemoveCluster("c1")
+	if _, err := repo.GetNamespaceList("c1"); !errors.Is(err, ErrClusterNotRegistered) {
+		t.Errorf("removed cluster: err = %v, want %v", err, ErrClusterNotRegistered)
+	}
+}
+
+func TestClusterStatusUnhealthy(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	repo := newTestClusterRepo(t)
+	server := newFakeAPIServer(t, "token")
+	err := repo.RegisterCluster("c1", &integration.K8sCredential{
+		AuthType: integration.K8sAuthServiceAccount,
+		Server:   server.URL,
+		Token:    "wrong-token",
+	})
+	if err != nil {
+		t.Fatalf("failed to register cluster: %v", err)
+	}
+	status, err := repo.GetClusterStatus("c1")
+	if err != nil || status.Healthy || len(status.Message) == 0 {
+		t.Errorf("status = %+v, err = %v", status, err)
+	}
+}
+
+func TestRestConfigFromCredential(t *testing.T) {
+	tests := []struct {
+		name string
+		cred *integration.K8sCredential
+		ok   bool
+	}{
+		{"empty", nil, false},
+		{"unknown auth type", &integration.K8sCredential{AuthType: "basic"}, false},
+		{"token without server", &integration.K8sCredential{AuthType: integration.K8sAuthServiceAccount, Token: "t"}, false},
+		{"service account", &integration.K8sCredential{AuthType: integration.K8sAuthServiceAccount, Server: "https://10.0.0.1:6443", Token: "t", Insecure: true}, true},
+		{"illegal kubeconfig", &integration.K8sCredential{AuthType: integration.K8sAuthKubeConfig, KubeConfig: "clusters: ["}, false},
+		{"kubeconfig", &integration.K8sCredential{AuthType: integration.K8sAuthKubeConfig, KubeConfig: `apiVersion: v1
+kind: Config
+clusters:
+- name: c1
+  cluster:
+    server: https://10.0.0.1:6443
+users:
+- name: admin
+  user:
+    token: t
+contexts:
+- name: c1
+  context:
+    cluster: c1
+    user: admin
+current-context: c1
+`}, true},
+	}
+	for _, tt := range tests {
+		restConfig, err := restConfigFromCredential(tt.cred)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+			continue
+		}
+		if err == nil && (restConfig.Host != "https://10.0.0.1:6443" || restConfig.BearerToken != "t") {
+			t.Errorf("%s: host = %s, token = %s", tt.name, restConfig.Host, restConfig.BearerToken)
+		}
+	}
+}
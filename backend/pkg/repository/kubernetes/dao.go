@@ -4,14 +4,19 @@
 package kubernetes
 
 import (
+	"context"
+	"sync"
+
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/CloudDetail/apo/backend/config"
 	"github.com/CloudDetail/apo/backend/pkg/model/amconfig"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 )
 
@@ -35,46 +40,58 @@ const (
 
 var _ Repo = &k8sApi{}
 
+// Repo accesses the kubernetes API of the clusters, DefaultClusterID refers to the cluster set in the config file.
 type Repo interface {
+	// Manage the clusters
+	RegisterCluster(clusterID string, cred *integration.K8sCredential) error
+	RemoveCluster(clusterID string)
+	GetClusterStatus(clusterID string) (*integration.K8sClusterStatus, error)
+	HasCluster(clusterID string) bool
+
 	// Sync with K8sAPIServer
-	SyncNow() error
-
-	GetAlertRuleConfigFile(alertRuleFile string) (map[string]string, error)
-	UpdateAlertRuleConfigFile(configFile string, content []byte) error
-	GetVectorConfigFile() (map[string]string, error)
-	UpdateVectorConfigFile(content []byte) error
-
-	GetAlertRules(configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int)
-	UpdateAlertRule(configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error
-	AddAlertRule(configFile string, alertRule request.AlertRule) error
-	DeleteAlertRule(configFile string, group, alert string) error
-	CheckAlertRule(configFile, group, alert string) (bool, error)
-
-	GetAMConfigReceiver(configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int)
-	AddAMConfigReceiver(configFile string, receiver amconfig.Receiver) error
-	UpdateAMConfigReceiver(configFile string, receiver amconfig.Receiver, oldName string) error
-	DeleteAMConfigReceiver(configFile string, name string) error
-
-	GetNamespaceList() (*v1.NamespaceList, error)
-	GetNamespaceInfo(namespace string) (*v1.Namespace, error)
-	GetPodList(namespace string) (*v1.PodList, error)
-	GetPodInfo(namespace string, pod string) (*v1.Pod, error)
+	SyncNow(clusterID string) error
+
+	GetAlertRuleConfigFile(clusterID string, alertRuleFile string) (map[string]string, error)
+	UpdateAlertRuleConfigFile(clusterID string, configFile string, content []byte) error
+	GetVectorConfigFile(clusterID string) (map[string]string, error)
+	UpdateVectorConfigFile(clusterID string, content []byte) error
+
+	GetAlertRules(clusterID string, configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int)
+	UpdateAlertRule(clusterID string, configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error
+	AddAlertRule(clusterID string, configFile string, alertRule request.AlertRule) error
+	DeleteAlertRule(clusterID string, configFile string, group, alert string) error
+	CheckAlertRule(clusterID string, configFile, group, alert string) (bool, error)
+
+	GetAMConfigReceiver(clusterID string, configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int)
+	AddAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver) error
+	UpdateAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver, oldName string) error
+	DeleteAMConfigReceiver(clusterID string, configFile string, name string) error
+
+	GetNamespaceList(clusterID string) (*v1.NamespaceList, error)
+	GetNamespaceInfo(clusterID string, namespace string) (*v1.Namespace, error)
+	GetPodList(clusterID string, namespace string) (*v1.PodList, error)
+	GetPodInfo(clusterID string, namespace string, pod string) (*v1.Pod, error)
+
+	GetDeployment(clusterID string, namespace string, name string) (*appsv1.Deployment, error)
+	GetStatefulSet(clusterID string, namespace string, name string) (*appsv1.StatefulSet, error)
+	GetDaemonSet(clusterID string, namespace string, name string) (*appsv1.DaemonSet, error)
+	GetReplicaSet(clusterID string, namespace string, name string) (*appsv1.ReplicaSet, error)
+	GetReplicaSetList(clusterID string, namespace string, matchLabels map[string]string) (*appsv1.ReplicaSetList, error)
+	GetHPAList(clusterID string, namespace string) (*autoscalingv2.HorizontalPodAutoscalerList, error)
+	GetNodeList(clusterID string) (*v1.NodeList, error)
+	GetNodeInfo(clusterID string, node string) (*v1.Node, error)
+	// The usages are read from metrics-server
+	GetPodUsage(clusterID string, namespace string, pod string) (map[string]v1.ResourceList, error)
+	GetNodeUsage(clusterID string, node string) (v1.ResourceList, error)
+	GetNodeUsageList(clusterID string) (map[string]v1.ResourceList, error)
+
+	// WatchChanges reports the changes of the workloads and configMaps until ctx is done
+	WatchChanges(ctx context.Context, handle ChangeHandler)
 }
 
+// New creates the repository with the default cluster set in the config file,
+// the other clusters are added by RegisterCluster.
 func New(logger *zap.Logger, authType, authFilePath string, setting config.MetadataSettings) (Repo, error) {
-	restConfig, err := createRestConfig(authType, authFilePath)
-	if err != nil {
-		logger.Info("failed to setup kubernetes repository, skip init", zap.Error(err))
-		return NoneRepo, nil
-	}
-
-	ctrl.SetLogger(zapr.NewLogger(logger))
-
-	cli, err := client.New(restConfig, client.Options{})
-	if err != nil {
-		return NoneRepo, err
-	}
-
 	if len(setting.Namespace) == 0 {
 		setting.Namespace = DefaultAPONS
 	}
@@ -99,24 +116,37 @@ func New(logger *zap.Logger, authType, authFilePath string, setting config.Metad
 
 	api := &k8sApi{
 		logger:           logger,
-		cli:              cli,
 		MetadataSettings: setting,
+		clusters:         map[string]*clusterClient{},
+	}
+
+	ctrl.SetLogger(zapr.NewLogger(logger))
 
-		Metadata: Metadata{
-			AlertRulesMap: map[string]*AlertRules{},
-			AMConfigMap:   map[string]*amconfig.Config{},
-		},
+	restConfig, err := createRestConfig(authType, authFilePath)
+	if err != nil {
+		logger.Info("failed to setup default kubernetes cluster, skip init", zap.Error(err))
+		return api, nil
 	}
 
-	api.SyncNow()
+	defaultCluster, err := newClusterClient(restConfig)
+	if err != nil {
+		return NoneRepo, err
+	}
+	api.clusters[DefaultClusterID] = defaultCluster
+	api.metadataCluster(DefaultClusterID)
 
 	return api, nil
 }
 
 type k8sApi struct {
 	logger *zap.Logger
-	cli    client.Client
 
 	config.MetadataSettings
-	Metadata
+
+	clustersLock sync.RWMutex
+	clusters     map[string]*clusterClient
+
+	// the watch is started for each cluster after WatchChanges is called
+	watchCtx    context.Context
+	watchHandle ChangeHandler
 }
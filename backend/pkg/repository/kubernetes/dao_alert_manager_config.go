@@ -14,8 +14,8 @@ import (
 	"go.uber.org/zap"
 )
 
-func (k *k8sApi) syncAMConfig() error {
-	res, err := k.GetAlertManagerConfigFile("")
+func (k *k8sApi) syncAMConfig(c *clusterClient) error {
+	res, err := k.getConfigMap(c, k.AlertManagerCMName, "")
 	if err != nil {
 		return err
 	}
@@ -26,26 +26,30 @@ func (k *k8sApi) syncAMConfig() error {
 			continue
 		}
 
-		k.Metadata.SetAMConfig(key, amConfig)
+		c.Metadata.SetAMConfig(key, amConfig)
 	}
 	return nil
 }
 
-func (k *k8sApi) GetAMConfigReceiver(configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int) {
+func (k *k8sApi) GetAMConfigReceiver(clusterID string, configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int) {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertManagerFileName
 	}
 
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return []amconfig.Receiver{}, 0
+	}
 	if syncNow {
-		err := k.syncAMConfig()
+		err := k.syncAMConfig(c)
 		if err != nil {
-			k.logger.Error("failed to sync amConfig with k8sAPI", zap.Error(err))
+			k.logger.Error("failed to sync amConfig with k8sAPI", zap.String("clusterId", clusterID), zap.Error(err))
 		}
 	}
-	return k.Metadata.GetAMConfigReceiver(configFile, filter, pageParam)
+	return c.Metadata.GetAMConfigReceiver(configFile, filter, pageParam)
 }
 
-func (k *k8sApi) AddAMConfigReceiver(configFile string, receiver amconfig.Receiver) error {
+func (k *k8sApi) AddAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertManagerFileName
 	}
@@ -55,19 +59,23 @@ func (k *k8sApi) AddAMConfigReceiver(configFile string, receiver amconfig.Receiv
 		return err
 	}
 
-	err = k.Metadata.AddAMConfigReceiver(configFile, receiver)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	err = c.Metadata.AddAMConfigReceiver(configFile, receiver)
 	if err != nil {
 		return err
 	}
 
-	content, err := k.Metadata.AlertManagerConfigMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertManagerConfigMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
-	return k.UpdateAlertManagerConfigFile(configFile, content)
+	return k.updateConfigMap(c, k.AlertManagerCMName, configFile, content)
 }
 
-func (k *k8sApi) UpdateAMConfigReceiver(configFile string, receiver amconfig.Receiver, oldName string) error {
+func (k *k8sApi) UpdateAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver, oldName string) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertManagerFileName
 	}
@@ -77,43 +85,43 @@ func (k *k8sApi) UpdateAMConfigReceiver(configFile string, receiver amconfig.Rec
 		return err
 	}
 
-	err = k.Metadata.UpdateAMConfigReceiver(configFile, receiver, oldName)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	err = c.Metadata.UpdateAMConfigReceiver(configFile, receiver, oldName)
 	if err != nil {
 		return err
 	}
 
-	content, err := k.Metadata.AlertManagerConfigMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertManagerConfigMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
 
-	return k.UpdateAlertManagerConfigFile(configFile, content)
+	return k.updateConfigMap(c, k.AlertManagerCMName, configFile, content)
 }
 
-func (k *k8sApi) DeleteAMConfigReceiver(configFile string, name string) error {
+func (k *k8sApi) DeleteAMConfigReceiver(clusterID string, configFile string, name string) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertManagerFileName
 	}
 
-	isDeleted, err := k.Metadata.DeleteAMConfigReceiver(configFile, name)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	isDeleted, err := c.Metadata.DeleteAMConfigReceiver(configFile, name)
 	if !isDeleted {
 		return err
 	}
 
-	content, err := k.Metadata.AlertManagerConfigMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertManagerConfigMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
 
-	return k.UpdateAlertManagerConfigFile(configFile, content)
-}
-
-func (k *k8sApi) GetAlertManagerConfigFile(alertManagerConfig string) (map[string]string, error) {
-	return k.getConfigMap(k.AlertManagerCMName, alertManagerConfig)
-}
-
-func (k *k8sApi) UpdateAlertManagerConfigFile(alertManagerConfig string, content []byte) error {
-	return k.updateConfigMap(k.AlertManagerCMName, alertManagerConfig, content)
+	return k.updateConfigMap(c, k.AlertManagerCMName, configFile, content)
 }
 
 func ValidateAMConfigReceiver(receiver amconfig.Receiver) error {
@@ -13,8 +13,8 @@ import (
 	"go.uber.org/zap"
 )
 
-func (k *k8sApi) syncAlertRule() error {
-	res, err := k.GetAlertRuleConfigFile("")
+func (k *k8sApi) syncAlertRule(c *clusterClient) error {
+	res, err := k.getConfigMap(c, k.AlertRuleCMName, "")
 	if err != nil {
 		return err
 	}
@@ -23,26 +23,30 @@ func (k *k8sApi) syncAlertRule() error {
 		if err != nil {
 			continue
 		}
-		k.Metadata.SetAlertRules(key, alertRules)
+		c.Metadata.SetAlertRules(key, alertRules)
 	}
 	return nil
 }
 
-func (k *k8sApi) GetAlertRules(configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int) {
+func (k *k8sApi) GetAlertRules(clusterID string, configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int) {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertRuleFileName
 	}
 
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return []*request.AlertRule{}, 0
+	}
 	if syncNow {
-		err := k.syncAlertRule()
+		err := k.syncAlertRule(c)
 		if err != nil {
-			k.logger.Error("failed to sync alertRule with k8sAPI", zap.Error(err))
+			k.logger.Error("failed to sync alertRule with k8sAPI", zap.String("clusterId", clusterID), zap.Error(err))
 		}
 	}
-	return k.Metadata.GetAlertRules(configFile, filter, pageParam)
+	return c.Metadata.GetAlertRules(configFile, filter, pageParam)
 }
 
-func (k *k8sApi) AddAlertRule(configFile string, alertRules request.AlertRule) error {
+func (k *k8sApi) AddAlertRule(clusterID string, configFile string, alertRules request.AlertRule) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertRuleFileName
 	}
@@ -51,27 +55,35 @@ func (k *k8sApi) AddAlertRule(configFile string, alertRules request.AlertRule) e
 		return err
 	}
 
-	if err := k.Metadata.AddAlertRule(configFile, alertRules); err != nil {
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	if err := c.Metadata.AddAlertRule(configFile, alertRules); err != nil {
 		return err
 	}
 
-	content, err := k.Metadata.AlertRuleMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertRuleMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
 
-	return k.UpdateAlertRuleConfigFile(configFile, content)
+	return k.updateConfigMap(c, k.AlertRuleCMName, configFile, content)
 }
 
-func (k *k8sApi) CheckAlertRule(configFile, group, alert string) (bool, error) {
+func (k *k8sApi) CheckAlertRule(clusterID string, configFile, group, alert string) (bool, error) {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertRuleFileName
 	}
 
-	return k.Metadata.CheckAlertRuleExists(configFile, group, alert)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return false, err
+	}
+	return c.Metadata.CheckAlertRuleExists(configFile, group, alert)
 }
 
-func (k *k8sApi) UpdateAlertRule(configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error {
+func (k *k8sApi) UpdateAlertRule(clusterID string, configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertRuleFileName
 	}
@@ -81,42 +93,58 @@ func (k *k8sApi) UpdateAlertRule(configFile string, alertRule request.AlertRule,
 		return err
 	}
 
-	err = k.Metadata.UpdateAlertRule(configFile, alertRule, oldGroup, oldAlert)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	err = c.Metadata.UpdateAlertRule(configFile, alertRule, oldGroup, oldAlert)
 	if err != nil {
 		return err
 	}
 
-	content, err := k.Metadata.AlertRuleMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertRuleMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
 
-	return k.UpdateAlertRuleConfigFile(configFile, content)
+	return k.updateConfigMap(c, k.AlertRuleCMName, configFile, content)
 }
 
-func (k *k8sApi) DeleteAlertRule(configFile string, group, alert string) error {
+func (k *k8sApi) DeleteAlertRule(clusterID string, configFile string, group, alert string) error {
 	if len(configFile) == 0 {
 		configFile = k.MetadataSettings.AlertRuleFileName
 	}
-	isDeleted := k.Metadata.DeleteAlertRule(configFile, group, alert)
+	c, err := k.metadataCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	isDeleted := c.Metadata.DeleteAlertRule(configFile, group, alert)
 	if !isDeleted {
 		return nil
 	}
 
-	content, err := k.Metadata.AlertRuleMarshalToYaml(configFile)
+	content, err := c.Metadata.AlertRuleMarshalToYaml(configFile)
 	if err != nil {
 		return err
 	}
 
-	return k.UpdateAlertRuleConfigFile(configFile, content)
+	return k.updateConfigMap(c, k.AlertRuleCMName, configFile, content)
 }
 
-func (k *k8sApi) GetAlertRuleConfigFile(alertRuleFile string) (map[string]string, error) {
-	return k.getConfigMap(k.AlertRuleCMName, alertRuleFile)
+func (k *k8sApi) GetAlertRuleConfigFile(clusterID string, alertRuleFile string) (map[string]string, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return k.getConfigMap(c, k.AlertRuleCMName, alertRuleFile)
 }
 
-func (k *k8sApi) UpdateAlertRuleConfigFile(configFile string, content []byte) error {
-	return k.updateConfigMap(k.AlertRuleCMName, configFile, content)
+func (k *k8sApi) UpdateAlertRuleConfigFile(clusterID string, configFile string, content []byte) error {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return err
+	}
+	return k.updateConfigMap(c, k.AlertRuleCMName, configFile, content)
 }
 
 func ValidateAlertRule(rule request.AlertRule) error {
@@ -0,0 +1,256 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// ChangeHandler receives the changes found by the watch, it is called concurrently by the clusters.
+type ChangeHandler func(event *model.ChangeEvent)
+
+// WatchChanges reports the changes of the workloads and configMaps in all clusters
+// until ctx is done, including the clusters registered later.
+func (k *k8sApi) WatchChanges(ctx context.Context, handle ChangeHandler) {
+	k.clustersLock.Lock()
+	defer k.clustersLock.Unlock()
+	k.watchCtx, k.watchHandle = ctx, handle
+	for clusterID, c := range k.clusters {
+		k.startWatching(clusterID, c)
+	}
+}
+
+// startWatching must be called with clustersLock held.
+func (k *k8sApi) startWatching(clusterID string, c *clusterClient) {
+	if k.watchHandle == nil {
+		return
+	}
+	cs, err := clientset.NewForConfig(c.restConfig)
+	if err != nil {
+		k.logger.Error("failed to watch kubernetes changes", zap.String("clusterId", clusterID), zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(k.watchCtx)
+	c.stopWatch = cancel
+	w := &changeWatcher{clusterID: clusterID, handle: k.watchHandle}
+
+	factory := informers.NewSharedInformerFactory(cs, 0)
+	factory.Apps().V1().Deployments().Informer().AddEventHandler(w.handlerFuncs())
+	factory.Apps().V1().StatefulSets().Informer().AddEventHandler(w.handlerFuncs())
+	factory.Apps().V1().DaemonSets().Informer().AddEventHandler(w.handlerFuncs())
+	factory.Core().V1().ConfigMaps().Informer().AddEventHandler(w.handlerFuncs())
+	factory.Start(ctx.Done())
+	k.logger.Info("start watching kubernetes changes", zap.String("clusterId", clusterID))
+}
+
+func (c *clusterClient) stopWatching() {
+	if c.stopWatch != nil {
+		c.stopWatch()
+		c.stopWatch = nil
+	}
+}
+
+type changeWatcher struct {
+	clusterID string
+	handle    ChangeHandler
+}
+
+func (w *changeWatcher) handlerFuncs() cache.ResourceEventHandlerDetailedFuncs {
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			// the existing objects are listed when the watch starts
+			if isInInitialList {
+				return
+			}
+			w.report(objectCreated(obj))
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			w.report(objectChanges(oldObj, newObj))
+		},
+	}
+}
+
+func (w *changeWatcher) report(events []*model.ChangeEvent) {
+	now := time.Now().UnixMicro()
+	for _, event := range events {
+		event.Source = model.ChangeSourceKubernetes
+		event.ClusterID = w.clusterID
+		event.Timestamp = now
+		w.handle(event)
+	}
+}
+
+func objectCreated(obj any) []*model.ChangeEvent {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return []*model.ChangeEvent{createdWorkload("Deployment", o.Namespace, o.Name, o.Spec.Template)}
+	case *appsv1.StatefulSet:
+		return []*model.ChangeEvent{createdWorkload("StatefulSet", o.Namespace, o.Name, o.Spec.Template)}
+	case *appsv1.DaemonSet:
+		return []*model.ChangeEvent{createdWorkload("DaemonSet", o.Namespace, o.Name, o.Spec.Template)}
+	case *v1.ConfigMap:
+		return []*model.ChangeEvent{{
+			Type: model.ChangeTypeConfig, Namespace: o.Namespace, Kind: "ConfigMap", Name: o.Name,
+			Summary: "created",
+		}}
+	}
+	return nil
+}
+
+func createdWorkload(kind string, namespace string, name string, template v1.PodTemplateSpec) *model.ChangeEvent {
+	images := make([]string, 0, len(template.Spec.Containers))
+	for _, container := range template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return &model.ChangeEvent{
+		Type: model.ChangeTypeDeploy, Namespace: namespace, Kind: kind, Name: name,
+		Version: imageTag(firstOf(images)),
+		Summary: "created with " + strings.Join(images, ", "),
+	}
+}
+
+// objectChanges compares the spec of the workloads and the data of configMaps, the status updates are ignored.
+func objectChanges(oldObj, newObj any) []*model.ChangeEvent {
+	switch o := newObj.(type) {
+	case *appsv1.Deployment:
+		old, ok := oldObj.(*appsv1.Deployment)
+		if !ok {
+			return nil
+		}
+		return workloadChanges("Deployment", o.Namespace, o.Name, old.Spec.Replicas, o.Spec.Replicas, old.Spec.Template, o.Spec.Template)
+	case *appsv1.StatefulSet:
+		old, ok := oldObj.(*appsv1.StatefulSet)
+		if !ok {
+			return nil
+		}
+		return workloadChanges("StatefulSet", o.Namespace, o.Name, old.Spec.Replicas, o.Spec.Replicas, old.Spec.Template, o.Spec.Template)
+	case *appsv1.DaemonSet:
+		old, ok := oldObj.(*appsv1.DaemonSet)
+		if !ok {
+			return nil
+		}
+		return workloadChanges("DaemonSet", o.Namespace, o.Name, nil, nil, old.Spec.Template, o.Spec.Template)
+	case *v1.ConfigMap:
+		old, ok := oldObj.(*v1.ConfigMap)
+		if !ok {
+			return nil
+		}
+		if event := configMapChange(old, o); event != nil {
+			return []*model.ChangeEvent{event}
+		}
+	}
+	return nil
+}
+
+func workloadChanges(kind string, namespace string, name string, oldReplicas, newReplicas *int32, oldTemplate, newTemplate v1.PodTemplateSpec) []*model.ChangeEvent {
+	var events []*model.ChangeEvent
+	newEvent := func(changeType string, summary string) *model.ChangeEvent {
+		return &model.ChangeEvent{Type: changeType, Namespace: namespace, Kind: kind, Name: name, Summary: summary}
+	}
+
+	if imageChanges, version := changedImages(oldTemplate.Spec.Containers, newTemplate.Spec.Containers); len(imageChanges) > 0 {
+		event := newEvent(model.ChangeTypeDeploy, strings.Join(imageChanges, "; "))
+		event.Version = version
+		events = append(events, event)
+	} else if !equality.Semantic.DeepEqual(oldTemplate, newTemplate) {
+		summary := "pod template changed"
+		if restartedAt := newTemplate.Annotations[restartedAtAnnotation]; restartedAt != oldTemplate.Annotations[restartedAtAnnotation] {
+			summary = "restarted at " + restartedAt
+		}
+		events = append(events, newEvent(model.ChangeTypeRollout, summary))
+	}
+
+	if oldReplicas != nil && newReplicas != nil && *oldReplicas != *newReplicas {
+		events = append(events, newEvent(model.ChangeTypeScale, fmt.Sprintf("scaled from %d to %d", *oldReplicas, *newReplicas)))
+	}
+	return events
+}
+
+// changedImages returns the image changes of the containers and the tag of the first changed image.
+func changedImages(oldContainers, newContainers []v1.Container) ([]string, string) {
+	oldImages := make(map[string]string, len(oldContainers))
+	for _, container := range oldContainers {
+		oldImages[container.Name] = container.Image
+	}
+
+	var changes []string
+	var version string
+	for _, container := range newContainers {
+		oldImage, find := oldImages[container.Name]
+		if find && oldImage == container.Image {
+			continue
+		}
+		if find {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", container.Name, oldImage, container.Image))
+		} else {
+			changes = append(changes, fmt.Sprintf("%s: added %s", container.Name, container.Image))
+		}
+		if len(version) == 0 {
+			version = imageTag(container.Image)
+		}
+	}
+	return changes, version
+}
+
+func configMapChange(old, cm *v1.ConfigMap) *model.ChangeEvent {
+	var keys []string
+	for key, value := range cm.Data {
+		if oldValue, find := old.Data[key]; !find || oldValue != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range old.Data {
+		if _, find := cm.Data[key]; !find {
+			keys = append(keys, key)
+		}
+	}
+	if !equality.Semantic.DeepEqual(old.BinaryData, cm.BinaryData) {
+		keys = append(keys, "binaryData")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return &model.ChangeEvent{
+		Type: model.ChangeTypeConfig, Namespace: cm.Namespace, Kind: "ConfigMap", Name: cm.Name,
+		Summary: "changed " + strings.Join(keys, ", "),
+	}
+}
+
+// imageTag returns the tag or digest of the image, e.g. v1.2.3 of registry:5000/app:v1.2.3.
+func imageTag(image string) string {
+	if idx := strings.LastIndex(image, "@"); idx >= 0 {
+		return image[idx+1:]
+	}
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		return image[idx+1:]
+	}
+	if len(image) == 0 {
+		return ""
+	}
+	return "latest"
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
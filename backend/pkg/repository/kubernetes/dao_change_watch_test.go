@@ -0,0 +1,102 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testDeployment(replicas int32, image string, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+				Spec: v1.PodSpec{Containers: []v1.Container{
+					{Name: "cart", Image: image},
+					{Name: "proxy", Image: "envoy:1.28"},
+				}},
+			},
+		},
+	}
+}
+
+func TestObjectChanges(t *testing.T) {
+	old := testDeployment(2, "registry:5000/shop/cart:v1.2.2", nil)
+	tests := []struct {
+		name    string
+		new     any
+		types   []string
+		version string
+		summary string
+	}{
+		{"status only", func() any { d := old.DeepCopy(); d.Status.ReadyReplicas = 2; return d }(), nil, "", ""},
+		{"image", testDeployment(2, "registry:5000/shop/cart:v1.2.3", nil),
+			[]string{model.ChangeTypeDeploy}, "v1.2.3", "cart: registry:5000/shop/cart:v1.2.2 -> registry:5000/shop/cart:v1.2.3"},
+		{"scale", testDeployment(4, "registry:5000/shop/cart:v1.2.2", nil),
+			[]string{model.ChangeTypeScale}, "", "scaled from 2 to 4"},
+		{"restart", testDeployment(2, "registry:5000/shop/cart:v1.2.2", map[string]string{restartedAtAnnotation: "2025-06-01T14:02:00Z"}),
+			[]string{model.ChangeTypeRollout}, "", "restarted at 2025-06-01T14:02:00Z"},
+		{"image and scale", testDeployment(3, "registry:5000/shop/cart:v1.2.3", nil),
+			[]string{model.ChangeTypeDeploy, model.ChangeTypeScale}, "v1.2.3", ""},
+	}
+	for _, tt := range tests {
+		events := objectChanges(old, tt.new)
+		if len(events) != len(tt.types) {
+			t.Errorf("%s: events = %+v", tt.name, events)
+			continue
+		}
+		for i, event := range events {
+			if event.Type != tt.types[i] || event.Kind != "Deployment" || event.Namespace != "shop" || event.Name != "cart" {
+				t.Errorf("%s: event[%d] = %+v", tt.name, i, event)
+			}
+		}
+		if len(events) > 0 && events[0].Version != tt.version {
+			t.Errorf("%s: version = %s, want %s", tt.name, events[0].Version, tt.version)
+		}
+		if len(tt.summary) > 0 && events[0].Summary != tt.summary {
+			t.Errorf("%s: summary = %s, want %s", tt.name, events[0].Summary, tt.summary)
+		}
+	}
+}
+
+func TestConfigMapChange(t *testing.T) {
+	old := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-config"},
+		Data:       map[string]string{"app.yaml": "a: 1", "log.yaml": "level: info", "old.yaml": ""},
+	}
+	cm := old.DeepCopy()
+	cm.Annotations = map[string]string{"leader": "pod-1"}
+	if event := configMapChange(old, cm); event != nil {
+		t.Errorf("metadata change: %+v", event)
+	}
+
+	cm.Data = map[string]string{"app.yaml": "a: 2", "log.yaml": "level: info", "new.yaml": ""}
+	event := configMapChange(old, cm)
+	if event == nil || event.Type != model.ChangeTypeConfig || event.Summary != "changed app.yaml, new.yaml, old.yaml" {
+		t.Errorf("data change: %+v", event)
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                             "latest",
+		"nginx:1.25":                        "1.25",
+		"registry:5000/shop/cart":           "latest",
+		"registry:5000/shop/cart:v1.2.3":    "v1.2.3",
+		"shop/cart@sha256:0123456789abcdef": "sha256:0123456789abcdef",
+		"":                                  "",
+	}
+	for image, want := range tests {
+		if got := imageTag(image); got != want {
+			t.Errorf("imageTag(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
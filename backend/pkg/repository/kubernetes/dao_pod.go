@@ -5,29 +5,38 @@ package kubernetes
 
 import (
 	"context"
+
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (k *k8sApi) GetPodList(namespace string) (*v1.PodList, error) {
+func (k *k8sApi) GetPodList(clusterID string, namespace string) (*v1.PodList, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
 	list := &v1.PodList{}
-	err := k.cli.List(context.Background(), list, &client.ListOptions{Namespace: namespace})
+	err = c.cli.List(context.Background(), list, &client.ListOptions{Namespace: namespace})
 	if err != nil {
 		return nil, err
 	}
 	return list, nil
 }
 
-func (k *k8sApi) GetPodInfo(namespace string, pod string) (*v1.Pod, error) {
+func (k *k8sApi) GetPodInfo(clusterID string, namespace string, pod string) (*v1.Pod, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
 	podInfo := &v1.Pod{}
 	key := client.ObjectKey{
 		Name:      pod,
 		Namespace: namespace,
 	}
-	err := k.cli.Get(context.Background(), key, podInfo)
+	err = c.cli.Get(context.Background(), key, podInfo)
 	if err != nil {
-		k.logger.Error("Get pod error: ", zap.Error(err))
+		k.logger.Error("Get pod error: ", zap.String("clusterId", clusterID), zap.Error(err))
 		return nil, err
 	}
 
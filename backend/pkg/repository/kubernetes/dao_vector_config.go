@@ -3,10 +3,18 @@
 
 package kubernetes
 
-func (k *k8sApi) GetVectorConfigFile() (map[string]string, error) {
-	return k.getConfigMap(k.VectorCMName, k.VectorFileName)
+func (k *k8sApi) GetVectorConfigFile(clusterID string) (map[string]string, error) {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return k.getConfigMap(c, k.VectorCMName, k.VectorFileName)
 }
 
-func (k *k8sApi) UpdateVectorConfigFile(content []byte) error {
-	return k.updateConfigMap(k.VectorCMName, k.VectorFileName, content)
+func (k *k8sApi) UpdateVectorConfigFile(clusterID string, content []byte) error {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return err
+	}
+	return k.updateConfigMap(c, k.VectorCMName, k.VectorFileName, content)
 }
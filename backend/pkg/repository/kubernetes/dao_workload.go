@@ -0,0 +1,154 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	podMetricsGVK  = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+	nodeMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+
+	nodeMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetricsList"}
+)
+
+// workloadRequestTimeout bounds a request to the kubernetes API, e.g. when metrics-server is slow
+const workloadRequestTimeout = 10 * time.Second
+
+func (k *k8sApi) getObject(clusterID string, namespace string, name string, obj client.Object) error {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), workloadRequestTimeout)
+	defer cancel()
+	return c.cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj)
+}
+
+func (k *k8sApi) listObjects(clusterID string, list client.ObjectList, opts ...client.ListOption) error {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), workloadRequestTimeout)
+	defer cancel()
+	return c.cli.List(ctx, list, opts...)
+}
+
+func (k *k8sApi) GetDeployment(clusterID string, namespace string, name string) (*appsv1.Deployment, error) {
+	obj := &appsv1.Deployment{}
+	return obj, k.getObject(clusterID, namespace, name, obj)
+}
+
+func (k *k8sApi) GetStatefulSet(clusterID string, namespace string, name string) (*appsv1.StatefulSet, error) {
+	obj := &appsv1.StatefulSet{}
+	return obj, k.getObject(clusterID, namespace, name, obj)
+}
+
+func (k *k8sApi) GetDaemonSet(clusterID string, namespace string, name string) (*appsv1.DaemonSet, error) {
+	obj := &appsv1.DaemonSet{}
+	return obj, k.getObject(clusterID, namespace, name, obj)
+}
+
+func (k *k8sApi) GetReplicaSet(clusterID string, namespace string, name string) (*appsv1.ReplicaSet, error) {
+	obj := &appsv1.ReplicaSet{}
+	return obj, k.getObject(clusterID, namespace, name, obj)
+}
+
+// GetReplicaSetList returns the replicaSets in the namespace matching the labels, e.g. the selector of a deployment.
+func (k *k8sApi) GetReplicaSetList(clusterID string, namespace string, matchLabels map[string]string) (*appsv1.ReplicaSetList, error) {
+	list := &appsv1.ReplicaSetList{}
+	return list, k.listObjects(clusterID, list, client.InNamespace(namespace), client.MatchingLabels(matchLabels))
+}
+
+func (k *k8sApi) GetHPAList(clusterID string, namespace string) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	list := &autoscalingv2.HorizontalPodAutoscalerList{}
+	return list, k.listObjects(clusterID, list, client.InNamespace(namespace))
+}
+
+func (k *k8sApi) GetNodeList(clusterID string) (*v1.NodeList, error) {
+	list := &v1.NodeList{}
+	return list, k.listObjects(clusterID, list)
+}
+
+func (k *k8sApi) GetNodeInfo(clusterID string, node string) (*v1.Node, error) {
+	obj := &v1.Node{}
+	return obj, k.getObject(clusterID, "", node, obj)
+}
+
+// GetPodUsage returns the resource usage of the containers in the pod from metrics-server.
+func (k *k8sApi) GetPodUsage(clusterID string, namespace string, pod string) (map[string]v1.ResourceList, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(podMetricsGVK)
+	if err := k.getObject(clusterID, namespace, pod, obj); err != nil {
+		return nil, err
+	}
+
+	containers, _, err := unstructured.NestedSlice(obj.Object, "containers")
+	if err != nil {
+		return nil, err
+	}
+	usages := make(map[string]v1.ResourceList, len(containers))
+	for _, container := range containers {
+		fields, ok := container.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(fields, "name")
+		usage, _, _ := unstructured.NestedStringMap(fields, "usage")
+		usages[name] = parseResourceList(usage)
+	}
+	return usages, nil
+}
+
+// GetNodeUsage returns the resource usage of the node from metrics-server.
+func (k *k8sApi) GetNodeUsage(clusterID string, node string) (v1.ResourceList, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(nodeMetricsGVK)
+	if err := k.getObject(clusterID, "", node, obj); err != nil {
+		return nil, err
+	}
+	usage, _, err := unstructured.NestedStringMap(obj.Object, "usage")
+	if err != nil {
+		return nil, err
+	}
+	return parseResourceList(usage), nil
+}
+
+// GetNodeUsageList returns the resource usage of all nodes in the cluster from metrics-server, keyed by the node names.
+func (k *k8sApi) GetNodeUsageList(clusterID string) (map[string]v1.ResourceList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(nodeMetricsListGVK)
+	if err := k.listObjects(clusterID, list); err != nil {
+		return nil, err
+	}
+	usages := make(map[string]v1.ResourceList, len(list.Items))
+	for _, item := range list.Items {
+		usage, _, _ := unstructured.NestedStringMap(item.Object, "usage")
+		usages[item.GetName()] = parseResourceList(usage)
+	}
+	return usages, nil
+}
+
+func parseResourceList(values map[string]string) v1.ResourceList {
+	res := v1.ResourceList{}
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			continue
+		}
+		res[v1.ResourceName(name)] = quantity
+	}
+	return res
+}
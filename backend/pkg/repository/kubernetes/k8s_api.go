@@ -12,9 +12,12 @@ import (
 	"os"
 
 	"github.com/CloudDetail/apo/backend/pkg/model/amconfig"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/hashicorp/go-multierror"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
@@ -77,31 +80,39 @@ func createRestConfig(authType string, authFilePath string) (*rest.Config, error
 	return authConf, nil
 }
 
-func (k *k8sApi) SyncNow() error {
+func (k *k8sApi) SyncNow(clusterID string) error {
+	c, err := k.cluster(clusterID)
+	if err != nil {
+		return err
+	}
+	return k.syncCluster(clusterID, c)
+}
+
+func (k *k8sApi) syncCluster(clusterID string, c *clusterClient) error {
 	var combineErr error
-	err := k.syncAlertRule()
+	err := k.syncAlertRule(c)
 	if err != nil {
-		k.logger.Warn("failed to sync alertRule with k8sAPI", zap.Error(err))
+		k.logger.Warn("failed to sync alertRule with k8sAPI", zap.String("clusterId", clusterID), zap.Error(err))
 		combineErr = multierror.Append(combineErr, err)
 	}
 
-	err = k.syncAMConfig()
+	err = k.syncAMConfig(c)
 	if err != nil {
-		k.logger.Warn("failed to sync alertManagerConfig with k8sAPI", zap.Error(err))
+		k.logger.Warn("failed to sync alertManagerConfig with k8sAPI", zap.String("clusterId", clusterID), zap.Error(err))
 		combineErr = multierror.Append(combineErr, err)
 	}
 
 	return combineErr
 }
 
-func (k *k8sApi) getConfigMap(cm string, dataKey string) (map[string]string, error) {
+func (k *k8sApi) getConfigMap(c *clusterClient, cm string, dataKey string) (map[string]string, error) {
 	obj := &v1.ConfigMap{}
 	key := client.ObjectKey{
 		Namespace: k.Namespace,
 		Name:      cm,
 	}
 
-	err := k.cli.Get(context.Background(), key, obj)
+	err := c.cli.Get(context.Background(), key, obj)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +126,7 @@ func (k *k8sApi) getConfigMap(cm string, dataKey string) (map[string]string, err
 	return obj.Data, nil
 }
 
-func (k *k8sApi) updateConfigMap(cm string, dataKey string, content []byte) error {
+func (k *k8sApi) updateConfigMap(c *clusterClient, cm string, dataKey string, content []byte) error {
 	obj := &v1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cm,
@@ -123,10 +134,13 @@ func (k *k8sApi) updateConfigMap(cm string, dataKey string, content []byte) erro
 		},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(context.Background(), k.cli, obj, func() error {
+	_, err := controllerutil.CreateOrUpdate(context.Background(), c.cli, obj, func() error {
 		if content == nil {
 			delete(obj.Data, dataKey)
 		} else {
+			if obj.Data == nil {
+				obj.Data = map[string]string{}
+			}
 			obj.Data[dataKey] = string(content)
 		}
 		return nil
@@ -139,85 +153,152 @@ var NoneRepo Repo = &NoneAPI{}
 type NoneAPI struct{}
 
 // GetVectorConfigFile implements Repo.
-func (n *NoneAPI) GetVectorConfigFile() (map[string]string, error) {
+func (n *NoneAPI) GetVectorConfigFile(clusterID string) (map[string]string, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
 
 // UpdateVectorConfigFile implements Repo.
-func (n *NoneAPI) UpdateVectorConfigFile(content []byte) error {
+func (n *NoneAPI) UpdateVectorConfigFile(clusterID string, content []byte) error {
+	return ErrKubernetesRepoNotReady
+}
+
+var (
+	ErrKubernetesRepoNotReady = errors.New("kubernetes repo is not ready")
+	ErrClusterNotRegistered   = errors.New("kubernetes cluster is not registered")
+)
+
+// RegisterCluster implements Repo.
+func (n *NoneAPI) RegisterCluster(clusterID string, cred *integration.K8sCredential) error {
 	return ErrKubernetesRepoNotReady
 }
 
-var ErrKubernetesRepoNotReady = errors.New("kubernetes repo is not ready")
+// RemoveCluster implements Repo.
+func (n *NoneAPI) RemoveCluster(clusterID string) {}
+
+// HasCluster implements Repo.
+func (n *NoneAPI) HasCluster(clusterID string) bool {
+	return false
+}
+
+// GetClusterStatus implements Repo.
+func (n *NoneAPI) GetClusterStatus(clusterID string) (*integration.K8sClusterStatus, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
 
 // SyncNow implements Repo.
-func (n *NoneAPI) SyncNow() error {
+func (n *NoneAPI) SyncNow(clusterID string) error {
 	return ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) GetAlertRules(configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int) {
+func (n *NoneAPI) GetAlertRules(clusterID string, configFile string, filter *request.AlertRuleFilter, pageParam *request.PageParam, syncNow bool) ([]*request.AlertRule, int) {
 	return []*request.AlertRule{}, 0
 }
 
-func (n *NoneAPI) UpdateAlertRule(configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error {
+func (n *NoneAPI) UpdateAlertRule(clusterID string, configFile string, alertRule request.AlertRule, oldGroup, oldAlert string) error {
 	return ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) AddAlertRule(configFile string, alertRule request.AlertRule) error {
+func (n *NoneAPI) AddAlertRule(clusterID string, configFile string, alertRule request.AlertRule) error {
 	return ErrKubernetesRepoNotReady
 }
 
 // GetAlertRules implements Repo.
 
-func (n *NoneAPI) DeleteAlertRule(configFile string, group string, alert string) error {
+func (n *NoneAPI) DeleteAlertRule(clusterID string, configFile string, group string, alert string) error {
 	return ErrKubernetesRepoNotReady
 }
 
 // GetAlertRuleConfigFile implements Repo.
-func (n *NoneAPI) GetAlertRuleConfigFile(alertRuleFile string) (map[string]string, error) {
+func (n *NoneAPI) GetAlertRuleConfigFile(clusterID string, alertRuleFile string) (map[string]string, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
 
 // UpdateAlertRuleConfigFile implements Repo.
-func (n *NoneAPI) UpdateAlertRuleConfigFile(configFile string, content []byte) error {
+func (n *NoneAPI) UpdateAlertRuleConfigFile(clusterID string, configFile string, content []byte) error {
 	return ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) AddAMConfigReceiver(configFile string, receiver amconfig.Receiver) error {
+func (n *NoneAPI) AddAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver) error {
 	return ErrKubernetesRepoNotReady
 }
 
 // UpdateAMConfigReceiver implements Repo.
-func (n *NoneAPI) UpdateAMConfigReceiver(configFile string, receiver amconfig.Receiver, oldName string) error {
+func (n *NoneAPI) UpdateAMConfigReceiver(clusterID string, configFile string, receiver amconfig.Receiver, oldName string) error {
 	return ErrKubernetesRepoNotReady
 }
 
 // DeleteAMConfigReceiver implements Repo.
-func (n *NoneAPI) DeleteAMConfigReceiver(configFile string, name string) error {
+func (n *NoneAPI) DeleteAMConfigReceiver(clusterID string, configFile string, name string) error {
 	return ErrKubernetesRepoNotReady
 }
 
 // GetAMConfigReceiver implements Repo.
-func (n *NoneAPI) GetAMConfigReceiver(configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int) {
+func (n *NoneAPI) GetAMConfigReceiver(clusterID string, configFile string, filter *request.AMConfigReceiverFilter, pageParam *request.PageParam, syncNow bool) ([]amconfig.Receiver, int) {
 	return []amconfig.Receiver{}, 0
 }
 
-func (n *NoneAPI) CheckAlertRule(configFile, group, alert string) (bool, error) {
+func (n *NoneAPI) CheckAlertRule(clusterID string, configFile, group, alert string) (bool, error) {
 	return false, ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) GetNamespaceList() (*v1.NamespaceList, error) {
+func (n *NoneAPI) GetNamespaceList(clusterID string) (*v1.NamespaceList, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetNamespaceInfo(clusterID string, namespace string) (*v1.Namespace, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetPodList(clusterID string, namespace string) (*v1.PodList, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetPodInfo(clusterID string, namespace string, pod string) (*v1.Pod, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetDeployment(clusterID string, namespace string, name string) (*appsv1.Deployment, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetStatefulSet(clusterID string, namespace string, name string) (*appsv1.StatefulSet, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetDaemonSet(clusterID string, namespace string, name string) (*appsv1.DaemonSet, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetReplicaSet(clusterID string, namespace string, name string) (*appsv1.ReplicaSet, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetReplicaSetList(clusterID string, namespace string, matchLabels map[string]string) (*appsv1.ReplicaSetList, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetHPAList(clusterID string, namespace string) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetNodeList(clusterID string) (*v1.NodeList, error) {
+	return nil, ErrKubernetesRepoNotReady
+}
+
+func (n *NoneAPI) GetNodeInfo(clusterID string, node string) (*v1.Node, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) GetNamespaceInfo(namespace string) (*v1.Namespace, error) {
+func (n *NoneAPI) GetPodUsage(clusterID string, namespace string, pod string) (map[string]v1.ResourceList, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) GetPodList(namespace string) (*v1.PodList, error) {
+func (n *NoneAPI) GetNodeUsage(clusterID string, node string) (v1.ResourceList, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
 
-func (n *NoneAPI) GetPodInfo(namespace string, pod string) (*v1.Pod, error) {
+func (n *NoneAPI) GetNodeUsageList(clusterID string) (map[string]v1.ResourceList, error) {
 	return nil, ErrKubernetesRepoNotReady
 }
+
+func (n *NoneAPI) WatchChanges(ctx context.Context, handle ChangeHandler) {}
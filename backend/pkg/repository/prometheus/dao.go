@@ -94,12 +94,19 @@ type Repo interface {
 	GetDataplaneServiceList(ctx core.Context, startTime int64, endTime int64, filter string) ([]*model.Service, error)
 	GetDataplaneServiceInstances(ctx core.Context, startTime int64, endTime int64, cluster string, serviceName string) ([]*model.ServiceInstance, error)
 
+	// SetRecordingRules replaces the recording rules used to rewrite queries.
+	SetRecordingRules(rules []ActiveRecordingRule)
+	GetRecordingRules() []ActiveRecordingRule
+	RecordedSeriesExists(ctx core.Context, record string, ts time.Time) (bool, error)
+
 	QueryWithPQLFilter
 }
 
 type promRepo struct {
 	api       v1.API
 	promRange string
+
+	recordingRules *recordingRuleApi
 }
 
 func New(
@@ -117,22 +124,21 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to promethues: %s", err)
 	}
-	api := v1.NewAPI(prometheusClient)
+	var api v1.API = v1.NewAPI(prometheusClient)
 	// Use the wrapped Conn at the Debug log level, and output the time taken to execute SQL.
 	if logger.Level() == zap.DebugLevel {
-		return &promRepo{
-			api: &WrappedApi{
-				API:    api,
-				logger: logger,
-			},
-			promRange: promRange,
-		}, nil
-	} else {
-		return &promRepo{
-			api:       api,
-			promRange: promRange,
-		}, nil
+		api = &WrappedApi{
+			API:    api,
+			logger: logger,
+		}
 	}
+	// Queries are rewritten before logged, so the recorded series used are visible in debug log.
+	recordingRules := &recordingRuleApi{API: api}
+	return &promRepo{
+		api:            recordingRules,
+		promRange:      promRange,
+		recordingRules: recordingRules,
+	}, nil
 }
 
 func (repo *promRepo) GetApi() v1.API {
@@ -0,0 +1,58 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const seasonLabel = "apo_season"
+
+// PQLSeasonalSeries returns the metric at the same time of the last `weeks` weeks,
+// each week is marked with label apo_season.
+func PQLSeasonalSeries(template PQLTemplate, weeks int) PQLTemplate {
+	return func(rng string, gran string, filter PQLFilter, offset string) string {
+		series := make([]string, 0, weeks)
+		for i := 1; i <= weeks; i++ {
+			week := strconv.Itoa(i)
+			series = append(series, fmt.Sprintf(`label_replace(%s, "%s", "%s", "", "")`,
+				template(rng, gran, filter, "offset "+week+"w"), seasonLabel, week))
+		}
+		return "(" + strings.Join(series, " or ") + ")"
+	}
+}
+
+// PQLSeasonalMedian returns the median of the metric at the same time of the last `weeks` weeks.
+func PQLSeasonalMedian(template PQLTemplate, weeks int) PQLTemplate {
+	return func(rng string, gran string, filter PQLFilter, offset string) string {
+		return fmt.Sprintf("quantile by (%s) (0.5, %s)", gran, PQLSeasonalSeries(template, weeks)(rng, gran, filter, offset))
+	}
+}
+
+// PQLSeasonalMAD returns the median absolute deviation of the metric at the same time of the last `weeks` weeks.
+func PQLSeasonalMAD(template PQLTemplate, weeks int) PQLTemplate {
+	return func(rng string, gran string, filter PQLFilter, offset string) string {
+		series := PQLSeasonalSeries(template, weeks)(rng, gran, filter, offset)
+		median := PQLSeasonalMedian(template, weeks)(rng, gran, filter, offset)
+		return fmt.Sprintf("quantile by (%s) (0.5, abs(%s - ignoring(%s) group_left %s))", gran, series, seasonLabel, median)
+	}
+}
+
+// PQLAnomalyScore returns the robust z-score of the metric compared with the same time of the last `weeks` weeks.
+// The deviation is not less than 5% of the median to avoid huge scores for flat series.
+//
+// (current - median) / max(1.4826 * MAD, 0.05 * median)
+func PQLAnomalyScore(template PQLTemplate, weeks int) PQLTemplate {
+	return func(rng string, gran string, filter PQLFilter, offset string) string {
+		current := template(rng, gran, filter, "")
+		median := PQLSeasonalMedian(template, weeks)(rng, gran, filter, offset)
+		mad := PQLSeasonalMAD(template, weeks)(rng, gran, filter, offset)
+
+		minDeviation := fmt.Sprintf("(0.05 * %s)", median)
+		deviation := fmt.Sprintf("((1.4826 * %s) >= %s or %s)", mad, minDeviation, minDeviation)
+		return fmt.Sprintf("((%s) - %s) / %s", current, median, deviation)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const SPAN_TRACE_DURATION_BUCKET Metric = "kindling_span_trace_duration_nanoseconds_bucket"
+
+// PQLAvailabilityErrorRatio returns the ratio of failed requests, used as the SLI of availability SLO.
+func PQLAvailabilityErrorRatio(rng string, gran string, filter PQLFilter, offset string) string {
+	return PQLAvgErrorRateWithPQLFilter(rng, gran, filter, offset)
+}
+
+// PQLLatencyErrorRatio returns a template which computes the ratio of requests slower than thresholdNs,
+// used as the SLI of latency SLO.
+//
+// promRange is the bucket label returned by Repo.GetRange(). VictoriaMetrics uses histogram_share
+// over vmrange buckets, so any threshold is supported. Prometheus can only use an existing le bucket,
+// so thresholdNs must equal to one bucket boundary, which the caller checks with the le values.
+func PQLLatencyErrorRatio(thresholdNs int64, promRange string) PQLTemplate {
+	threshold := strconv.FormatInt(thresholdNs, 10)
+	le := LEValuePattern(thresholdNs)
+	return func(rng string, gran string, filter PQLFilter, offset string) string {
+		if promRange == "vmrange" {
+			buckets := sumBy(gran+", vmrange", increase(rangeVec(SPAN_TRACE_DURATION_BUCKET, filter, rng, offset)))
+			return fmt.Sprintf("1 - histogram_share(%s, %s)", threshold, buckets)
+		}
+
+		fastCount := sumBy(gran, increase(rangeVec(SPAN_TRACE_DURATION_BUCKET, Clone(filter).RegexMatch("le", le), rng, offset)))
+		requestCount := sumBy(gran, increase(rangeVec(SPAN_TRACE_COUNT, filter, rng, offset)))
+		return withDef(sub("1", div(fastCount, requestCount)), requestCount, "0")
+	}
+}
+
+// LEValuePattern matches the le label of the bucket boundary, which is formatted
+// as an integer or a float by exporters, e.g. 500000000 or 5e+08.
+func LEValuePattern(boundaryNs int64) string {
+	integer := strconv.FormatInt(boundaryNs, 10)
+	float := strconv.FormatFloat(float64(boundaryNs), 'g', -1, 64)
+	if integer == float {
+		return RegexMultipleValue(integer)
+	}
+	return RegexMultipleValue(integer, float)
+}
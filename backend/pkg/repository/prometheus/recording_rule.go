@@ -0,0 +1,229 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// RecordingRuleInterval is the evaluation interval of recording rules,
+// each recorded sample is the increase of the source metric in the last interval.
+const RecordingRuleInterval = time.Minute
+
+// RecordingRule pre-aggregates a counter metric by Labels.
+type RecordingRule struct {
+	Record string   `json:"record"`
+	Metric Metric   `json:"metric"`
+	Labels []string `json:"labels"`
+}
+
+// Expr returns the PQL evaluated by vmalert.
+func (r RecordingRule) Expr() string {
+	return fmt.Sprintf("sum by (%s) (increase(%s[%s]))",
+		strings.Join(r.Labels, ", "), r.Metric, model.Duration(RecordingRuleInterval))
+}
+
+// ActiveRecordingRule is a deployed rule whose recorded series are available since ActiveSince.
+type ActiveRecordingRule struct {
+	RecordingRule
+	ActiveSince time.Time `json:"activeSince"`
+}
+
+// endpointLabels covers the labels used by the service and endpoint level PQL templates.
+var endpointLabels = []string{"cluster_id", "namespace", "svc_name", "content_key", "is_error"}
+
+// DefaultRecordingRules returns the recording rules of the metrics used by the common PQL templates.
+// promRange is the bucket label returned by Repo.GetRange().
+func DefaultRecordingRules(promRange string) []RecordingRule {
+	return []RecordingRule{
+		newRecordingRule(SPAN_TRACE_COUNT, endpointLabels),
+		newRecordingRule(SPAN_TRACE_DURATION_SUM, endpointLabels),
+		newRecordingRule(SPAN_TRACE_DURATION_BUCKET, append(slices.Clone(endpointLabels), promRange)),
+	}
+}
+
+func newRecordingRule(metric Metric, labels []string) RecordingRule {
+	return RecordingRule{
+		Record: fmt.Sprintf("apo:%s:increase%s", metric, model.Duration(RecordingRuleInterval)),
+		Metric: metric,
+		Labels: labels,
+	}
+}
+
+// RewriteWithRecordingRules replaces `sum by (...) (increase|rate(metric[range]))` in query
+// with the recorded series, if the grouping and matched labels are all kept by the recording rule
+// and the recorded series cover the whole range. Queries which can not be parsed (e.g. MetricsQL
+// extensions) are returned as is.
+func RewriteWithRecordingRules(query string, rules []ActiveRecordingRule, start time.Time) (string, bool) {
+	if len(rules) == 0 {
+		return query, false
+	}
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return query, false
+	}
+
+	var rewritten bool
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		agg, ok := node.(*parser.AggregateExpr)
+		if !ok || agg.Op != parser.SUM || agg.Without {
+			return nil
+		}
+		if newExpr := rewriteAggregatedCounter(agg, rules, start); newExpr != nil {
+			agg.Expr = newExpr
+			rewritten = true
+		}
+		return nil
+	})
+	if !rewritten {
+		return query, false
+	}
+	return expr.String(), true
+}
+
+func rewriteAggregatedCounter(agg *parser.AggregateExpr, rules []ActiveRecordingRule, start time.Time) parser.Expr {
+	call, ok := agg.Expr.(*parser.Call)
+	if !ok || (call.Func.Name != "increase" && call.Func.Name != "rate") || len(call.Args) != 1 {
+		return nil
+	}
+	matrix, ok := call.Args[0].(*parser.MatrixSelector)
+	if !ok {
+		return nil
+	}
+	vs, ok := matrix.VectorSelector.(*parser.VectorSelector)
+	if !ok || vs.Timestamp != nil || vs.StartOrEnd != 0 {
+		return nil
+	}
+	if matrix.Range < RecordingRuleInterval || matrix.Range%RecordingRuleInterval != 0 {
+		return nil
+	}
+
+	rule := findRecordingRule(rules, metricName(vs))
+	if rule == nil {
+		return nil
+	}
+	// The recorded series must exist before the earliest sample used by the query
+	if start.Add(-vs.OriginalOffset - matrix.Range).Before(rule.ActiveSince) {
+		return nil
+	}
+	for _, label := range agg.Grouping {
+		if !slices.Contains(rule.Labels, label) {
+			return nil
+		}
+	}
+
+	matchers := make([]*labels.Matcher, 0, len(vs.LabelMatchers))
+	for _, matcher := range vs.LabelMatchers {
+		if matcher.Name == labels.MetricName {
+			continue
+		}
+		if !slices.Contains(rule.Labels, matcher.Name) {
+			return nil
+		}
+		matchers = append(matchers, matcher)
+	}
+	matchers = append(matchers, labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, rule.Record))
+
+	recorded := &parser.Call{
+		Func: parser.Functions["sum_over_time"],
+		Args: parser.Expressions{&parser.MatrixSelector{
+			VectorSelector: &parser.VectorSelector{
+				Name:           rule.Record,
+				OriginalOffset: vs.OriginalOffset,
+				LabelMatchers:  matchers,
+			},
+			Range: matrix.Range,
+		}},
+	}
+	if call.Func.Name == "increase" {
+		return recorded
+	}
+	return &parser.BinaryExpr{
+		Op:  parser.DIV,
+		LHS: recorded,
+		RHS: &parser.NumberLiteral{Val: matrix.Range.Seconds()},
+	}
+}
+
+func metricName(vs *parser.VectorSelector) string {
+	if len(vs.Name) > 0 {
+		return vs.Name
+	}
+	for _, matcher := range vs.LabelMatchers {
+		if matcher.Name == labels.MetricName && matcher.Type == labels.MatchEqual {
+			return matcher.Value
+		}
+	}
+	return ""
+}
+
+func findRecordingRule(rules []ActiveRecordingRule, metric string) *ActiveRecordingRule {
+	for i := range rules {
+		if string(rules[i].Metric) == metric {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// recordingRuleApi rewrites queries to use the recorded series of active recording rules.
+type recordingRuleApi struct {
+	v1.API
+	rules atomic.Pointer[[]ActiveRecordingRule]
+}
+
+func (api *recordingRuleApi) rewrite(query string, start time.Time) string {
+	rules := api.rules.Load()
+	if rules == nil {
+		return query
+	}
+	query, _ = RewriteWithRecordingRules(query, *rules, start)
+	return query
+}
+
+func (api *recordingRuleApi) Query(ctx context.Context, query string, ts time.Time, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+	return api.API.Query(ctx, api.rewrite(query, ts), ts, opts...)
+}
+
+func (api *recordingRuleApi) QueryRange(ctx context.Context, query string, r v1.Range, opts ...v1.Option) (model.Value, v1.Warnings, error) {
+	return api.API.QueryRange(ctx, api.rewrite(query, r.Start), r, opts...)
+}
+
+func (repo *promRepo) SetRecordingRules(rules []ActiveRecordingRule) {
+	repo.recordingRules.rules.Store(&rules)
+}
+
+func (repo *promRepo) GetRecordingRules() []ActiveRecordingRule {
+	rules := repo.recordingRules.rules.Load()
+	if rules == nil {
+		return nil
+	}
+	return *rules
+}
+
+// RecordedSeriesExists checks whether the recorded series are written at ts.
+// The query is sent without rewriting.
+func (repo *promRepo) RecordedSeriesExists(ctx core.Context, record string, ts time.Time) (bool, error) {
+	query := fmt.Sprintf("count(last_over_time(%s[%s]))", record, model.Duration(5*RecordingRuleInterval))
+	value, _, err := repo.recordingRules.API.Query(ctx.GetContext(), query, ts)
+	if err != nil {
+		return false, err
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return false, fmt.Errorf("unexpected type %T, expected model.Vector", value)
+	}
+	return len(vector) > 0 && vector[0].Value > 0, nil
+}
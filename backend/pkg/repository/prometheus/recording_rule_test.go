@@ -0,0 +1,85 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRewriteWithRecordingRules(t *testing.T) {
+	now := time.Now()
+	rules := make([]ActiveRecordingRule, 0)
+	for _, rule := range DefaultRecordingRules("vmrange") {
+		rules = append(rules, ActiveRecordingRule{RecordingRule: rule, ActiveSince: now.Add(-24 * time.Hour)})
+	}
+	countRecord := rules[0].Record
+
+	filter := NewFilter().Equal("svc_name", "ts-order-service")
+
+	tests := []struct {
+		name      string
+		query     string
+		start     time.Time
+		rewritten bool
+		contains  []string
+	}{
+		{
+			name:      "avg latency",
+			query:     PQLAvgLatencyWithPQLFilter("5m", string(EndpointGranularity), filter, ""),
+			start:     now,
+			rewritten: true,
+			contains:  []string{"sum_over_time(" + countRecord + `{svc_name="ts-order-service"}[5m])`, "sum_over_time(" + rules[1].Record},
+		},
+		{
+			name:      "tps",
+			query:     PQLAvgTPSWithPQLFilter("5m", string(SVCGranularity), filter, ""),
+			start:     now,
+			rewritten: true,
+			contains:  []string{"sum_over_time(" + countRecord, "/ 300"},
+		},
+		{
+			name:      "range with offset before active",
+			query:     PQLAvgTPSWithPQLFilter("5m", string(SVCGranularity), filter, "offset 2d"),
+			start:     now,
+			rewritten: false,
+		},
+		{
+			name:      "instance granularity not recorded",
+			query:     PQLAvgTPSWithPQLFilter("5m", string(InstanceGranularity), filter, ""),
+			start:     now,
+			rewritten: false,
+		},
+		{
+			name:      "range not aligned to interval",
+			query:     PQLAvgTPSWithPQLFilter("90s", string(SVCGranularity), filter, ""),
+			start:     now,
+			rewritten: false,
+		},
+		{
+			name:      "unknown metric",
+			query:     PQLAvgSQLTPSWithPQLFilter("5m", string(SVCGranularity), filter, ""),
+			start:     now,
+			rewritten: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rewritten := RewriteWithRecordingRules(tt.query, rules, tt.start)
+			if rewritten != tt.rewritten {
+				t.Fatalf("rewritten = %v, want %v, query: %s", rewritten, tt.rewritten, got)
+			}
+			if !rewritten && got != tt.query {
+				t.Errorf("query is changed without rewriting: %s", got)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(got, s) {
+					t.Errorf("%s does not contain %s", got, s)
+				}
+			}
+		})
+	}
+}
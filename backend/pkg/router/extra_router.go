@@ -4,8 +4,14 @@
 package router
 
 import (
+	"context"
+	"time"
+
 	"github.com/CloudDetail/apo/backend/config"
 	"github.com/CloudDetail/apo/backend/pkg/core"
+	alertinput "github.com/CloudDetail/apo/backend/pkg/services/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/services/logexport"
+	"github.com/CloudDetail/apo/backend/pkg/services/logmetric"
 	"github.com/CloudDetail/apo/backend/pkg/util"
 	"github.com/CloudDetail/metadata/source"
 )
@@ -14,6 +20,8 @@ type ExtraRouter func(mux *core.Mux, r *resource) error
 
 var extraRouters = map[string]ExtraRouter{
 	"metaserver": SetMetaServerRouter,
+	"logmetric":  SetLogMetricRouter,
+	"logexport":  SetLogExportRouter,
 }
 
 func SetMetaServerRouter(mux *core.Mux, _ *resource) error {
@@ -35,3 +43,31 @@ func SetMetaServerRouter(mux *core.Mux, _ *resource) error {
 	}
 	return nil
 }
+
+// logMetricEvaluateInterval is the period of checking the due log metrics
+const logMetricEvaluateInterval = 10 * time.Second
+
+// SetLogMetricRouter evaluates the log metrics and exposes them at /metrics for vmalert to scrape.
+func SetLogMetricRouter(mux *core.Mux, r *resource) error {
+	if !config.Get().LogMetric.Enable {
+		return nil
+	}
+
+	service := logmetric.New(r.logger, r.ch, r.pkg_db, alertinput.New(r.prom, r.pkg_db, r.ch, r.dify))
+	go service.KeepEvaluating(context.Background(), logMetricEvaluateInterval)
+
+	mux.Group("").GET_Gin("/metrics", core.WrapHTTPHandler(service.MetricsHandler()))
+	return nil
+}
+
+// logArchiveCheckInterval is the period of checking the due log archive jobs and the expired log exports
+const logArchiveCheckInterval = time.Minute
+
+// SetLogExportRouter fails the exports interrupted by the last restart, runs the scheduled log archive jobs
+// and removes the expired log exports.
+func SetLogExportRouter(_ *core.Mux, r *resource) error {
+	service := logexport.New(r.logger, r.ch, r.pkg_db)
+	service.FailInterruptedExports(core.EmptyCtx())
+	go service.KeepArchiving(context.Background(), logArchiveCheckInterval)
+	return nil
+}
@@ -7,12 +7,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/CloudDetail/apo/backend/pkg/receiver"
 	"github.com/CloudDetail/apo/backend/pkg/repository/cache"
 	"github.com/CloudDetail/apo/backend/pkg/repository/dataplane"
 	"github.com/CloudDetail/apo/backend/pkg/repository/dify"
 	"github.com/CloudDetail/apo/backend/pkg/repository/jaeger"
+	"github.com/CloudDetail/apo/backend/pkg/services/anomaly"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
+	"github.com/CloudDetail/apo/backend/pkg/services/change"
+	"github.com/CloudDetail/apo/backend/pkg/services/common"
+	dataplaneservice "github.com/CloudDetail/apo/backend/pkg/services/dataplane"
+	"github.com/CloudDetail/apo/backend/pkg/services/integration"
+	"github.com/CloudDetail/apo/backend/pkg/services/recordingrule"
 
 	"go.uber.org/zap"
 
@@ -66,12 +74,20 @@ func NewHTTPServer(logger *zap.Logger) (*Server, error) {
 	}
 	r.pkg_db = pkgRepo
 
+	auditCfg := config.Get().Audit
+	if auditCfg.Enable && auditCfg.RetentionDays > 0 {
+		go audit.New(logger, r.pkg_db).KeepRetention(context.Background(),
+			time.Duration(auditCfg.RetentionDays)*24*time.Hour, time.Hour)
+	}
+
 	// Initialize ClickHouse
 	cfg := config.Get().ClickHouse
 	chRepo, err := clickhouse.New(logger, []string{cfg.Address}, cfg.Database, cfg.Username, cfg.Password)
 	if err != nil {
 		logger.Fatal("new clickhouse err", zap.Error(err))
 	}
+	// logs, traces and alert events are limited to the data groups of the requesting user
+	chRepo.SetDataScopeProvider(common.NewDataScopeProvider(pkgRepo))
 	r.ch = chRepo
 
 	deepflowCfg := config.Get().DeepFlow
@@ -95,6 +111,13 @@ func NewHTTPServer(logger *zap.Logger) (*Server, error) {
 	}
 	r.prom = promRepo
 
+	anomalyCfg := config.Get().Anomaly
+	if anomalyCfg.Enable && anomalyCfg.HistoryDays > 0 && anomalyCfg.LearnIntervalHours > 0 {
+		go anomaly.New(logger, r.prom, r.pkg_db).KeepLearning(context.Background(),
+			time.Duration(anomalyCfg.HistoryDays)*24*time.Hour,
+			time.Duration(anomalyCfg.LearnIntervalHours)*time.Hour)
+	}
+
 	// Initialize PolarisAnalyzer
 	polRepo, err := polarisanalyzer.New()
 	if err != nil {
@@ -117,18 +140,51 @@ func NewHTTPServer(logger *zap.Logger) (*Server, error) {
 		logger.Fatal("new kubernetes api err", zap.Error(err))
 	}
 	r.k8sApi = k8sApi
+	if err := integration.New(r.pkg_db, r.k8sApi).LoadK8sClusters(core.EmptyCtx()); err != nil {
+		logger.Warn("failed to load kubernetes clusters", zap.Error(err))
+	}
+
+	changeCfg := config.Get().ChangeEvent
+	changeService := change.New(logger, r.pkg_db, r.prom, r.k8sApi)
+	if changeCfg.Watch {
+		changeService.WatchKubernetes(context.Background())
+	}
+	if changeCfg.RetentionDays > 0 {
+		go changeService.KeepRetention(context.Background(),
+			time.Duration(changeCfg.RetentionDays)*24*time.Hour, time.Hour)
+	}
+
+	snapshotCfg := config.Get().TopologySnapshot
+	if snapshotCfg.Enable && snapshotCfg.IntervalMinutes > 0 {
+		go dataplaneservice.New(logger, r.ch, r.prom, r.pkg_db).KeepSnapshotting(context.Background(),
+			time.Duration(snapshotCfg.IntervalMinutes)*time.Minute,
+			time.Duration(snapshotCfg.RetentionDays)*24*time.Hour)
+	}
+
+	customTopologyCfg := config.Get().CustomTopology
+	if customTopologyCfg.ReconcileMinutes > 0 {
+		go dataplaneservice.New(logger, r.ch, r.prom, r.pkg_db).KeepReconcilingCustomTopology(context.Background(),
+			time.Duration(customTopologyCfg.ReconcileMinutes)*time.Minute,
+			time.Duration(customTopologyCfg.ExpiredRetentionDays)*24*time.Hour)
+	}
+
+	recordingRuleCfg := config.Get().RecordingRule
+	if recordingRuleCfg.Enable && recordingRuleCfg.RefreshMinutes > 0 {
+		go recordingrule.New(logger, r.prom, r.pkg_db, r.k8sApi).KeepRefreshing(context.Background(),
+			time.Duration(recordingRuleCfg.RefreshMinutes)*time.Minute)
+	}
 
 	if config.Get().AlertReceiver.Enabled {
 		// migrate AMReceiver from ConfigMap to database
 		if r.pkg_db.CheckAMReceiverCount(nil) <= 0 {
-			receivers, total := r.k8sApi.GetAMConfigReceiver("", nil, nil, true)
+			receivers, total := r.k8sApi.GetAMConfigReceiver(kubernetes.DefaultClusterID, "", nil, nil, true)
 			if total > 0 {
 				migratedReceivers, err := r.pkg_db.MigrateAMReceiver(core.EmptyCtx(), receivers)
 				if err != nil {
 					logger.Fatal("failed to migrate amconfig ", zap.Error(err))
 				}
 				for _, receiver := range migratedReceivers {
-					err := r.k8sApi.DeleteAMConfigReceiver("", receiver.Name)
+					err := r.k8sApi.DeleteAMConfigReceiver(kubernetes.DefaultClusterID, "", receiver.Name)
 					if err != nil {
 						logger.Warn("remove migratedReceiver failed", zap.String("name", receiver.Name), zap.Error(err))
 					}
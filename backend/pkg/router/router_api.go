@@ -6,6 +6,10 @@ package router
 import (
 	alertinput "github.com/CloudDetail/apo/backend/pkg/api/alertinput"
 	"github.com/CloudDetail/apo/backend/pkg/api/alerts"
+	"github.com/CloudDetail/apo/backend/pkg/api/anomaly"
+	auditapi "github.com/CloudDetail/apo/backend/pkg/api/audit"
+	catalogapi "github.com/CloudDetail/apo/backend/pkg/api/catalog"
+	changeapi "github.com/CloudDetail/apo/backend/pkg/api/change"
 	"github.com/CloudDetail/apo/backend/pkg/api/config"
 	"github.com/CloudDetail/apo/backend/pkg/api/data"
 	"github.com/CloudDetail/apo/backend/pkg/api/dataplane"
@@ -13,12 +17,15 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/api/integration"
 	"github.com/CloudDetail/apo/backend/pkg/api/k8s"
 	"github.com/CloudDetail/apo/backend/pkg/api/log"
+	"github.com/CloudDetail/apo/backend/pkg/api/logmetric"
 	"github.com/CloudDetail/apo/backend/pkg/api/metric"
 	networkapi "github.com/CloudDetail/apo/backend/pkg/api/network"
 	"github.com/CloudDetail/apo/backend/pkg/api/permission"
+	"github.com/CloudDetail/apo/backend/pkg/api/recordingrule"
 	"github.com/CloudDetail/apo/backend/pkg/api/role"
 	"github.com/CloudDetail/apo/backend/pkg/api/service"
 	"github.com/CloudDetail/apo/backend/pkg/api/serviceoverview"
+	"github.com/CloudDetail/apo/backend/pkg/api/slo"
 	"github.com/CloudDetail/apo/backend/pkg/api/team"
 	"github.com/CloudDetail/apo/backend/pkg/api/trace"
 	"github.com/CloudDetail/apo/backend/pkg/api/user"
@@ -26,7 +33,8 @@ import (
 )
 
 func setApiRouter(r *resource) {
-	middlewares := middleware.New(r.cache, r.pkg_db, r.dify)
+	middlewares := middleware.New(r.logger, r.cache, r.pkg_db, r.dify)
+	withAudit := middlewares.AuditMiddleware()
 
 	serviceApi := r.mux.Group("/api/service").Use(middlewares.AuthMiddleware())
 	{
@@ -35,14 +43,23 @@ func setApiRouter(r *resource) {
 		serviceApi.Any("/servicesAlert", serviceOverviewHandler.GetServicesAlert())
 		serviceApi.Any("/moreUrl", serviceOverviewHandler.GetServiceMoreUrlList())
 		serviceApi.GET("/getThreshold", serviceOverviewHandler.GetThreshold())
-		serviceApi.POST("/setThreshold", serviceOverviewHandler.SetThreshold())
+		serviceApi.POST("/setThreshold", withAudit, serviceOverviewHandler.SetThreshold())
 		serviceApi.GET("/ryglight", serviceOverviewHandler.GetRYGLight())
+		serviceApi.GET("/ryglight/explain", serviceOverviewHandler.ExplainRYGLight())
+		serviceApi.GET("/healthModel", serviceOverviewHandler.GetHealthScoreModel())
+		serviceApi.POST("/healthModel/set", withAudit, serviceOverviewHandler.SetHealthScoreModel())
+		serviceApi.POST("/healthModel/delete", withAudit, serviceOverviewHandler.DeleteHealthScoreModel())
 		serviceApi.GET("/monitor/status", serviceOverviewHandler.GetMonitorStatus())
 
 		serviceHandler := service.New(r.logger, r.ch, r.prom, r.pol, r.pkg_db, r.k8sApi)
 		serviceApi.Any("/entry/endpoints", serviceHandler.GetServiceEntryEndpoints())
 		serviceApi.Any("/relation", serviceHandler.GetServiceEndpointRelation())
 		serviceApi.Any("/topology", serviceHandler.GetServiceEndpointTopology())
+		serviceApi.GET("/topology/export", serviceHandler.ExportServiceEndpointTopology())
+		serviceApi.GET("/graph/blastRadius", serviceHandler.GetBlastRadius())
+		serviceApi.GET("/graph/criticalPath", serviceHandler.GetCriticalPath())
+		serviceApi.GET("/graph/cycles", serviceHandler.DetectCycles())
+		serviceApi.GET("/graph/spof", serviceHandler.GetSinglePointsOfFailure())
 		serviceApi.Any("/descendant/metrics", serviceHandler.GetDescendantMetrics())
 		serviceApi.Any("/descendant/relevance", serviceHandler.GetDescendantRelevance())
 		serviceApi.Any("/polaris/infer", serviceHandler.GetPolarisInfer())
@@ -76,24 +93,57 @@ func setApiRouter(r *resource) {
 		logApi.POST("/fault/content", logHandler.GetFaultLogContent())
 
 		logApi.POST("/context", logHandler.QueryLogContext())
+		logApi.POST("/correlate", logHandler.CorrelateLog())
 
 		logApi.POST("/query", logHandler.QueryLog())
+		logApi.GET("/query/saved/list", logHandler.ListLogSavedQueries())
+		logApi.GET("/query/saved/get", logHandler.GetLogSavedQuery())
+		logApi.POST("/query/saved/create", logHandler.CreateLogSavedQuery())
+		logApi.POST("/query/saved/update", logHandler.UpdateLogSavedQuery())
+		logApi.POST("/query/saved/delete", logHandler.DeleteLogSavedQuery())
+		logApi.GET("/query/history", logHandler.ListLogQueryHistory())
+		logApi.POST("/query/history/delete", logHandler.DeleteLogQueryHistory())
 		logApi.POST("/chart", logHandler.GetLogChart())
 		logApi.POST("/index", logHandler.GetLogIndex())
+		logApi.POST("/pattern", logHandler.GetLogPatterns())
+		logApi.GET("/tail", logHandler.TailLog())
 
 		logApi.GET("/table", logHandler.GetLogTableInfo())
+		logApi.POST("/schema/infer", logHandler.InferLogSchema())
+		logApi.POST("/schema/update", withAudit, logHandler.UpdateLogSchema())
 
 		logApi.GET("/rule/service", logHandler.GetServiceRoute())
 
 		logApi.GET("/rule/get", logHandler.GetLogParseRule())
-		logApi.POST("/rule/update", logHandler.UpdateLogParseRule())
-		logApi.POST("/rule/add", logHandler.AddLogParseRule())
-		logApi.DELETE("/rule/delete", logHandler.DeleteLogParseRule())
+		logApi.POST("/rule/update", withAudit, logHandler.UpdateLogParseRule())
+		logApi.POST("/rule/add", withAudit, logHandler.AddLogParseRule())
+		logApi.DELETE("/rule/delete", withAudit, logHandler.DeleteLogParseRule())
+		logApi.POST("/rule/test", logHandler.TestLogParseRule())
+
+		logApi.GET("/pipeline", logHandler.GetVectorPipeline())
+		logApi.GET("/pipeline/version", logHandler.GetVectorPipelineVersion())
+		logApi.GET("/pipeline/versions", logHandler.ListVectorPipelineVersions())
+		logApi.GET("/pipeline/diff", logHandler.DiffVectorPipeline())
+		logApi.POST("/pipeline/update", withAudit, logHandler.UpdateVectorPipeline())
+		logApi.POST("/pipeline/rollback", withAudit, logHandler.RollbackVectorPipeline())
+
+		logApi.POST("/export/create", logHandler.CreateLogExport())
+		logApi.GET("/export/list", logHandler.ListLogExports())
+		logApi.GET("/export/progress", logHandler.GetLogExport())
+		logApi.GET("/export/download", withAudit, logHandler.DownloadLogExport())
+		logApi.POST("/export/delete", logHandler.DeleteLogExport())
+
+		logApi.GET("/archive/list", logHandler.ListLogArchiveJobs())
+		logApi.GET("/archive/records", logHandler.ListLogArchiveRecords())
+		logApi.POST("/archive/create", withAudit, logHandler.CreateLogArchiveJob())
+		logApi.POST("/archive/update", withAudit, logHandler.UpdateLogArchiveJob())
+		logApi.POST("/archive/delete", withAudit, logHandler.DeleteLogArchiveJob())
+		logApi.POST("/archive/run", withAudit, logHandler.RunLogArchiveJob())
 
 		logApi.GET("/other", logHandler.OtherTable())
 		logApi.GET("/other/table", logHandler.OtherTableInfo())
-		logApi.POST("/other/add", logHandler.AddOtherTable())
-		logApi.DELETE("/other/delete", logHandler.DeleteOtherTable())
+		logApi.POST("/other/add", withAudit, logHandler.AddOtherTable())
+		logApi.DELETE("/other/delete", withAudit, logHandler.DeleteOtherTable())
 	}
 
 	traceApi := r.mux.Group("/api/trace")
@@ -122,28 +172,28 @@ func setApiRouter(r *resource) {
 
 		alertApi.Use(middlewares.AuthMiddleware())
 		alertApi.GET("/rules/file", alertHandler.GetAlertRuleFile())
-		alertApi.POST("/rules/file", alertHandler.UpdateAlertRuleFile())
+		alertApi.POST("/rules/file", withAudit, alertHandler.UpdateAlertRuleFile())
 
 		alertApi.GET("/rule/groups", alertHandler.GetGroupList())
 		alertApi.GET("/rule/metrics", alertHandler.GetMetricPQL())
 
 		alertApi.POST("/rule/list", alertHandler.GetAlertRules())
-		alertApi.POST("/rule", alertHandler.UpdateAlertRule())
-		alertApi.DELETE("/rule", alertHandler.DeleteAlertRule())
-		alertApi.POST("/rule/add", alertHandler.AddAlertRule())
+		alertApi.POST("/rule", withAudit, alertHandler.UpdateAlertRule())
+		alertApi.DELETE("/rule", withAudit, alertHandler.DeleteAlertRule())
+		alertApi.POST("/rule/add", withAudit, alertHandler.AddAlertRule())
 		alertApi.GET("/rule/available", alertHandler.CheckAlertRule())
 
-		alertApi.POST("/alertmanager/receiver/list", alertHandler.GetAlertManagerConfigReceiver())
-		alertApi.POST("/alertmanager/receiver/add", alertHandler.AddAlertManagerConfigReceiver())
-		alertApi.POST("/alertmanager/receiver", alertHandler.UpdateAlertManagerConfigReceiver())
-		alertApi.DELETE("/alertmanager/receiver", alertHandler.DeleteAlertManagerConfigReceiver())
+		alertApi.POST("/alertmanager/receiver/list", withAudit, alertHandler.GetAlertManagerConfigReceiver())
+		alertApi.POST("/alertmanager/receiver/add", withAudit, alertHandler.AddAlertManagerConfigReceiver())
+		alertApi.POST("/alertmanager/receiver", withAudit, alertHandler.UpdateAlertManagerConfigReceiver())
+		alertApi.DELETE("/alertmanager/receiver", withAudit, alertHandler.DeleteAlertManagerConfigReceiver())
 
 		alertApi.GET("/slient", alertHandler.GetAlertSlienceConfig())
 		alertApi.GET("/slient/list", alertHandler.ListAlertSlienceConfig())
-		alertApi.DELETE("/slient", alertHandler.RemoveAlertSlienceConfig())
-		alertApi.POST("/slient", alertHandler.SetAlertSlienceConfig())
+		alertApi.DELETE("/slient", withAudit, alertHandler.RemoveAlertSlienceConfig())
+		alertApi.POST("/slient", withAudit, alertHandler.SetAlertSlienceConfig())
 
-		alertApi.POST("/resolve", alertHandler.MarkAlertResolvedManually())
+		alertApi.POST("/resolve", withAudit, alertHandler.MarkAlertResolvedManually())
 
 		alertApi.GET("/filter/keys", alertHandler.GetAlertEventStaticFilters())
 		alertApi.POST("/filter/labelkeys", alertHandler.GetAlertEventLabelFilterKeys())
@@ -153,8 +203,8 @@ func setApiRouter(r *resource) {
 	configApi := r.mux.Group("/api/config").Use(middlewares.AuthMiddleware())
 	{
 		configHandler := config.New(r.logger, r.ch)
-		configApi.POST("/setTTL", configHandler.SetTTL())
-		configApi.POST("/setSingleTableTTL", configHandler.SetSingleTableTTL())
+		configApi.POST("/setTTL", withAudit, configHandler.SetTTL())
+		configApi.POST("/setSingleTableTTL", withAudit, configHandler.SetSingleTableTTL())
 		configApi.GET("/getTTL", configHandler.GetTTL())
 	}
 
@@ -165,16 +215,16 @@ func setApiRouter(r *resource) {
 		userApi.POST("/logout", userHandler.Logout())
 		userApi.GET("/refresh", userHandler.RefreshToken())
 		userApi.Use(middlewares.AuthMiddleware())
-		userApi.POST("/create", userHandler.CreateUser())
-		userApi.POST("/update/password", userHandler.UpdateUserPassword())
+		userApi.POST("/create", withAudit, userHandler.CreateUser())
+		userApi.POST("/update/password", withAudit, userHandler.UpdateUserPassword())
 		userApi.POST("/update/phone", userHandler.UpdateUserPhone())
 		userApi.POST("/update/email", userHandler.UpdateUserEmail())
-		userApi.POST("/update/info", userHandler.UpdateUserInfo())
+		userApi.POST("/update/info", withAudit, userHandler.UpdateUserInfo())
 		userApi.POST("/update/self", userHandler.UpdateSelfInfo())
 		userApi.GET("/info", userHandler.GetUserInfo())
 		userApi.GET("/list", userHandler.GetUserList())
-		userApi.POST("/remove", userHandler.RemoveUser())
-		userApi.POST("/reset", userHandler.ResetPassword())
+		userApi.POST("/remove", withAudit, userHandler.RemoveUser())
+		userApi.POST("/reset", withAudit, userHandler.ResetPassword())
 		userApi.GET("/team", userHandler.GetUserTeam())
 	}
 
@@ -184,8 +234,8 @@ func setApiRouter(r *resource) {
 		permissionApi.GET("/config", permissionHandler.GetUserConfig())
 		permissionApi.GET("/feature", permissionHandler.GetFeature())
 		permissionApi.GET("/sub/feature", permissionHandler.GetSubjectFeature())
-		permissionApi.POST("/operation", permissionHandler.PermissionOperation())
-		permissionApi.POST("/menu/configure", permissionHandler.ConfigureMenu())
+		permissionApi.POST("/operation", withAudit, permissionHandler.PermissionOperation())
+		permissionApi.POST("/menu/configure", withAudit, permissionHandler.ConfigureMenu())
 		permissionApi.GET("/router", permissionHandler.CheckRouterPermission())
 	}
 
@@ -194,10 +244,10 @@ func setApiRouter(r *resource) {
 		roleHandler := role.New(r.logger, r.pkg_db)
 		roleApi.GET("/roles", roleHandler.GetRole())
 		roleApi.GET("/user", roleHandler.GetUserRole())
-		roleApi.POST("/operation", roleHandler.RoleOperation())
-		roleApi.POST("/create", roleHandler.CreateRole())
-		roleApi.POST("/update", roleHandler.UpdateRole())
-		roleApi.POST("/delete", roleHandler.DeleteRole())
+		roleApi.POST("/operation", withAudit, roleHandler.RoleOperation())
+		roleApi.POST("/create", withAudit, roleHandler.CreateRole())
+		roleApi.POST("/update", withAudit, roleHandler.UpdateRole())
+		roleApi.POST("/delete", withAudit, roleHandler.DeleteRole())
 	}
 
 	dataApi := r.mux.Group("/api/data").Use(middlewares.AuthMiddleware())
@@ -207,9 +257,9 @@ func setApiRouter(r *resource) {
 		dataApiV2.GET("/group", dataHandler.GetDataGroupV2())
 		dataApiV2.GET("/group/datasource/list", dataHandler.GetDGScopeList())
 		dataApiV2.GET("/group/detail", dataHandler.GetDGDetailV2())
-		dataApiV2.POST("/group/add", dataHandler.CreateDataGroupV2())
-		dataApiV2.POST("/group/update", dataHandler.UpdateDataGroupV2())
-		dataApiV2.DELETE("/group/delete", dataHandler.DeleteDataGroupV2())
+		dataApiV2.POST("/group/add", withAudit, dataHandler.CreateDataGroupV2())
+		dataApiV2.POST("/group/update", withAudit, dataHandler.UpdateDataGroupV2())
+		dataApiV2.DELETE("/group/delete", withAudit, dataHandler.DeleteDataGroupV2())
 		dataApiV2.POST("/group/filter", dataHandler.GetFilterByGroupIDV2())
 		dataApiV2.Any("/group/datasource/refresh", dataHandler.CleanExpiredDataScope())
 
@@ -221,31 +271,36 @@ func setApiRouter(r *resource) {
 		//dataApi.POST("/group/create", dataHandler.CreateDataGroup())
 		dataApi.GET("/sub/group", dataHandler.GetSubjectDataGroup())
 		dataApi.GET("/user/group", dataHandler.GetUserDataGroup())
-		dataApi.POST("/group/operation", dataHandler.DataGroupOperation())
+		dataApi.POST("/group/operation", withAudit, dataHandler.DataGroupOperation())
 		dataApi.GET("/subs", dataHandler.GetGroupSubs())
-		dataApi.POST("/subs/operation", dataHandler.GroupSubsOperation())
+		dataApi.POST("/subs/operation", withAudit, dataHandler.GroupSubsOperation())
 	}
 
 	teamApi := r.mux.Group("/api/team").Use(middlewares.AuthMiddleware())
 	{
 		teamHandler := team.New(r.logger, r.pkg_db)
-		teamApi.POST("/create", teamHandler.CreateTeam())
-		teamApi.POST("/update", teamHandler.UpdateTeam())
+		teamApi.POST("/create", withAudit, teamHandler.CreateTeam())
+		teamApi.POST("/update", withAudit, teamHandler.UpdateTeam())
 		teamApi.GET("", teamHandler.GetTeam())
-		teamApi.POST("/delete", teamHandler.DeleteTeam())
-		teamApi.POST("/operation", teamHandler.TeamOperation())
-		teamApi.POST("/user/operation", teamHandler.TeamUserOperation())
+		teamApi.POST("/delete", withAudit, teamHandler.DeleteTeam())
+		teamApi.POST("/operation", withAudit, teamHandler.TeamOperation())
+		teamApi.POST("/user/operation", withAudit, teamHandler.TeamUserOperation())
 		teamApi.GET("/user", teamHandler.GetTeamUser())
 	}
 
 	k8sApi := r.mux.Group("/api/k8s")
 	{
-		k8sHandler := k8s.New(r.k8sApi)
+		k8sHandler := k8s.New(r.k8sApi, r.prom)
 		// These APIs are used by another project. DO NOT Auth.
 		k8sApi.GET("/namespaces", k8sHandler.GetNamespaceList())
 		k8sApi.GET("/namespace/info", k8sHandler.GetNamespaceInfo())
 		k8sApi.GET("/pods", k8sHandler.GetPodList())
 		k8sApi.GET("/pod/info", k8sHandler.GetPodInfo())
+
+		k8sAuthApi := r.mux.Group("/api/k8s").Use(middlewares.AuthMiddleware())
+		k8sAuthApi.GET("/workload", k8sHandler.GetK8sWorkload())
+		k8sAuthApi.GET("/nodes", k8sHandler.GetK8sNodeList())
+		k8sAuthApi.GET("/service/workloads", k8sHandler.GetServiceWorkloads())
 	}
 	networkApi := r.mux.Group("/api/network/")
 	{
@@ -265,46 +320,46 @@ func setApiRouter(r *resource) {
 		handler := alertinput.New(r.logger, r.ch, r.prom, r.pkg_db, r.dify)
 		alertInputApi.POST("/event/source", handler.SourceHandler())
 		alertInputApi.POST("/event/json", handler.JsonHandler())
-		alertInputApi.POST("/source/create", handler.CreateAlertSource())
-		alertInputApi.POST("/source/update", handler.UpdateAlertSource())
-		alertInputApi.POST("/source/get", handler.GetAlertSource())
-		alertInputApi.POST("/source/delete", handler.DeleteAlertSource())
+		alertInputApi.POST("/source/create", withAudit, handler.CreateAlertSource())
+		alertInputApi.POST("/source/update", withAudit, handler.UpdateAlertSource())
+		alertInputApi.POST("/source/get", withAudit, handler.GetAlertSource())
+		alertInputApi.POST("/source/delete", withAudit, handler.DeleteAlertSource())
 		alertInputApi.GET("/source/list", handler.ListAlertSource())
-		alertInputApi.POST("/source/enrich/update", handler.UpdateAlertSourceEnrichRule())
+		alertInputApi.POST("/source/enrich/update", withAudit, handler.UpdateAlertSourceEnrichRule())
 		alertInputApi.POST("/source/enrich/get", handler.GetAlertSourceEnrichRule())
 		alertInputApi.GET("/enrich/tags/list", handler.ListTargetTags())
 
-		alertInputApi.POST("/cluster/create", handler.CreateCluster())
+		alertInputApi.POST("/cluster/create", withAudit, handler.CreateCluster())
 		alertInputApi.GET("/cluster/list", handler.ListCluster())
-		alertInputApi.POST("/cluster/update", handler.UpdateCluster())
-		alertInputApi.POST("/cluster/delete", handler.DeleteCluster())
+		alertInputApi.POST("/cluster/update", withAudit, handler.UpdateCluster())
+		alertInputApi.POST("/cluster/delete", withAudit, handler.DeleteCluster())
 
-		alertInputApi.POST("/schema/create", handler.CreateSchema())
-		alertInputApi.GET("/schema/delete", handler.DeleteSchema())
+		alertInputApi.POST("/schema/create", withAudit, handler.CreateSchema())
+		alertInputApi.GET("/schema/delete", withAudit, handler.DeleteSchema())
 		alertInputApi.GET("/schema/used/check", handler.CheckSchemaIsUsed())
 		alertInputApi.GET("/schema/list", handler.ListSchema())
 		alertInputApi.GET("/schema/listwithcolumns", handler.ListSchemaWithColumns())
 		alertInputApi.GET("/schema/column/get", handler.GetSchemaColumns())
-		alertInputApi.POST("/schema/data/update", handler.UpdateSchemaData())
+		alertInputApi.POST("/schema/data/update", withAudit, handler.UpdateSchemaData())
 		alertInputApi.GET("/schema/data/get", handler.GetSchemaData())
 
-		alertInputApi.GET("/source/enrich/default/clear", handler.ClearDefaultAlertEnrichRule())
+		alertInputApi.GET("/source/enrich/default/clear", withAudit, handler.ClearDefaultAlertEnrichRule())
 		alertInputApi.GET("/source/enrich/default/get", handler.GetDefaultAlertEnrichRule())
-		alertInputApi.POST("/source/enrich/default/set", handler.SetDefaultAlertEnrichRule())
+		alertInputApi.POST("/source/enrich/default/set", withAudit, handler.SetDefaultAlertEnrichRule())
 	}
 
 	integrationAPI := r.mux.Group("/api/integration")
 	{
-		handler := integration.New(r.pkg_db)
+		handler := integration.New(r.pkg_db, r.k8sApi)
 		integrationAPI.GET("/configuration", handler.GetStaticIntegration())
 
 		integrationAPI.GET("/cluster/list", handler.ListCluster())
-		integrationAPI.GET("/cluster/get", handler.GetCluster())
-		integrationAPI.POST("/cluster/create", handler.CreateCluster())
-		integrationAPI.POST("/cluster/update", handler.UpdateCluster())
-		integrationAPI.GET("/cluster/delete", handler.DeleteCluster())
+		integrationAPI.GET("/cluster/get", withAudit, handler.GetCluster())
+		integrationAPI.POST("/cluster/create", withAudit, handler.CreateCluster())
+		integrationAPI.POST("/cluster/update", withAudit, handler.UpdateCluster())
+		integrationAPI.GET("/cluster/delete", withAudit, handler.DeleteCluster())
 
-		integrationAPI.GET("/cluster/install/config", handler.GetIntegrationInstallConfigFile())
+		integrationAPI.GET("/cluster/install/config", withAudit, handler.GetIntegrationInstallConfigFile())
 		integrationAPI.GET("/cluster/install/cmd", handler.GetIntegrationInstallDoc())
 		integrationAPI.GET("/adapter/update", handler.TriggerAdapterUpdate())
 	}
@@ -318,20 +373,92 @@ func setApiRouter(r *resource) {
 
 	dataplaneAPI := r.mux.Group("/api/dataplane")
 	{
-		handler := dataplane.New(r.logger, r.ch, r.prom, r.pkg_db)
+		handler := dataplane.New(r.logger, r.ch, r.prom, r.pkg_db, r.k8sApi)
 		dataplaneAPI.GET("/services", handler.QueryServices())
 		dataplaneAPI.GET("/redcharts", handler.QueryServiceRedCharts())
 		dataplaneAPI.GET("/endpoints", handler.QueryServiceEndpoints())
 		dataplaneAPI.GET("/instances", handler.QueryServiceInstances())
 		dataplaneAPI.POST("/servicename", handler.QueryServiceName())
 		dataplaneAPI.GET("/topology", handler.QueryTopology())
+		dataplaneAPI.GET("/topology/export", middlewares.AuthMiddleware(), handler.ExportTopology())
 
-		dataplaneAPI.POST("/customtopology/create", handler.CreateCustomTopology())
+		dataplaneAPI.POST("/customtopology/create", withAudit, handler.CreateCustomTopology())
 		dataplaneAPI.GET("/customtopology/list", handler.ListCustomTopology())
-		dataplaneAPI.POST("/customtopology/delete", handler.DeleteCustomTopology())
+		dataplaneAPI.POST("/customtopology/delete", withAudit, handler.DeleteCustomTopology())
+		dataplaneAPI.POST("/customtopology/import", middlewares.AuthMiddleware(), withAudit, handler.ImportCustomTopology())
+		dataplaneAPI.POST("/customtopology/reconcile", middlewares.AuthMiddleware(), handler.ReconcileCustomTopology())
 		dataplaneAPI.POST("/servicename/checkRule", handler.CheckServiceNameRule())
-		dataplaneAPI.POST("/servicename/upsertRule", handler.SetServiceNameRule())
+		dataplaneAPI.POST("/servicename/upsertRule", withAudit, handler.SetServiceNameRule())
 		dataplaneAPI.GET("/servicename/listRule", handler.ListServiceNameRule())
-		dataplaneAPI.POST("/servicename/deleteRule", handler.DeleteServiceNameRule())
+		dataplaneAPI.POST("/servicename/deleteRule", withAudit, handler.DeleteServiceNameRule())
+		dataplaneAPI.POST("/servicename/previewRules", handler.PreviewServiceNameRules())
+
+		dataplaneAPI.POST("/topology/snapshot/create", middlewares.AuthMiddleware(), withAudit, handler.CreateTopologySnapshot())
+		dataplaneAPI.GET("/topology/snapshots", middlewares.AuthMiddleware(), handler.ListTopologySnapshots())
+		dataplaneAPI.GET("/topology/snapshot", middlewares.AuthMiddleware(), handler.GetTopologySnapshot())
+		dataplaneAPI.GET("/topology/diff", middlewares.AuthMiddleware(), handler.DiffTopology())
+	}
+
+	changeAPI := r.mux.Group("/api/change")
+	{
+		handler := changeapi.New(r.logger, r.pkg_db, r.prom, r.k8sApi)
+		changeAPI.GET("/events", middlewares.AuthMiddleware(), handler.GetChangeEvents())
+		// reported by the CI/CD systems with the webhook token
+		changeAPI.POST("/webhook", handler.ReportChangeEvent())
+	}
+
+	auditAPI := r.mux.Group("/api/audit").Use(middlewares.AuthMiddleware())
+	{
+		handler := auditapi.New(r.logger, r.pkg_db)
+		auditAPI.GET("/logs", handler.GetAuditLogs())
+	}
+
+	sloAPI := r.mux.Group("/api/slo").Use(middlewares.AuthMiddleware())
+	{
+		handler := slo.New(r.logger, r.prom, r.pkg_db, r.k8sApi)
+		sloAPI.GET("/list", handler.ListSLO())
+		sloAPI.GET("/status", handler.GetSLOStatus())
+		sloAPI.POST("/create", withAudit, handler.CreateSLO())
+		sloAPI.POST("/update", withAudit, handler.UpdateSLO())
+		sloAPI.POST("/delete", withAudit, handler.DeleteSLO())
+	}
+
+	catalogAPI := r.mux.Group("/api/catalog").Use(middlewares.AuthMiddleware())
+	{
+		handler := catalogapi.New(r.logger, r.pkg_db)
+		catalogAPI.GET("/list", handler.ListServiceCatalogs())
+		catalogAPI.GET("/service", handler.GetServiceCatalog())
+		catalogAPI.POST("/save", withAudit, handler.SaveServiceCatalog())
+		catalogAPI.POST("/delete", withAudit, handler.DeleteServiceCatalog())
+		catalogAPI.POST("/import", withAudit, handler.ImportServiceCatalog())
+	}
+
+	anomalyAPI := r.mux.Group("/api/anomaly").Use(middlewares.AuthMiddleware())
+	{
+		handler := anomaly.New(r.logger, r.prom, r.pkg_db)
+		anomalyAPI.GET("/scores", handler.GetAnomalyScores())
+		anomalyAPI.GET("/rule", handler.GetAnomalyAlertRule())
+		anomalyAPI.POST("/learn", withAudit, handler.LearnBaselines())
+	}
+
+	recordingRuleAPI := r.mux.Group("/api/recordingrule").Use(middlewares.AuthMiddleware())
+	{
+		handler := recordingrule.New(r.logger, r.prom, r.pkg_db, r.k8sApi)
+		recordingRuleAPI.GET("/list", handler.ListRecordingRules())
+		recordingRuleAPI.POST("/deploy", withAudit, handler.DeployRecordingRules())
+		recordingRuleAPI.POST("/delete", withAudit, handler.DeleteRecordingRules())
+	}
+
+	logMetricAPI := r.mux.Group("/api/logmetric").Use(middlewares.AuthMiddleware())
+	{
+		handler := logmetric.New(r.logger, r.ch, r.prom, r.pkg_db, r.dify)
+		logMetricAPI.GET("/list", handler.ListLogMetrics())
+		logMetricAPI.POST("/create", withAudit, handler.CreateLogMetric())
+		logMetricAPI.POST("/update", withAudit, handler.UpdateLogMetric())
+		logMetricAPI.POST("/delete", withAudit, handler.DeleteLogMetric())
+		logMetricAPI.GET("/rule/list", handler.ListLogAlertRules())
+		logMetricAPI.POST("/rule/create", withAudit, handler.CreateLogAlertRule())
+		logMetricAPI.POST("/rule/update", withAudit, handler.UpdateLogAlertRule())
+		logMetricAPI.POST("/rule/delete", withAudit, handler.DeleteLogAlertRule())
 	}
 }
@@ -20,5 +20,5 @@ func (s *service) AddAlertRule(ctx core.Context, req *request.AddAlertRuleReques
 		req.AlertRule.Labels["groupId"] = strconv.FormatInt(req.GroupID, 10)
 	}
 
-	return s.k8sApi.AddAlertRule(req.AlertRuleFile, req.AlertRule)
+	return s.k8sApi.AddAlertRule(req.ClusterID, req.AlertRuleFile, req.AlertRule)
 }
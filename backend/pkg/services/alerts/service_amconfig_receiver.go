@@ -13,6 +13,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/amconfig"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 	uuid2 "github.com/google/uuid"
 )
 
@@ -43,7 +44,7 @@ func (s *service) GetAMReceiversFromExternalAM(ctx core.Context, req *request.Ge
 		}
 	}
 	// get the configuration of am from memory
-	receivers, totalCount := s.k8sApi.GetAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiverFilter, req.PageParam, req.RefreshCache)
+	receivers, totalCount := s.k8sApi.GetAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiverFilter, req.PageParam, req.RefreshCache)
 	resp := response.GetAlertManagerConfigReceiverResponse{
 		AMConfigReceivers: receivers,
 		Pagination: &model.Pagination{
@@ -89,7 +90,7 @@ func (s *service) AddAMConfigReceiver(ctx core.Context, req *request.AddAlertMan
 
 func (s *service) AddAMReceiversForExternalAM(ctx core.Context, req *request.AddAlertManagerConfigReceiver) error {
 	if req.Type != "dingtalk" {
-		return s.k8sApi.AddAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiver)
+		return s.k8sApi.AddAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiver)
 	}
 
 	if req.AMConfigReceiver.DingTalkConfigs == nil || len(req.AMConfigReceiver.DingTalkConfigs) == 0 {
@@ -97,7 +98,7 @@ func (s *service) AddAMReceiversForExternalAM(ctx core.Context, req *request.Add
 	}
 
 	for i := range req.AMConfigReceiver.DingTalkConfigs {
-		uuid, err := s.addDingTalkWebhook(req.AMConfigReceiver.Name)
+		uuid, err := s.addDingTalkWebhook(req.ClusterID, req.AMConfigReceiver.Name)
 		if err != nil {
 			return err
 		}
@@ -106,7 +107,7 @@ func (s *service) AddAMReceiversForExternalAM(ctx core.Context, req *request.Add
 		req.AMConfigReceiver.DingTalkConfigs[i].ConfigFile = req.AMConfigFile
 		err = s.dbRepo.CreateDingTalkReceiver(ctx, req.AMConfigReceiver.DingTalkConfigs[i])
 		if err != nil {
-			s.k8sApi.DeleteAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiver.Name)
+			s.k8sApi.DeleteAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiver.Name)
 			return err
 		}
 	}
@@ -114,6 +115,7 @@ func (s *service) AddAMReceiversForExternalAM(ctx core.Context, req *request.Add
 }
 
 func (s *service) UpdateAMConfigReceiver(ctx core.Context, req *request.UpdateAlertManagerConfigReceiver) error {
+	s.setStoredReceiver(ctx, req)
 	if !s.enableInnerReceiver {
 		return s.UpdateAMReceiverForExternalAM(ctx, req)
 	}
@@ -121,9 +123,28 @@ func (s *service) UpdateAMConfigReceiver(ctx core.Context, req *request.UpdateAl
 	return s.receivers.UpdateAMConfigReceiver(ctx, req.AMConfigReceiver, req.OldName)
 }
 
+// setStoredReceiver keeps the receiver before the update for the audit log.
+func (s *service) setStoredReceiver(ctx core.Context, req *request.UpdateAlertManagerConfigReceiver) {
+	filter := &request.AMConfigReceiverFilter{Name: req.OldName}
+	var receivers []amconfig.Receiver
+	if s.enableInnerReceiver {
+		receivers, _ = s.receivers.GetAMConfigReceiver(ctx, filter, nil)
+	} else {
+		receivers, _ = s.k8sApi.GetAMConfigReceiver(req.ClusterID, req.AMConfigFile, filter, nil, false)
+	}
+	for _, receiver := range receivers {
+		if receiver.Name == req.OldName {
+			stored := *req
+			stored.AMConfigReceiver = receiver
+			audit.SetStoredConfig(ctx, stored)
+			return
+		}
+	}
+}
+
 func (s *service) UpdateAMReceiverForExternalAM(ctx core.Context, req *request.UpdateAlertManagerConfigReceiver) error {
 	if req.Type != "dingtalk" {
-		return s.k8sApi.UpdateAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiver, req.OldName)
+		return s.k8sApi.UpdateAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiver, req.OldName)
 	}
 
 	if req.AMConfigReceiver.DingTalkConfigs == nil || len(req.AMConfigReceiver.DingTalkConfigs) == 0 {
@@ -132,7 +153,7 @@ func (s *service) UpdateAMReceiverForExternalAM(ctx core.Context, req *request.U
 
 	for i := range req.AMConfigReceiver.DingTalkConfigs {
 		// regard as a create option
-		uuid, err := s.addDingTalkWebhook(req.AMConfigReceiver.Name)
+		uuid, err := s.addDingTalkWebhook(req.ClusterID, req.AMConfigReceiver.Name)
 		req.AMConfigReceiver.DingTalkConfigs[i].UUID = uuid
 		req.AMConfigReceiver.DingTalkConfigs[i].AlertName = req.AMConfigReceiver.Name
 		req.AMConfigReceiver.DingTalkConfigs[i].ConfigFile = req.AMConfigFile
@@ -140,11 +161,11 @@ func (s *service) UpdateAMReceiverForExternalAM(ctx core.Context, req *request.U
 		err = s.dbRepo.UpdateDingTalkReceiver(ctx, req.AMConfigReceiver.DingTalkConfigs[i], req.OldName)
 		if err != nil {
 			// redo
-			s.k8sApi.DeleteAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiver.Name)
+			s.k8sApi.DeleteAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiver.Name)
 			return err
 		}
 		// remove old config
-		s.k8sApi.DeleteAMConfigReceiver(req.AMConfigFile, req.OldName)
+		s.k8sApi.DeleteAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.OldName)
 	}
 	return nil
 }
@@ -158,7 +179,7 @@ func (s *service) DeleteAMConfigReceiver(ctx core.Context, req *request.DeleteAl
 }
 
 func (s *service) DeleteAMReceiverForExternalAM(ctx core.Context, req *request.DeleteAlertManagerConfigReceiverRequest) error {
-	err := s.k8sApi.DeleteAMConfigReceiver(req.AMConfigFile, req.Name)
+	err := s.k8sApi.DeleteAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.Name)
 	if err != nil {
 		return err
 	}
@@ -168,15 +189,15 @@ func (s *service) DeleteAMReceiverForExternalAM(ctx core.Context, req *request.D
 	return s.dbRepo.DeleteDingTalkReceiver(ctx, req.AMConfigFile, req.Name)
 }
 
-func (s *service) addDingTalkWebhook(name string) (string, error) {
+func (s *service) addDingTalkWebhook(clusterID string, name string) (string, error) {
 	uuid := uuid2.New()
 	escapedUUID := url.PathEscape(uuid.String())
 	webhookURL := fmt.Sprintf(`http://apo-backend-svc:8080/api/alerts/outputs/dingtalk/%s`, escapedUUID)
 	webhookConfig := amconfig.NewWebhookConfig(webhookURL)
-	req := request.AddAlertManagerConfigReceiver{}
+	req := request.AddAlertManagerConfigReceiver{ClusterID: clusterID}
 	req.AMConfigReceiver.WebhookConfigs = []*amconfig.WebhookConfig{webhookConfig}
 	req.AMConfigReceiver.Name = name
-	err := s.k8sApi.AddAMConfigReceiver(req.AMConfigFile, req.AMConfigReceiver)
+	err := s.k8sApi.AddAMConfigReceiver(req.ClusterID, req.AMConfigFile, req.AMConfigReceiver)
 	if err != nil {
 		return "", err
 	}
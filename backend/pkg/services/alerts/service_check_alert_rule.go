@@ -11,7 +11,7 @@ import (
 
 func (s *service) CheckAlertRule(ctx core.Context, req *request.CheckAlertRuleRequest) (response.CheckAlertRuleResponse, error) {
 	var resp response.CheckAlertRuleResponse
-	find, err := s.k8sApi.CheckAlertRule(req.AlertRuleFile, req.Group, req.Alert)
+	find, err := s.k8sApi.CheckAlertRule(req.ClusterID, req.AlertRuleFile, req.Group, req.Alert)
 	if err != nil {
 		resp.Available = false
 		return resp, err
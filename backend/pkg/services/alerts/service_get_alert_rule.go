@@ -18,7 +18,7 @@ func (s *service) GetAlertRules(ctx core.Context, req *request.GetAlertRuleReque
 		}
 	}
 
-	rules, totalCount := s.k8sApi.GetAlertRules(req.AlertRuleFile, req.AlertRuleFilter, req.PageParam, req.RefreshCache)
+	rules, totalCount := s.k8sApi.GetAlertRules(req.ClusterID, req.AlertRuleFile, req.AlertRuleFilter, req.PageParam, req.RefreshCache)
 	return response.GetAlertRulesResponse{
 		AlertRules: rules,
 		Pagination: &model.Pagination{
@@ -31,7 +31,7 @@ func (s *service) GetAlertRules(ctx core.Context, req *request.GetAlertRuleReque
 
 // GetAlertRuleFile get alarm rules
 func (s *service) GetAlertRuleFile(ctx core.Context, req *request.GetAlertRuleConfigRequest) (*response.GetAlertRuleFileResponse, error) {
-	rules, err := s.k8sApi.GetAlertRuleConfigFile(req.AlertRuleFile)
+	rules, err := s.k8sApi.GetAlertRuleConfigFile(req.ClusterID, req.AlertRuleFile)
 	if err != nil {
 		return &response.GetAlertRuleFileResponse{AlertRules: map[string]string{}}, err
 	}
@@ -10,6 +10,7 @@ import (
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 )
 
 func (s *service) UpdateAlertRule(ctx core.Context, req *request.UpdateAlertRuleRequest) error {
@@ -24,15 +25,26 @@ func (s *service) UpdateAlertRule(ctx core.Context, req *request.UpdateAlertRule
 		req.AlertRule.Labels["groupId"] = strconv.FormatInt(req.GroupID, 10)
 	}
 
-	return s.k8sApi.UpdateAlertRule(req.AlertRuleFile, req.AlertRule, req.OldGroup, req.OldAlert)
+	filter := &request.AlertRuleFilter{Group: req.OldGroup, Alert: req.OldAlert}
+	rules, _ := s.k8sApi.GetAlertRules(req.ClusterID, req.AlertRuleFile, filter, nil, false)
+	for _, rule := range rules {
+		if rule.Group == req.OldGroup && rule.Alert == req.OldAlert {
+			stored := *req
+			stored.AlertRule = *rule
+			audit.SetStoredConfig(ctx, stored)
+			break
+		}
+	}
+
+	return s.k8sApi.UpdateAlertRule(req.ClusterID, req.AlertRuleFile, req.AlertRule, req.OldGroup, req.OldAlert)
 }
 
 func (s *service) DeleteAlertRule(ctx core.Context, req *request.DeleteAlertRuleRequest) error {
-	return s.k8sApi.DeleteAlertRule(req.AlertRuleFile, req.Group, req.Alert)
+	return s.k8sApi.DeleteAlertRule(req.ClusterID, req.AlertRuleFile, req.Group, req.Alert)
 }
 
 func (s *service) UpdateAlertRuleFile(ctx core.Context, req *request.UpdateAlertRuleConfigRequest) error {
-	return s.k8sApi.UpdateAlertRuleConfigFile(req.AlertRuleFile, []byte(req.Content))
+	return s.k8sApi.UpdateAlertRuleConfigFile(req.ClusterID, req.AlertRuleFile, []byte(req.Content))
 }
 
 // checkOrFillGroupsLabel check the correspondence between the group and the label. if the label is empty, fill it
@@ -0,0 +1,119 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+const (
+	// minBucketSamples is the minimum samples needed before a bucket is used,
+	// otherwise the bucket of the same hour-of-day is used.
+	minBucketSamples = 3
+	// madScale makes MAD a consistent estimator of the standard deviation under normal distribution.
+	madScale = 1.4826
+	// minRelativeDeviation avoids huge scores for flat series whose MAD is 0.
+	minRelativeDeviation = 0.05
+)
+
+// Stats is the robust statistics of one seasonal bucket.
+type Stats struct {
+	Median float64 `json:"median"`
+	MAD    float64 `json:"mad"`
+	Count  int     `json:"count"`
+}
+
+// Baseline is the seasonal pattern learned from history,
+// Weekly is indexed by hour-of-week (0 is Sunday 00:00) and Daily by hour-of-day.
+type Baseline struct {
+	Weekly map[int]Stats `json:"weekly"`
+	Daily  map[int]Stats `json:"daily"`
+}
+
+func hourOfWeek(t time.Time) int {
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// LearnBaseline builds the baseline from points whose timestamp is in microseconds.
+// NaN and Inf are ignored.
+func LearnBaseline(points []prometheus.Points) Baseline {
+	weekly := map[int][]float64{}
+	daily := map[int][]float64{}
+	for _, point := range points {
+		if math.IsNaN(point.Value) || math.IsInf(point.Value, 0) {
+			continue
+		}
+		t := time.UnixMicro(point.TimeStamp)
+		weekly[hourOfWeek(t)] = append(weekly[hourOfWeek(t)], point.Value)
+		daily[t.Hour()] = append(daily[t.Hour()], point.Value)
+	}
+
+	baseline := Baseline{
+		Weekly: make(map[int]Stats, len(weekly)),
+		Daily:  make(map[int]Stats, len(daily)),
+	}
+	for bucket, values := range weekly {
+		baseline.Weekly[bucket] = robustStats(values)
+	}
+	for bucket, values := range daily {
+		baseline.Daily[bucket] = robustStats(values)
+	}
+	return baseline
+}
+
+// Expected returns the stats of the bucket which t falls in, nil if not learned yet.
+func (b *Baseline) Expected(t time.Time) *Stats {
+	if stats, find := b.Weekly[hourOfWeek(t)]; find && stats.Count >= minBucketSamples {
+		return &stats
+	}
+	if stats, find := b.Daily[t.Hour()]; find && stats.Count >= minBucketSamples {
+		return &stats
+	}
+	return nil
+}
+
+// Score returns the robust z-score of value, positive when value is higher than usual.
+func Score(value float64, stats *Stats) float64 {
+	deviation := stats.MAD * madScale
+	if floor := math.Abs(stats.Median) * minRelativeDeviation; deviation < floor {
+		deviation = floor
+	}
+	if deviation == 0 {
+		if value == stats.Median {
+			return 0
+		}
+		deviation = 1e-9
+	}
+	return (value - stats.Median) / deviation
+}
+
+func robustStats(values []float64) Stats {
+	m := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - m)
+	}
+	return Stats{
+		Median: m,
+		MAD:    median(deviations),
+		Count:  len(values),
+	}
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
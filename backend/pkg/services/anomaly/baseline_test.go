@@ -0,0 +1,60 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+func TestLearnBaseline(t *testing.T) {
+	// 4 weeks of hourly samples, 100 in working hours and 10 otherwise, with one outlier
+	start := time.Date(2025, 1, 5, 0, 0, 0, 0, time.Local) // Sunday
+	var points []prometheus.Points
+	for ts := start; ts.Before(start.Add(28 * 24 * time.Hour)); ts = ts.Add(time.Hour) {
+		value := 10.0
+		if ts.Hour() >= 9 && ts.Hour() < 18 && ts.Weekday() != time.Sunday && ts.Weekday() != time.Saturday {
+			value = 100
+		}
+		points = append(points, prometheus.Points{TimeStamp: ts.UnixMicro(), Value: value})
+	}
+	points[34].Value = 10000 // Monday 10:00 of the first week
+	points = append(points, prometheus.Points{TimeStamp: start.UnixMicro(), Value: math.NaN()})
+
+	baseline := LearnBaseline(points)
+
+	monday := time.Date(2025, 3, 3, 10, 30, 0, 0, time.Local)
+	stats := baseline.Expected(monday)
+	if stats == nil || stats.Median != 100 || stats.Count != 4 {
+		t.Fatalf("unexpected stats of Monday 10:00: %+v", stats)
+	}
+	if score := Score(100, stats); score != 0 {
+		t.Errorf("expected score 0 for usual value, got %v", score)
+	}
+	if score := Score(200, stats); score < 3 {
+		t.Errorf("expected high score for doubled value, got %v", score)
+	}
+
+	sunday := time.Date(2025, 3, 2, 10, 0, 0, 0, time.Local)
+	if stats := baseline.Expected(sunday); stats == nil || stats.Median != 10 {
+		t.Errorf("unexpected stats of Sunday 10:00: %+v", stats)
+	}
+}
+
+func TestExpectedFallbackToDaily(t *testing.T) {
+	baseline := Baseline{
+		Weekly: map[int]Stats{25: {Median: 1, Count: 1}},
+		Daily:  map[int]Stats{1: {Median: 5, Count: 7}},
+	}
+	monday := time.Date(2025, 3, 3, 1, 0, 0, 0, time.Local)
+	if stats := baseline.Expected(monday); stats == nil || stats.Median != 5 {
+		t.Errorf("expected fallback to hour-of-day bucket, got %+v", stats)
+	}
+	if stats := baseline.Expected(monday.Add(time.Hour)); stats != nil {
+		t.Errorf("expected nil for unknown bucket, got %+v", stats)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// LearnBaselines learns the seasonal baselines of RED metrics from the history in Prometheus.
+	LearnBaselines(ctx core.Context, history time.Duration) error
+	// KeepLearning relearns the baselines periodically until ctx is done.
+	KeepLearning(ctx context.Context, history time.Duration, interval time.Duration)
+
+	// ScoreEndpoints compares RED metrics in [startTime, endTime] (microseconds) with the learned baselines.
+	// Scores of the whole service are keyed with empty ContentKey when gran is SVCGranularity.
+	ScoreEndpoints(ctx core.Context, startTime int64, endTime int64, gran prometheus.Granularity, filter prometheus.PQLFilter) (map[EndpointKey]*response.AnomalyScore, error)
+	GetAnomalyScores(ctx core.Context, req *request.GetAnomalyScoresRequest) (*response.GetAnomalyScoresResponse, error)
+
+	// GetAnomalyAlertRule generates an alert rule which fires when the anomaly score exceeds the threshold.
+	GetAnomalyAlertRule(ctx core.Context, req *request.GetAnomalyAlertRuleRequest) (*request.AlertRule, error)
+}
+
+type EndpointKey struct {
+	SvcName    string
+	ContentKey string
+}
+
+type service struct {
+	logger   *zap.Logger
+	promRepo prometheus.Repo
+	dbRepo   database.Repo
+}
+
+func New(logger *zap.Logger, promRepo prometheus.Repo, dbRepo database.Repo) Service {
+	return &service{
+		logger:   logger,
+		promRepo: promRepo,
+		dbRepo:   dbRepo,
+	}
+}
+
+// redTemplates are the metrics which baselines are learned for.
+var redTemplates = map[prometheus.MName]prometheus.PQLTemplate{
+	prometheus.LATENCY:    prometheus.PQLAvgLatencyWithPQLFilter,
+	prometheus.ERROR_RATE: prometheus.PQLAvgErrorRateWithPQLFilter,
+	prometheus.THROUGHPUT: prometheus.PQLAvgTPSWithPQLFilter,
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+const (
+	defaultScoreThreshold = 3
+	defaultBaselineWeeks  = 4
+	// ruleRange is the window of the metric compared with the baseline
+	ruleRange = "10m"
+)
+
+func (s *service) GetAnomalyAlertRule(ctx core.Context, req *request.GetAnomalyAlertRuleRequest) (*request.AlertRule, error) {
+	tpl, find := redTemplates[prometheus.MName(req.Metric)]
+	if !find {
+		return nil, core.Error(code.AnomalyMetricIllegalError, fmt.Sprintf("unsupported metric: %s", req.Metric))
+	}
+	threshold := req.Threshold
+	if threshold <= 0 {
+		threshold = defaultScoreThreshold
+	}
+	weeks := req.Weeks
+	if weeks <= 0 {
+		weeks = defaultBaselineWeeks
+	}
+
+	gran := prometheus.SVCGranularity
+	filter := prometheus.EqualFilter(prometheus.ServiceNameKey, req.ServiceName)
+	alertName := fmt.Sprintf("Anomaly-%s-%s", req.ServiceName, req.Metric)
+	if len(req.Endpoint) > 0 {
+		gran = prometheus.EndpointGranularity
+		filter.Equal(prometheus.ContentKeyKey, req.Endpoint)
+		alertName = fmt.Sprintf("Anomaly-%s-%s-%s", req.ServiceName, req.Endpoint, req.Metric)
+	}
+
+	score := prometheus.PQLAnomalyScore(tpl, weeks)(ruleRange, string(gran), filter, "")
+	thresholdStr := strconv.FormatFloat(threshold, 'g', -1, 64)
+	// Throughput is abnormal when it drops, latency and error rate when they rise
+	var expr string
+	if req.Metric == string(prometheus.THROUGHPUT) {
+		expr = fmt.Sprintf("%s < -%s", score, thresholdStr)
+	} else {
+		expr = fmt.Sprintf("%s > %s", score, thresholdStr)
+	}
+
+	return &request.AlertRule{
+		Group: kubernetes.MutationAppLabelVal,
+		Alert: alertName,
+		Expr:  expr,
+		For:   "5m",
+		Labels: map[string]string{
+			"group":    kubernetes.MutationAppLabelKey,
+			"severity": "warning",
+		},
+		Annotations: map[string]string{
+			"summary": fmt.Sprintf("%s of %s deviates from its baseline of the last %d weeks", req.Metric, req.ServiceName, weeks),
+		},
+	}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+// learnStep is the size of each sample, a sample is the average of the metric in one hour.
+const learnStep = time.Hour
+
+func (s *service) KeepLearning(ctx context.Context, history time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.LearnBaselines(core.EmptyCtx(), history); err != nil {
+			s.logger.Error("failed to learn anomaly baselines", zap.Error(err))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *service) LearnBaselines(ctx core.Context, history time.Duration) error {
+	endTime := time.Now().Truncate(learnStep)
+	startTime := endTime.Add(-history)
+
+	var errs []error
+	for _, gran := range []prometheus.Granularity{prometheus.SVCGranularity, prometheus.EndpointGranularity} {
+		for metric, tpl := range redTemplates {
+			results, err := s.promRepo.QueryRangeMetricsWithPQLFilter(ctx, tpl,
+				startTime.UnixMicro(), endTime.UnixMicro(), learnStep.Microseconds(),
+				gran, prometheus.NewFilter())
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			baselines := make([]database.AnomalyBaseline, 0, len(results))
+			for _, result := range results {
+				if len(result.Metric.SvcName) == 0 {
+					continue
+				}
+				content, err := json.Marshal(LearnBaseline(result.Values))
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				baselines = append(baselines, database.AnomalyBaseline{
+					ServiceName: result.Metric.SvcName,
+					Endpoint:    result.Metric.ContentKey,
+					Metric:      string(metric),
+					Baseline:    string(content),
+				})
+			}
+			if err := s.dbRepo.SaveAnomalyBaselines(ctx, baselines); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package anomaly
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+func (s *service) GetAnomalyScores(ctx core.Context, req *request.GetAnomalyScoresRequest) (*response.GetAnomalyScoresResponse, error) {
+	gran := prometheus.EndpointGranularity
+	if req.ServiceLevel {
+		gran = prometheus.SVCGranularity
+	}
+	filter := prometheus.NewFilter()
+	filter.EqualIfNotEmpty(prometheus.ServiceNameKey, req.ServiceName)
+	if !req.ServiceLevel {
+		filter.EqualIfNotEmpty(prometheus.ContentKeyKey, req.Endpoint)
+	}
+
+	scores, err := s.ScoreEndpoints(ctx, req.StartTime, req.EndTime, gran, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.GetAnomalyScoresResponse{
+		Scores: make([]response.EndpointAnomalyScore, 0, len(scores)),
+	}
+	for key, score := range scores {
+		resp.Scores = append(resp.Scores, response.EndpointAnomalyScore{
+			ServiceName:  key.SvcName,
+			Endpoint:     key.ContentKey,
+			AnomalyScore: *score,
+		})
+	}
+	sort.Slice(resp.Scores, func(i, j int) bool {
+		if resp.Scores[i].ServiceName != resp.Scores[j].ServiceName {
+			return resp.Scores[i].ServiceName < resp.Scores[j].ServiceName
+		}
+		return resp.Scores[i].Endpoint < resp.Scores[j].Endpoint
+	})
+	return resp, nil
+}
+
+func (s *service) ScoreEndpoints(ctx core.Context, startTime int64, endTime int64, gran prometheus.Granularity, filter prometheus.PQLFilter) (map[EndpointKey]*response.AnomalyScore, error) {
+	// Baselines are learned per hour, use the middle of the window to locate the bucket
+	at := time.UnixMicro(startTime + (endTime-startTime)/2)
+	scores := map[EndpointKey]*response.AnomalyScore{}
+
+	for metric, tpl := range redTemplates {
+		results, err := s.promRepo.QueryMetricsWithPQLFilter(ctx, tpl, startTime, endTime, gran, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 0 {
+			continue
+		}
+
+		baselines, err := s.getBaselines(ctx, results, metric)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			if len(result.Values) == 0 {
+				continue
+			}
+			value := result.Values[0].Value
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+
+			key := EndpointKey{SvcName: result.Metric.SvcName}
+			if gran != prometheus.SVCGranularity {
+				key.ContentKey = result.Metric.ContentKey
+			}
+			baseline, find := baselines[key]
+			if !find {
+				continue
+			}
+			stats := baseline.Expected(at)
+			if stats == nil {
+				continue
+			}
+
+			score := Score(value, stats)
+			anomalyScore, find := scores[key]
+			if !find {
+				anomalyScore = &response.AnomalyScore{}
+				scores[key] = anomalyScore
+			}
+			switch metric {
+			case prometheus.LATENCY:
+				anomalyScore.Latency = &score
+			case prometheus.ERROR_RATE:
+				anomalyScore.ErrorRate = &score
+			case prometheus.THROUGHPUT:
+				anomalyScore.TPS = &score
+			}
+		}
+	}
+	return scores, nil
+}
+
+func (s *service) getBaselines(ctx core.Context, results []prometheus.MetricResult, metric prometheus.MName) (map[EndpointKey]*Baseline, error) {
+	serviceSet := map[string]struct{}{}
+	for _, result := range results {
+		serviceSet[result.Metric.SvcName] = struct{}{}
+	}
+	serviceNames := make([]string, 0, len(serviceSet))
+	for svcName := range serviceSet {
+		serviceNames = append(serviceNames, svcName)
+	}
+
+	records, err := s.dbRepo.ListAnomalyBaselines(ctx, serviceNames, string(metric))
+	if err != nil {
+		return nil, err
+	}
+	baselines := make(map[EndpointKey]*Baseline, len(records))
+	for _, record := range records {
+		baseline := &Baseline{}
+		if err := json.Unmarshal([]byte(record.Baseline), baseline); err != nil {
+			continue
+		}
+		baselines[EndpointKey{SvcName: record.ServiceName, ContentKey: record.Endpoint}] = baseline
+	}
+	return baselines, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/amconfig"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+const secretFieldValue = "<secret>"
+
+// secretKeys holds json and form names of all fields marked with `secret:"true"`
+// in the request bodies of audited APIs.
+var secretKeys = collectSecretKeys(
+	integration.ClusterIntegration{},
+	amconfig.Receiver{},
+	request.LoginRequest{},
+	request.CreateUserRequest{},
+	request.UpdateUserPasswordRequest{},
+	request.ResetPasswordRequest{},
+)
+
+// secretValuePattern matches the secret fields of a JSON document and their string values,
+// the value may be cut at the end of a truncated body.
+var secretValuePattern = compileSecretValuePattern(secretKeys)
+
+func compileSecretValuePattern(keys map[string]struct{}) *regexp.Regexp {
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		names = append(names, regexp.QuoteMeta(key))
+	}
+	sort.Strings(names)
+	return regexp.MustCompile(`("(?:` + strings.Join(names, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|\\?$)`)
+}
+
+func collectSecretKeys(objs ...any) map[string]struct{} {
+	keys := map[string]struct{}{}
+	visited := map[reflect.Type]struct{}{}
+	for _, obj := range objs {
+		walkSecretFields(reflect.TypeOf(obj), keys, visited)
+	}
+	return keys
+}
+
+func walkSecretFields(typ reflect.Type, keys map[string]struct{}, visited map[reflect.Type]struct{}) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	if _, find := visited[typ]; find {
+		return
+	}
+	visited[typ] = struct{}{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Tag.Get("secret") == "true" {
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if len(name) == 0 {
+				name = field.Name
+			}
+			keys[name] = struct{}{}
+			if formName := strings.Split(field.Tag.Get("form"), ",")[0]; len(formName) > 0 {
+				keys[formName] = struct{}{}
+			}
+			continue
+		}
+		walkSecretFields(field.Type, keys, visited)
+	}
+}
+
+// RedactBody replaces the value of secret fields with "<secret>".
+// The body is a JSON document or an url-encoded form, other body is returned as is.
+func RedactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var obj any
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return redactForm(body)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redact(obj)); err != nil {
+		return body
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// RedactTruncatedBody redacts the head of a large body, which can not be parsed as a JSON document.
+func RedactTruncatedBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return redactForm(body)
+	}
+	return secretValuePattern.ReplaceAll(body, []byte(`${1}"`+secretFieldValue+`"`))
+}
+
+// redactForm redacts the url-encoded form, the body is kept as is if there is no secret field.
+func redactForm(body []byte) []byte {
+	// the pairs before an illegal one are still returned
+	values, _ := url.ParseQuery(string(body))
+	found := false
+	for key, value := range values {
+		if _, isSecret := secretKeys[key]; !isSecret {
+			continue
+		}
+		found = true
+		for i := range value {
+			if len(value[i]) > 0 {
+				value[i] = secretFieldValue
+			}
+		}
+	}
+	if !found {
+		return body
+	}
+	return []byte(values.Encode())
+}
+
+func redact(obj any) any {
+	switch v := obj.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, isSecret := secretKeys[key]; isSecret {
+				if s, ok := value.(string); ok && len(s) == 0 {
+					continue
+				}
+				v[key] = secretFieldValue
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return obj
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old,omitempty"`
+	New   any    `json:"new,omitempty"`
+}
+
+// DiffBody compares the request body with the stored configuration and returns the changed fields,
+// nested fields are flattened as "a.b.0.c". Stored fields are only compared when the body
+// contains their top-level field, as other fields are not changed by the request.
+func DiffBody(storedBody []byte, body []byte) []FieldChange {
+	var storedObj, obj any
+	if err := json.Unmarshal(storedBody, &storedObj); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil
+	}
+
+	storedFields := map[string]any{}
+	fields := map[string]any{}
+	flatten("", storedObj, storedFields)
+	flatten("", obj, fields)
+
+	topFields := map[string]struct{}{}
+	for field := range fields {
+		topFields[strings.SplitN(field, ".", 2)[0]] = struct{}{}
+	}
+
+	var changes []FieldChange
+	for field, value := range fields {
+		storedValue, find := storedFields[field]
+		if !find || !reflect.DeepEqual(storedValue, value) {
+			changes = append(changes, FieldChange{Field: field, Old: storedValue, New: value})
+		}
+	}
+	for field, storedValue := range storedFields {
+		if _, find := fields[field]; find {
+			continue
+		}
+		if _, find := topFields[strings.SplitN(field, ".", 2)[0]]; find {
+			changes = append(changes, FieldChange{Field: field, Old: storedValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func flatten(prefix string, obj any, fields map[string]any) {
+	join := func(key string) string {
+		if len(prefix) == 0 {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v := obj.(type) {
+	case map[string]any:
+		for key, value := range v {
+			flatten(join(key), value, fields)
+		}
+	case []any:
+		for i, value := range v {
+			flatten(join(strconv.Itoa(i)), value, fields)
+		}
+	default:
+		fields[prefix] = v
+	}
+}
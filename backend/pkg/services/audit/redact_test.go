@@ -0,0 +1,88 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"clusterId":"c1","trace":{"traceAPI":{"jaeger":{"address":"a","user":"root","password":"123"}}},"log":{"logAPI":{"clickhouse":{"password":""}}}}`)
+	redacted := string(RedactBody(body))
+
+	assert.Contains(t, redacted, `"user":"<secret>"`)
+	assert.Contains(t, redacted, `"password":"<secret>"`)
+	assert.Contains(t, redacted, `"password":""`)
+	assert.Contains(t, redacted, `"address":"a"`)
+	assert.NotContains(t, redacted, "123")
+
+}
+
+func TestRedactFormBody(t *testing.T) {
+	form := []byte("email=a%40b.com&password=p%40ss&confirmPassword=p%40ss&corporation=")
+	redacted := string(RedactBody(form))
+	assert.NotContains(t, redacted, "p%40ss")
+	assert.Contains(t, redacted, "password=%3Csecret%3E")
+	assert.Contains(t, redacted, "confirmPassword=%3Csecret%3E")
+	assert.Contains(t, redacted, "email=a%40b.com")
+
+	reset := string(RedactBody([]byte("userId=1&newPassword=abc&confirmPassword=abc")))
+	assert.NotContains(t, reset, "abc")
+
+	plain := []byte("a=b")
+	assert.Equal(t, plain, RedactBody(plain))
+}
+
+func TestDiffBody(t *testing.T) {
+	storedBody := []byte(`{"name":"rule","labels":{"severity":"warning","team":"a"},"for":"1m","createdAt":1}`)
+	body := []byte(`{"name":"rule","labels":{"severity":"critical"},"for":"1m","keepFiringFor":"5m"}`)
+
+	changes := DiffBody(storedBody, body)
+	assert.Equal(t, []FieldChange{
+		{Field: "keepFiringFor", New: "5m"},
+		{Field: "labels.severity", Old: "warning", New: "critical"},
+		{Field: "labels.team", Old: "a"},
+	}, changes)
+}
+
+type fakeAuditRepo struct {
+	database.Repo
+	auditLog *database.AuditLog
+}
+
+func (f *fakeAuditRepo) CreateAuditLog(ctx core.Context, auditLog *database.AuditLog) error {
+	f.auditLog = auditLog
+	return nil
+}
+
+func TestRecordDiffWithStoredConfig(t *testing.T) {
+	repo := &fakeAuditRepo{}
+	s := New(zap.NewNop(), repo)
+	ctx := core.EmptyCtx()
+
+	stored := map[string]any{"name": "webhook", "password": "old"}
+	SetStoredConfig(ctx, stored)
+	stored["name"] = "changed after set"
+
+	body := []byte(`{"name":"webhook2","password":"new"}`)
+	err := s.Record(ctx, &database.AuditLog{Success: true}, body)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"field":"name","old":"webhook","new":"webhook2"}]`, repo.auditLog.Diff)
+	assert.NotContains(t, repo.auditLog.Body, "new\"")
+}
+
+func TestRedactTruncatedBody(t *testing.T) {
+	body := []byte(`{"clusterId":"c1","trace":{"traceAPI":{"jaeger":{"user":"root","password":"12\"3"}}},"log":{"password":"abc`)
+	redacted := string(RedactTruncatedBody(body))
+
+	assert.Equal(t, `{"clusterId":"c1","trace":{"traceAPI":{"jaeger":{"user":"<secret>","password":"<secret>"}}},"log":{"password":"<secret>"`, redacted)
+
+	form := string(RedactTruncatedBody([]byte("email=a%40b.com&password=p%40s")))
+	assert.NotContains(t, form, "p%40s")
+}
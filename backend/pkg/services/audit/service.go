@@ -0,0 +1,38 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// Record saves an audit log, the body is redacted and compared with
+	// the configuration stored before the request, which is set by SetStoredConfig.
+	Record(ctx core.Context, auditLog *database.AuditLog, body []byte) error
+	GetAuditLogs(ctx core.Context, req *request.GetAuditLogRequest) (*response.GetAuditLogResponse, error)
+	// KeepRetention removes expired audit logs periodically until ctx is done.
+	KeepRetention(ctx context.Context, retention time.Duration, interval time.Duration)
+}
+
+type service struct {
+	logger *zap.Logger
+	dbRepo database.Repo
+}
+
+func New(logger *zap.Logger, dbRepo database.Repo) Service {
+	return &service{
+		logger: logger,
+		dbRepo: dbRepo,
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+)
+
+func (s *service) GetAuditLogs(ctx core.Context, req *request.GetAuditLogRequest) (*response.GetAuditLogResponse, error) {
+	auditLogs, count, err := s.dbRepo.GetAuditLogs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.GetAuditLogResponse{
+		AuditLogs: auditLogs,
+		Pagination: model.Pagination{
+			Total: count,
+		},
+	}
+	if req.PageParam != nil {
+		resp.CurrentPage = req.CurrentPage
+		resp.PageSize = req.PageSize
+	}
+	return resp, nil
+}
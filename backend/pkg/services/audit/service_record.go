@@ -0,0 +1,33 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) Record(ctx core.Context, auditLog *database.AuditLog, body []byte) error {
+	var redacted []byte
+	if auditLog.BodyTruncated {
+		redacted = RedactTruncatedBody(body)
+	} else {
+		redacted = RedactBody(body)
+	}
+	auditLog.Body = string(redacted)
+
+	if storedBody, find := getStoredConfig(ctx); find && auditLog.Success && len(redacted) > 0 {
+		changes := DiffBody(RedactBody(storedBody), redacted)
+		if len(changes) > 0 {
+			diff, err := json.Marshal(changes)
+			if err != nil {
+				return err
+			}
+			auditLog.Diff = string(diff)
+		}
+	}
+	return s.dbRepo.CreateAuditLog(ctx, auditLog)
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"go.uber.org/zap"
+)
+
+func (s *service) KeepRetention(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.cleanExpired(retention)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *service) cleanExpired(retention time.Duration) {
+	expireBefore := time.Now().Add(-retention).UnixMicro()
+	deleted, err := s.dbRepo.DeleteAuditLogBefore(core.EmptyCtx(), expireBefore)
+	if err != nil {
+		s.logger.Error("failed to clean expired audit log", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		s.logger.Info("clean expired audit log", zap.Int64("deleted", deleted))
+	}
+}
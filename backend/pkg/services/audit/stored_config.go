@@ -0,0 +1,39 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+const storedConfigKey = "_auditStoredConfig"
+
+// SetStoredConfig keeps the configuration stored before the request changes it,
+// the audit log of the request records the changes of the request body compared with it.
+// The config should be in the same shape as the request body, e.g. the request filled with the stored values.
+// It is encoded at once, so later changes of the config do not affect the audit log.
+func SetStoredConfig(ctx core.Context, config any) {
+	if ctx == nil {
+		return
+	}
+	storedBody, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+	ctx.Set(storedConfigKey, storedBody)
+}
+
+func getStoredConfig(ctx core.Context) ([]byte, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	stored, find := ctx.Get(storedConfigKey)
+	if !find {
+		return nil, false
+	}
+	storedBody, ok := stored.([]byte)
+	return storedBody, ok
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// annotationServiceName maps the component to the APO service when the names are different
+	annotationServiceName = "apo/service-name"
+	annotationTier        = "apo/tier"
+	annotationRunbookURL  = "apo/runbook-url"
+	annotationOnCall      = "apo/oncall"
+
+	annotationSourceLocation = "backstage.io/source-location"
+	annotationGithubSlug     = "github.com/project-slug"
+)
+
+// backstageEntity is the part of the Backstage catalog entity used by the service catalog.
+type backstageEntity struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name        string            `yaml:"name"`
+		Description string            `yaml:"description"`
+		Annotations map[string]string `yaml:"annotations"`
+		Labels      map[string]string `yaml:"labels"`
+		Tags        []string          `yaml:"tags"`
+		Links       []struct {
+			URL   string `yaml:"url"`
+			Title string `yaml:"title"`
+			Type  string `yaml:"type"`
+		} `yaml:"links"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Owner string `yaml:"owner"`
+	} `yaml:"spec"`
+}
+
+// catalogEntry is a service catalog parsed from a component, Owner is the name of the owner team.
+type catalogEntry struct {
+	Owner   string
+	Catalog database.ServiceCatalog
+}
+
+// parseBackstageCatalog parses the Component entities in the yaml documents, other kinds are skipped.
+func parseBackstageCatalog(content string) ([]catalogEntry, []response.SkippedCatalogEntity, error) {
+	var entries []catalogEntry
+	var skipped []response.SkippedCatalogEntity
+
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	for {
+		var entity backstageEntity
+		err := decoder.Decode(&entity)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid catalog yaml: %w", err)
+		}
+		if len(entity.Kind) == 0 && len(entity.Metadata.Name) == 0 {
+			// empty document
+			continue
+		}
+		if !strings.EqualFold(entity.Kind, "Component") {
+			skipped = append(skipped, response.SkippedCatalogEntity{
+				Name:   entity.Metadata.Name,
+				Reason: fmt.Sprintf("kind %s is not Component", entity.Kind),
+			})
+			continue
+		}
+
+		entry := toCatalogEntry(&entity)
+		if len(entry.Catalog.Service) == 0 {
+			skipped = append(skipped, response.SkippedCatalogEntity{Reason: "metadata.name is empty"})
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped, nil
+}
+
+func toCatalogEntry(entity *backstageEntity) catalogEntry {
+	annotations := entity.Metadata.Annotations
+
+	service := entity.Metadata.Name
+	if name := annotations[annotationServiceName]; len(name) > 0 {
+		service = name
+	}
+	tier := annotations[annotationTier]
+	if len(tier) == 0 {
+		tier = entity.Metadata.Labels["tier"]
+	}
+
+	return catalogEntry{
+		Owner: ownerName(entity.Spec.Owner),
+		Catalog: database.ServiceCatalog{
+			Service:       service,
+			Tier:          tier,
+			Description:   entity.Metadata.Description,
+			RepositoryURL: repositoryURL(annotations),
+			RunbookURL:    runbookURL(entity),
+			OnCall:        annotations[annotationOnCall],
+			Tags:          integration.JSONField[[]string]{Obj: entity.Metadata.Tags},
+		},
+	}
+}
+
+// ownerName returns the name of the entity reference, e.g. team-a of group:default/team-a.
+func ownerName(ref string) string {
+	if idx := strings.Index(ref, ":"); idx >= 0 {
+		ref = ref[idx+1:]
+	}
+	if idx := strings.LastIndex(ref, "/"); idx >= 0 {
+		ref = ref[idx+1:]
+	}
+	return ref
+}
+
+func repositoryURL(annotations map[string]string) string {
+	if location := annotations[annotationSourceLocation]; len(location) > 0 {
+		return strings.TrimPrefix(location, "url:")
+	}
+	if slug := annotations[annotationGithubSlug]; len(slug) > 0 {
+		return "https://github.com/" + slug
+	}
+	return ""
+}
+
+func runbookURL(entity *backstageEntity) string {
+	if url := entity.Metadata.Annotations[annotationRunbookURL]; len(url) > 0 {
+		return url
+	}
+	for _, link := range entity.Metadata.Links {
+		if strings.EqualFold(link.Type, "runbook") || strings.Contains(strings.ToLower(link.Title), "runbook") {
+			return link.URL
+		}
+	}
+	return ""
+}
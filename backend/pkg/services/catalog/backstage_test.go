@@ -0,0 +1,84 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+const catalogInfo = `
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: payment
+  description: Payment gateway
+  annotations:
+    apo/service-name: payment-service
+    apo/oncall: "#payment-oncall"
+    backstage.io/source-location: url:https://git.example.com/payment
+  labels:
+    tier: tier-1
+  tags: [java, core]
+  links:
+    - url: https://wiki.example.com/payment/runbook
+      title: Payment Runbook
+spec:
+  type: service
+  owner: group:default/team-pay
+---
+apiVersion: backstage.io/v1alpha1
+kind: Group
+metadata:
+  name: team-pay
+---
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: order
+  annotations:
+    apo/tier: tier-2
+    apo/runbook-url: https://wiki.example.com/order
+    github.com/project-slug: example/order
+spec:
+  owner: team-order
+---
+`
+
+func TestParseBackstageCatalog(t *testing.T) {
+	entries, skipped, err := parseBackstageCatalog(catalogInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if len(skipped) != 1 || skipped[0].Name != "team-pay" {
+		t.Errorf("expected the group to be skipped, got %v", skipped)
+	}
+
+	payment := entries[0]
+	if payment.Owner != "team-pay" {
+		t.Errorf("unexpected owner %s", payment.Owner)
+	}
+	c := payment.Catalog
+	if c.Service != "payment-service" || c.Tier != "tier-1" || c.Description != "Payment gateway" ||
+		c.RepositoryURL != "https://git.example.com/payment" || c.RunbookURL != "https://wiki.example.com/payment/runbook" ||
+		c.OnCall != "#payment-oncall" || !reflect.DeepEqual(c.Tags.Obj, []string{"java", "core"}) {
+		t.Errorf("unexpected payment catalog %+v", c)
+	}
+
+	order := entries[1]
+	c = order.Catalog
+	if order.Owner != "team-order" || c.Service != "order" || c.Tier != "tier-2" ||
+		c.RepositoryURL != "https://github.com/example/order" || c.RunbookURL != "https://wiki.example.com/order" {
+		t.Errorf("unexpected order catalog %+v, owner %s", c, order.Owner)
+	}
+}
+
+func TestParseBackstageCatalogInvalid(t *testing.T) {
+	if _, _, err := parseBackstageCatalog("kind: [Component"); err == nil {
+		t.Error("expected error for invalid yaml")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// SaveServiceCatalog creates or replaces the catalog of the service.
+	SaveServiceCatalog(ctx core.Context, req *request.SaveServiceCatalogRequest) error
+	DeleteServiceCatalog(ctx core.Context, req *request.DeleteServiceCatalogRequest) error
+	// GetServiceCatalog returns nil if the service is not in the catalog.
+	GetServiceCatalog(ctx core.Context, req *request.GetServiceCatalogRequest) (*database.ServiceCatalog, error)
+	ListServiceCatalogs(ctx core.Context, req *request.ListServiceCatalogsRequest) (*response.ListServiceCatalogsResponse, error)
+	// ImportServiceCatalog saves the Component entities of the Backstage catalog-info.yaml.
+	ImportServiceCatalog(ctx core.Context, req *request.ImportServiceCatalogRequest) (*response.ImportServiceCatalogResponse, error)
+}
+
+type service struct {
+	dbRepo database.Repo
+}
+
+func New(dbRepo database.Repo) Service {
+	return &service{
+		dbRepo: dbRepo,
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) SaveServiceCatalog(ctx core.Context, req *request.SaveServiceCatalogRequest) error {
+	if req.TeamID > 0 {
+		exists, err := s.dbRepo.TeamExist(ctx, model.TeamFilter{ID: req.TeamID})
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return core.Error(code.TeamNotExistError, "team does not exist")
+		}
+	}
+
+	catalog := database.ServiceCatalog{
+		Service:       req.Service,
+		TeamID:        req.TeamID,
+		Tier:          req.Tier,
+		Description:   req.Description,
+		RepositoryURL: req.RepositoryURL,
+		RunbookURL:    req.RunbookURL,
+		OnCall:        req.OnCall,
+		Tags:          integration.JSONField[[]string]{Obj: req.Tags},
+	}
+	return s.dbRepo.SaveServiceCatalogs(ctx, []database.ServiceCatalog{catalog})
+}
+
+func (s *service) DeleteServiceCatalog(ctx core.Context, req *request.DeleteServiceCatalogRequest) error {
+	return s.dbRepo.DeleteServiceCatalog(ctx, req.Service)
+}
+
+func (s *service) GetServiceCatalog(ctx core.Context, req *request.GetServiceCatalogRequest) (*database.ServiceCatalog, error) {
+	return s.dbRepo.GetServiceCatalog(ctx, req.Service)
+}
+
+func (s *service) ListServiceCatalogs(ctx core.Context, req *request.ListServiceCatalogsRequest) (*response.ListServiceCatalogsResponse, error) {
+	catalogs, err := s.dbRepo.ListServiceCatalogs(ctx, req.Services, req.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListServiceCatalogsResponse{Catalogs: catalogs}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package catalog
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) ImportServiceCatalog(ctx core.Context, req *request.ImportServiceCatalogRequest) (*response.ImportServiceCatalogResponse, error) {
+	entries, skipped, err := parseBackstageCatalog(req.Content)
+	if err != nil {
+		return nil, core.Error(code.ImportServiceCatalogError, err.Error())
+	}
+
+	var owners []string
+	for _, entry := range entries {
+		if len(entry.Owner) > 0 {
+			owners = append(owners, entry.Owner)
+		}
+	}
+	teamIDs := make(map[string]int64)
+	if len(owners) > 0 {
+		teams, err := s.dbRepo.GetTeamsByName(ctx, owners)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			teamIDs[team.TeamName] = team.TeamID
+		}
+	}
+
+	resp := &response.ImportServiceCatalogResponse{
+		Imported:     []string{},
+		Skipped:      skipped,
+		UnknownTeams: []string{},
+	}
+	if resp.Skipped == nil {
+		resp.Skipped = []response.SkippedCatalogEntity{}
+	}
+	unknown := make(map[string]bool)
+	// the later component wins if a service is defined more than once
+	indexes := make(map[string]int)
+	catalogs := make([]database.ServiceCatalog, 0, len(entries))
+	for _, entry := range entries {
+		teamID, find := teamIDs[entry.Owner]
+		if !find && len(entry.Owner) > 0 && !unknown[entry.Owner] {
+			unknown[entry.Owner] = true
+			resp.UnknownTeams = append(resp.UnknownTeams, entry.Owner)
+		}
+		entry.Catalog.TeamID = teamID
+		if idx, find := indexes[entry.Catalog.Service]; find {
+			catalogs[idx] = entry.Catalog
+			continue
+		}
+		indexes[entry.Catalog.Service] = len(catalogs)
+		catalogs = append(catalogs, entry.Catalog)
+		resp.Imported = append(resp.Imported, entry.Catalog.Service)
+	}
+
+	if err := s.dbRepo.SaveServiceCatalogs(ctx, catalogs); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// GetChangeEvents returns the changes of the service or in the namespace, used to annotate the charts and alerts.
+	GetChangeEvents(ctx core.Context, req *request.GetChangeEventsRequest) (*response.GetChangeEventsResponse, error)
+	// ReportChangeEvent saves the change sent by the CI/CD systems.
+	ReportChangeEvent(ctx core.Context, req *request.ReportChangeEventRequest) error
+	// WatchKubernetes records the changes of the workloads and configMaps until ctx is done.
+	WatchKubernetes(ctx context.Context)
+	// KeepRetention removes expired change events periodically until ctx is done.
+	KeepRetention(ctx context.Context, retention time.Duration, interval time.Duration)
+}
+
+type service struct {
+	logger   *zap.Logger
+	dbRepo   database.Repo
+	promRepo prometheus.Repo
+	k8sRepo  kubernetes.Repo
+}
+
+func New(logger *zap.Logger, dbRepo database.Repo, promRepo prometheus.Repo, k8sRepo kubernetes.Repo) Service {
+	return &service{
+		logger:   logger,
+		dbRepo:   dbRepo,
+		promRepo: promRepo,
+		k8sRepo:  k8sRepo,
+	}
+}
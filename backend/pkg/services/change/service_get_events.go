@@ -0,0 +1,140 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"strconv"
+	"strings"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+)
+
+// podRef is a pod running the service, clusterID is the cluster watched for the pod.
+type podRef struct {
+	clusterID string
+	namespace string
+	name      string
+}
+
+func (s *service) GetChangeEvents(ctx core.Context, req *request.GetChangeEventsRequest) (*response.GetChangeEventsResponse, error) {
+	filter := &database.ChangeEventFilter{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Types:     req.Types,
+		ClusterID: req.ClusterID,
+	}
+	if len(req.Service) == 0 {
+		filter.Namespaces = []string{req.Namespace}
+		events, err := s.dbRepo.ListChangeEvents(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &response.GetChangeEventsResponse{Events: events}, nil
+	}
+
+	pods, err := s.servicePods(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	filter.Services = []string{req.Service}
+	for _, pod := range pods {
+		filter.Namespaces = appendUnique(filter.Namespaces, pod.namespace)
+	}
+	events, err := s.dbRepo.ListChangeEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &response.GetChangeEventsResponse{Events: filterServiceEvents(events, req.Service, pods)}, nil
+}
+
+// servicePods returns the pods of the service instances, limited to the namespace if set.
+func (s *service) servicePods(ctx core.Context, req *request.GetChangeEventsRequest) ([]podRef, error) {
+	instances, err := s.promRepo.GetActiveInstanceList(ctx, req.StartTime, req.EndTime, []string{req.Service})
+	if err != nil || instances == nil {
+		return nil, err
+	}
+
+	var pods []podRef
+	for _, instance := range instances.GetInstances() {
+		if len(instance.PodName) == 0 || len(instance.Namespace) == 0 {
+			continue
+		}
+		if len(req.Namespace) > 0 && instance.Namespace != req.Namespace {
+			continue
+		}
+		// the changes of unregistered clusters are watched in the default one
+		clusterID := kubernetes.DefaultClusterID
+		if len(instance.ClusterID) > 0 && s.k8sRepo.HasCluster(instance.ClusterID) {
+			clusterID = instance.ClusterID
+		}
+		pods = append(pods, podRef{clusterID: clusterID, namespace: instance.Namespace, name: instance.PodName})
+	}
+	return pods, nil
+}
+
+// filterServiceEvents keeps the changes reported for the service and the changes of the kubernetes objects related to its pods:
+// the workloads owning the pods, and the configMaps or namespace-wide changes in the same namespaces.
+func filterServiceEvents(events []model.ChangeEvent, service string, pods []podRef) []model.ChangeEvent {
+	res := make([]model.ChangeEvent, 0, len(events))
+	for _, event := range events {
+		if event.Service == service || relatedToPods(&event, pods) {
+			res = append(res, event)
+		}
+	}
+	return res
+}
+
+func relatedToPods(event *model.ChangeEvent, pods []podRef) bool {
+	for _, pod := range pods {
+		if pod.namespace != event.Namespace {
+			continue
+		}
+		// the webhook may report the changes without cluster
+		if event.Source == model.ChangeSourceKubernetes && pod.clusterID != event.ClusterID {
+			continue
+		}
+		switch event.Kind {
+		case "", "ConfigMap":
+			return true
+		default:
+			if ownsPod(event.Kind, event.Name, pod.name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ownsPod checks the name of the pod generated by the workload,
+// e.g. <deployment>-<hash>-<id>, <statefulset>-<ordinal> or <daemonset>-<id>.
+func ownsPod(kind string, workload string, pod string) bool {
+	if len(workload) == 0 || !strings.HasPrefix(pod, workload+"-") {
+		return false
+	}
+	suffix := pod[len(workload)+1:]
+	switch kind {
+	case "Deployment":
+		return strings.Count(suffix, "-") == 1
+	case "StatefulSet":
+		_, err := strconv.Atoi(suffix)
+		return err == nil
+	case "DaemonSet":
+		return !strings.Contains(suffix, "-")
+	}
+	return true
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+)
+
+func TestFilterServiceEvents(t *testing.T) {
+	pods := []podRef{
+		{clusterID: "", namespace: "shop", name: "cart-7d9f8c6b5-x2k4p"},
+		{clusterID: "c1", namespace: "shop", name: "cart-0"},
+		{clusterID: "", namespace: "shop", name: "cart-canary-5c6d7e8f9-abcde"},
+	}
+	events := []model.ChangeEvent{
+		{ID: 1, Source: model.ChangeSourceWebhook, Service: "cart", Version: "v1.2.3"},
+		{ID: 2, Source: model.ChangeSourceKubernetes, Namespace: "shop", Kind: "Deployment", Name: "cart"},
+		{ID: 3, Source: model.ChangeSourceKubernetes, Namespace: "shop", Kind: "Deployment", Name: "cart-worker"},
+		{ID: 4, Source: model.ChangeSourceKubernetes, ClusterID: "c1", Namespace: "shop", Kind: "StatefulSet", Name: "cart"},
+		{ID: 5, Source: model.ChangeSourceKubernetes, ClusterID: "c2", Namespace: "shop", Kind: "StatefulSet", Name: "cart"},
+		{ID: 6, Source: model.ChangeSourceKubernetes, Namespace: "shop", Kind: "ConfigMap", Name: "cart-config"},
+		{ID: 7, Source: model.ChangeSourceKubernetes, Namespace: "payment", Kind: "ConfigMap", Name: "payment-config"},
+		{ID: 8, Source: model.ChangeSourceWebhook, Namespace: "shop", Service: "payment", Kind: "Deployment", Name: "payment"},
+		{ID: 9, Source: model.ChangeSourceWebhook, Namespace: "shop"},
+		{ID: 10, Source: model.ChangeSourceKubernetes, Namespace: "shop", Kind: "DaemonSet", Name: "cart"},
+		{ID: 11, Source: model.ChangeSourceKubernetes, Namespace: "shop", Kind: "Deployment", Name: "cart-canary"},
+	}
+
+	got := filterServiceEvents(events, "cart", pods)
+	want := []int64{1, 2, 4, 6, 9, 11}
+	if len(got) != len(want) {
+		t.Fatalf("events = %+v, want ids %v", got, want)
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("events[%d].ID = %d, want %d", i, got[i].ID, id)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+func (s *service) ReportChangeEvent(ctx core.Context, req *request.ReportChangeEventRequest) error {
+	event := &model.ChangeEvent{
+		Source:    model.ChangeSourceWebhook,
+		Type:      req.Type,
+		ClusterID: req.ClusterID,
+		Namespace: req.Namespace,
+		Kind:      req.Kind,
+		Name:      req.Name,
+		Service:   req.Service,
+		Version:   req.Version,
+		Summary:   req.Summary,
+		Author:    req.Author,
+		URL:       req.URL,
+		Timestamp: req.Timestamp,
+	}
+	if len(event.Type) == 0 {
+		event.Type = model.ChangeTypeDeploy
+	}
+	if len(event.Summary) == 0 && len(event.Version) > 0 {
+		event.Summary = event.Type + " " + event.Version
+	}
+	if event.Timestamp <= 0 {
+		event.Timestamp = time.Now().UnixMicro()
+	}
+	return s.dbRepo.CreateChangeEvent(ctx, event)
+}
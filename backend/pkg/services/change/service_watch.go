@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package change
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"go.uber.org/zap"
+)
+
+func (s *service) WatchKubernetes(ctx context.Context) {
+	s.k8sRepo.WatchChanges(ctx, func(event *model.ChangeEvent) {
+		if err := s.dbRepo.CreateChangeEvent(core.EmptyCtx(), event); err != nil {
+			s.logger.Error("failed to save change event",
+				zap.String("kind", event.Kind), zap.String("name", event.Name), zap.Error(err))
+		}
+	})
+}
+
+func (s *service) KeepRetention(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.cleanExpired(retention)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *service) cleanExpired(retention time.Duration) {
+	expireBefore := time.Now().Add(-retention).UnixMicro()
+	deleted, err := s.dbRepo.DeleteChangeEventBefore(core.EmptyCtx(), expireBefore)
+	if err != nil {
+		s.logger.Error("failed to clean expired change events", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		s.logger.Info("clean expired change events", zap.Int64("deleted", deleted))
+	}
+}
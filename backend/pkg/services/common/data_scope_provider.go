@@ -0,0 +1,79 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"slices"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+var _ clickhouse.DataScopeProvider = (*dataScopeProvider)(nil)
+
+// dataScopeProvider resolves the data scopes of the users from the data groups assigned to them and their teams.
+type dataScopeProvider struct {
+	dbRepo database.Repo
+}
+
+func NewDataScopeProvider(dbRepo database.Repo) clickhouse.DataScopeProvider {
+	return &dataScopeProvider{dbRepo: dbRepo}
+}
+
+func (p *dataScopeProvider) UserDataScope(ctx core.Context, userID int64, category string) (*datagroup.DataScopeTreeNode, bool, error) {
+	isAdmin, err := p.isAdmin(ctx, userID)
+	if err != nil {
+		return nil, true, err
+	}
+	if isAdmin {
+		return nil, false, nil
+	}
+
+	groupIDs, err := p.dbRepo.GetDataGroupIDsByUserId(ctx, userID)
+	if err != nil {
+		return nil, true, err
+	}
+	// the root group contains all data
+	if slices.Contains(groupIDs, 0) {
+		return nil, false, nil
+	}
+	if len(groupIDs) == 0 {
+		return nil, true, nil
+	}
+	selected, err := p.dbRepo.GetScopeIDsSelectedByPermGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, true, err
+	}
+	if len(selected) == 0 {
+		return nil, true, nil
+	}
+	if DataGroupStorage == nil || DataGroupStorage.DataScopeTree == nil {
+		return nil, true, errors.New("data scopes are not loaded")
+	}
+	scope, _ := DataGroupStorage.CloneWithCategory(selected, category)
+	return scope, true, nil
+}
+
+func (p *dataScopeProvider) isAdmin(ctx core.Context, userID int64) (bool, error) {
+	userRoles, err := p.dbRepo.GetUserRole(ctx, userID)
+	if err != nil || len(userRoles) == 0 {
+		return false, err
+	}
+	roles, err := p.dbRepo.GetRoles(ctx, model.RoleFilter{Name: model.ROLE_ADMIN})
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		for _, userRole := range userRoles {
+			if userRole.RoleID == role.RoleID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -126,3 +126,34 @@ func ConvertScopeNodeToPQLFilter(scopeNode *datagroup.DataScopeTreeNode) prometh
 
 	return prometheus.Or(filters...)
 }
+
+// CutTopologyGraphInGroup removes the services out of the group and their edges from the graph.
+func CutTopologyGraphInGroup(ctx core.Context, dbRepo database.Repo, groupID int64, graph *TopologyGraph) error {
+	if groupID == 0 {
+		return nil
+	}
+
+	selected, err := dbRepo.GetScopeIDsSelectedByGroupID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	svcList := DataGroupStorage.GetFullPermissionSvcList(selected)
+	removed := make(map[string]bool)
+	nodes := make([]*TopologyGraphNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if isServiceGroup(node.Group) && !slices.Contains(svcList, node.Service) {
+			removed[node.ID] = true
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	edges := make([]*TopologyGraphEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if !removed[edge.Source] && !removed[edge.Target] {
+			edges = append(edges, edge)
+		}
+	}
+	graph.Nodes, graph.Edges = nodes, edges
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+const (
+	TopologyFormatDOT     = "dot"
+	TopologyFormatMermaid = "mermaid"
+	// TopologyFormatJGF is the JSON Graph Format, see https://jsongraphformat.info
+	TopologyFormatJGF = "jgf"
+)
+
+var topologyFormatFiles = map[string]struct {
+	ext         string
+	contentType string
+}{
+	TopologyFormatDOT:     {ext: "dot", contentType: "text/vnd.graphviz"},
+	TopologyFormatMermaid: {ext: "mmd", contentType: "text/plain"},
+	TopologyFormatJGF:     {ext: "json", contentType: "application/json"},
+}
+
+// TopologyGraph is the topology to export, the edges refer to the nodes by ID.
+type TopologyGraph struct {
+	Label string
+	Nodes []*TopologyGraphNode
+	Edges []*TopologyGraphEdge
+}
+
+type TopologyGraphNode struct {
+	ID       string
+	Service  string
+	Endpoint string
+	Group    string
+	IsCustom bool
+	// RED is nil if the node is not traced
+	RED *TopologyRED
+}
+
+type TopologyGraphEdge struct {
+	Source string
+	Target string
+	// IsCustom is set if the edge is from the custom service topology
+	IsCustom bool
+}
+
+type TopologyRED struct {
+	Latency   float64 `json:"latency"`   // average latency in microseconds
+	ErrorRate float64 `json:"errorRate"` // percent
+	TPM       float64 `json:"tpm"`       // requests per minute
+}
+
+// ExportTopology renders the graph in the format as a file named by the name.
+func ExportTopology(graph *TopologyGraph, format string, name string) (*response.ExportTopologyResponse, error) {
+	file, find := topologyFormatFiles[format]
+	if !find {
+		return nil, fmt.Errorf("unknown topology format %s", format)
+	}
+
+	var content []byte
+	switch format {
+	case TopologyFormatDOT:
+		content = renderDOT(graph)
+	case TopologyFormatMermaid:
+		content = renderMermaid(graph)
+	case TopologyFormatJGF:
+		var err error
+		if content, err = renderJGF(graph); err != nil {
+			return nil, err
+		}
+	}
+	return &response.ExportTopologyResponse{
+		FileName:    name + "." + file.ext,
+		ContentType: file.contentType,
+		Content:     content,
+	}, nil
+}
+
+// QueryTopologyRED queries the average RED metrics by the granularity, keyed by keyOf the labels of the results.
+func QueryTopologyRED(ctx core.Context, promRepo prometheus.Repo, startTime int64, endTime int64,
+	gran prometheus.Granularity, filter prometheus.PQLFilter, keyOf func(labels *prometheus.Labels) string) (map[string]*TopologyRED, error) {
+	res := make(map[string]*TopologyRED)
+	queries := []struct {
+		tpl    prometheus.PQLTemplate
+		metric prometheus.MName
+		set    func(red *TopologyRED, value float64)
+	}{
+		{prometheus.PQLAvgLatencyWithPQLFilter, prometheus.LATENCY, func(red *TopologyRED, value float64) { red.Latency = value }},
+		{prometheus.PQLAvgErrorRateWithPQLFilter, prometheus.ERROR_RATE, func(red *TopologyRED, value float64) { red.ErrorRate = value }},
+		{prometheus.PQLAvgTPSWithPQLFilter, prometheus.THROUGHPUT, func(red *TopologyRED, value float64) { red.TPM = value }},
+	}
+	for _, query := range queries {
+		results, err := promRepo.QueryMetricsWithPQLFilter(ctx, query.tpl, startTime, endTime, gran, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			if len(result.Values) == 0 {
+				continue
+			}
+			key := keyOf(&result.Metric)
+			red, find := res[key]
+			if !find {
+				red = &TopologyRED{}
+				res[key] = red
+			}
+			query.set(red, prometheus.AdjustREDValue(prometheus.AVG, query.metric, result.Values[0].Value))
+		}
+	}
+	return res, nil
+}
+
+func isServiceGroup(group string) bool {
+	return group != model.GROUP_DB && group != model.GROUP_MQ && group != model.GROUP_EXTERNAL
+}
+
+func (n *TopologyGraphNode) label() string {
+	label := n.Service
+	if len(n.Endpoint) > 0 {
+		label += "\n" + n.Endpoint
+	}
+	if n.RED != nil {
+		label += fmt.Sprintf("\nlatency %.2fms | errors %.2f%% | %.2f rpm", n.RED.Latency/1e3, n.RED.ErrorRate, n.RED.TPM)
+	}
+	return label
+}
+
+func renderDOT(graph *TopologyGraph) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(graph.Label))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range graph.Nodes {
+		attrs := []string{"label=" + dotQuote(node.label())}
+		switch node.Group {
+		case model.GROUP_DB:
+			attrs = append(attrs, "shape=cylinder")
+		case model.GROUP_MQ:
+			attrs = append(attrs, "shape=cds")
+		case model.GROUP_EXTERNAL:
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if node.IsCustom {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	}
+	for _, edge := range graph.Edges {
+		if edge.IsCustom {
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=\"custom\"];\n", dotQuote(edge.Source), dotQuote(edge.Target))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target))
+		}
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// renderMermaid renders a flowchart, the nodes are named by their index as the IDs may contain any characters.
+func renderMermaid(graph *TopologyGraph) []byte {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	ids := make(map[string]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.ID] = id
+		label := mermaidQuote(node.label())
+		switch node.Group {
+		case model.GROUP_DB:
+			fmt.Fprintf(&b, "  %s[(%s)]\n", id, label)
+		case model.GROUP_MQ:
+			fmt.Fprintf(&b, "  %s[/%s/]\n", id, label)
+		case model.GROUP_EXTERNAL:
+			fmt.Fprintf(&b, "  %s(%s)\n", id, label)
+		default:
+			fmt.Fprintf(&b, "  %s[%s]\n", id, label)
+		}
+	}
+	for _, edge := range graph.Edges {
+		source, target := ids[edge.Source], ids[edge.Target]
+		if len(source) == 0 || len(target) == 0 {
+			continue
+		}
+		if edge.IsCustom {
+			fmt.Fprintf(&b, "  %s -.->|custom| %s\n", source, target)
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", source, target)
+		}
+	}
+	return []byte(b.String())
+}
+
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return `"` + strings.ReplaceAll(s, "\n", "<br/>") + `"`
+}
+
+type jgfDocument struct {
+	Graph jgfGraph `json:"graph"`
+}
+
+type jgfGraph struct {
+	Label    string             `json:"label,omitempty"`
+	Directed bool               `json:"directed"`
+	Nodes    map[string]jgfNode `json:"nodes"`
+	Edges    []jgfEdge          `json:"edges"`
+}
+
+type jgfNode struct {
+	Label    string         `json:"label"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+type jgfEdge struct {
+	Source   string         `json:"source"`
+	Target   string         `json:"target"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+func renderJGF(graph *TopologyGraph) ([]byte, error) {
+	doc := jgfDocument{Graph: jgfGraph{
+		Label:    graph.Label,
+		Directed: true,
+		Nodes:    make(map[string]jgfNode, len(graph.Nodes)),
+		Edges:    make([]jgfEdge, 0, len(graph.Edges)),
+	}}
+	for _, node := range graph.Nodes {
+		metadata := map[string]any{
+			"service":  node.Service,
+			"group":    node.Group,
+			"isCustom": node.IsCustom,
+		}
+		if len(node.Endpoint) > 0 {
+			metadata["endpoint"] = node.Endpoint
+		}
+		if node.RED != nil {
+			metadata["red"] = node.RED
+		}
+		label := node.Service
+		if len(node.Endpoint) > 0 {
+			label += " " + node.Endpoint
+		}
+		doc.Graph.Nodes[node.ID] = jgfNode{Label: label, Metadata: metadata}
+	}
+	for _, edge := range graph.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, jgfEdge{
+			Source:   edge.Source,
+			Target:   edge.Target,
+			Metadata: map[string]any{"isCustom": edge.IsCustom},
+		})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// SortTopologyGraph orders the nodes and edges by ID to keep the exported files stable.
+func SortTopologyGraph(graph *TopologyGraph) {
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Source != graph.Edges[j].Source {
+			return graph.Edges[i].Source < graph.Edges[j].Source
+		}
+		return graph.Edges[i].Target < graph.Edges[j].Target
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+)
+
+func exportTestGraph() *TopologyGraph {
+	return &TopologyGraph{
+		Label: "test",
+		Nodes: []*TopologyGraphNode{
+			{ID: "gateway", Service: "gateway", Group: model.GROUP_SERVICE, RED: &TopologyRED{Latency: 1500, ErrorRate: 1, TPM: 60}},
+			{ID: "cart", Service: "cart", Group: model.GROUP_SERVICE},
+			{ID: "mysql", Service: `my"sql`, Group: model.GROUP_DB, IsCustom: true},
+		},
+		Edges: []*TopologyGraphEdge{
+			{Source: "gateway", Target: "cart"},
+			{Source: "cart", Target: "mysql", IsCustom: true},
+		},
+	}
+}
+
+func TestExportTopologyDOT(t *testing.T) {
+	resp, err := ExportTopology(exportTestGraph(), TopologyFormatDOT, "topology")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FileName != "topology.dot" {
+		t.Errorf("unexpected file name %s", resp.FileName)
+	}
+	dot := string(resp.Content)
+	for _, expected := range []string{
+		`digraph "test" {`,
+		`"gateway" [label="gateway\nlatency 1.50ms | errors 1.00% | 60.00 rpm"];`,
+		`"mysql" [label="my\"sql", shape=cylinder, style=dashed];`,
+		`"gateway" -> "cart";`,
+		`"cart" -> "mysql" [style=dashed, label="custom"];`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("expected %s in\n%s", expected, dot)
+		}
+	}
+}
+
+func TestExportTopologyMermaid(t *testing.T) {
+	resp, err := ExportTopology(exportTestGraph(), TopologyFormatMermaid, "topology")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mermaid := string(resp.Content)
+	for _, expected := range []string{
+		"flowchart LR\n",
+		`n0["gateway<br/>latency 1.50ms | errors 1.00% | 60.00 rpm"]`,
+		`n2[("my#quot;sql")]`,
+		"n0 --> n1",
+		"n1 -.->|custom| n2",
+	} {
+		if !strings.Contains(mermaid, expected) {
+			t.Errorf("expected %s in\n%s", expected, mermaid)
+		}
+	}
+}
+
+func TestExportTopologyJGF(t *testing.T) {
+	resp, err := ExportTopology(exportTestGraph(), TopologyFormatJGF, "topology")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc jgfDocument
+	if err := json.Unmarshal(resp.Content, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if !doc.Graph.Directed || len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 2 {
+		t.Fatalf("unexpected graph %+v", doc.Graph)
+	}
+	if _, find := doc.Graph.Nodes["gateway"].Metadata["red"]; !find {
+		t.Errorf("expected RED metrics of gateway")
+	}
+	if doc.Graph.Edges[1].Metadata["isCustom"] != true {
+		t.Errorf("expected custom edge cart->mysql")
+	}
+}
+
+func TestExportTopologyUnknownFormat(t *testing.T) {
+	if _, err := ExportTopology(exportTestGraph(), "svg", "topology"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
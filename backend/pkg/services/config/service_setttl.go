@@ -13,6 +13,7 @@ import (
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 )
 
 // Regular expression variables at the package level
@@ -76,6 +77,20 @@ func convertModifyTableTTLMap(tables []model.TablesQuery, day int) ([]model.Modi
 	return mapResult, nil
 }
 
+// storedTTLDay returns the TTL days of the first table which has one, for the audit log.
+func (s *service) storedTTLDay(ctx core.Context, tableNames []model.Table) (int, bool) {
+	tables, err := s.chRepo.GetTables(ctx, tableNames)
+	if err != nil {
+		return 0, false
+	}
+	for _, info := range prepareTTLInfo(tables) {
+		if info.OriginalDays != nil {
+			return *info.OriginalDays, true
+		}
+	}
+	return 0, false
+}
+
 func (s *service) SetTTL(ctx core.Context, req *request.SetTTLRequest) error {
 	if req.Day <= 0 {
 		return errors.New("[SetTTL] Error : day should > 0  ")
@@ -85,6 +100,9 @@ func (s *service) SetTTL(ctx core.Context, req *request.SetTTLRequest) error {
 	if len(tables) == 0 {
 		return fmt.Errorf("type: %s does not have tables", req.DataType)
 	}
+	if day, find := s.storedTTLDay(ctx, tables); find {
+		audit.SetStoredConfig(ctx, request.SetTTLRequest{DataType: req.DataType, Day: day})
+	}
 	err := s.SetTableTTL(ctx, tables, req.Day)
 	return err
 }
@@ -100,6 +118,9 @@ func (s *service) SetSingleTableTTL(ctx core.Context, req *request.SetSingleTTLR
 	tables := []model.Table{
 		{Name: req.Name},
 	}
+	if day, find := s.storedTTLDay(ctx, tables); find {
+		audit.SetStoredConfig(ctx, request.SetSingleTTLRequest{Name: req.Name, Day: day})
+	}
 	err := s.SetTableTTL(ctx, tables, req.Day)
 	return err
 }
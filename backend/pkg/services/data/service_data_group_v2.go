@@ -13,6 +13,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/datagroup"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 	"github.com/CloudDetail/apo/backend/pkg/services/common"
 	"github.com/CloudDetail/apo/backend/pkg/util"
 )
@@ -144,6 +145,18 @@ func (s *service) UpdateDataGroupV2(ctx core.Context, req *request.UpdateDataGro
 	if err != nil {
 		return err
 	}
+	groups, _, err := s.dbRepo.GetDataGroup(ctx, model.DataGroupFilter{ID: req.GroupID})
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		audit.SetStoredConfig(ctx, request.UpdateDataGroupRequest{
+			GroupID:      req.GroupID,
+			GroupName:    groups[0].GroupName,
+			Description:  groups[0].Description,
+			DataScopeIDs: oldSelected,
+		})
+	}
 	oldPermScopeIDs := common.DataGroupStorage.GetFullPermissionScopeList(oldSelected)
 	existedNewScopes := []string{}
 	for _, id := range req.DataScopeIDs {
@@ -12,6 +12,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	"github.com/CloudDetail/apo/backend/pkg/services/common"
 )
@@ -36,7 +37,7 @@ func (s *service) GetDataSource(ctx core.Context) (resp response.GetDatasourceRe
 		return
 	}
 
-	allNamespaces, err := s.k8sRepo.GetNamespaceList()
+	allNamespaces, err := s.k8sRepo.GetNamespaceList(kubernetes.DefaultClusterID)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,201 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"gopkg.in/yaml.v3"
+)
+
+// customTopologyRecord is an edge in the imported file, the numbers are kept as text to report the invalid ones.
+type customTopologyRecord struct {
+	ClusterId    string `yaml:"clusterId"`
+	LeftNode     string `yaml:"leftNode"`
+	LeftType     string `yaml:"leftType"`
+	RightNode    string `yaml:"rightNode"`
+	RightType    string `yaml:"rightType"`
+	Protocol     string `yaml:"protocol"`
+	Port         string `yaml:"port"`
+	Description  string `yaml:"description"`
+	SourceSystem string `yaml:"sourceSystem"`
+	// StartTime and ExpireTime are microseconds or RFC3339 time
+	StartTime  string `yaml:"startTime"`
+	ExpireTime string `yaml:"expireTime"`
+}
+
+func (r *customTopologyRecord) fields() map[string]*string {
+	return map[string]*string{
+		"clusterid":    &r.ClusterId,
+		"leftnode":     &r.LeftNode,
+		"lefttype":     &r.LeftType,
+		"rightnode":    &r.RightNode,
+		"righttype":    &r.RightType,
+		"protocol":     &r.Protocol,
+		"port":         &r.Port,
+		"description":  &r.Description,
+		"sourcesystem": &r.SourceSystem,
+		"starttime":    &r.StartTime,
+		"expiretime":   &r.ExpireTime,
+	}
+}
+
+// parseCustomTopologyCSV reads the edges by the header line, the invalid lines are reported as failed.
+func parseCustomTopologyCSV(content string) ([]*database.CustomServiceTopology, []response.ImportCustomTopologyError, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+		if _, find := new(customTopologyRecord).fields()[header[i]]; !find {
+			return nil, nil, fmt.Errorf("unknown csv column: %s", name)
+		}
+	}
+
+	var topologies []*database.CustomServiceTopology
+	var failed []response.ImportCustomTopologyError
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount {
+				failed = append(failed, response.ImportCustomTopologyError{Line: parseErr.StartLine, Reason: "wrong number of fields"})
+				continue
+			}
+			return nil, nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		record := &customTopologyRecord{}
+		fields := record.fields()
+		for i, value := range row {
+			*fields[header[i]] = strings.TrimSpace(value)
+		}
+		topology, err := record.toCustomTopology()
+		if err != nil {
+			failed = append(failed, response.ImportCustomTopologyError{Line: line, Reason: err.Error()})
+			continue
+		}
+		topologies = append(topologies, topology)
+	}
+	return topologies, failed, nil
+}
+
+// parseCustomTopologyYAML reads the edges from a yaml list.
+func parseCustomTopologyYAML(content string) ([]*database.CustomServiceTopology, []response.ImportCustomTopologyError, error) {
+	var records []customTopologyRecord
+	if err := yaml.Unmarshal([]byte(content), &records); err != nil {
+		return nil, nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	var topologies []*database.CustomServiceTopology
+	var failed []response.ImportCustomTopologyError
+	for i := range records {
+		topology, err := records[i].toCustomTopology()
+		if err != nil {
+			failed = append(failed, response.ImportCustomTopologyError{Line: i + 1, Reason: err.Error()})
+			continue
+		}
+		topologies = append(topologies, topology)
+	}
+	return topologies, failed, nil
+}
+
+func (r *customTopologyRecord) toCustomTopology() (*database.CustomServiceTopology, error) {
+	topology := &database.CustomServiceTopology{
+		ClusterId:    r.ClusterId,
+		LeftNode:     r.LeftNode,
+		LeftType:     r.LeftType,
+		RightNode:    r.RightNode,
+		RightType:    r.RightType,
+		Protocol:     r.Protocol,
+		Description:  r.Description,
+		SourceSystem: r.SourceSystem,
+	}
+	var err error
+	if len(r.Port) > 0 {
+		if topology.Port, err = strconv.Atoi(r.Port); err != nil {
+			return nil, fmt.Errorf("invalid port: %s", r.Port)
+		}
+	}
+	if topology.StartTime, err = parseTopologyTime(r.StartTime); err != nil {
+		return nil, fmt.Errorf("invalid startTime: %s", r.StartTime)
+	}
+	if topology.ExpireTime, err = parseTopologyTime(r.ExpireTime); err != nil {
+		return nil, fmt.Errorf("invalid expireTime: %s", r.ExpireTime)
+	}
+	if err := validateCustomTopology(topology); err != nil {
+		return nil, err
+	}
+	return topology, nil
+}
+
+// parseTopologyTime accepts microseconds or RFC3339 time, 0 if empty.
+func parseTopologyTime(value string) (int64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ts, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMicro(), nil
+}
+
+func validateCustomTopology(topology *database.CustomServiceTopology) error {
+	switch {
+	case len(topology.ClusterId) == 0:
+		return errors.New("clusterId is required")
+	case len(topology.LeftNode) == 0 || len(topology.LeftType) == 0:
+		return errors.New("leftNode and leftType are required")
+	case len(topology.RightNode) == 0 || len(topology.RightType) == 0:
+		return errors.New("rightNode and rightType are required")
+	case topology.Port < 0 || topology.Port > 65535:
+		return fmt.Errorf("invalid port: %d", topology.Port)
+	case topology.StartTime < 0 || topology.ExpireTime < 0:
+		return errors.New("startTime and expireTime must not be negative")
+	case topology.StartTime > 0 && topology.ExpireTime > 0 && topology.ExpireTime <= topology.StartTime:
+		return errors.New("expireTime must be later than startTime")
+	}
+	return nil
+}
+
+func customTopologyKey(topology *database.CustomServiceTopology) [3]string {
+	return [3]string{topology.ClusterId, topology.LeftNode, topology.RightNode}
+}
+
+// reconcileCustomTopology marks the custom edges found in the realtime edges of their clusters as redundant,
+// and clears the mark once the edges disappear. The edges are updated in place.
+func reconcileCustomTopology(topologies []database.CustomServiceTopology, realEdges map[string]map[[2]string]bool, now int64) {
+	for i := range topologies {
+		topology := &topologies[i]
+		found := realEdges[topology.ClusterId][[2]string{topology.LeftNode, topology.RightNode}]
+		switch {
+		case found && !topology.Redundant:
+			topology.Redundant = true
+			topology.RedundantSince = now
+		case !found && topology.Redundant:
+			topology.Redundant = false
+			topology.RedundantSince = 0
+		}
+		topology.ReconciledAt = now
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func TestParseCustomTopologyCSV(t *testing.T) {
+	content := `clusterId,leftNode,leftType,rightNode,rightType,protocol,port,sourceSystem,expireTime
+c1,order,service,mysql,db,mysql,3306,cmdb,2025-01-02T00:00:00Z
+c1,order,service,,db,,,,
+c1,order,service,redis,cache,redis,abc,,
+c1,order
+`
+	topologies, failed, err := parseCustomTopologyCSV(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topologies) != 1 {
+		t.Fatalf("expected 1 edge, got %d", len(topologies))
+	}
+	topology := topologies[0]
+	if topology.RightNode != "mysql" || topology.Port != 3306 || topology.SourceSystem != "cmdb" || topology.ExpireTime != 1735776000000000 {
+		t.Errorf("unexpected edge %+v", topology)
+	}
+	if len(failed) != 3 || failed[0].Line != 3 || failed[1].Line != 4 || failed[2].Line != 5 {
+		t.Errorf("unexpected failed lines %+v", failed)
+	}
+
+	if _, _, err := parseCustomTopologyCSV("cluster,left\n"); err == nil {
+		t.Error("expected unknown column error")
+	}
+}
+
+func TestParseCustomTopologyYAML(t *testing.T) {
+	content := `
+- clusterId: c1
+  leftNode: gateway
+  leftType: service
+  rightNode: legacy-erp
+  rightType: external
+  protocol: http
+  port: 8080
+  startTime: 1000
+  expireTime: 2000
+- clusterId: c1
+  leftNode: gateway
+  leftType: service
+  rightNode: erp
+  rightType: external
+  startTime: 2000
+  expireTime: 1000
+`
+	topologies, failed, err := parseCustomTopologyYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topologies) != 1 || topologies[0].Port != 8080 || topologies[0].StartTime != 1000 || topologies[0].ExpireTime != 2000 {
+		t.Errorf("unexpected edges %+v", topologies)
+	}
+	if len(failed) != 1 || failed[0].Line != 2 {
+		t.Errorf("expected the second edge to fail, got %+v", failed)
+	}
+}
+
+func TestReconcileCustomTopology(t *testing.T) {
+	topologies := []database.CustomServiceTopology{
+		{ClusterId: "c1", LeftNode: "a", RightNode: "b"},
+		{ClusterId: "c1", LeftNode: "a", RightNode: "c", Redundant: true, RedundantSince: 10},
+		{ClusterId: "c2", LeftNode: "a", RightNode: "b"},
+		{ClusterId: "c1", LeftNode: "b", RightNode: "d", Redundant: true, RedundantSince: 10},
+	}
+	realEdges := map[string]map[[2]string]bool{
+		"c1": {{"a", "b"}: true, {"b", "d"}: true},
+	}
+	reconcileCustomTopology(topologies, realEdges, 100)
+
+	if !topologies[0].Redundant || topologies[0].RedundantSince != 100 {
+		t.Errorf("expected the traced edge to be redundant, got %+v", topologies[0])
+	}
+	if topologies[1].Redundant || topologies[1].RedundantSince != 0 {
+		t.Errorf("expected the redundant mark to be cleared, got %+v", topologies[1])
+	}
+	if topologies[2].Redundant {
+		t.Error("expected the edge of other cluster not redundant")
+	}
+	if topologies[3].RedundantSince != 10 || topologies[3].ReconciledAt != 100 {
+		t.Errorf("expected the first found time kept, got %+v", topologies[3])
+	}
+}
+
+func TestCustomTopologyActiveAt(t *testing.T) {
+	topology := database.CustomServiceTopology{StartTime: 100, ExpireTime: 200}
+	if topology.ActiveAt(50) || !topology.ActiveAt(150) || topology.ActiveAt(250) {
+		t.Error("unexpected active window")
+	}
+	if !(&database.CustomServiceTopology{}).ActiveAt(1) {
+		t.Error("expected the unlimited edge to be active")
+	}
+}
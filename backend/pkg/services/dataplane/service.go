@@ -4,12 +4,16 @@
 package dataplane
 
 import (
+	"context"
+	"time"
+
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
 )
 
 var _ Service = (*service)(nil)
@@ -22,30 +26,50 @@ type Service interface {
 	GetServiceInstances(ctx core.Context, req *request.QueryServiceInstancesRequest) *response.QueryServiceInstancesResponse
 	GetServiceName(ctx core.Context, req *request.QueryServiceNameRequest) *response.QueryServiceNameResponse
 	GetServiceTopology(ctx core.Context, req *request.QueryTopologyRequest) *response.QueryTopologyResponse
+	ExportServiceTopology(ctx core.Context, req *request.ExportTopologyRequest) (*response.ExportTopologyResponse, error)
 
 	CreateCustomTopology(ctx core.Context, req *request.CreateCustomTopologyRequest) error
 	DeleteCustomTopology(ctx core.Context, req *request.DeleteCustomTopologyRequest) error
 	ListCustomTopology(ctx core.Context, req *request.ListCustomTopologyRequest) (*response.ListCustomTopologyResponse, error)
+	// ImportCustomTopology creates the edges in the csv or yaml file, the existing edges of the same nodes are replaced.
+	ImportCustomTopology(ctx core.Context, req *request.ImportCustomTopologyRequest) (*response.ImportCustomTopologyResponse, error)
+	// ReconcileCustomTopology flags the custom edges found in the realtime topology as redundant.
+	ReconcileCustomTopology(ctx core.Context, req *request.ReconcileCustomTopologyRequest) (*response.ReconcileCustomTopologyResponse, error)
 	CheckServiceNameRule(ctx core.Context, req *request.SetServiceNameRuleRequest) (*response.CheckServiceNameRuleResponse, error)
 	SetServiceNameRule(ctx core.Context, req *request.SetServiceNameRuleRequest) error
 	ListServiceNameRule(ctx core.Context) (*response.ListServiceNameRuleResponse, error)
 	DeleteServiceNameRule(ctx core.Context, req *request.DeleteServiceNameRuleRequest) error
+	// PreviewServiceNameRules applies the rules to the instances reported by the dataplane,
+	// and shows the instances renamed, merged or left unmatched.
+	PreviewServiceNameRules(ctx core.Context, req *request.PreviewServiceNameRulesRequest) (*response.PreviewServiceNameRulesResponse, error)
 	ListAPPInfoLabelsKeys(ctx core.Context, req *request.QueryAPPInfoTagsRequest) (*response.QueryAPPInfoTagsResponse, error)
 	ListAPPInfoLabelValues(ctx core.Context, req *request.QueryAPPInfoTagValuesRequest) (*response.QueryAPPInfoTagValuesResponse, error)
+
+	CreateTopologySnapshot(ctx core.Context, req *request.CreateTopologySnapshotRequest) (*response.TopologySnapshotSummary, error)
+	ListTopologySnapshots(ctx core.Context, req *request.ListTopologySnapshotsRequest) (*response.ListTopologySnapshotsResponse, error)
+	GetTopologySnapshot(ctx core.Context, req *request.GetTopologySnapshotRequest) (*response.GetTopologySnapshotResponse, error)
+	DiffTopology(ctx core.Context, req *request.DiffTopologyRequest) (*response.DiffTopologyResponse, error)
+	// KeepSnapshotting takes a topology snapshot every interval and removes the expired ones until ctx is done.
+	KeepSnapshotting(ctx context.Context, interval time.Duration, retention time.Duration)
+	// KeepReconcilingCustomTopology reconciles the custom topology every interval until ctx is done.
+	KeepReconcilingCustomTopology(ctx context.Context, interval time.Duration, expiredRetention time.Duration)
 }
 
 type service struct {
+	logger   *zap.Logger
 	chRepo   clickhouse.Repo
 	promRepo prometheus.Repo
 	dbRepo   database.Repo
 }
 
 func New(
+	logger *zap.Logger,
 	chRepo clickhouse.Repo,
 	promRepo prometheus.Repo,
 	dbRepo database.Repo,
 ) Service {
 	return &service{
+		logger:   logger,
 		chRepo:   chRepo,
 		promRepo: promRepo,
 		dbRepo:   dbRepo,
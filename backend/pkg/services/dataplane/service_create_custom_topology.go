@@ -4,17 +4,28 @@
 package dataplane
 
 import (
+	"github.com/CloudDetail/apo/backend/pkg/code"
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 )
 
 func (s *service) CreateCustomTopology(ctx core.Context, req *request.CreateCustomTopologyRequest) error {
-	return s.dbRepo.CreateCustomServiceTopology(ctx, &database.CustomServiceTopology{
-		ClusterId: req.ClusterId,
-		LeftNode:  req.LeftNode,
-		LeftType:  req.LeftType,
-		RightNode: req.RightNode,
-		RightType: req.RightType,
-	})
+	topology := &database.CustomServiceTopology{
+		ClusterId:    req.ClusterId,
+		LeftNode:     req.LeftNode,
+		LeftType:     req.LeftType,
+		RightNode:    req.RightNode,
+		RightType:    req.RightType,
+		StartTime:    req.StartTime,
+		ExpireTime:   req.ExpireTime,
+		Protocol:     req.Protocol,
+		Port:         req.Port,
+		Description:  req.Description,
+		SourceSystem: req.SourceSystem,
+	}
+	if err := validateCustomTopology(topology); err != nil {
+		return core.Error(code.CustomTopologyIllegalError, err.Error())
+	}
+	return s.dbRepo.CreateCustomServiceTopology(ctx, topology)
 }
@@ -0,0 +1,62 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"errors"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/common"
+)
+
+func (s *service) ExportServiceTopology(ctx core.Context, req *request.ExportTopologyRequest) (*response.ExportTopologyResponse, error) {
+	topology := s.GetServiceTopology(ctx, &request.QueryTopologyRequest{
+		Cluster:   req.Cluster,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if len(topology.Msg) > 0 {
+		return nil, errors.New(topology.Msg)
+	}
+	custom, err := s.customTopologyEdges(ctx, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &common.TopologyGraph{Label: "service topology"}
+	for _, node := range topology.Results {
+		graph.Nodes = append(graph.Nodes, &common.TopologyGraphNode{
+			ID:       node.Name,
+			Service:  node.Name,
+			Group:    node.Category,
+			IsCustom: node.IsCustom,
+		})
+		for _, child := range node.Children {
+			graph.Edges = append(graph.Edges, &common.TopologyGraphEdge{
+				Source:   node.Name,
+				Target:   child,
+				IsCustom: custom[[2]string{node.Name, child}],
+			})
+		}
+	}
+	if err := common.CutTopologyGraphInGroup(ctx, s.dbRepo, req.GroupID, graph); err != nil {
+		return nil, err
+	}
+
+	red, err := common.QueryTopologyRED(ctx, s.promRepo, req.StartTime, req.EndTime,
+		prometheus.SVCGranularity,
+		prometheus.NewFilter().EqualIfNotEmpty(prometheus.ClusterIDKey, req.Cluster),
+		func(labels *prometheus.Labels) string { return labels.SvcName })
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range graph.Nodes {
+		node.RED = red[node.ID]
+	}
+	common.SortTopologyGraph(graph)
+	return common.ExportTopology(graph, req.Format, "topology")
+}
@@ -18,7 +18,7 @@ func (s *service) GetServiceTopology(ctx core.Context, req *request.QueryTopolog
 			Msg: "query realtime topology failed: " + err.Error(),
 		}
 	}
-	staticTopologies, err := s.dbRepo.ListCustomServiceTopology(ctx)
+	staticTopologies, err := s.dbRepo.ListActiveCustomServiceTopology(ctx, req.EndTime)
 	if err != nil {
 		return &response.QueryTopologyResponse{
 			Msg: "query custom topology failed: " + err.Error(),
@@ -51,19 +51,17 @@ func (s *service) GetServiceTopology(ctx core.Context, req *request.QueryTopolog
 	}
 
 	for _, staticTopology := range staticTopologies {
-		if staticTopology.ExpireTime == 0 || staticTopology.ExpireTime >= req.EndTime {
-			parentNode, ok := nodes[staticTopology.LeftNode]
-			if !ok {
-				parentNode = model.NewServiceToplogyNode(staticTopology.LeftNode, staticTopology.LeftType, true)
-				nodes[staticTopology.LeftNode] = parentNode 
-			}
-			childNode, ok := nodes[staticTopology.RightNode]
-			if !ok {
-				childNode = model.NewServiceToplogyNode(staticTopology.RightNode, staticTopology.RightType, true)
-				nodes[staticTopology.RightNode] = childNode 
-			}
-			parentNode.AddChild(childNode)
+		parentNode, ok := nodes[staticTopology.LeftNode]
+		if !ok {
+			parentNode = model.NewServiceToplogyNode(staticTopology.LeftNode, staticTopology.LeftType, true)
+			nodes[staticTopology.LeftNode] = parentNode
+		}
+		childNode, ok := nodes[staticTopology.RightNode]
+		if !ok {
+			childNode = model.NewServiceToplogyNode(staticTopology.RightNode, staticTopology.RightType, true)
+			nodes[staticTopology.RightNode] = childNode
 		}
+		parentNode.AddChild(childNode)
 	}
 
 	results := make([]*model.ServiceToplogyNode, 0)
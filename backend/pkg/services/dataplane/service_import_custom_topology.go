@@ -0,0 +1,65 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) ImportCustomTopology(ctx core.Context, req *request.ImportCustomTopologyRequest) (*response.ImportCustomTopologyResponse, error) {
+	var topologies []*database.CustomServiceTopology
+	var failed []response.ImportCustomTopologyError
+	var err error
+	if req.Format == "csv" {
+		topologies, failed, err = parseCustomTopologyCSV(req.Content)
+	} else {
+		topologies, failed, err = parseCustomTopologyYAML(req.Content)
+	}
+	if err != nil {
+		return nil, core.Error(code.ImportCustomTopologyError, err.Error())
+	}
+
+	resp := &response.ImportCustomTopologyResponse{Failed: failed}
+	if resp.Failed == nil {
+		resp.Failed = []response.ImportCustomTopologyError{}
+	}
+	// the edges are imported all or none
+	err = s.dbRepo.Transaction(ctx, func(txCtx core.Context) error {
+		existing, err := s.dbRepo.ListCustomServiceTopology(txCtx)
+		if err != nil {
+			return err
+		}
+		existingIDs := make(map[[3]string]int, len(existing))
+		for i := range existing {
+			existingIDs[customTopologyKey(&existing[i])] = existing[i].ID
+		}
+
+		for _, topology := range topologies {
+			key := customTopologyKey(topology)
+			if id, find := existingIDs[key]; find {
+				// the edge is declared again, the reconciliation result is reset
+				topology.ID = id
+				if err := s.dbRepo.UpdateCustomServiceTopology(txCtx, topology); err != nil {
+					return err
+				}
+				resp.Updated++
+				continue
+			}
+			if err := s.dbRepo.CreateCustomServiceTopology(txCtx, topology); err != nil {
+				return err
+			}
+			existingIDs[key] = topology.ID
+			resp.Created++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
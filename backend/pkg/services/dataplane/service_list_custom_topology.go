@@ -17,8 +17,8 @@ func (s *service) ListCustomTopology(ctx core.Context, req *request.ListCustomTo
 	}
 	result := make([]*database.CustomServiceTopology, 0)
 	for _, topology := range topologies {
-		if topology.StartTime > 0 && topology.ExpireTime > 0 &&
-			(req.StartTime > topology.ExpireTime || req.EndTime < topology.StartTime) {
+		if (topology.StartTime > 0 && req.EndTime < topology.StartTime) ||
+			(topology.ExpireTime > 0 && req.StartTime > topology.ExpireTime) {
 			continue
 		}
 		result = append(result, &topology)
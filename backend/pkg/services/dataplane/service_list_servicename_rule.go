@@ -43,6 +43,7 @@ func (s *service) ListServiceNameRule(ctx core.Context) (*response.ListServiceNa
 		rules = append(rules, &response.ListServiceNameRule{
 			Id:          serviceRule.ID,
 			ServiceName: serviceRule.Service,
+			Priority:    serviceRule.Priority,
 			Conditions:  conditions,
 		})
 	}
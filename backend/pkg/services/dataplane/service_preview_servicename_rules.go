@@ -0,0 +1,74 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"fmt"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) PreviewServiceNameRules(ctx core.Context, req *request.PreviewServiceNameRulesRequest) (*response.PreviewServiceNameRulesResponse, error) {
+	var rules []*nameRule
+	if len(req.Rules) == 0 {
+		saved, err := s.savedNameRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rules = saved
+	} else {
+		for i := range req.Rules {
+			rule, err := toNameRule(&req.Rules[i])
+			if err != nil {
+				return nil, core.Error(code.ServiceNameRuleIllegalError, err.Error())
+			}
+			rules = append(rules, rule)
+		}
+		sortNameRules(rules)
+	}
+
+	apps, err := s.chRepo.ListAppInfos(ctx, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ClusterId) > 0 {
+		clusterApps := make([]*model.AppInfo, 0, len(apps))
+		for _, app := range apps {
+			if app.Labels["cluster_id"] == req.ClusterId {
+				clusterApps = append(clusterApps, app)
+			}
+		}
+		apps = clusterApps
+	}
+	return previewNameRules(rules, apps), nil
+}
+
+// toNameRule compiles the rule of the request, the rules not saved yet are identified by their position.
+func toNameRule(req *request.SetServiceNameRuleRequest) (*nameRule, error) {
+	conditions := make([]database.ServiceNameRuleCondition, 0, len(req.Conditions))
+	for _, condition := range req.Conditions {
+		conditions = append(conditions, database.ServiceNameRuleCondition{
+			ID:        condition.CondtiondId,
+			RuleID:    req.RuleId,
+			Key:       condition.Key,
+			MatchType: condition.MatchType,
+			Value:     condition.Value,
+		})
+	}
+	rule, err := compileNameRule(database.ServiceNameRule{
+		ID:        req.RuleId,
+		Service:   req.ServiceName,
+		ClusterId: req.ClusterId,
+		Priority:  req.Priority,
+	}, conditions)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: %w", req.ServiceName, err)
+	}
+	return rule, nil
+}
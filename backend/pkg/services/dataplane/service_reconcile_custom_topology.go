@@ -0,0 +1,83 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+func (s *service) ReconcileCustomTopology(ctx core.Context, req *request.ReconcileCustomTopologyRequest) (*response.ReconcileCustomTopologyResponse, error) {
+	topologies, err := s.dbRepo.ListActiveCustomServiceTopology(ctx, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	realEdges := make(map[string]map[[2]string]bool)
+	for _, topology := range topologies {
+		if _, find := realEdges[topology.ClusterId]; find {
+			continue
+		}
+		realTopologies, err := s.chRepo.QueryRealtimeServiceTopology(ctx, req.StartTime, req.EndTime, topology.ClusterId)
+		if err != nil {
+			return nil, err
+		}
+		edges := make(map[[2]string]bool, len(realTopologies))
+		for _, real := range realTopologies {
+			edges[[2]string{real.ParentService, real.ChildService}] = true
+		}
+		realEdges[topology.ClusterId] = edges
+	}
+
+	reconcileCustomTopology(topologies, realEdges, time.Now().UnixMicro())
+
+	resp := &response.ReconcileCustomTopologyResponse{Redundant: []*database.CustomServiceTopology{}}
+	for i := range topologies {
+		if err := s.dbRepo.UpdateCustomServiceTopologyReconciliation(ctx, &topologies[i]); err != nil {
+			return nil, err
+		}
+		if topologies[i].Redundant {
+			resp.Redundant = append(resp.Redundant, &topologies[i])
+		}
+	}
+	return resp, nil
+}
+
+// KeepReconcilingCustomTopology checks the custom edges against the realtime topology of each interval,
+// and removes the edges expired longer than the retention, 0 to keep them.
+func (s *service) KeepReconcilingCustomTopology(ctx context.Context, interval time.Duration, expiredRetention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		now := time.Now()
+		resp, err := s.ReconcileCustomTopology(core.EmptyCtx(), &request.ReconcileCustomTopologyRequest{
+			StartTime: now.Add(-interval).UnixMicro(),
+			EndTime:   now.UnixMicro(),
+		})
+		if err != nil {
+			s.logger.Error("failed to reconcile custom topology", zap.Error(err))
+		} else if len(resp.Redundant) > 0 {
+			s.logger.Info("custom topology edges found in realtime topology", zap.Int("redundant", len(resp.Redundant)))
+		}
+
+		if expiredRetention > 0 {
+			expireBefore := now.Add(-expiredRetention).UnixMicro()
+			if _, err := s.dbRepo.DeleteCustomServiceTopologyExpiredBefore(core.EmptyCtx(), expireBefore); err != nil {
+				s.logger.Error("failed to clean expired custom topology", zap.Error(err))
+			}
+		}
+	}
+}
@@ -5,7 +5,12 @@ package dataplane
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/code"
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
@@ -16,16 +21,49 @@ func (s *service) SetServiceNameRule(ctx core.Context, req *request.SetServiceNa
 		return errors.New("serviceName is miss")
 	}
 
+	savedRules, err := s.savedNameRules(ctx)
+	if err != nil {
+		return err
+	}
+	rule := database.ServiceNameRule{ID: req.RuleId, Service: req.ServiceName, ClusterId: req.ClusterId, Priority: req.Priority}
+	var savedConditions []database.ServiceNameRuleCondition
+	if req.RuleId > 0 {
+		found := false
+		for _, saved := range savedRules {
+			if saved.rule.ID == req.RuleId {
+				// the cluster of the rule is not changed
+				rule.ClusterId = saved.rule.ClusterId
+				savedConditions = saved.conditions
+				found = true
+			}
+		}
+		if !found {
+			return core.Error(code.ServiceNameRuleNotExistsError, "service name rule does not exist")
+		}
+	}
+	conditions := mergeRuleConditions(savedConditions, req)
+	if !config.Get().Dataplane.ExtendedServiceNameRule {
+		if err := checkExtendedNameRule(rule, conditions); err != nil {
+			return core.Error(code.ServiceNameRuleIllegalError, err.Error())
+		}
+	}
+	newRule, err := compileNameRule(rule, conditions)
+	if err != nil {
+		return core.Error(code.ServiceNameRuleIllegalError, err.Error())
+	}
+	if conflict := findConflictRule(savedRules, newRule); conflict != nil {
+		return core.Error(code.ServiceNameRuleConflictError,
+			fmt.Sprintf("rule %d has the same conditions and priority", conflict.rule.ID))
+	}
+
 	ruleId := req.RuleId
 	if ruleId == 0 {
-		rule := &database.ServiceNameRule{
-			Service:   req.ServiceName,
-			ClusterId: req.ClusterId,
-		}
-		if err := s.dbRepo.CreateServiceNameRule(ctx, rule); err != nil {
+		if err := s.dbRepo.CreateServiceNameRule(ctx, &rule); err != nil {
 			return err
 		}
 		ruleId = rule.ID
+	} else if err := s.dbRepo.UpdateServiceNameRule(ctx, &rule); err != nil {
+		return err
 	}
 
 	for _, condition := range req.Conditions {
@@ -42,3 +80,81 @@ func (s *service) SetServiceNameRule(ctx core.Context, req *request.SetServiceNa
 	}
 	return nil
 }
+
+// savedNameRules returns the saved rules ordered by priority.
+func (s *service) savedNameRules(ctx core.Context) ([]*nameRule, error) {
+	rules, err := s.dbRepo.ListAllServiceNameRule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := s.dbRepo.ListAllServiceNameRuleCondition(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conditionMap := make(map[int][]database.ServiceNameRuleCondition)
+	for _, condition := range conditions {
+		conditionMap[condition.RuleID] = append(conditionMap[condition.RuleID], condition)
+	}
+
+	result := make([]*nameRule, 0, len(rules))
+	for _, rule := range rules {
+		compiled, err := compileNameRule(rule, conditionMap[rule.ID])
+		if err != nil {
+			// keep the invalid rule in the list so it can be fixed, it never matches
+			compiled = &nameRule{rule: rule}
+		}
+		result = append(result, compiled)
+	}
+	sortNameRules(result)
+	return result, nil
+}
+
+// mergeRuleConditions applies the upserted conditions of the request to the saved ones.
+func mergeRuleConditions(saved []database.ServiceNameRuleCondition, req *request.SetServiceNameRuleRequest) []database.ServiceNameRuleCondition {
+	conditions := make([]database.ServiceNameRuleCondition, len(saved))
+	copy(conditions, saved)
+	for _, condition := range req.Conditions {
+		newCondition := database.ServiceNameRuleCondition{
+			ID:        condition.CondtiondId,
+			RuleID:    req.RuleId,
+			Key:       condition.Key,
+			MatchType: condition.MatchType,
+			Value:     condition.Value,
+		}
+		updated := false
+		for i := range conditions {
+			if newCondition.ID > 0 && conditions[i].ID == newCondition.ID {
+				conditions[i] = newCondition
+				updated = true
+			}
+		}
+		if !updated {
+			conditions = append(conditions, newCondition)
+		}
+	}
+	return conditions
+}
+
+// findConflictRule returns the other rule of the cluster with the same priority and conditions,
+// which makes the service name of the matched instances ambiguous.
+func findConflictRule(rules []*nameRule, rule *nameRule) *nameRule {
+	signature := conditionSignature(rule.conditions)
+	for _, other := range rules {
+		if other.rule.ID == rule.rule.ID || other.rule.ClusterId != rule.rule.ClusterId || other.rule.Priority != rule.rule.Priority {
+			continue
+		}
+		if other.rule.Service != rule.rule.Service && conditionSignature(other.conditions) == signature {
+			return other
+		}
+	}
+	return nil
+}
+
+func conditionSignature(conditions []database.ServiceNameRuleCondition) string {
+	keys := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		keys = append(keys, condition.Key+"\x00"+condition.MatchType+"\x00"+condition.Value)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "\x01")
+}
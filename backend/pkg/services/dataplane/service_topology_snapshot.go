@@ -0,0 +1,221 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+const defaultTopologyChangeRatio = 0.5
+
+func (s *service) CreateTopologySnapshot(ctx core.Context, req *request.CreateTopologySnapshotRequest) (*response.TopologySnapshotSummary, error) {
+	snapshot, err := s.takeTopologySnapshot(ctx, req.Cluster, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	summary := snapshotSummary(snapshot)
+	return &summary, nil
+}
+
+func (s *service) ListTopologySnapshots(ctx core.Context, req *request.ListTopologySnapshotsRequest) (*response.ListTopologySnapshotsResponse, error) {
+	snapshots, err := s.dbRepo.ListTopologySnapshots(ctx, req.Cluster, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]response.TopologySnapshotSummary, 0, len(snapshots))
+	for i := range snapshots {
+		summaries = append(summaries, snapshotSummary(&snapshots[i]))
+	}
+	return &response.ListTopologySnapshotsResponse{Snapshots: summaries}, nil
+}
+
+func (s *service) GetTopologySnapshot(ctx core.Context, req *request.GetTopologySnapshotRequest) (*response.GetTopologySnapshotResponse, error) {
+	snapshot, err := s.dbRepo.GetTopologySnapshot(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, core.Error(code.TopologySnapshotNotExist, "topology snapshot not exists")
+	}
+	return &response.GetTopologySnapshotResponse{TopologySnapshot: snapshot}, nil
+}
+
+func (s *service) DiffTopology(ctx core.Context, req *request.DiffTopologyRequest) (*response.DiffTopologyResponse, error) {
+	base, err := s.findTopologySnapshot(ctx, req.Cluster, req.BaseID, req.BaseTime)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.findTopologySnapshot(ctx, req.Cluster, req.TargetID, req.TargetTime)
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := req.ChangeRatio
+	if ratio <= 0 {
+		ratio = defaultTopologyChangeRatio
+	}
+	resp := diffTopologySnapshots(base, target, ratio)
+	resp.Base = snapshotSummary(base)
+	resp.Target = snapshotSummary(target)
+	return resp, nil
+}
+
+// findTopologySnapshot selects the snapshot by id, or the latest one of the cluster taken before the time.
+func (s *service) findTopologySnapshot(ctx core.Context, cluster string, id int64, at int64) (*database.TopologySnapshot, error) {
+	var (
+		snapshot *database.TopologySnapshot
+		err      error
+	)
+	if id > 0 {
+		snapshot, err = s.dbRepo.GetTopologySnapshot(ctx, id)
+	} else {
+		snapshot, err = s.dbRepo.GetTopologySnapshotAt(ctx, cluster, at)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, core.Error(code.TopologySnapshotNotExist, "topology snapshot not exists")
+	}
+	return snapshot, nil
+}
+
+// takeTopologySnapshot saves the service topology of the window with the traced calls and the latency of the edges.
+func (s *service) takeTopologySnapshot(ctx core.Context, cluster string, startTime int64, endTime int64) (*database.TopologySnapshot, error) {
+	topology := s.GetServiceTopology(ctx, &request.QueryTopologyRequest{
+		Cluster:   cluster,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if len(topology.Msg) > 0 {
+		return nil, errors.New(topology.Msg)
+	}
+
+	callCounts, err := s.chRepo.QueryServiceCallCounts(ctx, startTime, endTime, cluster)
+	if err != nil {
+		return nil, err
+	}
+	calls := make(map[[2]string]uint64, len(callCounts))
+	for _, count := range callCounts {
+		calls[[2]string{count.ParentService, count.Service}] = count.Calls
+	}
+
+	custom, err := s.customTopologyEdges(ctx, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	edgeLatencies, err := s.chRepo.QueryServiceEdgeLatencies(ctx, startTime, endTime, cluster)
+	if err != nil {
+		return nil, err
+	}
+	latency := make(map[[2]string]float64, len(edgeLatencies))
+	for _, edge := range edgeLatencies {
+		latency[[2]string{edge.ParentService, edge.Service}] = edge.AvgLatency
+	}
+
+	nodes := make([]database.TopologySnapshotNode, 0, len(topology.Results))
+	var edges []database.TopologySnapshotEdge
+	for _, node := range topology.Results {
+		nodes = append(nodes, database.TopologySnapshotNode{
+			Service:  node.Name,
+			Category: node.Category,
+			IsCustom: node.IsCustom,
+		})
+		for _, child := range node.Children {
+			key := [2]string{node.Name, child}
+			edges = append(edges, database.TopologySnapshotEdge{
+				Parent:     node.Name,
+				Child:      child,
+				IsCustom:   custom[key],
+				Calls:      calls[key],
+				AvgLatency: latency[key],
+			})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Service < nodes[j].Service })
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Parent != edges[j].Parent {
+			return edges[i].Parent < edges[j].Parent
+		}
+		return edges[i].Child < edges[j].Child
+	})
+
+	snapshot := &database.TopologySnapshot{
+		ClusterID: cluster,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Nodes:     integration.JSONField[[]database.TopologySnapshotNode]{Obj: nodes},
+		Edges:     integration.JSONField[[]database.TopologySnapshotEdge]{Obj: edges},
+	}
+	if err := s.dbRepo.CreateTopologySnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *service) KeepSnapshotting(ctx context.Context, interval time.Duration, retention time.Duration) {
+	for {
+		// the snapshots cover the whole intervals, e.g. from 10:00 to 11:00 for hourly
+		next := time.Now().Truncate(interval).Add(interval)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		snapshot, err := s.takeTopologySnapshot(core.EmptyCtx(), "", next.Add(-interval).UnixMicro(), next.UnixMicro())
+		if err != nil {
+			s.logger.Error("failed to take topology snapshot", zap.Error(err))
+		} else {
+			s.logger.Info("take topology snapshot",
+				zap.Int("nodes", len(snapshot.Nodes.Obj)), zap.Int("edges", len(snapshot.Edges.Obj)))
+		}
+
+		if retention > 0 {
+			expireBefore := time.Now().Add(-retention).UnixMicro()
+			if _, err := s.dbRepo.DeleteTopologySnapshotBefore(core.EmptyCtx(), expireBefore); err != nil {
+				s.logger.Error("failed to clean expired topology snapshots", zap.Error(err))
+			}
+		}
+	}
+}
+
+// customTopologyEdges returns the edges of the custom service topology not expired at the time.
+func (s *service) customTopologyEdges(ctx core.Context, endTime int64) (map[[2]string]bool, error) {
+	customTopologies, err := s.dbRepo.ListActiveCustomServiceTopology(ctx, endTime)
+	if err != nil {
+		return nil, err
+	}
+	edges := make(map[[2]string]bool, len(customTopologies))
+	for _, topology := range customTopologies {
+		edges[[2]string{topology.LeftNode, topology.RightNode}] = true
+	}
+	return edges, nil
+}
+
+func snapshotSummary(snapshot *database.TopologySnapshot) response.TopologySnapshotSummary {
+	return response.TopologySnapshotSummary{
+		ID:        snapshot.ID,
+		ClusterID: snapshot.ClusterID,
+		StartTime: snapshot.StartTime,
+		EndTime:   snapshot.EndTime,
+		NodeCount: len(snapshot.Nodes.Obj),
+		EdgeCount: len(snapshot.Edges.Obj),
+		CreatedAt: snapshot.CreatedAt,
+	}
+}
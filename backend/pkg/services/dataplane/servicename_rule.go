@@ -0,0 +1,226 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+// templateRefRegex matches $1, ${1}, ${name} and the escaped $$ in the service name template.
+var templateRefRegex = regexp.MustCompile(`\$(\$|\d+|\{\w+\})`)
+
+// nameRule is a service name rule with the regex conditions compiled.
+type nameRule struct {
+	rule       database.ServiceNameRule
+	conditions []database.ServiceNameRuleCondition
+	// regexps of the conditions, nil for the other match types
+	regexps []*regexp.Regexp
+}
+
+// compileNameRule checks the regex conditions and the references of the template.
+// The capture groups are numbered across the regex conditions in order,
+// e.g. $1 is the first group of the first regex condition.
+func compileNameRule(rule database.ServiceNameRule, conditions []database.ServiceNameRuleCondition) (*nameRule, error) {
+	r := &nameRule{rule: rule, conditions: conditions, regexps: make([]*regexp.Regexp, len(conditions))}
+	groupCount := 0
+	groupNames := make(map[string]bool)
+	for i, condition := range conditions {
+		if condition.MatchType != "regex" {
+			continue
+		}
+		re, err := regexp.Compile(condition.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex of %s: %w", condition.Key, err)
+		}
+		r.regexps[i] = re
+		groupCount += re.NumSubexp()
+		for _, name := range re.SubexpNames() {
+			if len(name) > 0 {
+				groupNames[name] = true
+			}
+		}
+	}
+
+	for _, ref := range templateRefRegex.FindAllStringSubmatch(rule.Service, -1) {
+		name := strings.Trim(ref[1], "{}")
+		if name == "$" {
+			continue
+		}
+		if idx, err := strconv.Atoi(name); err == nil {
+			if idx < 1 || idx > groupCount {
+				return nil, fmt.Errorf("capture group %d of %s not found", idx, rule.Service)
+			}
+		} else if !groupNames[name] {
+			return nil, fmt.Errorf("capture group %s of %s not found", name, rule.Service)
+		}
+	}
+	return r, nil
+}
+
+// checkExtendedNameRule rejects the regex conditions, capture group templates and priorities,
+// which are not supported by the dataplane consumers of the rules yet.
+func checkExtendedNameRule(rule database.ServiceNameRule, conditions []database.ServiceNameRuleCondition) error {
+	for _, condition := range conditions {
+		if condition.MatchType == "regex" {
+			return fmt.Errorf("regex condition of %s is not supported by the dataplane", condition.Key)
+		}
+	}
+	if templateRefRegex.MatchString(rule.Service) {
+		return fmt.Errorf("template %s is not supported by the dataplane", rule.Service)
+	}
+	if rule.Priority != 0 {
+		return fmt.Errorf("priority is not supported by the dataplane")
+	}
+	return nil
+}
+
+// apply returns the service name of the instance if all conditions are matched.
+func (r *nameRule) apply(labels map[string]string) (string, bool) {
+	if len(r.conditions) == 0 {
+		return "", false
+	}
+	groups := []string{""}
+	named := make(map[string]string)
+	for i := range r.conditions {
+		re := r.regexps[i]
+		if re == nil {
+			if !r.conditions[i].Match(labels) {
+				return "", false
+			}
+			continue
+		}
+		value, ok := labels[r.conditions[i].Key]
+		if !ok {
+			return "", false
+		}
+		submatch := re.FindStringSubmatch(value)
+		if submatch == nil {
+			return "", false
+		}
+		groups = append(groups, submatch[1:]...)
+		for j, name := range re.SubexpNames() {
+			if len(name) > 0 {
+				named[name] = submatch[j]
+			}
+		}
+	}
+	return expandServiceName(r.rule.Service, groups, named), true
+}
+
+func expandServiceName(template string, groups []string, named map[string]string) string {
+	return templateRefRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		name := strings.Trim(ref[1:], "{}")
+		if name == "$" {
+			return "$"
+		}
+		if idx, err := strconv.Atoi(name); err == nil {
+			if idx < len(groups) {
+				return groups[idx]
+			}
+			return ""
+		}
+		return named[name]
+	})
+}
+
+// sortNameRules orders the rules by priority, the earlier created one first if the priorities are the same.
+func sortNameRules(rules []*nameRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].rule.Priority != rules[j].rule.Priority {
+			return rules[i].rule.Priority > rules[j].rule.Priority
+		}
+		return rules[i].rule.ID < rules[j].rule.ID
+	})
+}
+
+// previewNameRules applies the sorted rules to the instances of their clusters, the first matched rule names the instance.
+func previewNameRules(rules []*nameRule, apps []*model.AppInfo) *response.PreviewServiceNameRulesResponse {
+	resp := &response.PreviewServiceNameRulesResponse{
+		Renamed:   []response.ServiceNamePreview{},
+		Merged:    []response.MergedServiceName{},
+		Unmatched: []response.ServiceNamePreview{},
+		Conflicts: []response.ServiceNameRuleConflict{},
+	}
+
+	// final service name -> the original service names of its instances
+	sources := make(map[string]map[string]bool)
+	instanceCount := make(map[string]int)
+	addSource := func(finalName string, current string) {
+		if _, find := sources[finalName]; !find {
+			sources[finalName] = make(map[string]bool)
+		}
+		if len(current) > 0 {
+			sources[finalName][current] = true
+		}
+		instanceCount[finalName]++
+	}
+
+	for _, app := range apps {
+		current := app.Labels["service_name"]
+		preview := response.ServiceNamePreview{App: app, CurrentService: current}
+
+		var winner *nameRule
+		for _, r := range rules {
+			if r.rule.ClusterId != app.Labels["cluster_id"] {
+				continue
+			}
+			name, ok := r.apply(app.Labels)
+			if !ok {
+				continue
+			}
+			if winner == nil {
+				winner = r
+				preview.RuleID = r.rule.ID
+				preview.NewService = name
+				continue
+			}
+			preview.ShadowedRuleIDs = append(preview.ShadowedRuleIDs, r.rule.ID)
+			if r.rule.Priority == winner.rule.Priority && name != preview.NewService {
+				resp.Conflicts = append(resp.Conflicts, response.ServiceNameRuleConflict{
+					App:      app,
+					RuleIDs:  []int{winner.rule.ID, r.rule.ID},
+					Services: []string{preview.NewService, name},
+				})
+			}
+		}
+
+		switch {
+		case winner == nil:
+			resp.Unmatched = append(resp.Unmatched, preview)
+			if len(current) > 0 {
+				addSource(current, current)
+			}
+		case preview.NewService == current:
+			resp.Unchanged++
+			addSource(current, current)
+		default:
+			resp.Renamed = append(resp.Renamed, preview)
+			addSource(preview.NewService, current)
+		}
+	}
+
+	for finalName, names := range sources {
+		if len(names) < 2 {
+			continue
+		}
+		merged := response.MergedServiceName{Service: finalName, InstanceCount: instanceCount[finalName]}
+		for name := range names {
+			merged.FromServices = append(merged.FromServices, name)
+		}
+		sort.Strings(merged.FromServices)
+		resp.Merged = append(resp.Merged, merged)
+	}
+	sort.Slice(resp.Merged, func(i, j int) bool {
+		return resp.Merged[i].Service < resp.Merged[j].Service
+	})
+	return resp
+}
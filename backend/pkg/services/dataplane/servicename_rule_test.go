@@ -0,0 +1,131 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func mustCompileNameRule(t *testing.T, id int, service string, priority int, conditions ...database.ServiceNameRuleCondition) *nameRule {
+	t.Helper()
+	rule, err := compileNameRule(database.ServiceNameRule{ID: id, Service: service, ClusterId: "c1", Priority: priority}, conditions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+func TestNameRuleCaptureGroups(t *testing.T) {
+	rule := mustCompileNameRule(t, 1, "${app}-$2", 0,
+		database.ServiceNameRuleCondition{Key: "pod_labels", MatchType: "regex", Value: `app=(?P<app>[a-z]+)`},
+		database.ServiceNameRuleCondition{Key: "namespace", MatchType: "regex", Value: `^(prod|test)$`},
+		database.ServiceNameRuleCondition{Key: "cluster_id", MatchType: "equals", Value: "c1"},
+	)
+
+	name, ok := rule.apply(map[string]string{"pod_labels": "app=order,tier=web", "namespace": "prod", "cluster_id": "c1"})
+	if !ok || name != "order-prod" {
+		t.Errorf("expected order-prod, got %s %v", name, ok)
+	}
+	if _, ok := rule.apply(map[string]string{"pod_labels": "app=order", "namespace": "dev", "cluster_id": "c1"}); ok {
+		t.Error("expected unmatched namespace")
+	}
+	if got := expandServiceName("$$1-$1", []string{"", "a"}, nil); got != "$1-a" {
+		t.Errorf("unexpected escape %s", got)
+	}
+}
+
+func TestCompileNameRuleInvalid(t *testing.T) {
+	regex := database.ServiceNameRuleCondition{Key: "k", MatchType: "regex", Value: `(a)`}
+	if _, err := compileNameRule(database.ServiceNameRule{Service: "$2"}, []database.ServiceNameRuleCondition{regex}); err == nil {
+		t.Error("expected missing group error")
+	}
+	if _, err := compileNameRule(database.ServiceNameRule{Service: "${name}"}, []database.ServiceNameRuleCondition{regex}); err == nil {
+		t.Error("expected missing named group error")
+	}
+	invalid := database.ServiceNameRuleCondition{Key: "k", MatchType: "regex", Value: `(a`}
+	if _, err := compileNameRule(database.ServiceNameRule{Service: "s"}, []database.ServiceNameRuleCondition{invalid}); err == nil {
+		t.Error("expected invalid regex error")
+	}
+}
+
+func TestPreviewNameRules(t *testing.T) {
+	byPod := database.ServiceNameRuleCondition{Key: "pod_name", MatchType: "regex", Value: `^(\w+)-v\d-`}
+	rules := []*nameRule{
+		mustCompileNameRule(t, 1, "$1", 0, byPod),
+		mustCompileNameRule(t, 2, "legacy", 0, database.ServiceNameRuleCondition{Key: "pod_name", MatchType: "startsWith", Value: "cart"}),
+		mustCompileNameRule(t, 3, "shop", 10, database.ServiceNameRuleCondition{Key: "pod_name", MatchType: "startsWith", Value: "shop"}),
+	}
+	sortNameRules(rules)
+	if rules[0].rule.ID != 3 {
+		t.Fatalf("expected the rule of higher priority first, got %d", rules[0].rule.ID)
+	}
+
+	app := func(pod string, service string) *model.AppInfo {
+		return &model.AppInfo{Labels: map[string]string{"cluster_id": "c1", "pod_name": pod, "service_name": service}}
+	}
+	resp := previewNameRules(rules, []*model.AppInfo{
+		app("cart-v1-abc", "cart-java"),
+		app("cart-v2-def", "cart-go"),
+		app("shop-v1-xyz", "shop"),
+		app("order-v1-xyz", "order"),
+		app("pay-7f9c", "pay"),
+	})
+
+	if resp.Unchanged != 2 {
+		t.Errorf("expected 2 unchanged, got %d", resp.Unchanged)
+	}
+	if len(resp.Renamed) != 2 || resp.Renamed[0].NewService != "cart" || !reflect.DeepEqual(resp.Renamed[0].ShadowedRuleIDs, []int{2}) {
+		t.Errorf("unexpected renamed %+v", resp.Renamed)
+	}
+	if len(resp.Unmatched) != 1 || resp.Unmatched[0].CurrentService != "pay" {
+		t.Errorf("unexpected unmatched %+v", resp.Unmatched)
+	}
+	if len(resp.Merged) != 1 || resp.Merged[0].Service != "cart" ||
+		!reflect.DeepEqual(resp.Merged[0].FromServices, []string{"cart-go", "cart-java"}) || resp.Merged[0].InstanceCount != 2 {
+		t.Errorf("unexpected merged %+v", resp.Merged)
+	}
+	if len(resp.Conflicts) != 2 || !reflect.DeepEqual(resp.Conflicts[0].Services, []string{"cart", "legacy"}) {
+		t.Errorf("unexpected conflicts %+v", resp.Conflicts)
+	}
+}
+
+func TestFindConflictRule(t *testing.T) {
+	condition := database.ServiceNameRuleCondition{Key: "pod_name", MatchType: "startsWith", Value: "cart"}
+	saved := []*nameRule{mustCompileNameRule(t, 1, "cart", 0, condition)}
+
+	if findConflictRule(saved, mustCompileNameRule(t, 0, "legacy", 0, condition)) == nil {
+		t.Error("expected conflict of the same conditions and priority")
+	}
+	if findConflictRule(saved, mustCompileNameRule(t, 0, "legacy", 1, condition)) != nil {
+		t.Error("expected no conflict with different priority")
+	}
+	if findConflictRule(saved, mustCompileNameRule(t, 1, "legacy", 0, condition)) != nil {
+		t.Error("expected no conflict with the rule itself")
+	}
+}
+
+func TestCheckExtendedNameRule(t *testing.T) {
+	equals := database.ServiceNameRuleCondition{Key: "namespace", MatchType: "equals", Value: "prod"}
+	regex := database.ServiceNameRuleCondition{Key: "pod_name", MatchType: "regex", Value: `^(\w+)-`}
+	tests := []struct {
+		name       string
+		rule       database.ServiceNameRule
+		conditions []database.ServiceNameRuleCondition
+		ok         bool
+	}{
+		{"plain rule", database.ServiceNameRule{Service: "order"}, []database.ServiceNameRuleCondition{equals}, true},
+		{"regex condition", database.ServiceNameRule{Service: "order"}, []database.ServiceNameRuleCondition{equals, regex}, false},
+		{"template", database.ServiceNameRule{Service: "${app}-svc"}, []database.ServiceNameRuleCondition{equals}, false},
+		{"priority", database.ServiceNameRule{Service: "order", Priority: 1}, []database.ServiceNameRuleCondition{equals}, false},
+	}
+	for _, tt := range tests {
+		if err := checkExtendedNameRule(tt.rule, tt.conditions); (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+}
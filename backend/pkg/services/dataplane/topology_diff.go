@@ -0,0 +1,93 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"math"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+// diffTopologySnapshots compares the nodes and edges of two snapshots, the edges in both are reported
+// if the calls per minute or the latency changed more than the ratio. The values unknown in either
+// snapshot, e.g. the custom edges without traced calls, are not compared.
+func diffTopologySnapshots(base, target *database.TopologySnapshot, ratio float64) *response.DiffTopologyResponse {
+	resp := &response.DiffTopologyResponse{
+		AddedNodes:   []database.TopologySnapshotNode{},
+		RemovedNodes: []database.TopologySnapshotNode{},
+		AddedEdges:   []database.TopologySnapshotEdge{},
+		RemovedEdges: []database.TopologySnapshotEdge{},
+		ChangedEdges: []response.TopologyEdgeChange{},
+	}
+
+	baseNodes := make(map[string]bool, len(base.Nodes.Obj))
+	for _, node := range base.Nodes.Obj {
+		baseNodes[node.Service] = true
+	}
+	targetNodes := make(map[string]bool, len(target.Nodes.Obj))
+	for _, node := range target.Nodes.Obj {
+		targetNodes[node.Service] = true
+		if !baseNodes[node.Service] {
+			resp.AddedNodes = append(resp.AddedNodes, node)
+		}
+	}
+	for _, node := range base.Nodes.Obj {
+		if !targetNodes[node.Service] {
+			resp.RemovedNodes = append(resp.RemovedNodes, node)
+		}
+	}
+
+	baseEdges := make(map[[2]string]database.TopologySnapshotEdge, len(base.Edges.Obj))
+	for _, edge := range base.Edges.Obj {
+		baseEdges[[2]string{edge.Parent, edge.Child}] = edge
+	}
+	targetEdges := make(map[[2]string]bool, len(target.Edges.Obj))
+	baseMinutes, targetMinutes := windowMinutes(base), windowMinutes(target)
+	for _, edge := range target.Edges.Obj {
+		key := [2]string{edge.Parent, edge.Child}
+		targetEdges[key] = true
+		baseEdge, find := baseEdges[key]
+		if !find {
+			resp.AddedEdges = append(resp.AddedEdges, edge)
+			continue
+		}
+
+		change := response.TopologyEdgeChange{
+			Parent:               edge.Parent,
+			Child:                edge.Child,
+			BaseCallsPerMinute:   float64(baseEdge.Calls) / baseMinutes,
+			TargetCallsPerMinute: float64(edge.Calls) / targetMinutes,
+			BaseAvgLatency:       baseEdge.AvgLatency,
+			TargetAvgLatency:     edge.AvgLatency,
+		}
+		change.CallsChange = relativeChange(change.BaseCallsPerMinute, change.TargetCallsPerMinute)
+		change.LatencyChange = relativeChange(change.BaseAvgLatency, change.TargetAvgLatency)
+		if math.Abs(change.CallsChange) >= ratio || math.Abs(change.LatencyChange) >= ratio {
+			resp.ChangedEdges = append(resp.ChangedEdges, change)
+		}
+	}
+	for _, edge := range base.Edges.Obj {
+		if !targetEdges[[2]string{edge.Parent, edge.Child}] {
+			resp.RemovedEdges = append(resp.RemovedEdges, edge)
+		}
+	}
+	return resp
+}
+
+func windowMinutes(snapshot *database.TopologySnapshot) float64 {
+	minutes := float64(snapshot.EndTime-snapshot.StartTime) / 6e7
+	if minutes <= 0 {
+		return 1
+	}
+	return minutes
+}
+
+// relativeChange returns 0 if either value is unknown.
+func relativeChange(base, target float64) float64 {
+	if base <= 0 || target <= 0 {
+		return 0
+	}
+	return (target - base) / base
+}
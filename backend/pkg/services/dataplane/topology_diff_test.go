@@ -0,0 +1,84 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package dataplane
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func newSnapshot(minutes int64, services []string, edges []database.TopologySnapshotEdge) *database.TopologySnapshot {
+	nodes := make([]database.TopologySnapshotNode, 0, len(services))
+	for _, service := range services {
+		nodes = append(nodes, database.TopologySnapshotNode{Service: service})
+	}
+	return &database.TopologySnapshot{
+		StartTime: 0,
+		EndTime:   minutes * 60 * 1e6,
+		Nodes:     integration.JSONField[[]database.TopologySnapshotNode]{Obj: nodes},
+		Edges:     integration.JSONField[[]database.TopologySnapshotEdge]{Obj: edges},
+	}
+}
+
+func TestDiffTopologySnapshots(t *testing.T) {
+	base := newSnapshot(60, []string{"gateway", "cart", "stock", "legacy"}, []database.TopologySnapshotEdge{
+		{Parent: "gateway", Child: "cart", Calls: 6000, AvgLatency: 1000},
+		{Parent: "cart", Child: "stock", Calls: 600, AvgLatency: 2000},
+		{Parent: "gateway", Child: "legacy", Calls: 60},
+		{Parent: "cart", Child: "mysql", IsCustom: true},
+	})
+	// the target covers one day, the calls per minute of gateway->cart are unchanged
+	target := newSnapshot(1440, []string{"gateway", "cart", "stock", "payment"}, []database.TopologySnapshotEdge{
+		{Parent: "gateway", Child: "cart", Calls: 144000, AvgLatency: 1200},
+		{Parent: "cart", Child: "stock", Calls: 14400, AvgLatency: 5000},
+		{Parent: "cart", Child: "payment", Calls: 100},
+		{Parent: "cart", Child: "mysql", IsCustom: true},
+	})
+
+	resp := diffTopologySnapshots(base, target, 0.5)
+
+	if len(resp.AddedNodes) != 1 || resp.AddedNodes[0].Service != "payment" {
+		t.Errorf("unexpected added nodes: %v", resp.AddedNodes)
+	}
+	if len(resp.RemovedNodes) != 1 || resp.RemovedNodes[0].Service != "legacy" {
+		t.Errorf("unexpected removed nodes: %v", resp.RemovedNodes)
+	}
+	if len(resp.AddedEdges) != 1 || resp.AddedEdges[0].Child != "payment" {
+		t.Errorf("unexpected added edges: %v", resp.AddedEdges)
+	}
+	if len(resp.RemovedEdges) != 1 || resp.RemovedEdges[0].Child != "legacy" {
+		t.Errorf("unexpected removed edges: %v", resp.RemovedEdges)
+	}
+	if len(resp.ChangedEdges) != 1 {
+		t.Fatalf("expected 1 changed edge, got %v", resp.ChangedEdges)
+	}
+	change := resp.ChangedEdges[0]
+	if change.Parent != "cart" || change.Child != "stock" {
+		t.Errorf("unexpected changed edge %s->%s", change.Parent, change.Child)
+	}
+	if change.CallsChange != 0 || change.LatencyChange != 1.5 {
+		t.Errorf("unexpected change of calls %v, latency %v", change.CallsChange, change.LatencyChange)
+	}
+	if change.BaseCallsPerMinute != 10 || change.TargetCallsPerMinute != 10 {
+		t.Errorf("unexpected calls per minute %v -> %v", change.BaseCallsPerMinute, change.TargetCallsPerMinute)
+	}
+}
+
+func TestRelativeChange(t *testing.T) {
+	tests := []struct {
+		base, target, want float64
+	}{
+		{100, 200, 1},
+		{100, 50, -0.5},
+		{0, 100, 0},
+		{100, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := relativeChange(tt.base, tt.target); got != tt.want {
+			t.Errorf("relativeChange(%v, %v) = %v, want %v", tt.base, tt.target, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/services/audit"
 	"github.com/CloudDetail/apo/backend/pkg/services/integration/alert/enrich"
 	"github.com/google/uuid"
 	"go.uber.org/multierr"
@@ -19,6 +20,13 @@ func (s *service) UpdateAlertEnrichRule(ctx core.Context, req *alert.AlertEnrich
 		return alert.ErrAlertSourceNotExist{}
 	}
 
+	if storedRules, err := s.GetAlertEnrichRule(ctx, req.SourceId); err == nil {
+		audit.SetStoredConfig(ctx, alert.AlertEnrichRuleConfigRequest{
+			SourceId:          req.SourceId,
+			EnrichRuleConfigs: storedRules,
+		})
+	}
+
 	oldEnricher := oldEnricherPtr.(*enrich.AlertEnricher)
 	sourceFrom := &alert.SourceFrom{SourceID: req.SourceId, SourceInfo: oldEnricher.SourceInfo}
 	newTagEnricher, err := s.createAlertSource(sourceFrom, req.EnrichRuleConfigs)
@@ -7,6 +7,7 @@ import (
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/integration"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 )
 
 type Service interface {
@@ -24,16 +25,20 @@ type Service interface {
 	GetIntegrationInstallDoc(ctx core.Context, req *integration.GetCInstallRequest) ([]byte, error)
 
 	TriggerAdapterUpdate(ctx core.Context, req *integration.TriggerAdapterUpdateRequest)
+
+	LoadK8sClusters(ctx core.Context) error
 }
 
 var _ Service = &service{}
 
 type service struct {
-	dbRepo database.Repo
+	dbRepo  database.Repo
+	k8sRepo kubernetes.Repo
 }
 
-func New(database database.Repo) Service {
+func New(database database.Repo, k8sRepo kubernetes.Repo) Service {
 	return &service{
-		dbRepo: database,
+		dbRepo:  database,
+		k8sRepo: k8sRepo,
 	}
 }
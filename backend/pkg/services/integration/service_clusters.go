@@ -9,7 +9,16 @@ import (
 )
 
 func (s *service) ListCluster(ctx core.Context) ([]integration.Cluster, error) {
-	return s.dbRepo.ListCluster(ctx)
+	clusters, err := s.dbRepo.ListCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*integration.Cluster, 0, len(clusters))
+	for i := range clusters {
+		refs = append(refs, &clusters[i])
+	}
+	s.fillK8sStatus(refs...)
+	return clusters, nil
 }
 
 func (s *service) DeleteCluster(ctx core.Context, cluster *integration.Cluster) error {
@@ -17,6 +26,7 @@ func (s *service) DeleteCluster(ctx core.Context, cluster *integration.Cluster)
 	if err != nil {
 		return err
 	}
+	s.k8sRepo.RemoveCluster(cluster.ID)
 
 	return s.dbRepo.DeleteIntegrationConfig(ctx, cluster.ID)
 }
@@ -26,8 +26,12 @@ func (s *service) CreateCluster(ctx core.Context, cluster *integration.ClusterIn
 	}
 
 	cluster.APOCollector.RemoveHttpPrefix()
+	if err = s.registerK8sCluster(&cluster.Cluster); err != nil {
+		return nil, err
+	}
 	err = s.dbRepo.CreateCluster(ctx, &cluster.Cluster)
 	if err != nil {
+		s.k8sRepo.RemoveCluster(cluster.ID)
 		return nil, err
 	}
 
@@ -39,6 +43,7 @@ func (s *service) CreateCluster(ctx core.Context, cluster *integration.ClusterIn
 		return nil, err
 	}
 
+	s.fillK8sStatus(&cluster.Cluster)
 	return &cluster.Cluster, nil
 }
 
@@ -14,6 +14,7 @@ func (s *service) GetClusterIntegration(ctx core.Context, clusterID string) (*in
 		return nil, err
 	}
 
+	s.fillK8sStatus(&config.Cluster)
 	return &integration.ClusterIntegrationVO{
 		ClusterIntegration: config.RemoveSecret(),
 		ChartVersion:       apoChartVersion,
@@ -0,0 +1,70 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+)
+
+func hasK8sCredential(cluster *integration.Cluster) bool {
+	return cluster.K8sCredential != nil && len(cluster.K8sCredential.Obj.AuthType) > 0
+}
+
+// registerK8sCluster creates the kubernetes client of the cluster, the client is removed if the credential is cleared.
+func (s *service) registerK8sCluster(cluster *integration.Cluster) error {
+	if !hasK8sCredential(cluster) {
+		s.k8sRepo.RemoveCluster(cluster.ID)
+		return nil
+	}
+	if err := s.k8sRepo.RegisterCluster(cluster.ID, &cluster.K8sCredential.Obj); err != nil {
+		return core.Error(code.K8sCredentialIllegal, err.Error())
+	}
+	return nil
+}
+
+// LoadK8sClusters registers the kubernetes clients of the stored clusters.
+func (s *service) LoadK8sClusters(ctx core.Context) error {
+	clusters, err := s.dbRepo.ListClusterWithCredential(ctx)
+	if err != nil {
+		return err
+	}
+	var loadErr error
+	for i := range clusters {
+		if !hasK8sCredential(&clusters[i]) {
+			continue
+		}
+		if err := s.registerK8sCluster(&clusters[i]); err != nil {
+			loadErr = errors.Join(loadErr, fmt.Errorf("cluster %s: %w", clusters[i].Name, err))
+		}
+	}
+	return loadErr
+}
+
+// fillK8sStatus checks the kubernetes API of the clusters concurrently and hides the credentials.
+func (s *service) fillK8sStatus(clusters ...*integration.Cluster) {
+	var wg sync.WaitGroup
+	for _, cluster := range clusters {
+		if !hasK8sCredential(cluster) {
+			continue
+		}
+		cluster.K8sCredential.ReplaceSecret()
+
+		wg.Add(1)
+		go func(cluster *integration.Cluster) {
+			defer wg.Done()
+			status, err := s.k8sRepo.GetClusterStatus(cluster.ID)
+			if err != nil {
+				status = &integration.K8sClusterStatus{Message: err.Error()}
+			}
+			cluster.K8sStatus = status
+		}(cluster)
+	}
+	wg.Wait()
+}
@@ -35,6 +35,19 @@ func (s *service) UpdateClusterIntegration(ctx core.Context, cluster *integratio
 	}
 
 	cluster.APOCollector.RemoveHttpPrefix()
+	if cluster.K8sCredential != nil {
+		// the secrets replaced in the response are kept
+		old, err := s.dbRepo.GetCluster(ctx, cluster.ID)
+		if err != nil {
+			return err
+		}
+		if old.K8sCredential != nil {
+			cluster.K8sCredential.AcceptExistedSecret(old.K8sCredential.Obj)
+		}
+		if err := s.registerK8sCluster(&cluster.Cluster); err != nil {
+			return err
+		}
+	}
 	err := s.dbRepo.UpdateCluster(ctx, &cluster.Cluster)
 	if err != nil {
 		return err
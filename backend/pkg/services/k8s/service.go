@@ -8,21 +8,28 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 )
 
 type Service interface {
-	GetNamespaceList(ctx core.Context) (*response.GetNamespaceListResponse, error)
+	GetNamespaceList(ctx core.Context, req *request.GetNamespaceListRequest) (*response.GetNamespaceListResponse, error)
 	GetNamespaceInfo(ctx core.Context, req *request.GetNamespaceInfoRequest) (*response.GetNamespaceInfoResponse, error)
 	GetPodList(ctx core.Context, req *request.GetPodListRequest) (*response.GetPodListResponse, error)
 	GetPodInfo(ctx core.Context, req *request.GetPodInfoRequest) (*response.GetPodInfoResponse, error)
+
+	GetK8sWorkload(ctx core.Context, req *request.GetK8sWorkloadRequest) (*response.GetK8sWorkloadResponse, error)
+	GetK8sNodeList(ctx core.Context, req *request.GetK8sNodeListRequest) (*response.GetK8sNodeListResponse, error)
+	GetServiceWorkloads(ctx core.Context, req *request.GetServiceWorkloadsRequest) (*response.GetServiceWorkloadsResponse, error)
 }
 
 type service struct {
-	k8sRepo kubernetes.Repo
+	k8sRepo  kubernetes.Repo
+	promRepo prometheus.Repo
 }
 
-func New(k8sRepo kubernetes.Repo) Service {
+func New(k8sRepo kubernetes.Repo, promRepo prometheus.Repo) Service {
 	return &service{
-		k8sRepo: k8sRepo,
+		k8sRepo:  k8sRepo,
+		promRepo: promRepo,
 	}
 }
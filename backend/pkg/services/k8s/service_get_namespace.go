@@ -9,8 +9,8 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 )
 
-func (s service) GetNamespaceList(ctx core.Context) (*response.GetNamespaceListResponse, error) {
-	list, err := s.k8sRepo.GetNamespaceList()
+func (s service) GetNamespaceList(ctx core.Context, req *request.GetNamespaceListRequest) (*response.GetNamespaceListResponse, error) {
+	list, err := s.k8sRepo.GetNamespaceList(req.ClusterID)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,7 @@ func (s service) GetNamespaceList(ctx core.Context) (*response.GetNamespaceListR
 }
 
 func (s service) GetNamespaceInfo(ctx core.Context, req *request.GetNamespaceInfoRequest) (*response.GetNamespaceInfoResponse, error) {
-	info, err := s.k8sRepo.GetNamespaceInfo(req.Namespace)
+	info, err := s.k8sRepo.GetNamespaceInfo(req.ClusterID, req.Namespace)
 	if err != nil {
 		return nil, err
 	}
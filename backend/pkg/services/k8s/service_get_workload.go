@@ -0,0 +1,240 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func (s *service) GetK8sWorkload(ctx core.Context, req *request.GetK8sWorkloadRequest) (*response.GetK8sWorkloadResponse, error) {
+	workload, err := s.getWorkload(req.ClusterID, req.Namespace, req.Kind, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &response.GetK8sWorkloadResponse{K8sWorkload: workload}, nil
+}
+
+func (s *service) GetK8sNodeList(ctx core.Context, req *request.GetK8sNodeListRequest) (*response.GetK8sNodeListResponse, error) {
+	list, err := s.k8sRepo.GetNodeList(req.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	// usage is not available without metrics-server
+	usages, _ := s.k8sRepo.GetNodeUsageList(req.ClusterID)
+	nodes := make([]response.K8sNode, 0, len(list.Items))
+	for i := range list.Items {
+		nodes = append(nodes, nodeOf(req.ClusterID, &list.Items[i], usages[list.Items[i].Name]))
+	}
+	return &response.GetK8sNodeListResponse{Nodes: nodes}, nil
+}
+
+type workloadKey struct {
+	clusterID string
+	namespace string
+	kind      string
+	name      string
+}
+
+type nodeKey struct {
+	clusterID string
+	name      string
+}
+
+// GetServiceWorkloads finds the workloads and nodes running the pods of the service instances.
+func (s *service) GetServiceWorkloads(ctx core.Context, req *request.GetServiceWorkloadsRequest) (*response.GetServiceWorkloadsResponse, error) {
+	instances, err := s.promRepo.GetActiveInstanceList(ctx, req.StartTime, req.EndTime, []string{req.Service})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.GetServiceWorkloadsResponse{
+		Workloads:      []response.K8sWorkload{},
+		Nodes:          []response.K8sNode{},
+		StandalonePods: []response.K8sPod{},
+		UnresolvedPods: []string{},
+	}
+	if instances == nil {
+		return res, nil
+	}
+
+	workloadKeys := make([]workloadKey, 0)
+	nodeKeys := make([]nodeKey, 0)
+	seenWorkloads := map[workloadKey]struct{}{}
+	seenNodes := map[nodeKey]struct{}{}
+	seenPods := map[string]struct{}{}
+	addNode := func(clusterID string, name string) {
+		key := nodeKey{clusterID: clusterID, name: name}
+		if _, find := seenNodes[key]; find || len(name) == 0 {
+			return
+		}
+		seenNodes[key] = struct{}{}
+		nodeKeys = append(nodeKeys, key)
+	}
+
+	for _, instance := range instances.GetInstances() {
+		if len(instance.PodName) == 0 || len(instance.Namespace) == 0 {
+			continue
+		}
+		clusterID, registered := s.clusterOf(instance.ClusterID)
+		podID := clusterID + "/" + instance.Namespace + "/" + instance.PodName
+		if _, find := seenPods[podID]; find {
+			continue
+		}
+		seenPods[podID] = struct{}{}
+		if !registered {
+			// the pod can not be looked up, another cluster may have a pod with the same name
+			res.UnresolvedPods = append(res.UnresolvedPods, podID)
+			continue
+		}
+
+		pod, err := s.k8sRepo.GetPodInfo(clusterID, instance.Namespace, instance.PodName)
+		if apierrors.IsNotFound(err) {
+			// the pod is deleted after the instance is reported
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		kind, name, err := s.workloadOf(clusterID, pod)
+		if err != nil {
+			return nil, err
+		}
+		if len(kind) == 0 {
+			usages, _ := s.k8sRepo.GetPodUsage(clusterID, pod.Namespace, pod.Name)
+			res.StandalonePods = append(res.StandalonePods, podOf(pod, usages))
+			addNode(clusterID, pod.Spec.NodeName)
+			continue
+		}
+		key := workloadKey{clusterID: clusterID, namespace: pod.Namespace, kind: kind, name: name}
+		if _, find := seenWorkloads[key]; !find {
+			seenWorkloads[key] = struct{}{}
+			workloadKeys = append(workloadKeys, key)
+		}
+	}
+
+	for _, key := range workloadKeys {
+		workload, err := s.getWorkload(key.clusterID, key.namespace, key.kind, key.name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range workload.Pods {
+			addNode(key.clusterID, pod.NodeName)
+		}
+		res.Workloads = append(res.Workloads, *workload)
+	}
+	for _, key := range nodeKeys {
+		node, err := s.k8sRepo.GetNodeInfo(key.clusterID, key.name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		usage, _ := s.k8sRepo.GetNodeUsage(key.clusterID, key.name)
+		res.Nodes = append(res.Nodes, nodeOf(key.clusterID, node, usage))
+	}
+	return res, nil
+}
+
+// clusterOf returns the cluster to query, the instances without cluster are looked up in the default one.
+// registered is false if the cluster of the instance is not registered.
+func (s *service) clusterOf(clusterID string) (string, bool) {
+	if len(clusterID) == 0 {
+		return kubernetes.DefaultClusterID, true
+	}
+	return clusterID, s.k8sRepo.HasCluster(clusterID)
+}
+
+// workloadOf returns the Deployment, StatefulSet or DaemonSet controlling the pod, or empty if none.
+func (s *service) workloadOf(clusterID string, pod *v1.Pod) (kind string, name string, err error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", "", nil
+	}
+	switch owner.Kind {
+	case KindStatefulSet, KindDaemonSet:
+		return owner.Kind, owner.Name, nil
+	case KindReplicaSet:
+		rs, err := s.k8sRepo.GetReplicaSet(clusterID, pod.Namespace, owner.Name)
+		if apierrors.IsNotFound(err) {
+			return "", "", nil
+		} else if err != nil {
+			return "", "", err
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == KindDeployment {
+			return KindDeployment, rsOwner.Name, nil
+		}
+	}
+	return "", "", nil
+}
+
+func (s *service) getWorkload(clusterID string, namespace string, kind string, name string) (*response.K8sWorkload, error) {
+	var (
+		workload *response.K8sWorkload
+		selector *metav1.LabelSelector
+	)
+	switch kind {
+	case KindDeployment:
+		d, err := s.k8sRepo.GetDeployment(clusterID, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		workload, selector = deploymentWorkload(clusterID, d), d.Spec.Selector
+		if selector != nil {
+			replicaSets, err := s.k8sRepo.GetReplicaSetList(clusterID, namespace, selector.MatchLabels)
+			if err != nil {
+				return nil, err
+			}
+			workload.Revisions = deploymentRevisions(d, replicaSets.Items)
+		}
+	case KindStatefulSet:
+		sts, err := s.k8sRepo.GetStatefulSet(clusterID, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		workload, selector = statefulSetWorkload(clusterID, sts), sts.Spec.Selector
+	case KindDaemonSet:
+		ds, err := s.k8sRepo.GetDaemonSet(clusterID, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		workload, selector = daemonSetWorkload(clusterID, ds), ds.Spec.Selector
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+
+	if hpas, err := s.k8sRepo.GetHPAList(clusterID, namespace); err == nil {
+		workload.HPA = hpaOf(kind, name, hpas.Items)
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil || labelSelector.Empty() {
+		return workload, nil
+	}
+	pods, err := s.k8sRepo.GetPodList(clusterID, namespace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !labelSelector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		usages, _ := s.k8sRepo.GetPodUsage(clusterID, pod.Namespace, pod.Name)
+		workload.Pods = append(workload.Pods, podOf(pod, usages))
+	}
+	sort.Slice(workload.Pods, func(i, j int) bool {
+		return workload.Pods[i].Name < workload.Pods[j].Name
+	})
+	return workload, nil
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func (s service) GetPodList(ctx core.Context, req *request.GetPodListRequest) (*response.GetPodListResponse, error) {
-	list, err := s.k8sRepo.GetPodList(req.Namespace)
+	list, err := s.k8sRepo.GetPodList(req.ClusterID, req.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,7 @@ func (s service) GetPodList(ctx core.Context, req *request.GetPodListRequest) (*
 }
 
 func (s service) GetPodInfo(ctx core.Context, req *request.GetPodInfoRequest) (*response.GetPodInfoResponse, error) {
-	info, err := s.k8sRepo.GetPodInfo(req.Namespace, req.Pod)
+	info, err := s.k8sRepo.GetPodInfo(req.ClusterID, req.Namespace, req.Pod)
 	if err != nil {
 		return nil, err
 	}
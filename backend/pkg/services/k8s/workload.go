@@ -0,0 +1,441 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+	KindReplicaSet  = "ReplicaSet"
+
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func timeOf(t metav1.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func imagesOf(spec v1.PodSpec) []string {
+	images := make([]string, 0, len(spec.Containers))
+	for _, container := range spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+func resourcesOf(list v1.ResourceList) response.K8sResources {
+	var res response.K8sResources
+	if cpu, find := list[v1.ResourceCPU]; find {
+		res.CPU = cpu.MilliValue()
+	}
+	if memory, find := list[v1.ResourceMemory]; find {
+		res.Memory = memory.Value()
+	}
+	return res
+}
+
+func deploymentWorkload(clusterID string, d *appsv1.Deployment) *response.K8sWorkload {
+	rollout := deploymentRollout(d)
+	rollout.CurrentRevision = d.Annotations[revisionAnnotation]
+
+	conditions := make([]response.K8sCondition, 0, len(d.Status.Conditions))
+	for _, c := range d.Status.Conditions {
+		conditions = append(conditions, response.K8sCondition{
+			Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message,
+			LastTransitionTime: timeOf(c.LastTransitionTime),
+		})
+	}
+	return &response.K8sWorkload{
+		ClusterID: clusterID,
+		Namespace: d.Namespace,
+		Kind:      KindDeployment,
+		Name:      d.Name,
+		Images:    imagesOf(d.Spec.Template.Spec),
+		CreatedAt: timeOf(d.CreationTimestamp),
+		Replicas: response.K8sReplicaStatus{
+			Desired:   replicasOrDefault(d.Spec.Replicas),
+			Current:   d.Status.Replicas,
+			Ready:     d.Status.ReadyReplicas,
+			Available: d.Status.AvailableReplicas,
+			Updated:   d.Status.UpdatedReplicas,
+		},
+		Rollout:    rollout,
+		Conditions: conditions,
+		Revisions:  []response.K8sRevision{},
+		Pods:       []response.K8sPod{},
+	}
+}
+
+// deploymentRollout follows the checks of "kubectl rollout status".
+func deploymentRollout(d *appsv1.Deployment) response.K8sRolloutStatus {
+	if d.Spec.Paused {
+		return response.K8sRolloutStatus{Status: response.RolloutPaused, Message: "rollout is paused"}
+	}
+	if d.Generation > d.Status.ObservedGeneration {
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing, Message: "waiting for the spec update to be observed"}
+	}
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return response.K8sRolloutStatus{Status: response.RolloutFailed, Message: c.Message}
+		}
+	}
+
+	desired := replicasOrDefault(d.Spec.Replicas)
+	status := d.Status
+	switch {
+	case status.UpdatedReplicas < desired:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing,
+			Message: fmt.Sprintf("%d out of %d new replicas have been updated", status.UpdatedReplicas, desired)}
+	case status.Replicas > status.UpdatedReplicas:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing,
+			Message: fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)}
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing,
+			Message: fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)}
+	}
+	return response.K8sRolloutStatus{Status: response.RolloutComplete}
+}
+
+// deploymentRevisions returns the replicaSets owned by the deployment, the latest revision first.
+func deploymentRevisions(d *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) []response.K8sRevision {
+	revisions := make([]response.K8sRevision, 0)
+	for _, rs := range replicaSets {
+		owner := metav1.GetControllerOf(&rs)
+		if owner == nil || owner.UID != d.UID {
+			continue
+		}
+		revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		revisions = append(revisions, response.K8sRevision{
+			Revision:      revision,
+			Name:          rs.Name,
+			Images:        imagesOf(rs.Spec.Template.Spec),
+			Replicas:      rs.Status.Replicas,
+			ReadyReplicas: rs.Status.ReadyReplicas,
+			ChangeCause:   rs.Annotations[changeCauseAnnotation],
+			CreatedAt:     timeOf(rs.CreationTimestamp),
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	return revisions
+}
+
+func statefulSetWorkload(clusterID string, s *appsv1.StatefulSet) *response.K8sWorkload {
+	conditions := make([]response.K8sCondition, 0, len(s.Status.Conditions))
+	for _, c := range s.Status.Conditions {
+		conditions = append(conditions, response.K8sCondition{
+			Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message,
+			LastTransitionTime: timeOf(c.LastTransitionTime),
+		})
+	}
+	return &response.K8sWorkload{
+		ClusterID: clusterID,
+		Namespace: s.Namespace,
+		Kind:      KindStatefulSet,
+		Name:      s.Name,
+		Images:    imagesOf(s.Spec.Template.Spec),
+		CreatedAt: timeOf(s.CreationTimestamp),
+		Replicas: response.K8sReplicaStatus{
+			Desired:   replicasOrDefault(s.Spec.Replicas),
+			Current:   s.Status.Replicas,
+			Ready:     s.Status.ReadyReplicas,
+			Available: s.Status.AvailableReplicas,
+			Updated:   s.Status.UpdatedReplicas,
+		},
+		Rollout:    statefulSetRollout(s),
+		Conditions: conditions,
+		Revisions:  []response.K8sRevision{},
+		Pods:       []response.K8sPod{},
+	}
+}
+
+func statefulSetRollout(s *appsv1.StatefulSet) response.K8sRolloutStatus {
+	res := response.K8sRolloutStatus{
+		Status:          response.RolloutComplete,
+		CurrentRevision: s.Status.CurrentRevision,
+		UpdateRevision:  s.Status.UpdateRevision,
+	}
+	desired := replicasOrDefault(s.Spec.Replicas)
+	switch {
+	case s.Generation > s.Status.ObservedGeneration:
+		res.Status, res.Message = response.RolloutProgressing, "waiting for the spec update to be observed"
+	case s.Status.ReadyReplicas < desired:
+		res.Status, res.Message = response.RolloutProgressing, fmt.Sprintf("%d of %d pods are ready", s.Status.ReadyReplicas, desired)
+	case s.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		s.Spec.UpdateStrategy.RollingUpdate != nil && s.Spec.UpdateStrategy.RollingUpdate.Partition != nil:
+		// only the pods with ordinal not less than the partition are updated
+		partitioned := desired - *s.Spec.UpdateStrategy.RollingUpdate.Partition
+		if s.Status.UpdatedReplicas < partitioned {
+			res.Status, res.Message = response.RolloutProgressing,
+				fmt.Sprintf("%d of %d pods in the partition are updated", s.Status.UpdatedReplicas, partitioned)
+		}
+	case s.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType && s.Status.UpdateRevision != s.Status.CurrentRevision:
+		res.Status, res.Message = response.RolloutProgressing,
+			fmt.Sprintf("%d of %d pods are updated to revision %s", s.Status.UpdatedReplicas, desired, s.Status.UpdateRevision)
+	}
+	return res
+}
+
+func daemonSetWorkload(clusterID string, d *appsv1.DaemonSet) *response.K8sWorkload {
+	conditions := make([]response.K8sCondition, 0, len(d.Status.Conditions))
+	for _, c := range d.Status.Conditions {
+		conditions = append(conditions, response.K8sCondition{
+			Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message,
+			LastTransitionTime: timeOf(c.LastTransitionTime),
+		})
+	}
+	return &response.K8sWorkload{
+		ClusterID: clusterID,
+		Namespace: d.Namespace,
+		Kind:      KindDaemonSet,
+		Name:      d.Name,
+		Images:    imagesOf(d.Spec.Template.Spec),
+		CreatedAt: timeOf(d.CreationTimestamp),
+		Replicas: response.K8sReplicaStatus{
+			Desired:   d.Status.DesiredNumberScheduled,
+			Current:   d.Status.CurrentNumberScheduled,
+			Ready:     d.Status.NumberReady,
+			Available: d.Status.NumberAvailable,
+			Updated:   d.Status.UpdatedNumberScheduled,
+		},
+		Rollout:    daemonSetRollout(d),
+		Conditions: conditions,
+		Revisions:  []response.K8sRevision{},
+		Pods:       []response.K8sPod{},
+	}
+}
+
+func daemonSetRollout(d *appsv1.DaemonSet) response.K8sRolloutStatus {
+	status := d.Status
+	switch {
+	case d.Generation > status.ObservedGeneration:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing, Message: "waiting for the spec update to be observed"}
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing,
+			Message: fmt.Sprintf("%d out of %d new pods have been updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)}
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		return response.K8sRolloutStatus{Status: response.RolloutProgressing,
+			Message: fmt.Sprintf("%d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled)}
+	}
+	return response.K8sRolloutStatus{Status: response.RolloutComplete}
+}
+
+// hpaOf returns the HPA scaling the workload.
+func hpaOf(kind string, name string, hpas []autoscalingv2.HorizontalPodAutoscaler) *response.K8sHPAStatus {
+	for _, hpa := range hpas {
+		if hpa.Spec.ScaleTargetRef.Kind != kind || hpa.Spec.ScaleTargetRef.Name != name {
+			continue
+		}
+
+		current := map[string]string{}
+		for _, metric := range hpa.Status.CurrentMetrics {
+			current[hpaMetricStatusName(metric)] = hpaMetricValue(hpaMetricStatusValue(metric))
+		}
+		metrics := make([]response.K8sHPAMetric, 0, len(hpa.Spec.Metrics))
+		for _, metric := range hpa.Spec.Metrics {
+			name, target := hpaMetricSpec(metric)
+			metrics = append(metrics, response.K8sHPAMetric{Name: name, Target: target, Current: current[name]})
+		}
+		conditions := make([]response.K8sCondition, 0, len(hpa.Status.Conditions))
+		for _, c := range hpa.Status.Conditions {
+			conditions = append(conditions, response.K8sCondition{
+				Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message,
+				LastTransitionTime: timeOf(c.LastTransitionTime),
+			})
+		}
+		return &response.K8sHPAStatus{
+			Name:            hpa.Name,
+			MinReplicas:     replicasOrDefault(hpa.Spec.MinReplicas),
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			DesiredReplicas: hpa.Status.DesiredReplicas,
+			Metrics:         metrics,
+			Conditions:      conditions,
+		}
+	}
+	return nil
+}
+
+func hpaMetricSpec(metric autoscalingv2.MetricSpec) (name string, target string) {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource != nil {
+			return "resource/" + string(metric.Resource.Name), hpaMetricTarget(metric.Resource.Target)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource != nil {
+			return "container/" + metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name),
+				hpaMetricTarget(metric.ContainerResource.Target)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods != nil {
+			return "pods/" + metric.Pods.Metric.Name, hpaMetricTarget(metric.Pods.Target)
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object != nil {
+			return "object/" + metric.Object.DescribedObject.Kind + "/" + metric.Object.Metric.Name, hpaMetricTarget(metric.Object.Target)
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External != nil {
+			return "external/" + metric.External.Metric.Name, hpaMetricTarget(metric.External.Target)
+		}
+	}
+	return string(metric.Type), ""
+}
+
+func hpaMetricStatusName(metric autoscalingv2.MetricStatus) string {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource != nil {
+			return "resource/" + string(metric.Resource.Name)
+		}
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource != nil {
+			return "container/" + metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name)
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods != nil {
+			return "pods/" + metric.Pods.Metric.Name
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object != nil {
+			return "object/" + metric.Object.DescribedObject.Kind + "/" + metric.Object.Metric.Name
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External != nil {
+			return "external/" + metric.External.Metric.Name
+		}
+	}
+	return string(metric.Type)
+}
+
+func hpaMetricStatusValue(metric autoscalingv2.MetricStatus) autoscalingv2.MetricValueStatus {
+	switch {
+	case metric.Resource != nil:
+		return metric.Resource.Current
+	case metric.ContainerResource != nil:
+		return metric.ContainerResource.Current
+	case metric.Pods != nil:
+		return metric.Pods.Current
+	case metric.Object != nil:
+		return metric.Object.Current
+	case metric.External != nil:
+		return metric.External.Current
+	}
+	return autoscalingv2.MetricValueStatus{}
+}
+
+func hpaMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return ""
+}
+
+func hpaMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
+
+// podOf converts the pod, usages are the resource usages of the containers which may be empty.
+func podOf(pod *v1.Pod, usages map[string]v1.ResourceList) response.K8sPod {
+	statuses := make(map[string]v1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	res := response.K8sPod{
+		Name:       pod.Name,
+		Namespace:  pod.Namespace,
+		NodeName:   pod.Spec.NodeName,
+		Phase:      string(pod.Status.Phase),
+		CreatedAt:  timeOf(pod.CreationTimestamp),
+		Containers: make([]response.K8sContainer, 0, len(pod.Spec.Containers)),
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			res.Ready = c.Status == v1.ConditionTrue
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		status := statuses[container.Name]
+		item := response.K8sContainer{
+			Name:     container.Name,
+			Image:    container.Image,
+			Ready:    status.Ready,
+			Restarts: status.RestartCount,
+			Requests: resourcesOf(container.Resources.Requests),
+			Limits:   resourcesOf(container.Resources.Limits),
+		}
+		if usage, find := usages[container.Name]; find {
+			resources := resourcesOf(usage)
+			item.Usage = &resources
+		}
+		res.Restarts += status.RestartCount
+		res.Containers = append(res.Containers, item)
+	}
+	return res
+}
+
+func nodeOf(clusterID string, node *v1.Node, usage v1.ResourceList) response.K8sNode {
+	res := response.K8sNode{
+		ClusterID:      clusterID,
+		Name:           node.Name,
+		Unschedulable:  node.Spec.Unschedulable,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		Conditions:     make([]response.K8sCondition, 0, len(node.Status.Conditions)),
+		Capacity:       resourcesOf(node.Status.Capacity),
+		Allocatable:    resourcesOf(node.Status.Allocatable),
+	}
+	for _, c := range node.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			res.Ready = c.Status == v1.ConditionTrue
+		}
+		res.Conditions = append(res.Conditions, response.K8sCondition{
+			Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message,
+			LastTransitionTime: timeOf(c.LastTransitionTime),
+		})
+	}
+	if usage != nil {
+		resources := resourcesOf(usage)
+		res.Usage = &resources
+	}
+	return res
+}
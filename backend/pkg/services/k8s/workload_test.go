@@ -0,0 +1,276 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package k8s
+
+import (
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestDeploymentRollout(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   appsv1.DeploymentSpec
+		status appsv1.DeploymentStatus
+		want   string
+	}{
+		{"complete", appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}, response.RolloutComplete},
+		{"paused", appsv1.DeploymentSpec{Replicas: int32Ptr(2), Paused: true},
+			appsv1.DeploymentStatus{}, response.RolloutPaused},
+		{"updating", appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}, response.RolloutProgressing},
+		{"terminating old replicas", appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2}, response.RolloutProgressing},
+		{"deadline exceeded", appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+			}}, response.RolloutFailed},
+	}
+	for _, tt := range tests {
+		d := &appsv1.Deployment{Spec: tt.spec, Status: tt.status}
+		if got := deploymentRollout(d); got.Status != tt.want {
+			t.Errorf("%s: status = %s, want %s (%s)", tt.name, got.Status, tt.want, got.Message)
+		}
+	}
+}
+
+func TestStatefulSetRollout(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       int32Ptr(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "web-1", UpdateRevision: "web-2"},
+	}
+	if got := statefulSetRollout(sts); got.Status != response.RolloutProgressing || got.UpdateRevision != "web-2" {
+		t.Errorf("rolling update: %+v", got)
+	}
+
+	sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(2)}
+	if got := statefulSetRollout(sts); got.Status != response.RolloutComplete {
+		t.Errorf("partitioned update: %+v", got)
+	}
+}
+
+func TestDeploymentRevisions(t *testing.T) {
+	controller := true
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", UID: types.UID("d1")}}
+	replicaSet := func(name string, uid string, revision string, image string) appsv1.ReplicaSet {
+		return appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Annotations:     map[string]string{revisionAnnotation: revision, changeCauseAnnotation: "set image " + image},
+				OwnerReferences: []metav1.OwnerReference{{Kind: KindDeployment, Name: "web", UID: types.UID(uid), Controller: &controller}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: image}}}}},
+		}
+	}
+
+	revisions := deploymentRevisions(d, []appsv1.ReplicaSet{
+		replicaSet("web-a", "d1", "1", "web:1.0"),
+		replicaSet("web-c", "d1", "10", "web:1.2"),
+		replicaSet("web-b", "d1", "2", "web:1.1"),
+		replicaSet("other", "d2", "3", "other:1.0"),
+	})
+	if len(revisions) != 3 {
+		t.Fatalf("revisions = %+v", revisions)
+	}
+	for i, want := range []int64{10, 2, 1} {
+		if revisions[i].Revision != want {
+			t.Errorf("revisions[%d] = %d, want %d", i, revisions[i].Revision, want)
+		}
+	}
+	if revisions[0].Images[0] != "web:1.2" || revisions[0].ChangeCause != "set image web:1.2" {
+		t.Errorf("latest revision = %+v", revisions[0])
+	}
+}
+
+func TestHPAOf(t *testing.T) {
+	utilization := int32(80)
+	current := int32(45)
+	hpas := []autoscalingv2.HorizontalPodAutoscaler{{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: KindDeployment, Name: "web"},
+			MinReplicas:    int32Ptr(2),
+			MaxReplicas:    10,
+			Metrics: []autoscalingv2.MetricSpec{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   v1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization},
+				},
+			}},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 3,
+			CurrentMetrics: []autoscalingv2.MetricStatus{{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name:    v1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{AverageUtilization: &current},
+				},
+			}},
+		},
+	}}
+
+	if hpa := hpaOf(KindStatefulSet, "web", hpas); hpa != nil {
+		t.Errorf("hpa of another kind = %+v", hpa)
+	}
+	hpa := hpaOf(KindDeployment, "web", hpas)
+	if hpa == nil || hpa.MinReplicas != 2 || hpa.MaxReplicas != 10 || hpa.CurrentReplicas != 3 {
+		t.Fatalf("hpa = %+v", hpa)
+	}
+	want := response.K8sHPAMetric{Name: "resource/cpu", Target: "80%", Current: "45%"}
+	if len(hpa.Metrics) != 1 || hpa.Metrics[0] != want {
+		t.Errorf("metrics = %+v, want %+v", hpa.Metrics, want)
+	}
+}
+
+func TestPodOf(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+			Containers: []v1.Container{{
+				Name:  "web",
+				Image: "web:1.0",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m"), v1.ResourceMemory: resource.MustParse("128Mi")},
+					Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				},
+			}, {
+				Name:  "sidecar",
+				Image: "sidecar:1.0",
+			}},
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			Conditions:        []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			ContainerStatuses: []v1.ContainerStatus{{Name: "web", Ready: true, RestartCount: 2}, {Name: "sidecar", RestartCount: 1}},
+		},
+	}
+	usages := map[string]v1.ResourceList{
+		"web": {v1.ResourceCPU: resource.MustParse("120m"), v1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+
+	got := podOf(pod, usages)
+	if !got.Ready || got.Restarts != 3 || got.NodeName != "node-1" || len(got.Containers) != 2 {
+		t.Fatalf("pod = %+v", got)
+	}
+	web := got.Containers[0]
+	if web.Requests != (response.K8sResources{CPU: 250, Memory: 128 << 20}) || web.Limits != (response.K8sResources{CPU: 1000}) {
+		t.Errorf("web requests = %+v, limits = %+v", web.Requests, web.Limits)
+	}
+	if web.Usage == nil || *web.Usage != (response.K8sResources{CPU: 120, Memory: 64 << 20}) {
+		t.Errorf("web usage = %+v", web.Usage)
+	}
+	if got.Containers[1].Usage != nil {
+		t.Errorf("sidecar usage = %+v", got.Containers[1].Usage)
+	}
+}
+
+// nodeListRepo serves the nodes and their usage, GetNodeUsage is not implemented
+// so that the usage must be listed once for all nodes.
+type nodeListRepo struct {
+	kubernetes.Repo
+	usageLists int
+}
+
+func (r *nodeListRepo) GetNodeList(clusterID string) (*v1.NodeList, error) {
+	return &v1.NodeList{Items: []v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	}}, nil
+}
+
+func (r *nodeListRepo) GetNodeUsageList(clusterID string) (map[string]v1.ResourceList, error) {
+	r.usageLists++
+	return map[string]v1.ResourceList{
+		"node-1": {v1.ResourceCPU: resource.MustParse("1500m")},
+	}, nil
+}
+
+func TestGetK8sNodeList(t *testing.T) {
+	repo := &nodeListRepo{}
+	s := &service{k8sRepo: repo}
+	res, err := s.GetK8sNodeList(core.EmptyCtx(), &request.GetK8sNodeListRequest{ClusterID: "c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.usageLists != 1 || len(res.Nodes) != 2 {
+		t.Fatalf("usage lists = %d, nodes = %+v", repo.usageLists, res.Nodes)
+	}
+	if usage := res.Nodes[0].Usage; usage == nil || usage.CPU != 1500 {
+		t.Errorf("node-1 usage = %+v", usage)
+	}
+	if res.Nodes[1].Usage != nil {
+		t.Errorf("node-2 usage = %+v", res.Nodes[1].Usage)
+	}
+}
+
+type instancePromRepo struct {
+	prometheus.Repo
+}
+
+func (instancePromRepo) GetActiveInstanceList(_ core.Context, _ int64, _ int64, _ []string) (*model.ServiceInstances, error) {
+	instances := model.NewServiceInstances()
+	instances.AddInstances([]*model.ServiceInstance{
+		{ServiceName: "shop", PodName: "shop-0", Namespace: "default", ClusterID: "c1"},
+		{ServiceName: "shop", PodName: "shop-1", Namespace: "default", ClusterID: "unknown"},
+	})
+	return instances, nil
+}
+
+// podRepo has the registered cluster c1 with standalone pods.
+type podRepo struct {
+	kubernetes.Repo
+	queriedClusters []string
+}
+
+func (r *podRepo) HasCluster(clusterID string) bool {
+	return clusterID == "c1"
+}
+
+func (r *podRepo) GetPodInfo(clusterID string, namespace string, podName string) (*v1.Pod, error) {
+	r.queriedClusters = append(r.queriedClusters, clusterID)
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace}}, nil
+}
+
+func (r *podRepo) GetPodUsage(clusterID string, namespace string, podName string) (map[string]v1.ResourceList, error) {
+	return nil, nil
+}
+
+func TestGetServiceWorkloadsOfUnregisteredCluster(t *testing.T) {
+	repo := &podRepo{}
+	s := &service{k8sRepo: repo, promRepo: instancePromRepo{}}
+	res, err := s.GetServiceWorkloads(core.EmptyCtx(), &request.GetServiceWorkloadsRequest{Service: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.queriedClusters) != 1 || repo.queriedClusters[0] != "c1" {
+		t.Errorf("queried clusters = %v", repo.queriedClusters)
+	}
+	if len(res.StandalonePods) != 1 || len(res.UnresolvedPods) != 1 || res.UnresolvedPods[0] != "unknown/default/shop-1" {
+		t.Errorf("standalone pods = %+v, unresolved pods = %v", res.StandalonePods, res.UnresolvedPods)
+	}
+}
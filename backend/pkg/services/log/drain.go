@@ -0,0 +1,207 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Drain log template miner, see "Drain: An Online Log Parsing Approach with Fixed Depth Tree".
+//
+// Logs are routed by the number of tokens and the first tokens to a leaf of the tree,
+// then merged into the most similar cluster in the leaf. Tokens that differ between
+// logs of the same cluster become the wildcard "<*>".
+const drainWildcard = "<*>"
+
+const (
+	// depth of the tree including the root and the leaves, only the first token is used to route logs
+	defaultDrainDepth         = 3
+	defaultDrainSimilarity    = 0.5
+	defaultDrainMaxChildren   = 100
+	defaultDrainMaxSamples    = 3
+	drainMaxTokens            = 256
+	drainMaxSampleLength      = 1024
+	drainWindowCount          = 2
+	drainCurrentWindow        = 0
+	drainBaseWindow           = 1
+	drainMaxClustersPerLength = 1000
+)
+
+// variables which are masked before mining
+var drainMasks = []*regexp.Regexp{
+	regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){3}(:\d+)?$`),
+	regexp.MustCompile(`^(0x)?[0-9a-fA-F]{16,}$`),
+	regexp.MustCompile(`^0x[0-9a-fA-F]+$`),
+	regexp.MustCompile(`^[-+]?\d+(\.\d+)?([a-zA-Z%]{1,3})?[,;.]?$`),
+}
+
+type drainCluster struct {
+	tokens    []string
+	counts    [drainWindowCount]int
+	samples   []string
+	firstSeen [drainWindowCount]time.Time
+	lastSeen  [drainWindowCount]time.Time
+}
+
+func (c *drainCluster) template() string {
+	return strings.Join(c.tokens, " ")
+}
+
+type drainNode struct {
+	children map[string]*drainNode
+	clusters []*drainCluster
+}
+
+type drainMiner struct {
+	depth       int
+	similarity  float64
+	maxChildren int
+	maxSamples  int
+
+	// root is keyed by the number of tokens
+	root     map[int]*drainNode
+	clusters []*drainCluster
+}
+
+func newDrainMiner(similarity float64) *drainMiner {
+	if similarity <= 0 || similarity > 1 {
+		similarity = defaultDrainSimilarity
+	}
+	return &drainMiner{
+		depth:       defaultDrainDepth,
+		similarity:  similarity,
+		maxChildren: defaultDrainMaxChildren,
+		maxSamples:  defaultDrainMaxSamples,
+		root:        make(map[int]*drainNode),
+	}
+}
+
+// add the log of window into the miner.
+func (m *drainMiner) add(content string, ts time.Time, window int) {
+	tokens := tokenizeDrain(content)
+	if len(tokens) == 0 {
+		return
+	}
+
+	leaf := m.leafOf(tokens)
+	cluster := m.match(leaf, tokens)
+	if cluster == nil {
+		cluster = &drainCluster{tokens: tokens}
+		if len(leaf.clusters) < drainMaxClustersPerLength {
+			leaf.clusters = append(leaf.clusters, cluster)
+		}
+		m.clusters = append(m.clusters, cluster)
+	} else {
+		for i, token := range tokens {
+			if cluster.tokens[i] != token {
+				cluster.tokens[i] = drainWildcard
+			}
+		}
+	}
+
+	cluster.counts[window]++
+	if cluster.firstSeen[window].IsZero() || ts.Before(cluster.firstSeen[window]) {
+		cluster.firstSeen[window] = ts
+	}
+	if ts.After(cluster.lastSeen[window]) {
+		cluster.lastSeen[window] = ts
+	}
+	if window == drainCurrentWindow && len(cluster.samples) < m.maxSamples {
+		cluster.samples = append(cluster.samples, truncateSample(content))
+	}
+}
+
+// truncateSample cuts the content to at most drainMaxSampleLength bytes without splitting a UTF-8 character.
+func truncateSample(content string) string {
+	if len(content) <= drainMaxSampleLength {
+		return content
+	}
+	end := drainMaxSampleLength
+	for end > 0 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	return content[:end]
+}
+
+func (m *drainMiner) leafOf(tokens []string) *drainNode {
+	node, find := m.root[len(tokens)]
+	if !find {
+		node = &drainNode{}
+		m.root[len(tokens)] = node
+	}
+	for i := 0; i < m.depth-2 && i < len(tokens); i++ {
+		key := tokens[i]
+		if hasDigit(key) {
+			key = drainWildcard
+		}
+		if node.children == nil {
+			node.children = make(map[string]*drainNode)
+		}
+		child, find := node.children[key]
+		if !find {
+			if len(node.children) >= m.maxChildren {
+				key = drainWildcard
+				child, find = node.children[key]
+			}
+			if !find {
+				child = &drainNode{}
+				node.children[key] = child
+			}
+		}
+		node = child
+	}
+	return node
+}
+
+// match returns the most similar cluster, nil if none reaches the similarity threshold.
+func (m *drainMiner) match(leaf *drainNode, tokens []string) *drainCluster {
+	var (
+		best         *drainCluster
+		bestSim      = -1.0
+		bestWildcard = -1
+	)
+	for _, cluster := range leaf.clusters {
+		var same, wildcard int
+		for i, token := range cluster.tokens {
+			if token == drainWildcard {
+				wildcard++
+			} else if token == tokens[i] {
+				same++
+			}
+		}
+		sim := float64(same) / float64(len(tokens))
+		if sim > bestSim || (sim == bestSim && wildcard > bestWildcard) {
+			best, bestSim, bestWildcard = cluster, sim, wildcard
+		}
+	}
+	if best == nil || bestSim < m.similarity {
+		return nil
+	}
+	return best
+}
+
+func tokenizeDrain(content string) []string {
+	tokens := strings.Fields(content)
+	if len(tokens) > drainMaxTokens {
+		tokens = tokens[:drainMaxTokens]
+	}
+	for i, token := range tokens {
+		for _, mask := range drainMasks {
+			if mask.MatchString(token) {
+				tokens[i] = drainWildcard
+				break
+			}
+		}
+	}
+	return tokens
+}
+
+func hasDigit(token string) bool {
+	return strings.IndexFunc(token, unicode.IsDigit) >= 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestDrainMiner(t *testing.T) {
+	now := time.Now()
+	miner := newDrainMiner(0)
+	current := []string{
+		"connected to 10.0.0.1:3306 in 12ms",
+		"connected to 10.0.0.2:3306 in 8ms",
+		"user alice login failed",
+		"user bob login failed",
+		"order 0x1f3a created by alice",
+		"timeout while calling payment service",
+		"timeout while calling payment service",
+	}
+	base := []string{
+		"connected to 10.0.0.3:3306 in 5ms",
+		"connected to 10.0.0.4:3306 in 9ms",
+		"connected to 10.0.0.5:3306 in 7ms",
+		"connected to 10.0.0.6:3306 in 7ms",
+		"connected to 10.0.0.7:3306 in 11ms",
+		"connected to 10.0.0.8:3306 in 6ms",
+		"user carol login failed",
+		"order 0x2b created by bob",
+	}
+	for i, line := range current {
+		miner.add(line, now.Add(time.Duration(i)*time.Second), drainCurrentWindow)
+	}
+	for _, line := range base {
+		miner.add(line, now.Add(-time.Hour), drainBaseWindow)
+	}
+
+	patterns := toLogPatterns(miner.clusters, len(current), len(base), true)
+	want := map[string]struct {
+		count, baseCount int
+		isNew, spiking   bool
+	}{
+		"connected to <*> in <*>":               {2, 6, false, false},
+		"user <*> login failed":                 {2, 1, false, true},
+		"order <*> created by <*>":              {1, 1, false, false},
+		"timeout while calling payment service": {2, 0, true, false},
+	}
+	if len(patterns) != len(want) {
+		t.Fatalf("got %d patterns: %+v", len(patterns), patterns)
+	}
+	if !patterns[0].IsNew {
+		t.Errorf("new template is not the first: %+v", patterns[0])
+	}
+	for _, p := range patterns {
+		w, find := want[p.Template]
+		if !find {
+			t.Errorf("unexpected template %q", p.Template)
+			continue
+		}
+		if p.Count != w.count || p.BaseCount != w.baseCount || p.IsNew != w.isNew || p.IsSpiking != w.spiking {
+			t.Errorf("template %q: got %+v, want %+v", p.Template, p, w)
+		}
+	}
+	if p := patterns[0]; p.FirstSeen != now.Add(5*time.Second).UnixMicro() || p.LastSeen != now.Add(6*time.Second).UnixMicro() || len(p.Samples) != 2 {
+		t.Errorf("unexpected first/last seen or samples: %+v", p)
+	}
+}
+
+func TestTruncateSample(t *testing.T) {
+	// "日" is 3 bytes, the limit falls inside the last character
+	content := strings.Repeat("a", drainMaxSampleLength-1) + "日志"
+	sample := truncateSample(content)
+	if !utf8.ValidString(sample) || sample != strings.Repeat("a", drainMaxSampleLength-1) {
+		t.Errorf("unexpected sample of %d bytes: %q", len(sample), sample[len(sample)-4:])
+	}
+	if short := "日志"; truncateSample(short) != short {
+		t.Errorf("short content should be kept")
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxColumnTypeDepth limits the nesting of the column types, e.g. Array(Nullable(String)) is 2
+const maxColumnTypeDepth = 8
+
+// checkColumnType checks the type is a single ClickHouse data type, e.g. Nullable(String),
+// DateTime64(3, 'UTC'), Enum8('a' = 1, 'b' = 2), Map(String, Array(Int64)) or Tuple(code Int64, msg String).
+func checkColumnType(typ string) error {
+	p := &columnTypeParser{s: typ}
+	if !p.parseType(0) {
+		return fmt.Errorf("illegal type near position %d", p.pos)
+	}
+	if p.skipSpaces(); p.pos != len(p.s) {
+		return fmt.Errorf("unexpected %q after the type", p.s[p.pos:])
+	}
+	return nil
+}
+
+type columnTypeParser struct {
+	s   string
+	pos int
+}
+
+func (p *columnTypeParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *columnTypeParser) skipSpaces() {
+	for p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+func (p *columnTypeParser) ident() bool {
+	start := p.pos
+	for c := p.peek(); c == '_' || isLetter(c) || (p.pos > start && isDigit(c)); c = p.peek() {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// parseType parses a type name with the optional arguments in parentheses.
+func (p *columnTypeParser) parseType(depth int) bool {
+	if depth > maxColumnTypeDepth || !p.ident() {
+		return false
+	}
+	if p.skipSpaces(); p.peek() != '(' {
+		return true
+	}
+	p.pos++
+	for {
+		p.skipSpaces()
+		if !p.parseArg(depth + 1) {
+			return false
+		}
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// parseArg parses an argument of a type: a number, a string with an optional "= number" of enums,
+// a type, or a named element of tuples.
+func (p *columnTypeParser) parseArg(depth int) bool {
+	switch c := p.peek(); {
+	case isDigit(c) || c == '-':
+		return p.number()
+	case c == '\'':
+		if !p.quoted() {
+			return false
+		}
+		if p.skipSpaces(); p.peek() != '=' {
+			return true
+		}
+		p.pos++
+		p.skipSpaces()
+		return p.number()
+	}
+	if !p.parseType(depth) {
+		return false
+	}
+	// the name of a tuple element is followed by its type
+	if p.skipSpaces(); isLetter(p.peek()) {
+		return p.parseType(depth)
+	}
+	return true
+}
+
+func (p *columnTypeParser) number() bool {
+	if p.peek() == '-' {
+		p.pos++
+	}
+	start := p.pos
+	for c := p.peek(); isDigit(c) || c == '.'; c = p.peek() {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *columnTypeParser) quoted() bool {
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch p.s[p.pos] {
+		case '\\':
+			p.pos++
+		case '\'':
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// checkMaterialized checks the expression of a materialized column is a single expression,
+// which has balanced parentheses and no top-level comma, so that it can not add another ALTER command.
+func checkMaterialized(expr string) error {
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; c {
+		case '\'', '"', '`':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != c; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return errors.New("unterminated quote")
+			}
+			i = end
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth--; depth < 0 {
+				return errors.New("unbalanced parentheses")
+			}
+		case ',':
+			if depth == 0 {
+				return errors.New("top-level comma")
+			}
+		case ';', '#', '\n', '\r':
+			return fmt.Errorf("%q is not allowed", c)
+		case '-', '/':
+			if i+1 < len(expr) && (expr[i:i+2] == "--" || expr[i:i+2] == "/*") {
+				return errors.New("comments are not allowed")
+			}
+		}
+	}
+	if depth != 0 {
+		return errors.New("unbalanced parentheses")
+	}
+	return nil
+}
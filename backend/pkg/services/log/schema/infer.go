@@ -0,0 +1,501 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema infers the fields of the structured log tables from the sample logs.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+)
+
+const (
+	// DefaultMaxDepth is the depth of the nested objects flattened into columns
+	DefaultMaxDepth = 3
+	// DefaultMaxNestedKeys is the number of keys of a nested object flattened into columns,
+	// the objects having more keys are stored as Map(String, String)
+	DefaultMaxNestedKeys = 20
+
+	// the strings having at most lowCardinalityMax distinct values, each seen lowCardinalityRepeat times
+	// on average, are LowCardinality
+	lowCardinalityMax    = 50
+	lowCardinalityRepeat = 3
+	// the keys of the maps seen in frequentRatio of the logs are proposed as materialized columns
+	frequentRatio         = 0.5
+	maxMaterializedPerMap = 5
+	maxExamples           = 3
+)
+
+// builtinColumns are the columns of every log table created by the factory.
+var builtinColumns = map[string]struct{}{
+	"timestamp":          {},
+	"content":            {},
+	"source":             {},
+	"container_id":       {},
+	"pid":                {},
+	"container_name":     {},
+	"host_ip":            {},
+	"host_name":          {},
+	"k8s_namespace_name": {},
+	"k8s_pod_name":       {},
+}
+
+// keys usually filtered by equality, e.g. id, trace_id, userId and request.uuid
+var idPattern = regexp.MustCompile(`^(?i:id|uuid)$|[_.\-](?i:id|uuid)$|[a-z0-9](Id|ID|Uuid|UUID)$`)
+
+var (
+	// the layout JSONExtract parses as DateTime64
+	clickhouseTimeLayout = "2006-01-02 15:04:05.999999999"
+	otherTimeLayouts     = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+)
+
+type Options struct {
+	MaxDepth      int
+	MaxNestedKeys int
+	// FilteredFields are the fields frequently filtered, they are proposed with the skip indexes.
+	// The fields named like ids are proposed if it is empty.
+	FilteredFields []string
+}
+
+type kind int
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindInt
+	kindUInt
+	kindFloat
+	kindString
+	kindTime
+	kindObject
+	kindArray
+)
+
+// stat collects the values of a path in the logs.
+type stat struct {
+	path     []string
+	kinds    map[kind]int
+	elements map[kind]int
+	present  int
+	values   map[string]struct{}
+	examples []string
+	// keys of the objects, and the number of the logs containing each key
+	keys map[string]int
+}
+
+type inferrer struct {
+	opts  Options
+	stats map[string]*stat
+	order []string
+}
+
+// Infer proposes the fields of the structured log table from the samples of JSON logs.
+func Infer(samples []string, opts Options) *response.InferLogSchemaResponse {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.MaxNestedKeys <= 0 {
+		opts.MaxNestedKeys = DefaultMaxNestedKeys
+	}
+	in := &inferrer{opts: opts, stats: map[string]*stat{}}
+	res := &response.InferLogSchemaResponse{Fields: []response.InferredLogField{}, Warnings: []string{}}
+	for _, sample := range samples {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(sample)))
+		decoder.UseNumber()
+		var log map[string]any
+		if err := decoder.Decode(&log); err != nil || log == nil {
+			res.Invalid++
+			continue
+		}
+		res.Samples++
+		in.observeObject(nil, log)
+	}
+	if res.Samples == 0 {
+		return res
+	}
+	in.propose(res)
+	return res
+}
+
+func (in *inferrer) statOf(path []string) *stat {
+	key := strings.Join(path, "\x00")
+	s, find := in.stats[key]
+	if !find {
+		s = &stat{
+			path:     append([]string(nil), path...),
+			kinds:    map[kind]int{},
+			elements: map[kind]int{},
+			values:   map[string]struct{}{},
+			keys:     map[string]int{},
+		}
+		in.stats[key] = s
+		in.order = append(in.order, key)
+	}
+	return s
+}
+
+func (in *inferrer) observeObject(path []string, object map[string]any) {
+	// in order of keys for the stable names of the columns
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		in.observe(append(path[:len(path):len(path)], key), object[key])
+	}
+}
+
+func (in *inferrer) observe(path []string, value any) {
+	s := in.statOf(path)
+	s.present++
+	k := kindOf(value)
+	s.kinds[k]++
+	switch v := value.(type) {
+	case map[string]any:
+		for key := range v {
+			s.keys[key]++
+		}
+		if len(path) < in.opts.MaxDepth {
+			in.observeObject(path, v)
+		}
+	case []any:
+		for _, element := range v {
+			s.elements[kindOf(element)]++
+		}
+		s.addExample(compact(value))
+	case nil:
+	default:
+		s.addExample(fmt.Sprint(value))
+	}
+}
+
+func (s *stat) addExample(value string) {
+	if len(s.values) <= lowCardinalityMax {
+		s.values[value] = struct{}{}
+	}
+	if len(s.examples) < maxExamples && !contains(s.examples, value) {
+		s.examples = append(s.examples, value)
+	}
+}
+
+func kindOf(value any) kind {
+	switch v := value.(type) {
+	case nil:
+		return kindNull
+	case bool:
+		return kindBool
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return kindInt
+		}
+		if _, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return kindUInt
+		}
+		return kindFloat
+	case string:
+		if _, err := time.Parse(clickhouseTimeLayout, v); err == nil {
+			return kindTime
+		}
+		return kindString
+	case map[string]any:
+		return kindObject
+	case []any:
+		return kindArray
+	}
+	return kindString
+}
+
+func (in *inferrer) propose(res *response.InferLogSchemaResponse) {
+	filtered := make(map[string]struct{}, len(in.opts.FilteredFields))
+	for _, name := range in.opts.FilteredFields {
+		filtered[name] = struct{}{}
+	}
+	names := map[string]struct{}{}
+	for name := range builtinColumns {
+		names[name] = struct{}{}
+	}
+
+	// the objects stored as maps, their children are not columns
+	var maps []string
+	for _, key := range in.order {
+		s := in.stats[key]
+		if underMap(s.path, maps) {
+			continue
+		}
+		k := s.kind()
+		if k == kindObject && len(s.path) < in.opts.MaxDepth && len(s.keys) <= in.opts.MaxNestedKeys {
+			// flattened into the columns of the children
+			continue
+		}
+		if k == kindObject {
+			maps = append(maps, key+"\x00")
+		}
+
+		field := response.InferredLogField{
+			Frequency: float64(s.present) / float64(res.Samples),
+			Distinct:  len(s.values),
+			Examples:  s.examples,
+		}
+		field.Name = columnName(s.path, names)
+		if len(s.path) > 1 || field.Name != s.path[0] {
+			field.Path = s.path
+		}
+		if _, builtin := builtinColumns[s.path[0]]; builtin && len(s.path) == 1 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("%s conflicts with the built-in column, it is stored as %s", s.path[0], field.Name))
+		}
+		if field.Examples == nil {
+			field.Examples = []string{}
+		}
+		if !s.proposeType(&field) {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("%s is %s, it is only kept in the raw log", strings.Join(s.path, "."), field.Hints[0]))
+			continue
+		}
+		proposeIndex(&field, s, filtered)
+		res.Fields = append(res.Fields, field)
+
+		if field.Type == "Map(String, String)" {
+			res.Fields = append(res.Fields, materializedKeys(&field, s, res.Samples, names)...)
+		}
+	}
+	sort.SliceStable(res.Fields, func(i, j int) bool {
+		return res.Fields[i].Frequency > res.Fields[j].Frequency
+	})
+}
+
+func underMap(path []string, maps []string) bool {
+	key := strings.Join(path, "\x00")
+	for _, prefix := range maps {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// kind returns the kind of the values ignoring nulls, kindString if they are mixed.
+func (s *stat) kind() kind {
+	return mergeKinds(s.kinds)
+}
+
+func mergeKinds(kinds map[kind]int) kind {
+	merged := kindNull
+	for k, count := range kinds {
+		if k == kindNull || count == 0 {
+			continue
+		}
+		switch {
+		case merged == kindNull || merged == k:
+			merged = k
+		case isNumber(merged) && isNumber(k):
+			// decimals, or both negative and larger than Int64
+			merged = kindFloat
+		case (merged == kindTime && k == kindString) || (merged == kindString && k == kindTime):
+			merged = kindString
+		default:
+			return kindString
+		}
+	}
+	return merged
+}
+
+func isNumber(k kind) bool {
+	return k == kindInt || k == kindUInt || k == kindFloat
+}
+
+// proposeType returns false if the values can not be stored in a column.
+func (s *stat) proposeType(field *response.InferredLogField) bool {
+	k := s.kind()
+	if k == kindString && s.kinds[kindObject]+s.kinds[kindArray] > 0 {
+		field.Hints = append(field.Hints, "mixed objects and scalars")
+		return false
+	}
+	switch k {
+	case kindNull:
+		field.Type = "String"
+		field.Hints = append(field.Hints, "always null, String is proposed")
+	case kindBool:
+		field.Type = "Bool"
+	case kindInt:
+		field.Type = "Int64"
+	case kindUInt:
+		field.Type = "UInt64"
+	case kindFloat:
+		field.Type = "Float64"
+	case kindTime:
+		field.Type = "DateTime64(9)"
+	case kindObject:
+		field.Type = "Map(String, String)"
+		field.Hints = append(field.Hints, fmt.Sprintf("object of %d keys, the values are kept as strings", len(s.keys)))
+	case kindArray:
+		element := mergeKinds(s.elements)
+		switch element {
+		case kindObject, kindArray:
+			field.Hints = append(field.Hints, "array of objects")
+			return false
+		case kindNull:
+			element = kindString
+		}
+		field.Type = fmt.Sprintf("Array(%s)", scalarType(element))
+	default:
+		field.Type = "String"
+		if s.kinds[kindString] > 0 && s.kinds[kindTime] > 0 {
+			field.Hints = append(field.Hints, "some values are not time")
+		}
+		if len(s.kinds) > 1 && s.kinds[kindString] < s.present-s.kinds[kindNull] {
+			field.Hints = append(field.Hints, "mixed types, the values which are not strings are empty")
+		} else if s.isOtherTime() {
+			field.Hints = append(field.Hints, "looks like time, but JSONExtract does not parse the format as DateTime64")
+		} else if len(s.values) <= lowCardinalityMax && s.present >= lowCardinalityRepeat*len(s.values) {
+			field.Type = "LowCardinality(String)"
+			field.Hints = append(field.Hints, fmt.Sprintf("%d distinct values", len(s.values)))
+		}
+	}
+	return true
+}
+
+func (s *stat) isOtherTime() bool {
+	if len(s.examples) == 0 {
+		return false
+	}
+	for _, example := range s.examples {
+		parsed := false
+		for _, layout := range otherTimeLayouts {
+			if _, err := time.Parse(layout, example); err == nil {
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return false
+		}
+	}
+	return true
+}
+
+func scalarType(k kind) string {
+	switch k {
+	case kindBool:
+		return "Bool"
+	case kindInt:
+		return "Int64"
+	case kindUInt:
+		return "UInt64"
+	case kindFloat:
+		return "Float64"
+	case kindTime:
+		return "DateTime64(9)"
+	}
+	return "String"
+}
+
+// proposeIndex proposes the skip index for the filtered fields.
+func proposeIndex(field *response.InferredLogField, s *stat, filtered map[string]struct{}) {
+	dotted := strings.Join(s.path, ".")
+	_, isFiltered := filtered[field.Name]
+	if _, find := filtered[dotted]; find {
+		isFiltered = true
+	}
+	if len(filtered) == 0 && idPattern.MatchString(s.path[len(s.path)-1]) {
+		isFiltered = true
+	}
+	if !isFiltered {
+		return
+	}
+	switch {
+	case strings.HasPrefix(field.Type, "LowCardinality"):
+		field.Index = fmt.Sprintf("set(%d)", lowCardinalityMax*2)
+	case field.Type == "String":
+		field.Index = "bloom_filter(0.01)"
+	case field.Type == "Int64" || field.Type == "UInt64" || field.Type == "Float64" || strings.HasPrefix(field.Type, "DateTime64"):
+		field.Index = "minmax"
+	case strings.HasPrefix(field.Type, "Array") || strings.HasPrefix(field.Type, "Map"):
+		field.Index = "bloom_filter(0.01)"
+	default:
+		return
+	}
+	field.Hints = append(field.Hints, "frequently filtered, "+field.Index+" index is proposed")
+}
+
+// materializedKeys proposes the frequent keys of the map as materialized columns.
+func materializedKeys(field *response.InferredLogField, s *stat, samples int, names map[string]struct{}) []response.InferredLogField {
+	keys := make([]string, 0, len(s.keys))
+	for key, count := range s.keys {
+		if float64(count)/float64(samples) >= frequentRatio {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if s.keys[keys[i]] != s.keys[keys[j]] {
+			return s.keys[keys[i]] > s.keys[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxMaterializedPerMap {
+		keys = keys[:maxMaterializedPerMap]
+	}
+
+	var fields []response.InferredLogField
+	for _, key := range keys {
+		materialized := response.InferredLogField{
+			Frequency: float64(s.keys[key]) / float64(samples),
+			Examples:  []string{},
+			Hints:     []string{"frequent key of " + field.Name},
+		}
+		materialized.Name = columnName(append(s.path[:len(s.path):len(s.path)], key), names)
+		materialized.Type = "String"
+		materialized.Materialized = fmt.Sprintf("`%s`['%s']", field.Name, strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(key))
+		fields = append(fields, materialized)
+	}
+	return fields
+}
+
+var invalidName = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// columnName returns the unique name of the column from the path, e.g. http.status is http_status.
+func columnName(path []string, names map[string]struct{}) string {
+	name := strings.Trim(invalidName.ReplaceAllString(strings.Join(path, "_"), "_"), "_")
+	if len(name) == 0 || (name[0] >= '0' && name[0] <= '9') {
+		name = "f_" + name
+	}
+	if _, find := names[name]; find {
+		base := name
+		for i := 1; ; i++ {
+			if _, find := builtinColumns[base]; find && i == 1 {
+				name = "log_" + base
+			} else {
+				name = fmt.Sprintf("%s_%d", base, i)
+			}
+			if _, find := names[name]; !find {
+				break
+			}
+		}
+	}
+	names[name] = struct{}{}
+	return name
+}
+
+func compact(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
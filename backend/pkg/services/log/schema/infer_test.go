@@ -0,0 +1,137 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+)
+
+func TestInfer(t *testing.T) {
+	var samples []string
+	for i := 0; i < 10; i++ {
+		attributes := make([]string, 0, 25)
+		for j := 0; j < 25; j++ {
+			attributes = append(attributes, fmt.Sprintf(`"k%d": "v%d"`, j+i, j))
+		}
+		samples = append(samples, fmt.Sprintf(`{
+			"timestamp": "2025-01-02T03:04:05Z",
+			"level": "%s",
+			"cost": %d,
+			"ratio": %d.5,
+			"ok": true,
+			"traceId": "%032x",
+			"http": {"method": "GET", "status": 200, "request": {"path": "/api/%d", "headers": {"a": "b"}}},
+			"tags": ["a", "b"],
+			"items": [{"id": 1}],
+			"attributes": {%s},
+			"created": "2025-01-02 03:04:05.123",
+			"maybe": null
+		}`, []string{"info", "warn"}[i%2], i, i, i, i, strings.Join(attributes, ",")))
+	}
+	samples = append(samples, "not json", `["array"]`)
+
+	res := Infer(samples, Options{})
+	if res.Samples != 10 || res.Invalid != 2 {
+		t.Fatalf("samples = %d, invalid = %d", res.Samples, res.Invalid)
+	}
+
+	fields := map[string]response.InferredLogField{}
+	for _, field := range res.Fields {
+		fields[field.Name] = field
+	}
+	tests := []struct {
+		name  string
+		typ   string
+		path  []string
+		index string
+	}{
+		{"log_timestamp", "String", []string{"timestamp"}, ""},
+		{"level", "LowCardinality(String)", nil, ""},
+		{"cost", "Int64", nil, ""},
+		{"ratio", "Float64", nil, ""},
+		{"ok", "Bool", nil, ""},
+		{"traceId", "String", nil, "bloom_filter(0.01)"},
+		{"http_method", "LowCardinality(String)", []string{"http", "method"}, ""},
+		{"http_status", "Int64", []string{"http", "status"}, ""},
+		// deeper than MaxDepth
+		{"http_request_path", "String", []string{"http", "request", "path"}, ""},
+		{"http_request_headers", "Map(String, String)", []string{"http", "request", "headers"}, ""},
+		{"tags", "Array(String)", nil, ""},
+		// more keys than MaxNestedKeys
+		{"attributes", "Map(String, String)", nil, ""},
+		{"created", "DateTime64(9)", nil, ""},
+		{"maybe", "String", nil, ""},
+	}
+	for _, tt := range tests {
+		field, find := fields[tt.name]
+		if !find {
+			t.Errorf("%s not found in %v", tt.name, res.Fields)
+			continue
+		}
+		if field.Type != tt.typ || !reflect.DeepEqual(field.Path, tt.path) || field.Index != tt.index {
+			t.Errorf("%s = %+v", tt.name, field)
+		}
+	}
+	if _, find := fields["items"]; find {
+		t.Error("array of objects should not be a column")
+	}
+	if _, find := fields["attributes_k0"]; find {
+		t.Error("children of the map should not be columns")
+	}
+
+	// k9..k24 are in every log
+	materialized := fields["attributes_k10"]
+	if materialized.Materialized != "`attributes`['k10']" || materialized.Frequency != 1 {
+		t.Errorf("attributes_k10 = %+v", materialized)
+	}
+	var count int
+	for _, field := range res.Fields {
+		if strings.HasPrefix(field.Materialized, "`attributes`") {
+			count++
+		}
+	}
+	if count != maxMaterializedPerMap {
+		t.Errorf("materialized = %d", count)
+	}
+	if len(res.Warnings) != 2 {
+		t.Errorf("warnings = %v", res.Warnings)
+	}
+}
+
+func TestInferFilteredFields(t *testing.T) {
+	samples := []string{`{"user": {"id": "u1"}, "traceId": "t1", "cost": 1}`, `{"user": {"id": "u2"}, "cost": -1}`}
+	res := Infer(samples, Options{FilteredFields: []string{"user.id", "cost"}})
+	indexes := map[string]string{}
+	for _, field := range res.Fields {
+		indexes[field.Name] = field.Index
+	}
+	want := map[string]string{"user_id": "bloom_filter(0.01)", "traceId": "", "cost": "minmax"}
+	if !reflect.DeepEqual(indexes, want) {
+		t.Fatalf("indexes = %v", indexes)
+	}
+}
+
+func TestMergeKinds(t *testing.T) {
+	tests := []struct {
+		kinds map[kind]int
+		want  kind
+	}{
+		{map[kind]int{kindNull: 1}, kindNull},
+		{map[kind]int{kindInt: 1, kindNull: 1}, kindInt},
+		{map[kind]int{kindInt: 1, kindFloat: 1}, kindFloat},
+		{map[kind]int{kindInt: 1, kindUInt: 1}, kindFloat},
+		{map[kind]int{kindTime: 1, kindString: 1}, kindString},
+		{map[kind]int{kindBool: 1, kindInt: 1}, kindString},
+	}
+	for _, tt := range tests {
+		if got := mergeKinds(tt.kinds); got != tt.want {
+			t.Errorf("mergeKinds(%v) = %v, want %v", tt.kinds, got, tt.want)
+		}
+	}
+}
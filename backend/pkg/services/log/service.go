@@ -11,6 +11,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
 )
 
 var _ Service = (*service)(nil)
@@ -28,25 +29,55 @@ type Service interface {
 	UpdateLogTable(ctx core.Context, req *request.LogTableRequest) (*response.LogTableResponse, error)
 
 	GetLogTableInfo(ctx core.Context, req *request.LogTableInfoRequest) (*response.LogTableInfoResponse, error)
+	// Propose the fields of the structured logs from the samples
+	InferLogSchema(ctx core.Context, req *request.InferLogSchemaRequest) (*response.InferLogSchemaResponse, error)
+	// Change the fields of the log table and its view, the statements are only returned if dry run
+	UpdateLogSchema(ctx core.Context, req *request.UpdateLogSchemaRequest) (*response.UpdateLogSchemaResponse, error)
 
 	// Query full logs
 	QueryLog(ctx core.Context, req *request.LogQueryRequest) (*response.LogQueryResponse, error)
 
 	QueryLogContext(ctx core.Context, req *request.LogQueryContextRequest) (*response.LogQueryContextResponse, error)
+	// Correlate a log with its trace, span, service endpoint RED chart, pod k8s events and alerts
+	CorrelateLog(ctx core.Context, req *request.CorrelateLogRequest) (*response.CorrelateLogResponse, error)
+	// Saved queries owned by the user or shared with the user through a team or data group
+	CreateLogSavedQuery(ctx core.Context, req *request.CreateLogSavedQueryRequest) (*database.LogSavedQuery, error)
+	UpdateLogSavedQuery(ctx core.Context, req *request.UpdateLogSavedQueryRequest) error
+	DeleteLogSavedQuery(ctx core.Context, req *request.LogSavedQueryRequest) error
+	GetLogSavedQuery(ctx core.Context, req *request.LogSavedQueryRequest) (*response.LogSavedQuery, error)
+	ListLogSavedQueries(ctx core.Context) (*response.ListLogSavedQueriesResponse, error)
+	// Recent queries of the user, recorded by QueryLog
+	ListLogQueryHistory(ctx core.Context, req *request.ListLogQueryHistoryRequest) (*response.ListLogQueryHistoryResponse, error)
+	DeleteLogQueryHistory(ctx core.Context, req *request.DeleteLogQueryHistoryRequest) error
 	// Log Trend Chart
 	GetLogChart(ctx core.Context, req *request.LogQueryRequest) (*response.LogChartResponse, error)
 	// Field Analysis
 	GetLogIndex(ctx core.Context, req *request.LogIndexRequest) (*response.LogIndexResponse, error)
+	// Log templates mining
+	GetLogPatterns(ctx core.Context, req *request.LogPatternRequest) (*response.LogPatternResponse, error)
+	// Live log tail, pushes new logs by send until the session ends
+	TailLog(ctx core.Context, req *request.LogTailRequest, send func(event string, data any) error) error
 
 	GetServiceRoute(ctx core.Context, req *request.GetServiceRouteRequest) (*response.GetServiceRouteResponse, error)
 
 	GetLogParseRule(ctx core.Context, req *request.QueryLogParseRequest) (*response.LogParseResponse, error)
 
+	// UpdateLogParseRule validates the rule before pushing it to Vector, the push is rolled back if logs stop flowing
 	UpdateLogParseRule(ctx core.Context, req *request.UpdateLogParseRequest) (*response.LogParseResponse, error)
 
 	AddLogParseRule(ctx core.Context, req *request.AddLogParseRequest) (*response.LogParseResponse, error)
 
 	DeleteLogParseRule(ctx core.Context, req *request.DeleteLogParseRequest) (*response.LogParseResponse, error)
+	// Test the parse rule against the sample logs
+	TestLogParseRule(ctx core.Context, req *request.TestLogParseRequest) (*response.TestLogParseResponse, error)
+
+	// Vector pipeline, every push is recorded as a version
+	GetVectorPipeline(ctx core.Context) (*response.GetVectorPipelineResponse, error)
+	GetVectorPipelineVersion(ctx core.Context, req *request.GetVectorPipelineVersionRequest) (*response.GetVectorPipelineResponse, error)
+	UpdateVectorPipeline(ctx core.Context, req *request.UpdateVectorPipelineRequest) error
+	ListVectorPipelineVersions(ctx core.Context) (*response.ListVectorPipelineVersionsResponse, error)
+	DiffVectorPipeline(ctx core.Context, req *request.DiffVectorPipelineRequest) (*response.DiffVectorPipelineResponse, error)
+	RollbackVectorPipeline(ctx core.Context, req *request.RollbackVectorPipelineRequest) error
 
 	OtherTable(ctx core.Context, req *request.OtherTableRequest) (*response.OtherTableResponse, error)
 
@@ -58,14 +89,16 @@ type Service interface {
 }
 
 type service struct {
+	logger   *zap.Logger
 	chRepo   clickhouse.Repo
 	dbRepo   database.Repo
 	k8sApi   kubernetes.Repo
 	promRepo prometheus.Repo
 }
 
-func New(chRepo clickhouse.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo, promRepo prometheus.Repo) Service {
+func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo, promRepo prometheus.Repo) Service {
 	return &service{
+		logger:   logger,
 		chRepo:   chRepo,
 		dbRepo:   dbRepo,
 		k8sApi:   k8sApi,
@@ -9,12 +9,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/CloudDetail/apo/backend/pkg/code"
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/services/log/vector"
-	"gopkg.in/yaml.v3"
 )
 
 func getRouteRule(routeMap map[string]string) string {
@@ -34,7 +35,43 @@ func getRouteRule(routeMap map[string]string) string {
 
 var fieldsRegexp = regexp.MustCompile(`\?P<(?P<name>\w+)>`)
 
+// parsedFields returns the table fields of the named groups in the parse rule,
+// the types are String unless customized. The customized materialized columns are kept.
+func parsedFields(parseRule string, customized []request.Field) []request.Field {
+	fields := make([]request.Field, 0)
+	matchesFields := fieldsRegexp.FindAllStringSubmatch(parseRule, -1)
+	for _, match := range matchesFields {
+		if match[1] == "msg" || match[1] == "ts" {
+			continue
+		}
+
+		parsedField := request.Field{
+			Name: match[1],
+			Type: "String",
+		}
+
+		for _, customizedFiled := range customized {
+			if parsedField.Name == customizedFiled.Name {
+				parsedField = customizedFiled
+			}
+		}
+		fields = append(fields, parsedField)
+	}
+	for _, customizedFiled := range customized {
+		if len(customizedFiled.Materialized) > 0 {
+			fields = append(fields, customizedFiled)
+		}
+	}
+	return fields
+}
+
 func (s *service) AddLogParseRule(ctx core.Context, req *request.AddLogParseRequest) (*response.LogParseResponse, error) {
+	if !req.IsStructured {
+		if err := vector.ValidateVRL(req.ParseRule); err != nil {
+			return nil, core.Error(code.LogParseRuleIllegalError, err.Error())
+		}
+	}
+
 	// build the table first
 	logReq := &request.LogTableRequest{
 		TableName: "logs_" + req.ParseName,
@@ -44,24 +81,10 @@ func (s *service) AddLogParseRule(ctx core.Context, req *request.AddLogParseRequ
 	if req.IsStructured {
 		fields = req.Fields
 	} else {
-		matchesFields := fieldsRegexp.FindAllStringSubmatch(req.ParseRule, -1)
-		for _, match := range matchesFields {
-			if match[1] == "msg" || match[1] == "ts" {
-				continue
-			}
-
-			parsedField := request.Field{
-				Name: match[1],
-				Type: "String",
-			}
-
-			for _, customizedFiled := range req.Fields {
-				if parsedField.Name == customizedFiled.Name {
-					parsedField.Type = customizedFiled.Type
-				}
-			}
-			fields = append(fields, parsedField)
-		}
+		fields = parsedFields(req.ParseRule, req.Fields)
+	}
+	if err := checkFieldDefinitions(fields); err != nil {
+		return nil, core.Error(code.LogParseRuleIllegalError, err.Error())
 	}
 
 	logReq.TTL = req.LogTable.TTL
@@ -74,12 +97,11 @@ func (s *service) AddLogParseRule(ctx core.Context, req *request.AddLogParseRequ
 		return nil, err
 	}
 	// update k8s configmap
-	data, err := s.k8sApi.GetVectorConfigFile()
+	data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
 	if err != nil {
 		return nil, err
 	}
-	var vectorCfg vector.VectorConfig
-	err = yaml.Unmarshal([]byte(data["aggregator.yaml"]), &vectorCfg)
+	vectorCfg, err := vector.ParsePipeline(data["aggregator.yaml"])
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +121,11 @@ func (s *service) AddLogParseRule(ctx core.Context, req *request.AddLogParseRequ
 	if err != nil {
 		return nil, err
 	}
-	err = s.k8sApi.UpdateVectorConfigFile(newData)
+	// the table is kept if rolled back, it is reused when the rule is added again
+	tables := map[string][]string{logReq.DataBase: {"raw_logs", logReq.TableName}}
+	err = s.pushVectorConfig(ctx, data["aggregator.yaml"], newData, "add parse rule "+req.ParseName, tables, func(ctx core.Context) error {
+		return s.dbRepo.OperateLogTableInfo(ctx, &database.LogTableInfo{DataBase: logReq.DataBase, Table: logReq.TableName}, database.DELETE)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,340 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCorrelateWindow = 15 * time.Minute
+	// correlateChartPoints is the number of points of the RED chart
+	correlateChartPoints = 30
+	maxCorrelatedAlerts  = 50
+)
+
+// Common column names of the log tables, compared after normalizing, the first found is used.
+// The APO log tables use trace_id, k8s_pod_name, container_id, k8s_namespace_name and host_name.
+var (
+	traceIDColumns   = []string{"traceid"}
+	spanIDColumns    = []string{"spanid"}
+	podColumns       = []string{"k8spodname", "podname", "pod"}
+	containerColumns = []string{"containerid"}
+	namespaceColumns = []string{"k8snamespacename", "namespace"}
+	serviceColumns   = []string{"servicename", "svcname"}
+	nodeColumns      = []string{"hostname", "nodename", "k8snodename"}
+)
+
+var (
+	// trace_id=xxx, "traceId": "xxx", [traceid:xxx] and so on
+	traceIDPattern = regexp.MustCompile(`(?i)trace[_.\-]?id["']?\s*[:=]\s*["']?([0-9a-f]{16,32})\b`)
+	// W3C traceparent, version-traceid-spanid-flags
+	traceparentPattern = regexp.MustCompile(`\b[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}\b`)
+)
+
+func (s *service) CorrelateLog(ctx core.Context, req *request.CorrelateLogRequest) (*response.CorrelateLogResponse, error) {
+	correlation := correlationOfLog(req)
+	window := defaultCorrelateWindow
+	if req.WindowMinutes > 0 {
+		window = time.Duration(req.WindowMinutes) * time.Minute
+	}
+	at := time.UnixMicro(req.Time)
+	startTime, endTime := at.Add(-window), at.Add(window)
+
+	resp := &response.CorrelateLogResponse{
+		StartTime: startTime.UnixMicro(),
+		EndTime:   endTime.UnixMicro(),
+		Trace:     []clickhouse.CorrelatedSpan{},
+		K8sEvents: []clickhouse.K8sEvents{},
+		Alerts:    []alert.AlertEvent{},
+		Errors:    map[string]string{},
+	}
+	fail := func(name string, err error) {
+		s.logger.Warn("failed to correlate the log", zap.String("correlation", name), zap.Error(err))
+		resp.Errors[name] = err.Error()
+	}
+
+	if len(correlation.TraceID) > 0 {
+		spans, err := s.chRepo.ListTraceSpans(ctx, correlation.TraceID, startTime, endTime)
+		if err != nil {
+			fail("trace", err)
+		} else {
+			resp.Trace = spans
+			resp.Span = spanOfLog(spans, &correlation, req.Time)
+		}
+	}
+	if resp.Span == nil && (len(correlation.PodName) > 0 || len(correlation.ContainerID) > 0) {
+		span, err := s.chRepo.FindSpanAt(ctx, correlation.PodName, correlation.ContainerID, at, startTime, endTime)
+		if err != nil {
+			fail("span", err)
+		}
+		resp.Span = span
+	}
+	fillBySpan(&correlation, resp.Span)
+	resp.Correlation = correlation
+
+	if len(correlation.ServiceName) > 0 {
+		charts, err := s.getEndpointRedCharts(ctx, correlation.ServiceName, correlation.EndPoint, startTime, endTime)
+		if err != nil {
+			fail("redCharts", err)
+		}
+		resp.RedCharts = charts
+	}
+
+	if len(correlation.PodName) > 0 {
+		events, err := s.chRepo.GetPodK8sEvents(ctx, startTime, endTime, correlation.Namespace, correlation.PodName)
+		if err != nil {
+			fail("k8sEvents", err)
+		} else {
+			resp.K8sEvents = events
+		}
+	}
+
+	if len(correlation.ServiceName) > 0 || len(correlation.PodName) > 0 || len(correlation.NodeName) > 0 {
+		filter, instances := alertFilterOf(&correlation)
+		alerts, total, err := s.chRepo.GetAlertEvents(ctx, startTime, endTime, filter, instances, &request.PageParam{
+			CurrentPage: 1,
+			PageSize:    maxCorrelatedAlerts,
+		})
+		if err != nil {
+			fail("alerts", err)
+		} else {
+			resp.Alerts, resp.AlertTotal = alerts, total
+		}
+	}
+	return resp, nil
+}
+
+func (s *service) getEndpointRedCharts(ctx core.Context, serviceName string, endpoint string, startTime, endTime time.Time) (*response.RedCharts, error) {
+	step := max(endTime.Sub(startTime)/correlateChartPoints, time.Minute).Truncate(time.Minute)
+	filters := []string{prometheus.ServicePQLFilter, serviceName}
+	granularity := prometheus.SVCGranularity
+	if len(endpoint) > 0 {
+		filters = append(filters, prometheus.ContentKeyPQLFilter, endpoint)
+		granularity = prometheus.EndpointGranularity
+	}
+
+	charts := &response.RedCharts{}
+	for _, metric := range []struct {
+		pql   prometheus.AggPQLWithFilters
+		chart *map[int64]float64
+		scale float64
+	}{
+		{prometheus.PQLAvgLatencyWithFilters, &charts.Latency, 1e-3},
+		{prometheus.PQLAvgErrorRateWithFilters, &charts.ErrorRate, 100},
+		{prometheus.PQLAvgTPSWithFilters, &charts.RPS, 60},
+	} {
+		results, err := s.promRepo.QueryRangeAggMetricsWithFilter(ctx, metric.pql,
+			startTime.UnixMicro(), endTime.UnixMicro(), step.Microseconds(), granularity, filters...)
+		if err != nil {
+			return nil, err
+		}
+		chart := make(map[int64]float64)
+		if len(results) > 0 {
+			for _, point := range results[0].Values {
+				value := point.Value
+				if math.IsInf(value, 1) {
+					value = prometheus.RES_MAX_VALUE
+				} else {
+					value *= metric.scale
+				}
+				chart[point.TimeStamp] = value
+			}
+		}
+		*metric.chart = chart
+	}
+	return charts, nil
+}
+
+// correlationOfLog reads the trace, span, pod, container and service from the log row.
+func correlationOfLog(req *request.CorrelateLogRequest) response.LogCorrelation {
+	columns := make(map[string]string, len(req.Tags)+len(req.LogFields))
+	for _, fields := range []map[string]any{req.Tags, req.LogFields} {
+		for k, v := range fields {
+			if value := columnValue(v); len(value) > 0 {
+				columns[k] = value
+			}
+		}
+	}
+
+	correlation := response.LogCorrelation{
+		TraceID:     lookupColumn(columns, req.TraceIDField, traceIDColumns),
+		SpanID:      lookupColumn(columns, "", spanIDColumns),
+		PodName:     lookupColumn(columns, req.PodField, podColumns),
+		Namespace:   lookupColumn(columns, req.NamespaceField, namespaceColumns),
+		ContainerID: lookupColumn(columns, req.ContainerField, containerColumns),
+		ServiceName: lookupColumn(columns, req.ServiceField, serviceColumns),
+		NodeName:    lookupColumn(columns, "", nodeColumns),
+	}
+	if len(correlation.TraceID) == 0 {
+		correlation.TraceID, correlation.SpanID = traceOfContent(req.Content, correlation.SpanID)
+	}
+	return correlation
+}
+
+// lookupColumn returns the value of the field if it is set, or the first column matching the names.
+// A column matches if its normalized name equals the name or ends with it, e.g. resource.k8s.pod.name.
+func lookupColumn(columns map[string]string, field string, names []string) string {
+	if len(field) > 0 {
+		return columns[field]
+	}
+	normalized := make(map[string]string, len(columns))
+	for k, v := range columns {
+		normalized[normalizeColumn(k)] = v
+	}
+	for _, name := range names {
+		if v, find := normalized[name]; find {
+			return v
+		}
+	}
+	for _, name := range names {
+		var matched, value string
+		for k, v := range normalized {
+			// prefer the shortest column, break the ties by name for a stable result
+			if strings.HasSuffix(k, name) && (len(matched) == 0 || len(k) < len(matched) || (len(k) == len(matched) && k < matched)) {
+				matched, value = k, v
+			}
+		}
+		if len(matched) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+func normalizeColumn(column string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(column) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func columnValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(value)
+	case []byte:
+		return strings.TrimSpace(string(value))
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// traceOfContent finds the trace id in the content of the unstructured logs.
+func traceOfContent(content string, spanID string) (string, string) {
+	if matches := traceparentPattern.FindStringSubmatch(content); matches != nil {
+		if len(spanID) == 0 {
+			spanID = matches[2]
+		}
+		return matches[1], spanID
+	}
+	if matches := traceIDPattern.FindStringSubmatch(content); matches != nil {
+		return matches[1], spanID
+	}
+	return "", spanID
+}
+
+// spanOfLog finds the span which writes the log in the trace, it is the span of the same id,
+// or the shortest span running on the same pod or container at the time of the log.
+func spanOfLog(spans []clickhouse.CorrelatedSpan, correlation *response.LogCorrelation, timeUs int64) *clickhouse.CorrelatedSpan {
+	if len(correlation.SpanID) > 0 {
+		for i := range spans {
+			if spans[i].SpanId == correlation.SpanID {
+				return &spans[i]
+			}
+		}
+	}
+
+	var found *clickhouse.CorrelatedSpan
+	for i := range spans {
+		span := &spans[i]
+		if !sameInstance(span, correlation) {
+			continue
+		}
+		if timeUs < int64(span.StartTime) || timeUs > int64(span.EndTime) {
+			continue
+		}
+		if found == nil || span.Duration < found.Duration {
+			found = span
+		}
+	}
+	if found == nil && len(spans) == 1 {
+		return &spans[0]
+	}
+	return found
+}
+
+func sameInstance(span *clickhouse.CorrelatedSpan, correlation *response.LogCorrelation) bool {
+	if len(correlation.ContainerID) > 0 && len(span.ContainerId) > 0 {
+		// the container id is short in some logs
+		return strings.HasPrefix(span.ContainerId, correlation.ContainerID) || strings.HasPrefix(correlation.ContainerID, span.ContainerId)
+	}
+	if len(correlation.PodName) > 0 {
+		return span.PodName == correlation.PodName
+	}
+	if len(correlation.ServiceName) > 0 {
+		return span.ServiceName == correlation.ServiceName
+	}
+	return true
+}
+
+// fillBySpan completes the correlation by the span, the values read from the log take precedence.
+func fillBySpan(correlation *response.LogCorrelation, span *clickhouse.CorrelatedSpan) {
+	if span == nil {
+		return
+	}
+	fill := func(target *string, value string) {
+		if len(*target) == 0 {
+			*target = value
+		}
+	}
+	fill(&correlation.TraceID, span.TraceId)
+	fill(&correlation.SpanID, span.SpanId)
+	fill(&correlation.PodName, span.PodName)
+	fill(&correlation.Namespace, span.Namespace)
+	fill(&correlation.ContainerID, span.ContainerId)
+	fill(&correlation.NodeName, span.NodeName)
+	fill(&correlation.ServiceName, span.ServiceName)
+	fill(&correlation.EndPoint, span.EndPoint)
+}
+
+// alertFilterOf returns the alerts filter of the service endpoint, the pod and the node.
+func alertFilterOf(correlation *response.LogCorrelation) (request.AlertFilter, *model.RelatedInstances) {
+	var filter request.AlertFilter
+	if len(correlation.ServiceName) > 0 {
+		filter.Services = []string{correlation.ServiceName}
+		if len(correlation.EndPoint) > 0 {
+			filter.Endpoints = []string{correlation.EndPoint}
+		}
+	}
+	var instances *model.RelatedInstances
+	if len(correlation.PodName) > 0 || len(correlation.NodeName) > 0 {
+		instances = &model.RelatedInstances{SIs: []*model.ServiceInstance{{
+			ServiceName: correlation.ServiceName,
+			ContainerId: correlation.ContainerID,
+			PodName:     correlation.PodName,
+			Namespace:   correlation.Namespace,
+			NodeName:    correlation.NodeName,
+		}}}
+	}
+	return filter, instances
+}
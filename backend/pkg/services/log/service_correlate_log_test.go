@@ -0,0 +1,119 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+)
+
+func TestCorrelationOfLog(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *request.CorrelateLogRequest
+		want response.LogCorrelation
+	}{
+		{
+			name: "apo log table",
+			req: &request.CorrelateLogRequest{
+				Tags: map[string]any{
+					"k8s_pod_name":       "ts-order-0",
+					"k8s_namespace_name": "train",
+					"container_id":       "2f1c",
+					"container_name":     "ts-order",
+					"host_name":          "node-1",
+				},
+				LogFields: map[string]any{"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+			},
+			want: response.LogCorrelation{
+				TraceID:     "4bf92f3577b34da6a3ce929d0e0e4736",
+				PodName:     "ts-order-0",
+				Namespace:   "train",
+				ContainerID: "2f1c",
+				NodeName:    "node-1",
+			},
+		},
+		{
+			name: "otel columns of other log table",
+			req: &request.CorrelateLogRequest{
+				Tags: map[string]any{
+					"TraceId":                         "4bf92f3577b34da6a3ce929d0e0e4736",
+					"SpanId":                          "00f067aa0ba902b7",
+					"ResourceAttributes.k8s.pod.name": "ts-order-0",
+					"ResourceAttributes.service.name": "ts-order-service",
+				},
+			},
+			want: response.LogCorrelation{
+				TraceID:     "4bf92f3577b34da6a3ce929d0e0e4736",
+				SpanID:      "00f067aa0ba902b7",
+				PodName:     "ts-order-0",
+				ServiceName: "ts-order-service",
+			},
+		},
+		{
+			name: "specified fields",
+			req: &request.CorrelateLogRequest{
+				Tags:         map[string]any{"tid": "abc", "trace_id": "", "instance": "ts-order-0"},
+				TraceIDField: "tid",
+				PodField:     "instance",
+			},
+			want: response.LogCorrelation{TraceID: "abc", PodName: "ts-order-0"},
+		},
+		{
+			name: "trace id in content",
+			req: &request.CorrelateLogRequest{
+				Content: `2025-01-02 INFO [ts-order,traceId: 4bf92f3577b34da6a3ce929d0e0e4736] order created`,
+			},
+			want: response.LogCorrelation{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		},
+		{
+			name: "traceparent in content",
+			req: &request.CorrelateLogRequest{
+				Content: `request with traceparent 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01`,
+			},
+			want: response.LogCorrelation{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := correlationOfLog(tt.req); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpanOfLog(t *testing.T) {
+	spans := []clickhouse.CorrelatedSpan{
+		{SpanId: "root", StartTime: 100, EndTime: 500, Duration: 400, PodName: "gateway-0", ContainerId: "aaaa"},
+		{SpanId: "order", StartTime: 150, EndTime: 450, Duration: 300, PodName: "order-0", ContainerId: "bbbbbbbb"},
+		{SpanId: "order-retry", StartTime: 200, EndTime: 300, Duration: 100, PodName: "order-0", ContainerId: "bbbbbbbb"},
+	}
+	tests := []struct {
+		name        string
+		correlation response.LogCorrelation
+		time        int64
+		want        string
+	}{
+		{"by span id", response.LogCorrelation{SpanID: "order", PodName: "gateway-0"}, 250, "order"},
+		{"shortest span of the pod", response.LogCorrelation{PodName: "order-0"}, 250, "order-retry"},
+		{"short container id", response.LogCorrelation{ContainerID: "bbbb"}, 400, "order"},
+		{"out of the spans", response.LogCorrelation{PodName: "order-0"}, 600, ""},
+		{"unknown pod", response.LogCorrelation{PodName: "user-0"}, 250, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if span := spanOfLog(spans, &tt.correlation, tt.time); span != nil {
+				got = span.SpanId
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
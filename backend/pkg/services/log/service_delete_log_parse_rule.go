@@ -8,8 +8,8 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/services/log/vector"
-	"gopkg.in/yaml.v3"
 )
 
 func (s *service) DeleteLogParseRule(ctx core.Context, req *request.DeleteLogParseRequest) (*response.LogParseResponse, error) {
@@ -23,12 +23,11 @@ func (s *service) DeleteLogParseRule(ctx core.Context, req *request.DeleteLogPar
 	res := &response.LogParseResponse{
 		ParseName: req.ParseName,
 	}
-	data, err := s.k8sApi.GetVectorConfigFile()
+	data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
 	if err != nil {
 		return nil, err
 	}
-	var vectorCfg vector.VectorConfig
-	err = yaml.Unmarshal([]byte(data["aggregator.yaml"]), &vectorCfg)
+	vectorCfg, err := vector.ParsePipeline(data["aggregator.yaml"])
 	if err != nil {
 		return nil, err
 	}
@@ -40,10 +39,11 @@ func (s *service) DeleteLogParseRule(ctx core.Context, req *request.DeleteLogPar
 	if err != nil {
 		return nil, err
 	}
-	err = s.k8sApi.UpdateVectorConfigFile(newData)
+	err = s.k8sApi.UpdateVectorConfigFile(kubernetes.DefaultClusterID, newData)
 	if err != nil {
 		return nil, err
 	}
+	s.saveVectorConfigVersion(ctx, data["aggregator.yaml"], string(newData), "delete parse rule "+req.ParseName)
 	_, err = s.chRepo.DropLogTable(ctx, logReq)
 	if err != nil {
 		return nil, err
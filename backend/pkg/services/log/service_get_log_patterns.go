@@ -0,0 +1,103 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"sort"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+)
+
+const (
+	defaultPatternSampleSize = 10000
+	// a template is spiking if its percent is at least spikingRatio times of the base window
+	spikingRatio = 2.0
+)
+
+// GetLogPatterns clusters the latest logs into templates, and compares them with the base window if set.
+func (s *service) GetLogPatterns(ctx core.Context, req *request.LogPatternRequest) (*response.LogPatternResponse, error) {
+	sampleSize := req.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultPatternSampleSize
+	}
+
+	miner := newDrainMiner(req.SimilarityThreshold)
+	logs, err := s.chRepo.QueryLogContents(ctx, &req.LogQueryRequest, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		miner.add(log.Content, log.Timestamp, drainCurrentWindow)
+	}
+	res := &response.LogPatternResponse{
+		Total:   len(logs),
+		Sampled: len(logs) >= sampleSize,
+	}
+
+	compare := req.BaseEndTime > 0
+	if compare {
+		baseReq := req.LogQueryRequest
+		baseReq.StartTime, baseReq.EndTime = req.BaseStartTime, req.BaseEndTime
+		baseLogs, err := s.chRepo.QueryLogContents(ctx, &baseReq, sampleSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range baseLogs {
+			miner.add(log.Content, log.Timestamp, drainBaseWindow)
+		}
+		res.BaseTotal = len(baseLogs)
+		res.BaseSampled = len(baseLogs) >= sampleSize
+	}
+
+	res.Patterns = toLogPatterns(miner.clusters, res.Total, res.BaseTotal, compare)
+	return res, nil
+}
+
+func toLogPatterns(clusters []*drainCluster, total int, baseTotal int, compare bool) []response.LogPattern {
+	patterns := make([]response.LogPattern, 0, len(clusters))
+	for _, cluster := range clusters {
+		count := cluster.counts[drainCurrentWindow]
+		if count == 0 {
+			continue
+		}
+		pattern := response.LogPattern{
+			Template:  cluster.template(),
+			Count:     count,
+			Percent:   percentOf(count, total),
+			Samples:   cluster.samples,
+			FirstSeen: cluster.firstSeen[drainCurrentWindow].UnixMicro(),
+			LastSeen:  cluster.lastSeen[drainCurrentWindow].UnixMicro(),
+		}
+		if compare {
+			pattern.BaseCount = cluster.counts[drainBaseWindow]
+			pattern.BasePercent = percentOf(pattern.BaseCount, baseTotal)
+			pattern.IsNew = pattern.BaseCount == 0
+			pattern.IsSpiking = !pattern.IsNew && pattern.Percent >= pattern.BasePercent*spikingRatio
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		if compare {
+			// new templates first, then the spiking ones
+			if patterns[i].IsNew != patterns[j].IsNew {
+				return patterns[i].IsNew
+			}
+			if patterns[i].IsSpiking != patterns[j].IsSpiking {
+				return patterns[i].IsSpiking
+			}
+		}
+		return patterns[i].Count > patterns[j].Count
+	})
+	return patterns
+}
+
+func percentOf(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(total)
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse/factory"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/services/log/schema"
+)
+
+const defaultSchemaSampleSize = 100
+
+var (
+	fieldNamePattern  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	fieldIndexPattern = regexp.MustCompile(`^[a-z_0-9]+(\([0-9., ]*\))?$`)
+)
+
+// the columns of every log table, see the factory package
+var builtinLogColumns = []string{"timestamp", "content", "source", "container_id", "pid", "container_name", "host_ip", "host_name", "k8s_namespace_name", "k8s_pod_name"}
+
+func (s *service) InferLogSchema(ctx core.Context, req *request.InferLogSchemaRequest) (*response.InferLogSchemaResponse, error) {
+	samples := req.Samples
+	if len(samples) == 0 {
+		sampleSize := req.SampleSize
+		if sampleSize == 0 {
+			sampleSize = defaultSchemaSampleSize
+		}
+		var err error
+		if samples, err = s.querySampleLogs(ctx, req.DataBase, req.RouteRule, sampleSize); err != nil {
+			if errors.Is(err, errUnknownLogDataBase) {
+				return nil, core.Error(code.LogSchemaIllegalError, err.Error())
+			}
+			return nil, err
+		}
+	}
+	return schema.Infer(samples, schema.Options{
+		MaxDepth:       req.MaxDepth,
+		MaxNestedKeys:  req.MaxNestedKeys,
+		FilteredFields: req.FilteredFields,
+	}), nil
+}
+
+func (s *service) UpdateLogSchema(ctx core.Context, req *request.UpdateLogSchemaRequest) (*response.UpdateLogSchemaResponse, error) {
+	if err := checkLogFields(req.Fields); err != nil {
+		return nil, core.Error(code.LogSchemaIllegalError, err.Error())
+	}
+	logtable := &database.LogTableInfo{DataBase: req.DataBase, Table: req.TableName}
+	if err := s.dbRepo.OperateLogTableInfo(ctx, logtable, database.QUERY); err != nil {
+		return nil, err
+	}
+	var oldFields []request.Field
+	if len(logtable.Fields) > 0 {
+		if err := json.Unmarshal([]byte(logtable.Fields), &oldFields); err != nil {
+			return nil, err
+		}
+	}
+
+	logReq := &request.LogTableRequest{
+		DataBase:     req.DataBase,
+		TableName:    req.TableName,
+		Cluster:      logtable.Cluster,
+		Fields:       req.Fields,
+		IsStructured: logtable.IsStructured,
+	}
+	logReq.FillerValue()
+	if req.DryRun {
+		return &response.UpdateLogSchemaResponse{
+			DryRun: true,
+			Sqls:   factory.GetUpdateTablePlan(logReq, oldFields).SQLs(),
+		}, nil
+	}
+
+	sqls, err := s.chRepo.UpdateLogTable(ctx, logReq, oldFields)
+	if err != nil {
+		err = s.savePartialUpdate(ctx, logtable, req.Fields, err)
+		return nil, fmt.Errorf("%w, executed: %s", err, strings.Join(sqls, ";"))
+	}
+	fieldsJSON, err := json.Marshal(req.Fields)
+	if err != nil {
+		return nil, err
+	}
+	logtable.Fields = string(fieldsJSON)
+	if err := s.dbRepo.OperateLogTableInfo(ctx, logtable, database.UPDATE); err != nil {
+		return nil, err
+	}
+	return &response.UpdateLogSchemaResponse{Sqls: sqls}, nil
+}
+
+// checkLogFields checks the fields are safe to be put in the DDL.
+func checkLogFields(fields []request.Field) error {
+	names := make(map[string]struct{}, len(fields)+len(builtinLogColumns))
+	for _, column := range builtinLogColumns {
+		names[column] = struct{}{}
+	}
+	for _, field := range fields {
+		if !fieldNamePattern.MatchString(field.Name) {
+			return fmt.Errorf("illegal field name %q", field.Name)
+		}
+		if _, find := names[field.Name]; find {
+			return fmt.Errorf("field %s is duplicated or built-in", field.Name)
+		}
+		names[field.Name] = struct{}{}
+	}
+	return checkFieldDefinitions(fields)
+}
+
+// checkFieldDefinitions checks the types, indexes and materialized expressions of the fields
+// can only define their own columns in the DDL.
+func checkFieldDefinitions(fields []request.Field) error {
+	for _, field := range fields {
+		if err := checkColumnType(field.Type); err != nil {
+			return fmt.Errorf("illegal type %q of field %s: %w", field.Type, field.Name, err)
+		}
+		if len(field.Index) > 0 && !fieldIndexPattern.MatchString(field.Index) {
+			return fmt.Errorf("illegal index %q of field %s", field.Index, field.Name)
+		}
+		if err := checkMaterialized(field.Materialized); err != nil {
+			return fmt.Errorf("illegal materialized expression of field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+type schemaChRepo struct {
+	clickhouse.Repo
+	err error
+}
+
+func (r *schemaChRepo) UpdateLogTable(core.Context, *request.LogTableRequest, []request.Field) ([]string, error) {
+	return []string{"DROP VIEW", "ALTER TABLE logs_local"}, r.err
+}
+
+type schemaDBRepo struct {
+	database.Repo
+	fields string
+}
+
+func (r *schemaDBRepo) OperateLogTableInfo(_ core.Context, model *database.LogTableInfo, op database.Operator) error {
+	switch op {
+	case database.QUERY:
+		model.Cluster, model.Fields = "apo", r.fields
+	case database.UPDATE:
+		r.fields = model.Fields
+	}
+	return nil
+}
+
+func TestUpdateLogSchemaPartially(t *testing.T) {
+	t.Setenv("APO_CONFIG", "../../../config/apo.yml")
+	req := &request.UpdateLogSchemaRequest{
+		DataBase:  "apo",
+		TableName: "logs",
+		Fields:    []request.Field{{Name: "level", Type: "String"}},
+	}
+	newFields, _ := json.Marshal(req.Fields)
+
+	tests := []struct {
+		name   string
+		err    error
+		fields string
+	}{
+		{"local tables altered", &clickhouse.PartialUpdateError{Failed: []string{"ALTER TABLE logs"}, Err: errors.New("timeout")}, string(newFields)},
+		{"nothing altered", errors.New("timeout"), "[]"},
+	}
+	for _, tt := range tests {
+		dbRepo := &schemaDBRepo{fields: "[]"}
+		s := &service{chRepo: &schemaChRepo{err: tt.err}, dbRepo: dbRepo}
+		_, err := s.UpdateLogSchema(core.EmptyCtx(), req)
+		if err == nil || !errors.Is(err, tt.err) {
+			t.Fatalf("%s: err = %v", tt.name, err)
+		}
+		if dbRepo.fields != tt.fields {
+			t.Fatalf("%s: fields = %s", tt.name, dbRepo.fields)
+		}
+	}
+}
+
+func TestCheckColumnType(t *testing.T) {
+	tests := []struct {
+		typ string
+		ok  bool
+	}{
+		{"String", true},
+		{"Nullable(Int64)", true},
+		{"LowCardinality(Nullable(String))", true},
+		{"DateTime64(3, 'Asia/Shanghai')", true},
+		{"Decimal(10, 2)", true},
+		{"Enum8('a' = 1, 'b' = -2)", true},
+		{"Map(String, Array(Int64))", true},
+		{"Tuple(code Int64, msg String)", true},
+		{"Nullable(String), DROP COLUMN content, ADD COLUMN zz Nullable(String)", false},
+		{"Nullable(String) DEFAULT 1", false},
+		{"Nullable(String", false},
+		{"String)", false},
+		{"Nullable(String, DROP COLUMN content)", false},
+		{"Enum8('a\\') = 1)", false},
+		{"String; DROP TABLE logs", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if err := checkColumnType(tt.typ); (err == nil) != tt.ok {
+			t.Errorf("checkColumnType(%s) = %v", tt.typ, err)
+		}
+	}
+}
+
+func TestCheckFieldDefinitions(t *testing.T) {
+	tests := []struct {
+		field request.Field
+		ok    bool
+	}{
+		{request.Field{Name: "user_id", Type: "String", Materialized: "attributes['user_id']"}, true},
+		{request.Field{Name: "status", Type: "Int64", Materialized: "JSONExtractInt(content, 'http', 'status')", Index: "minmax"}, true},
+		{request.Field{Name: "x", Type: "String", Materialized: "x, DROP COLUMN content"}, false},
+		{request.Field{Name: "x", Type: "String", Materialized: "concat(a, ')'), DROP COLUMN content"}, false},
+		{request.Field{Name: "x", Type: "String", Materialized: "lower(a)) , DROP COLUMN content, ADD COLUMN y String MATERIALIZED (1"}, false},
+		{request.Field{Name: "x", Type: "String", Materialized: "a -- comment"}, false},
+		{request.Field{Name: "x", Type: "String", Index: "set(100), DROP COLUMN content"}, false},
+	}
+	for _, tt := range tests {
+		if err := checkFieldDefinitions([]request.Field{tt.field}); (err == nil) != tt.ok {
+			t.Errorf("checkFieldDefinitions(%+v) = %v", tt.field, err)
+		}
+	}
+}
@@ -5,10 +5,13 @@ package log
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 )
 
@@ -103,7 +106,25 @@ func (s *service) UpdateLogTable(ctx core.Context, req *request.LogTableRequest)
 	sqls, err := s.chRepo.UpdateLogTable(ctx, req, oldFields)
 	res.Sqls = sqls
 	if err != nil {
-		return nil, err
+		return nil, s.savePartialUpdate(ctx, logtable, req.Fields, err)
 	}
 	return res, nil
 }
+
+// savePartialUpdate saves the new fields if the local tables are altered but the distributed table or the view is not,
+// so that the next update is compared with the columns of the local tables. The error of the update is returned.
+func (s *service) savePartialUpdate(ctx core.Context, logtable *database.LogTableInfo, fields []request.Field, err error) error {
+	var partial *clickhouse.PartialUpdateError
+	if !errors.As(err, &partial) {
+		return err
+	}
+	fieldsJSON, jsonErr := json.Marshal(fields)
+	if jsonErr != nil {
+		return errors.Join(err, jsonErr)
+	}
+	logtable.Fields = string(fieldsJSON)
+	if saveErr := s.dbRepo.OperateLogTableInfo(ctx, logtable, database.UPDATE); saveErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to save the fields: %w", saveErr))
+	}
+	return err
+}
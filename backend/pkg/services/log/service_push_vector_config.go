@@ -0,0 +1,106 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"go.uber.org/zap"
+)
+
+const vectorConfigKey = "aggregator.yaml"
+
+// pushVectorConfig writes the Vector config and records it as a new version,
+// then verifies that logs are still written to the tables (database -> tables).
+// If there were logs before the push but none during the verification, Vector is considered broken
+// by the new config, the previous config is restored and rollback is called to revert the other changes.
+func (s *service) pushVectorConfig(ctx core.Context, previous string, content []byte, comment string, tables map[string][]string, rollback func(ctx core.Context) error) error {
+	if err := s.k8sApi.UpdateVectorConfigFile(kubernetes.DefaultClusterID, content); err != nil {
+		return err
+	}
+	version := s.saveVectorConfigVersion(ctx, previous, string(content), comment)
+
+	verifySeconds := config.Get().LogParseRule.VerifySeconds
+	if verifySeconds <= 0 {
+		return nil
+	}
+	pushedAt := time.Now()
+	window := time.Duration(verifySeconds) * time.Second
+	go func() {
+		time.Sleep(window)
+		ctx := core.EmptyCtx()
+
+		before, err := s.countLogs(ctx, tables, pushedAt.Add(-window), pushedAt)
+		if err != nil || before == 0 {
+			return
+		}
+		after, err := s.countLogs(ctx, tables, pushedAt, time.Now())
+		if err != nil || after > 0 {
+			return
+		}
+
+		// the config has been changed again
+		data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
+		if err != nil || data[vectorConfigKey] != string(content) {
+			return
+		}
+		s.logger.Warn("no logs written after the Vector config is updated, roll back to the previous config",
+			zap.Int64("version", version), zap.Uint64("logsBefore", before))
+		if err := s.k8sApi.UpdateVectorConfigFile(kubernetes.DefaultClusterID, []byte(previous)); err != nil {
+			s.logger.Error("failed to roll back the Vector config", zap.Error(err))
+			return
+		}
+		s.saveVectorConfigVersion(ctx, string(content), previous, fmt.Sprintf("roll back: no logs written after version %d", version))
+		if rollback != nil {
+			if err := rollback(ctx); err != nil {
+				s.logger.Error("failed to roll back the log parse rule", zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+// saveVectorConfigVersion records the pushed config, the previous one is recorded first if there is no history.
+// It returns the version of the pushed config, 0 if failed to record.
+func (s *service) saveVectorConfigVersion(ctx core.Context, previous string, content string, comment string) int64 {
+	versions, err := s.dbRepo.ListVectorConfigVersions(ctx)
+	if err == nil && len(versions) == 0 && len(previous) > 0 {
+		err = s.dbRepo.CreateVectorConfigVersion(ctx, &database.VectorConfigVersion{
+			Content: previous,
+			Comment: "initial version",
+		})
+	}
+	if err != nil {
+		s.logger.Error("failed to record the Vector config version", zap.Error(err))
+		return 0
+	}
+
+	version := &database.VectorConfigVersion{
+		Content: content,
+		Comment: comment,
+		UserID:  ctx.UserID(),
+	}
+	if err := s.dbRepo.CreateVectorConfigVersion(ctx, version); err != nil {
+		s.logger.Error("failed to record the Vector config version", zap.Error(err))
+		return 0
+	}
+	return version.Version
+}
+
+func (s *service) countLogs(ctx core.Context, tables map[string][]string, from, to time.Time) (uint64, error) {
+	var total uint64
+	for dataBase, names := range tables {
+		count, err := s.chRepo.CountLogs(ctx, dataBase, names, from, to)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
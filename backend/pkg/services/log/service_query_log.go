@@ -15,6 +15,10 @@ import (
 )
 
 func (s *service) QueryLog(ctx core.Context, req *request.LogQueryRequest) (*response.LogQueryResponse, error) {
+	// the following pages of the same query are not recorded
+	if req.PageNum <= 1 {
+		s.recordQueryHistory(ctx, req)
+	}
 	// calculate offset, if offset > 10000, calculate from histogram
 	offset := (req.PageNum - 1) * req.PageSize
 	if offset > 10000 {
@@ -0,0 +1,243 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/services/common"
+	prommodel "github.com/prometheus/common/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// the latest queries kept in the history of each user
+	logQueryHistoryKeep         = 100
+	defaultLogQueryHistoryLimit = 20
+)
+
+// savedQueryViewer decides which saved queries are visible to the user.
+type savedQueryViewer struct {
+	userID  int64
+	teamIDs []int64
+	// checkGroup returns whether the user has the permission of the data group
+	checkGroup func(groupID int64) bool
+}
+
+func (s *service) savedQueryViewer(ctx core.Context) (*savedQueryViewer, error) {
+	userID := ctx.UserID()
+	teamIDs, err := s.dbRepo.GetUserTeams(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := s.dbRepo.GetDataGroupIDsByUserId(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &savedQueryViewer{
+		userID:  userID,
+		teamIDs: teamIDs,
+		checkGroup: func(groupID int64) bool {
+			if slices.Contains(groupIDs, 0) {
+				return true
+			}
+			return common.DataGroupStorage != nil && common.DataGroupStorage.CheckGroupPermission(groupID, groupIDs)
+		},
+	}, nil
+}
+
+func (v *savedQueryViewer) canView(query *database.LogSavedQuery) bool {
+	if query.UserID == v.userID {
+		return true
+	}
+	switch query.ShareType {
+	case database.LogQueryShareTeam:
+		return slices.Contains(v.teamIDs, query.TeamID)
+	case database.LogQueryShareDataGroup:
+		return v.checkGroup(query.DataGroupID)
+	}
+	return false
+}
+
+// savedQueryOf checks the request, the query can only be shared with the teams
+// the user belongs to and the data groups the user has permission of.
+func (v *savedQueryViewer) savedQueryOf(req *request.CreateLogSavedQueryRequest) (*database.LogSavedQuery, error) {
+	if len(req.RelativeRange) > 0 {
+		if _, err := prommodel.ParseDuration(req.RelativeRange); err != nil {
+			return nil, fmt.Errorf("illegal relative range %q: %w", req.RelativeRange, err)
+		}
+	} else if req.EndTime <= req.StartTime {
+		return nil, fmt.Errorf("end time must be after start time when the relative range is empty")
+	}
+
+	query := &database.LogSavedQuery{
+		UserID:        v.userID,
+		Name:          req.Name,
+		Description:   req.Description,
+		DataBase:      req.DataBase,
+		Table:         req.TableName,
+		Query:         req.Query,
+		RawSQL:        req.RawSQL,
+		TimeField:     req.TimeField,
+		LogField:      req.LogField,
+		RelativeRange: req.RelativeRange,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Fields:        integration.JSONField[[]string]{Obj: req.Fields},
+		ShareType:     req.ShareType,
+	}
+	switch req.ShareType {
+	case "", database.LogQuerySharePrivate:
+		query.ShareType = database.LogQuerySharePrivate
+	case database.LogQueryShareTeam:
+		if !slices.Contains(v.teamIDs, req.TeamID) {
+			return nil, fmt.Errorf("can not share with team %d which the user does not belong to", req.TeamID)
+		}
+		query.TeamID = req.TeamID
+	case database.LogQueryShareDataGroup:
+		if req.DataGroupID <= 0 || !v.checkGroup(req.DataGroupID) {
+			return nil, fmt.Errorf("can not share with data group %d without its permission", req.DataGroupID)
+		}
+		query.DataGroupID = req.DataGroupID
+	default:
+		return nil, fmt.Errorf("illegal share type %q", req.ShareType)
+	}
+	return query, nil
+}
+
+func (s *service) CreateLogSavedQuery(ctx core.Context, req *request.CreateLogSavedQueryRequest) (*database.LogSavedQuery, error) {
+	viewer, err := s.savedQueryViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query, err := viewer.savedQueryOf(req)
+	if err != nil {
+		return nil, core.Error(code.LogSavedQueryIllegalError, err.Error())
+	}
+	if err := s.dbRepo.CreateLogSavedQuery(ctx, query); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func (s *service) UpdateLogSavedQuery(ctx core.Context, req *request.UpdateLogSavedQueryRequest) error {
+	if _, err := s.getOwnedSavedQuery(ctx, req.ID); err != nil {
+		return err
+	}
+	viewer, err := s.savedQueryViewer(ctx)
+	if err != nil {
+		return err
+	}
+	query, err := viewer.savedQueryOf(&req.CreateLogSavedQueryRequest)
+	if err != nil {
+		return core.Error(code.LogSavedQueryIllegalError, err.Error())
+	}
+	query.ID = req.ID
+	return s.dbRepo.UpdateLogSavedQuery(ctx, query)
+}
+
+func (s *service) DeleteLogSavedQuery(ctx core.Context, req *request.LogSavedQueryRequest) error {
+	if _, err := s.getOwnedSavedQuery(ctx, req.ID); err != nil {
+		return err
+	}
+	return s.dbRepo.DeleteLogSavedQuery(ctx, req.ID)
+}
+
+func (s *service) GetLogSavedQuery(ctx core.Context, req *request.LogSavedQueryRequest) (*response.LogSavedQuery, error) {
+	viewer, err := s.savedQueryViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query, err := s.dbRepo.GetLogSavedQuery(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the queries invisible to the user are treated as not existing
+	if query == nil || !viewer.canView(query) {
+		return nil, core.Error(code.LogSavedQueryNotExistError, fmt.Sprintf("saved log query %d not exists", req.ID))
+	}
+	return &response.LogSavedQuery{LogSavedQuery: *query, Owned: query.UserID == viewer.userID}, nil
+}
+
+func (s *service) ListLogSavedQueries(ctx core.Context) (*response.ListLogSavedQueriesResponse, error) {
+	viewer, err := s.savedQueryViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queries, err := s.dbRepo.ListLogSavedQueries(ctx, viewer.userID, viewer.teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := &response.ListLogSavedQueriesResponse{Queries: []response.LogSavedQuery{}}
+	for i := range queries {
+		if viewer.canView(&queries[i]) {
+			res.Queries = append(res.Queries, response.LogSavedQuery{LogSavedQuery: queries[i], Owned: queries[i].UserID == viewer.userID})
+		}
+	}
+	return res, nil
+}
+
+func (s *service) getOwnedSavedQuery(ctx core.Context, id int64) (*database.LogSavedQuery, error) {
+	query, err := s.dbRepo.GetLogSavedQuery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		return nil, core.Error(code.LogSavedQueryNotExistError, fmt.Sprintf("saved log query %d not exists", id))
+	}
+	if query.UserID != ctx.UserID() {
+		return nil, core.Error(code.LogSavedQueryNotOwnerError, fmt.Sprintf("saved log query %d is not owned by the user", id))
+	}
+	return query, nil
+}
+
+func (s *service) ListLogQueryHistory(ctx core.Context, req *request.ListLogQueryHistoryRequest) (*response.ListLogQueryHistoryResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLogQueryHistoryLimit
+	}
+	histories, err := s.dbRepo.ListLogQueryHistory(ctx, ctx.UserID(), limit)
+	if err != nil {
+		return nil, err
+	}
+	if histories == nil {
+		histories = []database.LogQueryHistory{}
+	}
+	return &response.ListLogQueryHistoryResponse{Histories: histories}, nil
+}
+
+func (s *service) DeleteLogQueryHistory(ctx core.Context, req *request.DeleteLogQueryHistoryRequest) error {
+	return s.dbRepo.DeleteLogQueryHistory(ctx, ctx.UserID(), req.ID)
+}
+
+// recordQueryHistory adds the query to the history of the user, the failure does not affect the query.
+func (s *service) recordQueryHistory(ctx core.Context, req *request.LogQueryRequest) {
+	userID := ctx.UserID()
+	if userID == 0 {
+		return
+	}
+	history := &database.LogQueryHistory{
+		UserID:    userID,
+		DataBase:  req.DataBase,
+		Table:     req.TableName,
+		Query:     req.Query,
+		RawSQL:    req.RawSQL,
+		TimeField: req.TimeField,
+		LogField:  req.LogField,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		QueriedAt: time.Now().Unix(),
+	}
+	if err := s.dbRepo.AddLogQueryHistory(ctx, history, logQueryHistoryKeep); err != nil {
+		s.logger.Warn("failed to record log query history", zap.Error(err))
+	}
+}
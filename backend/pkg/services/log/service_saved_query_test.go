@@ -0,0 +1,76 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func testViewer() *savedQueryViewer {
+	return &savedQueryViewer{
+		userID:     1,
+		teamIDs:    []int64{10},
+		checkGroup: func(groupID int64) bool { return groupID == 100 },
+	}
+}
+
+func TestSavedQueryCanView(t *testing.T) {
+	tests := []struct {
+		query database.LogSavedQuery
+		want  bool
+	}{
+		{database.LogSavedQuery{UserID: 1, ShareType: database.LogQuerySharePrivate}, true},
+		{database.LogSavedQuery{UserID: 2, ShareType: database.LogQuerySharePrivate}, false},
+		{database.LogSavedQuery{UserID: 2, ShareType: database.LogQueryShareTeam, TeamID: 10}, true},
+		{database.LogSavedQuery{UserID: 2, ShareType: database.LogQueryShareTeam, TeamID: 11}, false},
+		{database.LogSavedQuery{UserID: 2, ShareType: database.LogQueryShareDataGroup, DataGroupID: 100}, true},
+		{database.LogSavedQuery{UserID: 2, ShareType: database.LogQueryShareDataGroup, DataGroupID: 101}, false},
+	}
+	viewer := testViewer()
+	for _, tt := range tests {
+		if got := viewer.canView(&tt.query); got != tt.want {
+			t.Errorf("canView(%+v) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSavedQueryOf(t *testing.T) {
+	base := request.CreateLogSavedQueryRequest{Name: "errors", DataBase: "apo", TableName: "logs", RelativeRange: "1h"}
+	tests := []struct {
+		name   string
+		modify func(req *request.CreateLogSavedQueryRequest)
+		ok     bool
+	}{
+		{"private by default", func(req *request.CreateLogSavedQueryRequest) { req.TeamID = 11 }, true},
+		{"relative range in days", func(req *request.CreateLogSavedQueryRequest) { req.RelativeRange = "7d" }, true},
+		{"illegal relative range", func(req *request.CreateLogSavedQueryRequest) { req.RelativeRange = "1 hour" }, false},
+		{"absolute range", func(req *request.CreateLogSavedQueryRequest) {
+			req.RelativeRange, req.StartTime, req.EndTime = "", 1, 2
+		}, true},
+		{"empty range", func(req *request.CreateLogSavedQueryRequest) { req.RelativeRange = "" }, false},
+		{"own team", func(req *request.CreateLogSavedQueryRequest) { req.ShareType, req.TeamID = "team", 10 }, true},
+		{"other team", func(req *request.CreateLogSavedQueryRequest) { req.ShareType, req.TeamID = "team", 11 }, false},
+		{"permitted data group", func(req *request.CreateLogSavedQueryRequest) { req.ShareType, req.DataGroupID = "datagroup", 100 }, true},
+		{"forbidden data group", func(req *request.CreateLogSavedQueryRequest) { req.ShareType, req.DataGroupID = "datagroup", 101 }, false},
+	}
+	viewer := testViewer()
+	for _, tt := range tests {
+		req := base
+		tt.modify(&req)
+		query, err := viewer.savedQueryOf(&req)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+			continue
+		}
+		if err == nil && (query.UserID != 1 || query.ShareType == "") {
+			t.Errorf("%s: query = %+v", tt.name, query)
+		}
+		if err == nil && query.ShareType == database.LogQuerySharePrivate && query.TeamID != 0 {
+			t.Errorf("%s: private query shared with team %d", tt.name, query.TeamID)
+		}
+	}
+}
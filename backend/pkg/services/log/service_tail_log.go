@@ -0,0 +1,166 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+)
+
+const (
+	defaultTailPollInterval = 2 * time.Second
+	defaultTailBatchSize    = 500
+	defaultTailIdleTimeout  = 10 * time.Minute
+	defaultTailMaxSession   = time.Hour
+
+	tailHeartbeatInterval = 15 * time.Second
+)
+
+type tailOptions struct {
+	pollInterval time.Duration
+	batchSize    int
+	idleTimeout  time.Duration
+	maxSession   time.Duration
+}
+
+func getTailOptions() tailOptions {
+	cfg := config.Get().LogTail
+	opts := tailOptions{
+		pollInterval: time.Duration(cfg.PollIntervalSeconds) * time.Second,
+		batchSize:    cfg.BatchSize,
+		idleTimeout:  time.Duration(cfg.IdleTimeoutSeconds) * time.Second,
+		maxSession:   time.Duration(cfg.MaxSessionMinutes) * time.Minute,
+	}
+	if opts.pollInterval <= 0 {
+		opts.pollInterval = defaultTailPollInterval
+	}
+	if opts.batchSize <= 0 {
+		opts.batchSize = defaultTailBatchSize
+	}
+	if opts.idleTimeout <= 0 {
+		opts.idleTimeout = defaultTailIdleTimeout
+	}
+	if opts.maxSession <= 0 {
+		opts.maxSession = defaultTailMaxSession
+	}
+	return opts
+}
+
+// TailLog polls the logs written after req.StartTime and pushes them by send.
+// The next poll starts only after send returns, so a slow client slows down the polling
+// instead of piling up logs in memory. It returns when send fails, the client is gone,
+// no new logs arrive within the idle timeout or the session reaches the maximum length.
+func (s *service) TailLog(ctx core.Context, req *request.LogTailRequest, send func(event string, data any) error) error {
+	opts := getTailOptions()
+
+	var done <-chan struct{}
+	if httpReq := ctx.Request(); httpReq != nil {
+		done = httpReq.Context().Done()
+	}
+
+	sessionStart := time.Now()
+	cursor := sessionStart
+	if req.StartTime > 0 {
+		cursor = time.UnixMicro(req.StartTime)
+	}
+	var seen map[string]struct{}
+	lastActive, lastSent := sessionStart, sessionStart
+
+	ticker := time.NewTicker(opts.pollInterval)
+	defer ticker.Stop()
+	for {
+		limit := opts.batchSize + len(seen)
+		logs, err := s.chRepo.TailLogs(ctx, req, cursor, limit)
+		if err != nil {
+			return err
+		}
+
+		var fresh []map[string]any
+		fresh, cursor, seen = filterTailLogs(logs, req.TimeField, cursor, seen)
+		now := time.Now()
+		if len(fresh) > 0 {
+			resp := &response.LogTailResponse{
+				Logs:   toTailLogItems(fresh, req.TimeField, req.LogField),
+				Cursor: cursor.UnixMicro(),
+			}
+			if err := send(response.LogTailEventLogs, resp); err != nil {
+				return err
+			}
+			lastActive, lastSent = now, now
+		} else if now.Sub(lastActive) >= opts.idleTimeout {
+			return send(response.LogTailEventEnd, &response.LogTailEndResponse{Reason: response.LogTailEndIdle})
+		} else if now.Sub(lastSent) >= tailHeartbeatInterval {
+			if err := send(response.LogTailEventHeartbeat, &response.LogTailResponse{Cursor: cursor.UnixMicro()}); err != nil {
+				return err
+			}
+			lastSent = now
+		}
+
+		if now.Sub(sessionStart) >= opts.maxSession {
+			return send(response.LogTailEventEnd, &response.LogTailEndResponse{Reason: response.LogTailEndTimeout})
+		}
+		// more logs are pending, poll again without waiting
+		if len(logs) >= limit {
+			select {
+			case <-done:
+				return nil
+			default:
+				continue
+			}
+		}
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// filterTailLogs drops the logs which have been pushed and moves the cursor to the latest log.
+// Logs at the cursor are queried again in the next poll since more logs with the same time
+// may be written later, seen records the pushed ones to avoid duplicates.
+func filterTailLogs(logs []map[string]any, timeField string, cursor time.Time, seen map[string]struct{}) ([]map[string]any, time.Time, map[string]struct{}) {
+	var fresh []map[string]any
+	for _, log := range logs {
+		ts, ok := log[timeField].(time.Time)
+		if !ok || ts.Before(cursor) {
+			continue
+		}
+		key := fmt.Sprint(log)
+		if ts.Equal(cursor) {
+			if _, pushed := seen[key]; pushed {
+				continue
+			}
+		} else {
+			cursor = ts
+			seen = nil
+		}
+		if seen == nil {
+			seen = make(map[string]struct{})
+		}
+		seen[key] = struct{}{}
+		fresh = append(fresh, log)
+	}
+	return fresh, cursor, seen
+}
+
+func toTailLogItems(logs []map[string]any, timeField string, logField string) []response.LogItem {
+	items := make([]response.LogItem, 0, len(logs))
+	for _, log := range logs {
+		item := response.LogItem{Content: log[logField]}
+		if ts, ok := log[timeField].(time.Time); ok {
+			item.Time = ts.UnixMicro()
+		}
+		delete(log, logField)
+		delete(log, timeField)
+		item.Tags = log
+		items = append(items, item)
+	}
+	return items
+}
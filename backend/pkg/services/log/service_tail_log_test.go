@@ -0,0 +1,45 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterTailLogs(t *testing.T) {
+	t0 := time.Unix(1700000000, 0)
+	t1 := t0.Add(time.Millisecond)
+	newLog := func(ts time.Time, content string) map[string]any {
+		return map[string]any{"timestamp": ts, "content": content}
+	}
+
+	// first poll
+	logs := []map[string]any{newLog(t0, "a"), newLog(t1, "b"), newLog(t1, "c")}
+	fresh, cursor, seen := filterTailLogs(logs, "timestamp", t0, nil)
+	if len(fresh) != 3 || !cursor.Equal(t1) || len(seen) != 2 {
+		t.Fatalf("fresh = %d, cursor = %v, seen = %d", len(fresh), cursor, len(seen))
+	}
+
+	// logs at the cursor are queried again, only the new one is pushed
+	logs = []map[string]any{newLog(t1, "b"), newLog(t1, "c"), newLog(t1, "d")}
+	fresh, cursor, seen = filterTailLogs(logs, "timestamp", cursor, seen)
+	if len(fresh) != 1 || fresh[0]["content"] != "d" || !cursor.Equal(t1) || len(seen) != 3 {
+		t.Fatalf("fresh = %v, cursor = %v, seen = %d", fresh, cursor, len(seen))
+	}
+
+	// cursor moves forward and the seen logs are reset
+	t2 := t1.Add(time.Second)
+	logs = []map[string]any{newLog(t1, "b"), newLog(t2, "e")}
+	fresh, cursor, seen = filterTailLogs(logs, "timestamp", cursor, seen)
+	if len(fresh) != 1 || fresh[0]["content"] != "e" || !cursor.Equal(t2) || len(seen) != 1 {
+		t.Fatalf("fresh = %v, cursor = %v, seen = %d", fresh, cursor, len(seen))
+	}
+
+	// nothing new
+	fresh, _, _ = filterTailLogs(logs, "timestamp", cursor, seen)
+	if len(fresh) != 0 {
+		t.Fatalf("fresh = %v", fresh)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/services/log/vector"
+)
+
+const defaultParseSampleSize = 20
+
+var errUnknownLogDataBase = errors.New("unknown log database")
+
+// querySampleLogs reads the latest raw logs of a known log database, "apo" by default.
+func (s *service) querySampleLogs(ctx core.Context, dataBase string, routeRule map[string]string, size int) ([]string, error) {
+	if len(dataBase) == 0 {
+		dataBase = "apo"
+	}
+	if dataBase != "apo" {
+		tables, err := s.dbRepo.GetAllLogTable(ctx)
+		if err != nil {
+			return nil, err
+		}
+		known := false
+		for _, table := range tables {
+			if table.DataBase == dataBase {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: %s", errUnknownLogDataBase, dataBase)
+		}
+	}
+	return s.chRepo.QuerySampleLogs(ctx, dataBase, routeRule, size)
+}
+
+// TestLogParseRule runs the regexes of the parse rule, or extracts the fields of structured logs,
+// against the samples, and checks whether the values can be stored as the types of the table fields.
+func (s *service) TestLogParseRule(ctx core.Context, req *request.TestLogParseRequest) (*response.TestLogParseResponse, error) {
+	res := &response.TestLogParseResponse{Results: []response.LogParseSampleResult{}}
+
+	var (
+		regexes []*regexp.Regexp
+		fields  []request.Field
+	)
+	if req.IsStructured {
+		fields = req.TableFields
+	} else {
+		if err := vector.ValidateVRL(req.ParseRule); err != nil {
+			res.Error = err.Error()
+			return res, nil
+		}
+		var err error
+		if regexes, err = vector.RegexesOfVRL(req.ParseRule); err != nil {
+			res.Error = err.Error()
+			return res, nil
+		}
+		if len(regexes) == 0 {
+			res.Error = "parse_regex is not found in the parse rule"
+			return res, nil
+		}
+		fields = parsedFields(req.ParseRule, req.TableFields)
+	}
+
+	samples := req.Samples
+	if len(samples) == 0 {
+		sampleSize := req.SampleSize
+		if sampleSize == 0 {
+			sampleSize = defaultParseSampleSize
+		}
+		var err error
+		if samples, err = s.querySampleLogs(ctx, req.DataBase, req.RouteRule, sampleSize); err != nil {
+			if errors.Is(err, errUnknownLogDataBase) {
+				return nil, core.Error(code.LogParseRuleIllegalError, err.Error())
+			}
+			return nil, err
+		}
+	}
+
+	for _, content := range samples {
+		var values map[string]any
+		if req.IsStructured {
+			values = extractJSONFields(content)
+		} else {
+			values = extractRegexFields(regexes, content)
+		}
+		res.Results = append(res.Results, toSampleResult(content, values, fields))
+	}
+	return res, nil
+}
+
+// extractRegexFields returns the named groups of the first matched regex, nil if none matches.
+func extractRegexFields(regexes []*regexp.Regexp, content string) map[string]any {
+	for _, re := range regexes {
+		match := re.FindStringSubmatch(content)
+		if match == nil {
+			continue
+		}
+		values := make(map[string]any)
+		for i, name := range re.SubexpNames() {
+			if len(name) > 0 {
+				values[name] = match[i]
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// extractJSONFields returns nil if content is not a JSON object.
+func extractJSONFields(content string) map[string]any {
+	var values map[string]any
+	if err := json.Unmarshal([]byte(content), &values); err != nil {
+		return nil
+	}
+	return values
+}
+
+func toSampleResult(content string, values map[string]any, fields []request.Field) response.LogParseSampleResult {
+	result := response.LogParseSampleResult{
+		Content:    content,
+		Matched:    values != nil,
+		Fields:     map[string]string{},
+		TypeErrors: []string{},
+	}
+	for _, field := range fields {
+		if len(field.Materialized) > 0 {
+			continue
+		}
+		value, find := valueOfPath(values, field.JSONPath())
+		if !find || value == nil {
+			continue
+		}
+		result.Fields[field.Name] = fmt.Sprint(value)
+		if err := checkFieldType(field.Type, value); err != nil {
+			result.TypeErrors = append(result.TypeErrors, fmt.Sprintf("%s: %v", field.Name, err))
+		}
+	}
+	return result
+}
+
+// valueOfPath returns the value of the nested keys.
+func valueOfPath(values map[string]any, path []string) (any, bool) {
+	var value any = values
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// checkFieldType checks whether the value of the regex group or JSON can be extracted as typ.
+func checkFieldType(typ string, value any) error {
+	for _, wrapper := range []string{"Nullable(", "LowCardinality("} {
+		if strings.HasPrefix(typ, wrapper) && strings.HasSuffix(typ, ")") {
+			typ = typ[len(wrapper) : len(typ)-1]
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		var err error
+		switch {
+		case typ == "String":
+		case strings.HasPrefix(typ, "UInt"):
+			_, err = strconv.ParseUint(v, 10, intBits(typ, "UInt"))
+		case strings.HasPrefix(typ, "Int"):
+			_, err = strconv.ParseInt(v, 10, intBits(typ, "Int"))
+		case strings.HasPrefix(typ, "Float"):
+			_, err = strconv.ParseFloat(v, 64)
+		case typ == "Bool":
+			_, err = strconv.ParseBool(v)
+		case strings.HasPrefix(typ, "Date"):
+			err = parseDateTime(v)
+		}
+		if err != nil {
+			return fmt.Errorf("%q is not %s", v, typ)
+		}
+	case float64:
+		switch {
+		case strings.HasPrefix(typ, "UInt") || strings.HasPrefix(typ, "Int"):
+			if v != float64(int64(v)) || (strings.HasPrefix(typ, "UInt") && v < 0) {
+				return fmt.Errorf("%v is not %s", v, typ)
+			}
+		case strings.HasPrefix(typ, "Float"):
+		default:
+			return fmt.Errorf("number %v is not %s", v, typ)
+		}
+	case bool:
+		if typ != "Bool" {
+			return fmt.Errorf("bool %v is not %s", v, typ)
+		}
+	case map[string]any:
+		if !strings.HasPrefix(typ, "Map(") && typ != "String" {
+			return fmt.Errorf("object is not %s", typ)
+		}
+	case []any:
+		if !strings.HasPrefix(typ, "Array(") && typ != "String" {
+			return fmt.Errorf("array is not %s", typ)
+		}
+	default:
+		if typ != "String" {
+			return fmt.Errorf("%T is not %s", value, typ)
+		}
+	}
+	return nil
+}
+
+func intBits(typ string, prefix string) int {
+	bits, err := strconv.Atoi(strings.TrimPrefix(typ, prefix))
+	if err != nil || bits > 64 {
+		return 64
+	}
+	return bits
+}
+
+func parseDateTime(value string) error {
+	for _, layout := range dateTimeLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return nil
+		}
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return nil
+	}
+	return errors.New("unknown time format")
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+)
+
+func TestCheckFieldType(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value any
+		ok    bool
+	}{
+		{"String", "abc", true},
+		{"Nullable(Int64)", "123", true},
+		{"Int64", "12.3", false},
+		{"UInt8", "256", false},
+		{"UInt32", float64(7), true},
+		{"UInt32", float64(-7), false},
+		{"Float64", "1.5e3", true},
+		{"Float64", "fast", false},
+		{"Bool", true, true},
+		{"String", float64(1), false},
+		{"DateTime64(3)", "2024-01-02 03:04:05.678", true},
+		{"DateTime", "yesterday", false},
+		{"LowCardinality(String)", "x", true},
+	}
+	for _, tt := range tests {
+		err := checkFieldType(tt.typ, tt.value)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkFieldType(%s, %v) = %v", tt.typ, tt.value, err)
+		}
+	}
+}
+
+func TestToSampleResult(t *testing.T) {
+	fields := []request.Field{{Name: "level", Type: "String"}, {Name: "cost", Type: "Int64"}}
+
+	re := regexp.MustCompile(`\[(?P<level>\w+)\] cost=(?P<cost>\S+)`)
+	result := toSampleResult("[INFO] cost=12ms", extractRegexFields([]*regexp.Regexp{re}, "[INFO] cost=12ms"), fields)
+	if !result.Matched || result.Fields["level"] != "INFO" || result.Fields["cost"] != "12ms" || len(result.TypeErrors) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	result = toSampleResult("no match", extractRegexFields([]*regexp.Regexp{re}, "no match"), fields)
+	if result.Matched || len(result.Fields) != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	content := `{"level":"WARN","cost":12}`
+	result = toSampleResult(content, extractJSONFields(content), fields)
+	if !result.Matched || result.Fields["cost"] != "12" || len(result.TypeErrors) != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	result = toSampleResult("plain text", extractJSONFields("plain text"), fields)
+	if result.Matched {
+		t.Fatalf("result = %+v", result)
+	}
+}
@@ -7,38 +7,35 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/CloudDetail/apo/backend/pkg/code"
 	core "github.com/CloudDetail/apo/backend/pkg/core"
 	"github.com/CloudDetail/apo/backend/pkg/model/request"
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
 	"github.com/CloudDetail/apo/backend/pkg/services/log/vector"
-	"gopkg.in/yaml.v3"
 )
 
 func (s *service) UpdateLogParseRule(ctx core.Context, req *request.UpdateLogParseRequest) (*response.LogParseResponse, error) {
+	if !req.IsStructured {
+		if err := vector.ValidateVRL(req.ParseRule); err != nil {
+			return nil, core.Error(code.LogParseRuleIllegalError, err.Error())
+		}
+	}
+	oldLog := &database.LogTableInfo{DataBase: req.DataBase, Table: req.TableName}
+	if err := s.dbRepo.OperateLogTableInfo(ctx, oldLog, database.QUERY); err != nil {
+		oldLog = nil
+	}
+
 	// Update the log table
 	fields := make([]request.Field, 0)
 	if req.IsStructured {
 		fields = req.TableFields
 	} else {
-		matchesFields := fieldsRegexp.FindAllStringSubmatch(req.ParseRule, -1)
-		for _, match := range matchesFields {
-			if match[1] == "msg" || match[1] == "ts" {
-				continue
-			}
-
-			parsedField := request.Field{
-				Name: match[1],
-				Type: "String",
-			}
-
-			for _, customizedFiled := range req.TableFields {
-				if parsedField.Name == customizedFiled.Name {
-					parsedField.Type = customizedFiled.Type
-				}
-			}
-			fields = append(fields, parsedField)
-		}
+		fields = parsedFields(req.ParseRule, req.TableFields)
+	}
+	if err := checkFieldDefinitions(fields); err != nil {
+		return nil, core.Error(code.LogParseRuleIllegalError, err.Error())
 	}
 
 	logReq := &request.LogTableRequest{
@@ -54,12 +51,11 @@ func (s *service) UpdateLogParseRule(ctx core.Context, req *request.UpdateLogPar
 	}
 
 	// update k8s configmap
-	data, err := s.k8sApi.GetVectorConfigFile()
+	data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
 	if err != nil {
 		return nil, err
 	}
-	var vectorCfg vector.VectorConfig
-	err = yaml.Unmarshal([]byte(data["aggregator.yaml"]), &vectorCfg)
+	vectorCfg, err := vector.ParsePipeline(data["aggregator.yaml"])
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +73,14 @@ func (s *service) UpdateLogParseRule(ctx core.Context, req *request.UpdateLogPar
 	if err != nil {
 		return nil, err
 	}
-	err = s.k8sApi.UpdateVectorConfigFile(newData)
+	// the columns added to the table are kept if rolled back
+	tables := map[string][]string{req.DataBase: {"raw_logs", req.TableName}}
+	err = s.pushVectorConfig(ctx, data["aggregator.yaml"], newData, "update parse rule "+req.ParseName, tables, func(ctx core.Context) error {
+		if oldLog == nil {
+			return nil
+		}
+		return s.dbRepo.UpdateLogParseRule(ctx, oldLog)
+	})
 	if err != nil {
 		return nil, err
 	}
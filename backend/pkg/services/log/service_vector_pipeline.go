@@ -0,0 +1,159 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/services/log/vector"
+)
+
+func (s *service) GetVectorPipeline(ctx core.Context) (*response.GetVectorPipelineResponse, error) {
+	data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
+	if err != nil {
+		return nil, err
+	}
+	content := data[vectorConfigKey]
+	pipeline, err := vector.ParsePipeline(content)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.GetVectorPipelineResponse{Pipeline: pipeline, Content: content}
+	versions, err := s.dbRepo.ListVectorConfigVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) > 0 {
+		latest, err := s.dbRepo.GetVectorConfigVersion(ctx, versions[0].Version)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil && latest.Content == content {
+			res.Version = latest.Version
+		}
+	}
+	return res, nil
+}
+
+func (s *service) GetVectorPipelineVersion(ctx core.Context, req *request.GetVectorPipelineVersionRequest) (*response.GetVectorPipelineResponse, error) {
+	version, err := s.getVectorConfigVersion(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := vector.ParsePipeline(version.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &response.GetVectorPipelineResponse{
+		Pipeline: pipeline,
+		Content:  version.Content,
+		Version:  version.Version,
+	}, nil
+}
+
+func (s *service) UpdateVectorPipeline(ctx core.Context, req *request.UpdateVectorPipelineRequest) error {
+	if err := vector.ValidatePipeline(&req.Pipeline); err != nil {
+		return core.Error(code.VectorPipelineIllegalError, err.Error())
+	}
+	content, err := vector.MarshalPipeline(&req.Pipeline)
+	if err != nil {
+		return err
+	}
+	return s.pushPipeline(ctx, content, req.Comment)
+}
+
+func (s *service) ListVectorPipelineVersions(ctx core.Context) (*response.ListVectorPipelineVersionsResponse, error) {
+	versions, err := s.dbRepo.ListVectorConfigVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListVectorPipelineVersionsResponse{Versions: versions}, nil
+}
+
+func (s *service) DiffVectorPipeline(ctx core.Context, req *request.DiffVectorPipelineRequest) (*response.DiffVectorPipelineResponse, error) {
+	from, err := s.getVectorConfigVersion(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	fromPipeline, err := vector.ParsePipeline(from.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var toContent string
+	if req.To > 0 {
+		to, err := s.getVectorConfigVersion(ctx, req.To)
+		if err != nil {
+			return nil, err
+		}
+		toContent = to.Content
+	} else {
+		data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
+		if err != nil {
+			return nil, err
+		}
+		toContent = data[vectorConfigKey]
+	}
+	toPipeline, err := vector.ParsePipeline(toContent)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := vector.DiffPipelines(fromPipeline, toPipeline)
+	if err != nil {
+		return nil, err
+	}
+	return &response.DiffVectorPipelineResponse{From: req.From, To: req.To, Diffs: diffs}, nil
+}
+
+// RollbackVectorPipeline pushes the content of the version as a new version.
+func (s *service) RollbackVectorPipeline(ctx core.Context, req *request.RollbackVectorPipelineRequest) error {
+	version, err := s.getVectorConfigVersion(ctx, req.Version)
+	if err != nil {
+		return err
+	}
+	pipeline, err := vector.ParsePipeline(version.Content)
+	if err != nil {
+		return err
+	}
+	if err := vector.ValidatePipeline(pipeline); err != nil {
+		return core.Error(code.VectorPipelineIllegalError, err.Error())
+	}
+	return s.pushPipeline(ctx, []byte(version.Content), fmt.Sprintf("roll back to version %d", version.Version))
+}
+
+// pushPipeline pushes the whole pipeline, logs written to all the log tables are verified after the push.
+func (s *service) pushPipeline(ctx core.Context, content []byte, comment string) error {
+	data, err := s.k8sApi.GetVectorConfigFile(kubernetes.DefaultClusterID)
+	if err != nil {
+		return err
+	}
+	logTables, err := s.dbRepo.GetAllLogTable(ctx)
+	if err != nil {
+		return err
+	}
+	tables := make(map[string][]string)
+	for _, table := range logTables {
+		tables[table.DataBase] = append(tables[table.DataBase], table.Table)
+	}
+	return s.pushVectorConfig(ctx, data[vectorConfigKey], content, comment, tables, nil)
+}
+
+func (s *service) getVectorConfigVersion(ctx core.Context, version int64) (*database.VectorConfigVersion, error) {
+	result, err := s.dbRepo.GetVectorConfigVersion(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, core.Error(code.VectorPipelineVersionNotExistError, fmt.Sprintf("version %d not exists", version))
+	}
+	return result, nil
+}
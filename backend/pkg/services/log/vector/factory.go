@@ -4,17 +4,16 @@
 package vector
 
 import (
-	"encoding/json"
 	"errors"
 
-	"gopkg.in/yaml.v2"
+	model "github.com/CloudDetail/apo/backend/pkg/model/vector"
 )
 
-type VectorConfig struct {
-	Sources    map[string]interface{} `yaml:"sources"`
-	Transforms map[string]interface{} `yaml:"transforms"`
-	Sinks      map[string]interface{} `yaml:"sinks"`
-}
+const (
+	routeTransformName = "route_logs"
+	// the ClickHouse sink of raw_logs, it is the template of the sinks of parse rules
+	templateSinkName = "to_default_java"
+)
 
 type ParseInfo struct {
 	ParseName string
@@ -23,75 +22,75 @@ type ParseInfo struct {
 	ParseRule string
 }
 
-func (p *ParseInfo) AddParseRule(config VectorConfig) ([]byte, error) {
-	routeLogs, ok := config.Transforms["route_logs"].(map[string]interface{})
-	if ok {
-		route := routeLogs["route"].(map[string]interface{})
-		route[p.ParseName+"_route"] = p.RouteRule
+func (p *ParseInfo) AddParseRule(config *model.Pipeline) ([]byte, error) {
+	if route, ok := config.Transforms[routeTransformName]; ok && route.Type == model.TransformRoute {
+		if route.Route == nil {
+			route.Route = map[string]any{}
+		}
+		route.Route[p.ParseName+"_route"] = p.RouteRule
 	}
 
-	_, ok = config.Transforms["parse_"+p.ParseName].(map[string]interface{})
-	if ok {
+	if _, ok := config.Transforms["parse_"+p.ParseName]; ok {
 		return nil, errors.New("规则解析名已存在，请确保唯一")
-	} else {
-		new_transform := map[string]interface{}{
-			"type":   "remap",
-			"inputs": []string{"route_logs." + p.ParseName + "_route"},
-			"source": p.ParseRule,
-		}
-		config.Transforms["parse_"+p.ParseName] = new_transform
+	}
+	config.Transforms["parse_"+p.ParseName] = &model.Component{
+		Type:   model.TransformRemap,
+		Inputs: []string{routeTransformName + "." + p.ParseName + "_route"},
+		Source: p.ParseRule,
 	}
 
-	sinkOriginal := config.Sinks["to_default_java"].(map[string]interface{})
-	sinkTempBytes, _ := json.Marshal(sinkOriginal)
-	var sinkTemp map[string]interface{}
-	json.Unmarshal(sinkTempBytes, &sinkTemp)
-
-	sinkTemp["inputs"] = []string{"parse_" + p.ParseName}
-	sinkTemp["table"] = p.TableName + "_buffer"
-	config.Sinks["to_"+p.ParseName] = sinkTemp
-
-	updatedData, err := yaml.Marshal(&config)
+	template, ok := config.Sinks[templateSinkName]
+	if !ok {
+		return nil, errors.New("默认日志输出 " + templateSinkName + " 不存在")
+	}
+	sink, err := cloneComponent(template)
 	if err != nil {
-		return nil, errors.New("marshal failed")
+		return nil, err
 	}
-	return updatedData, nil
+	sink.Inputs = []string{"parse_" + p.ParseName}
+	if sink.Options == nil {
+		sink.Options = map[string]any{}
+	}
+	sink.Options["table"] = p.TableName + "_buffer"
+	config.Sinks["to_"+p.ParseName] = sink
+
+	return p.marshal(config)
 }
 
-func (p *ParseInfo) UpdateParseRule(config VectorConfig) ([]byte, error) {
-	routeLogs, ok := config.Transforms["route_logs"].(map[string]interface{})
-	if ok {
-		route := routeLogs["route"].(map[string]interface{})
-		route[p.ParseName+"_route"] = p.RouteRule
-	} else {
+func (p *ParseInfo) UpdateParseRule(config *model.Pipeline) ([]byte, error) {
+	route, ok := config.Transforms[routeTransformName]
+	if !ok || route.Type != model.TransformRoute {
 		return nil, errors.New("配置文件更新出错")
 	}
+	if route.Route == nil {
+		route.Route = map[string]any{}
+	}
+	route.Route[p.ParseName+"_route"] = p.RouteRule
 
 	// Update the source field of the parse_test
-	parseTest, ok := config.Transforms["parse_"+p.ParseName].(map[string]interface{})
-	if ok {
-		parseTest["source"] = p.ParseRule
-	} else {
+	parse, ok := config.Transforms["parse_"+p.ParseName]
+	if !ok {
 		return nil, errors.New("解析规则" + p.ParseName + "不存在")
 	}
-	updatedData, err := yaml.Marshal(&config)
-	if err != nil {
-		return nil, errors.New("配置文件更新出错")
-	}
-	return updatedData, nil
+	parse.Source = p.ParseRule
+	return p.marshal(config)
 }
 
-func (p *ParseInfo) DeleteParseRule(config VectorConfig) ([]byte, error) {
-	routeLogs, ok := config.Transforms["route_logs"].(map[string]interface{})
-	if ok {
-		route := routeLogs["route"].(map[string]interface{})
-		delete(route, p.ParseName+"_route")
-		routeLogs["route"] = route
-		config.Transforms["route_logs"] = routeLogs
+func (p *ParseInfo) DeleteParseRule(config *model.Pipeline) ([]byte, error) {
+	if route, ok := config.Transforms[routeTransformName]; ok {
+		delete(route.Route, p.ParseName+"_route")
 	}
 	delete(config.Transforms, "parse_"+p.ParseName)
 	delete(config.Sinks, "to_"+p.ParseName)
-	updatedData, err := yaml.Marshal(&config)
+	return p.marshal(config)
+}
+
+// marshal validates the pipeline before it is pushed to Vector.
+func (p *ParseInfo) marshal(config *model.Pipeline) ([]byte, error) {
+	if err := ValidatePipeline(config); err != nil {
+		return nil, err
+	}
+	updatedData, err := MarshalPipeline(config)
 	if err != nil {
 		return nil, errors.New("配置文件更新出错")
 	}
@@ -0,0 +1,305 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	model "github.com/CloudDetail/apo/backend/pkg/model/vector"
+	"gopkg.in/yaml.v3"
+)
+
+// ParsePipeline parses the aggregator config of Vector.
+func ParsePipeline(content string) (*model.Pipeline, error) {
+	var pipeline model.Pipeline
+	if err := yaml.Unmarshal([]byte(content), &pipeline); err != nil {
+		return nil, err
+	}
+	if pipeline.Sources == nil {
+		pipeline.Sources = map[string]*model.Component{}
+	}
+	if pipeline.Transforms == nil {
+		pipeline.Transforms = map[string]*model.Component{}
+	}
+	if pipeline.Sinks == nil {
+		pipeline.Sinks = map[string]*model.Component{}
+	}
+	return &pipeline, nil
+}
+
+func MarshalPipeline(pipeline *model.Pipeline) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(pipeline); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidatePipeline checks the settings of the components and the references of the inputs.
+func ValidatePipeline(pipeline *model.Pipeline) error {
+	var errs []error
+	kinds := map[string]string{}
+	outputs := map[string]struct{}{}
+	for _, group := range []struct {
+		kind       string
+		components map[string]*model.Component
+	}{
+		{model.KindSource, pipeline.Sources},
+		{model.KindTransform, pipeline.Transforms},
+		{model.KindSink, pipeline.Sinks},
+	} {
+		for name, component := range group.components {
+			if kind, exists := kinds[name]; exists {
+				errs = append(errs, fmt.Errorf("%s %s: the name is used by a %s", group.kind, name, kind))
+				continue
+			}
+			kinds[name] = group.kind
+			if component == nil {
+				errs = append(errs, fmt.Errorf("%s %s: empty component", group.kind, name))
+				continue
+			}
+			if len(component.Type) == 0 {
+				errs = append(errs, fmt.Errorf("%s %s: type is required", group.kind, name))
+			}
+			if group.kind == model.KindSink {
+				continue
+			}
+			outputs[name] = struct{}{}
+			if component.Type == model.TransformRoute {
+				for route := range component.Route {
+					outputs[name+"."+route] = struct{}{}
+				}
+				outputs[name+"._unmatched"] = struct{}{}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for name, source := range pipeline.Sources {
+		if len(source.Inputs) > 0 {
+			errs = append(errs, fmt.Errorf("source %s: sources have no inputs", name))
+		}
+	}
+	for _, group := range []struct {
+		kind       string
+		components map[string]*model.Component
+	}{
+		{model.KindTransform, pipeline.Transforms},
+		{model.KindSink, pipeline.Sinks},
+	} {
+		for name, component := range group.components {
+			if len(component.Inputs) == 0 {
+				errs = append(errs, fmt.Errorf("%s %s: inputs are required", group.kind, name))
+			}
+			for _, input := range component.Inputs {
+				if !matchOutputs(input, outputs) {
+					errs = append(errs, fmt.Errorf("%s %s: input %s does not exist", group.kind, name, input))
+				}
+			}
+		}
+	}
+	for name, transform := range pipeline.Transforms {
+		if err := validateTransform(transform); err != nil {
+			errs = append(errs, fmt.Errorf("transform %s: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return findCycle(pipeline.Transforms, outputs)
+}
+
+func validateTransform(transform *model.Component) error {
+	switch transform.Type {
+	case model.TransformRemap:
+		if len(transform.Source) == 0 {
+			return errors.New("source is required")
+		}
+		return ValidateVRL(transform.Source)
+	case model.TransformRoute:
+		if len(transform.Route) == 0 {
+			return errors.New("route is required")
+		}
+	case model.TransformFilter:
+		if transform.Condition == nil {
+			return errors.New("condition is required")
+		}
+	case model.TransformSample:
+		if transform.Rate <= 0 {
+			return errors.New("rate must be positive")
+		}
+	case model.TransformDedupe:
+		if transform.Fields != nil && len(transform.Fields.Match) > 0 && len(transform.Fields.Ignore) > 0 {
+			return errors.New("only one of fields.match and fields.ignore can be set")
+		}
+		if transform.Cache != nil && transform.Cache.Num <= 0 {
+			return errors.New("cache.num must be positive")
+		}
+	case model.TransformThrottle:
+		if transform.Threshold <= 0 {
+			return errors.New("threshold must be positive")
+		}
+		if transform.WindowSecs <= 0 {
+			return errors.New("window_secs must be positive")
+		}
+	}
+	return nil
+}
+
+// matchOutputs checks whether the input refers to any output, wildcards are supported as Vector does.
+func matchOutputs(input string, outputs map[string]struct{}) bool {
+	if _, find := outputs[input]; find {
+		return true
+	}
+	if !strings.Contains(input, "*") {
+		return false
+	}
+	for output := range outputs {
+		if matched, _ := path.Match(input, output); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func findCycle(transforms map[string]*model.Component, outputs map[string]struct{}) error {
+	// transform -> the transforms it reads from
+	upstreams := make(map[string][]string, len(transforms))
+	for name, transform := range transforms {
+		for _, input := range transform.Inputs {
+			for output := range outputs {
+				if output != input {
+					if matched, _ := path.Match(input, output); !matched {
+						continue
+					}
+				}
+				upstream, _, _ := strings.Cut(output, ".")
+				if _, isTransform := transforms[upstream]; isTransform {
+					upstreams[name] = append(upstreams[name], upstream)
+				}
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(transforms))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("transform %s: inputs form a cycle", name)
+		case visited:
+			return nil
+		}
+		states[name] = visiting
+		for _, upstream := range upstreams[name] {
+			if err := visit(upstream); err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+		return nil
+	}
+	for _, name := range sortedNames(transforms) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiffPipelines returns the components added, removed or modified from one version to another.
+func DiffPipelines(from *model.Pipeline, to *model.Pipeline) ([]model.ComponentDiff, error) {
+	diffs := make([]model.ComponentDiff, 0)
+	for _, group := range []struct {
+		kind     string
+		from, to map[string]*model.Component
+	}{
+		{model.KindSource, from.Sources, to.Sources},
+		{model.KindTransform, from.Transforms, to.Transforms},
+		{model.KindSink, from.Sinks, to.Sinks},
+	} {
+		names := map[string]struct{}{}
+		for name := range group.from {
+			names[name] = struct{}{}
+		}
+		for name := range group.to {
+			names[name] = struct{}{}
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			before, err := marshalComponent(group.from[name])
+			if err != nil {
+				return nil, err
+			}
+			after, err := marshalComponent(group.to[name])
+			if err != nil {
+				return nil, err
+			}
+			diff := model.ComponentDiff{Kind: group.kind, Name: name, Before: before, After: after}
+			switch {
+			case before == after:
+				continue
+			case len(before) == 0:
+				diff.Change = model.ComponentAdded
+			case len(after) == 0:
+				diff.Change = model.ComponentRemoved
+			default:
+				diff.Change = model.ComponentModified
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+func marshalComponent(component *model.Component) (string, error) {
+	if component == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(component)
+	return string(data), err
+}
+
+// cloneComponent returns a deep copy of the component.
+func cloneComponent(component *model.Component) (*model.Component, error) {
+	data, err := yaml.Marshal(component)
+	if err != nil {
+		return nil, err
+	}
+	var clone model.Component
+	if err := yaml.Unmarshal(data, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+func sortedNames(components map[string]*model.Component) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
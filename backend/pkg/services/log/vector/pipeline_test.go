@@ -0,0 +1,229 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"strings"
+	"testing"
+
+	model "github.com/CloudDetail/apo/backend/pkg/model/vector"
+)
+
+const aggregatorConfig = `sources:
+  from_ilogtail:
+    type: http_server
+    address: 0.0.0.0:4310
+    decoding:
+      codec: json
+transforms:
+  route_logs:
+    type: route
+    inputs:
+      - from_ilogtail
+    route:
+      all_logs_route: starts_with(string!(."k8s.pod.name"), "apo")
+  parse_all_logs:
+    type: remap
+    inputs:
+      - route_logs.all_logs_route
+    source: |
+      .msg, err = parse_regex(.content, r'(?P<level>\w+) (?P<msg>.*)')
+sinks:
+  to_default_java:
+    type: clickhouse
+    inputs:
+      - route_logs._unmatched
+    endpoint: http://clickhouse:8123
+    database: apo
+    table: raw_logs_buffer
+  to_all_logs:
+    type: clickhouse
+    inputs:
+      - parse_all_logs
+    endpoint: http://clickhouse:8123
+    database: apo
+    table: logs_all_logs_buffer
+`
+
+func TestParsePipeline(t *testing.T) {
+	pipeline, err := ParsePipeline(aggregatorConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidatePipeline(pipeline); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pipeline.Transforms["route_logs"].Route["all_logs_route"] == nil {
+		t.Fatalf("route = %v", pipeline.Transforms["route_logs"].Route)
+	}
+	if pipeline.Sinks["to_default_java"].Options["table"] != "raw_logs_buffer" {
+		t.Fatalf("options = %v", pipeline.Sinks["to_default_java"].Options)
+	}
+
+	// round trip
+	data, err := MarshalPipeline(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParsePipeline(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := DiffPipelines(pipeline, again)
+	if err != nil || len(diffs) != 0 {
+		t.Fatalf("diffs = %v, err = %v", diffs, err)
+	}
+}
+
+func TestValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *model.Pipeline)
+		errMsg string
+	}{
+		{
+			name: "filter, sample, dedupe and throttle",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["drop_debug"] = &model.Component{Type: model.TransformFilter, Inputs: []string{"route_logs._unmatched"}, Condition: `.level != "DEBUG"`}
+				p.Transforms["sample"] = &model.Component{Type: model.TransformSample, Inputs: []string{"drop_debug"}, Rate: 10}
+				p.Transforms["dedupe"] = &model.Component{Type: model.TransformDedupe, Inputs: []string{"sample"}, Fields: &model.DedupeFields{Match: []string{"content"}}}
+				p.Transforms["throttle"] = &model.Component{Type: model.TransformThrottle, Inputs: []string{"dedupe"}, Threshold: 100, WindowSecs: 1}
+				p.Sinks["to_default_java"].Inputs = []string{"throttle"}
+			},
+		},
+		{
+			name: "wildcard input",
+			modify: func(p *model.Pipeline) {
+				p.Sinks["to_default_java"].Inputs = []string{"parse_*"}
+			},
+		},
+		{
+			name: "unknown input",
+			modify: func(p *model.Pipeline) {
+				p.Sinks["to_all_logs"].Inputs = []string{"parse_nothing"}
+			},
+			errMsg: "sink to_all_logs: input parse_nothing does not exist",
+		},
+		{
+			name: "unknown route",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["parse_all_logs"].Inputs = []string{"route_logs.java_route"}
+			},
+			errMsg: "transform parse_all_logs: input route_logs.java_route does not exist",
+		},
+		{
+			name: "sink as input",
+			modify: func(p *model.Pipeline) {
+				p.Sinks["to_all_logs"].Inputs = []string{"to_default_java"}
+			},
+			errMsg: "input to_default_java does not exist",
+		},
+		{
+			name: "duplicated name",
+			modify: func(p *model.Pipeline) {
+				p.Sinks["parse_all_logs"] = &model.Component{Type: "console", Inputs: []string{"route_logs._unmatched"}}
+			},
+			errMsg: "the name is used by a transform",
+		},
+		{
+			name: "cycle",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["a"] = &model.Component{Type: model.TransformSample, Inputs: []string{"b"}, Rate: 2}
+				p.Transforms["b"] = &model.Component{Type: model.TransformSample, Inputs: []string{"a"}, Rate: 2}
+			},
+			errMsg: "inputs form a cycle",
+		},
+		{
+			name: "illegal remap",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["parse_all_logs"].Source = ". = parse_json(.content)"
+			},
+			errMsg: "transform parse_all_logs: line 1: the error of parse_json is not handled",
+		},
+		{
+			name: "throttle without window",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["throttle"] = &model.Component{Type: model.TransformThrottle, Inputs: []string{"route_logs._unmatched"}, Threshold: 1}
+			},
+			errMsg: "transform throttle: window_secs must be positive",
+		},
+		{
+			name: "missing inputs",
+			modify: func(p *model.Pipeline) {
+				p.Transforms["sample"] = &model.Component{Type: model.TransformSample, Rate: 2}
+			},
+			errMsg: "transform sample: inputs are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := ParsePipeline(aggregatorConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(pipeline)
+			err = ValidatePipeline(pipeline)
+			if len(tt.errMsg) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("error = %v, want %s", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParseRuleAndDiff(t *testing.T) {
+	base, _ := ParsePipeline(aggregatorConfig)
+	pipeline, _ := ParsePipeline(aggregatorConfig)
+
+	p := ParseInfo{
+		ParseName: "java",
+		TableName: "logs_java",
+		RouteRule: `starts_with(string!(."k8s.pod.name"), "java")`,
+		ParseRule: `.msg, err = parse_regex(.content, r'(?P<level>\w+)')`,
+	}
+	data, err := p.AddParseRule(pipeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, err := ParsePipeline(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Sinks["to_java"].Options["table"] != "logs_java_buffer" || added.Sinks["to_default_java"].Options["table"] != "raw_logs_buffer" {
+		t.Fatalf("sinks = %v, %v", added.Sinks["to_java"].Options, added.Sinks["to_default_java"].Options)
+	}
+
+	diffs, err := DiffPipelines(base, added)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		changes = append(changes, diff.Kind+"/"+diff.Name+"/"+diff.Change)
+	}
+	want := "transform/parse_java/added,transform/route_logs/modified,sink/to_java/added"
+	if strings.Join(changes, ",") != want {
+		t.Fatalf("changes = %v", changes)
+	}
+
+	p.ParseRule = ". = parse_json(.content)"
+	if _, err := p.UpdateParseRule(added); err == nil {
+		t.Fatal("illegal parse rule is pushed")
+	}
+
+	data, err = p.DeleteParseRule(added)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted, _ := ParsePipeline(string(data))
+	diffs, _ = DiffPipelines(base, deleted)
+	if len(diffs) != 0 {
+		t.Fatalf("diffs = %v", diffs)
+	}
+}
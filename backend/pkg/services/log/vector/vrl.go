@@ -0,0 +1,241 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fallibleFunctions always return an error for unexpected input,
+// Vector refuses to load a remap program if their errors are not handled.
+var fallibleFunctions = map[string]struct{}{
+	"parse_regex":            {},
+	"parse_regex_all":        {},
+	"parse_json":             {},
+	"parse_grok":             {},
+	"parse_groks":            {},
+	"parse_key_value":        {},
+	"parse_logfmt":           {},
+	"parse_syslog":           {},
+	"parse_timestamp":        {},
+	"parse_common_log":       {},
+	"parse_apache_log":       {},
+	"parse_nginx_log":        {},
+	"parse_csv":              {},
+	"parse_klog":             {},
+	"parse_glog":             {},
+	"parse_xml":              {},
+	"parse_duration":         {},
+	"parse_int":              {},
+	"parse_url":              {},
+	"parse_cef":              {},
+	"parse_aws_vpc_flow_log": {},
+}
+
+var (
+	// e.g. `.msg, err = ` or `msg, err = `
+	errAssignPattern = regexp.MustCompile(`^\s*\.?[\w."-]*\s*,\s*\w+\s*=[^=]`)
+	identPattern     = regexp.MustCompile(`[a-z_][a-z0-9_]*$`)
+)
+
+type vrlToken struct {
+	// one of ( ) [ ] { } s(string) r(regex) c(call)
+	kind byte
+	text string
+	line int
+	// for calls, whether called with ! and the statement of the call
+	abort     bool
+	statement string
+}
+
+// ValidateVRL checks the remap program before pushing it to Vector.
+// It is not a full VRL compiler, only the mistakes which stop Vector from loading the config are found:
+// unterminated literals, unbalanced brackets, illegal regex and unhandled errors of fallible functions.
+func ValidateVRL(source string) error {
+	tokens, err := lexVRL(source)
+	if err != nil {
+		return err
+	}
+
+	var stack []vrlToken
+	pairs := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	for _, token := range tokens {
+		switch token.kind {
+		case '(', '[', '{':
+			stack = append(stack, token)
+		case ')', ']', '}':
+			if len(stack) == 0 || stack[len(stack)-1].kind != pairs[token.kind] {
+				return fmt.Errorf("line %d: unexpected '%c'", token.line, token.kind)
+			}
+			stack = stack[:len(stack)-1]
+		case 'r':
+			if _, err := regexp.Compile(token.text); err != nil {
+				return fmt.Errorf("line %d: illegal regex: %v", token.line, err)
+			}
+		case 'c':
+			if _, fallible := fallibleFunctions[token.text]; !fallible || token.abort {
+				continue
+			}
+			if !errAssignPattern.MatchString(token.statement) && !strings.Contains(token.statement, "??") {
+				return fmt.Errorf("line %d: the error of %s is not handled, use %s! or assign it as 'value, err = %s(...)'",
+					token.line, token.text, token.text, token.text)
+			}
+		}
+	}
+	if len(stack) > 0 {
+		token := stack[len(stack)-1]
+		return fmt.Errorf("line %d: unclosed '%c'", token.line, token.kind)
+	}
+	return nil
+}
+
+// RegexesOfVRL returns the regexes passed to parse_regex in order.
+func RegexesOfVRL(source string) ([]*regexp.Regexp, error) {
+	tokens, err := lexVRL(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var regexes []*regexp.Regexp
+	for i, token := range tokens {
+		if token.kind != 'c' || token.text != "parse_regex" {
+			continue
+		}
+		// the first regex literal among the arguments
+		depth := 0
+		for _, arg := range tokens[i+1:] {
+			if arg.kind == '(' {
+				depth++
+			} else if arg.kind == ')' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			} else if arg.kind == 'r' && depth == 1 {
+				re, err := regexp.Compile(arg.text)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: illegal regex: %v", arg.line, err)
+				}
+				regexes = append(regexes, re)
+				break
+			}
+		}
+	}
+	return regexes, nil
+}
+
+// lexVRL splits the program into brackets, literals and function calls, the other tokens are dropped.
+func lexVRL(source string) ([]vrlToken, error) {
+	var (
+		tokens         []vrlToken
+		line           = 1
+		statementStart = 0
+		// calls in the current statement, their statement is filled at the end of it
+		pending []int
+		depth   int
+	)
+	endStatement := func(end int) {
+		statement := source[statementStart:end]
+		for _, idx := range pending {
+			tokens[idx].statement = statement
+		}
+		pending = pending[:0]
+		statementStart = end
+	}
+
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case c == '\n':
+			// newlines inside the arguments do not end the statement
+			if depth == 0 {
+				endStatement(i + 1)
+			}
+			line++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '"':
+			end, err := scanQuoted(source, i+1, '"', line)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, vrlToken{kind: 's', text: source[i+1 : end], line: line})
+			line += strings.Count(source[i:end], "\n")
+			i = end
+		case c == '\'' && i > 0 && strings.ContainsRune("rst", rune(source[i-1])) && !isIdentChar(source, i-2):
+			end, err := scanQuoted(source, i+1, '\'', line)
+			if err != nil {
+				return nil, err
+			}
+			kind := byte('s')
+			text := source[i+1 : end]
+			if source[i-1] == 'r' {
+				kind = 'r'
+				text = strings.ReplaceAll(text, `\'`, `'`)
+			}
+			tokens = append(tokens, vrlToken{kind: kind, text: text, line: line})
+			line += strings.Count(source[i:end], "\n")
+			i = end
+		case c == '\'':
+			return nil, fmt.Errorf("line %d: unexpected \"'\"", line)
+		case c == '(':
+			// function call: ident( or ident!(
+			prefix := source[statementStart:i]
+			abort := strings.HasSuffix(prefix, "!")
+			name := identPattern.FindString(strings.TrimSuffix(prefix, "!"))
+			if len(name) > 0 {
+				tokens = append(tokens, vrlToken{kind: 'c', text: name, line: line, abort: abort})
+				pending = append(pending, len(tokens)-1)
+			}
+			tokens = append(tokens, vrlToken{kind: c, line: line})
+			depth++
+		case c == '[':
+			tokens = append(tokens, vrlToken{kind: c, line: line})
+			depth++
+		case c == ')' || c == ']':
+			tokens = append(tokens, vrlToken{kind: c, line: line})
+			if depth > 0 {
+				depth--
+			}
+		case c == '{' || c == '}':
+			// blocks start new statements, e.g. if err == null { ... }
+			tokens = append(tokens, vrlToken{kind: c, line: line})
+			if depth == 0 {
+				endStatement(i + 1)
+			}
+		case c == ';':
+			if depth == 0 {
+				endStatement(i + 1)
+			}
+		}
+	}
+	endStatement(len(source))
+	return tokens, nil
+}
+
+// scanQuoted returns the index of the closing quote.
+func scanQuoted(source string, start int, quote byte, line int) (int, error) {
+	for i := start; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+		case quote:
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("line %d: unterminated string literal", line)
+}
+
+func isIdentChar(source string, i int) bool {
+	if i < 0 {
+		return false
+	}
+	c := source[i]
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package vector
+
+import (
+	"strings"
+	"testing"
+)
+
+const defaultParseRule = `.msg, err = parse_regex(.content, r' \[(?P<level>.*?)\] \[(?P<thread>.*?)\] \[(?P<method>.*?)\(.*?\)\] - (?P<msg>.*)')
+if err == null {
+	.content = encode_json(.msg)
+}
+del(.msg)
+`
+
+func TestValidateVRL(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		errMsg string
+	}{
+		{name: "default rule", source: defaultParseRule},
+		{name: "empty", source: ""},
+		{name: "abort on error", source: `. = parse_json!(.content)`},
+		{name: "coalesce", source: `.msg = parse_json(.content) ?? {}`},
+		{name: "multi-line call", source: ".msg, err = parse_regex(.content,\n  r'(?P<level>\\w+) (?P<msg>.*)')\n"},
+		{name: "escaped quote in regex", source: `.msg, err = parse_regex(.content, r'(?P<q>\'.*\')')`},
+		{name: "comment", source: "# parse_json(.content) )\n.a = 1"},
+		{name: "string with brackets", source: `.a = "(["`},
+		{name: "path with quotes", source: `.a = string!(."k8s.pod.name")`},
+		{
+			name:   "unhandled error",
+			source: `.msg = parse_json(.content)`,
+			errMsg: "line 1: the error of parse_json is not handled",
+		},
+		{
+			name:   "unhandled error in block",
+			source: "if true {\n  .msg = parse_regex(.content, r'a')\n}",
+			errMsg: "line 2: the error of parse_regex is not handled",
+		},
+		{
+			name:   "illegal regex",
+			source: `.msg, err = parse_regex(.content, r'(?P<level>\w+')`,
+			errMsg: "line 1: illegal regex",
+		},
+		{
+			name:   "unbalanced",
+			source: "if err == null {\n  .content = encode_json(.msg)\n",
+			errMsg: "line 1: unclosed '{'",
+		},
+		{
+			name:   "unexpected",
+			source: `.a = to_string(.b))`,
+			errMsg: "line 1: unexpected ')'",
+		},
+		{
+			name:   "unterminated string",
+			source: "\n.a = \"abc",
+			errMsg: "line 2: unterminated string literal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVRL(tt.source)
+			if len(tt.errMsg) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.errMsg) {
+				t.Fatalf("error = %v, want %s", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestRegexesOfVRL(t *testing.T) {
+	regexes, err := RegexesOfVRL(defaultParseRule + `.b, err = parse_regex(to_string(.a), r'(?P<x>\d+)')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regexes) != 2 {
+		t.Fatalf("len(regexes) = %d", len(regexes))
+	}
+	match := regexes[0].FindStringSubmatch("2024-01-01 [INFO] [main] [com.Foo.bar(Foo.java:1)] - started")
+	if match == nil || match[regexes[0].SubexpIndex("level")] != "INFO" || match[regexes[0].SubexpIndex("msg")] != "started" {
+		t.Fatalf("match = %v", match)
+	}
+	if regexes[1].String() != `(?P<x>\d+)` {
+		t.Fatalf("regexes[1] = %s", regexes[1])
+	}
+}
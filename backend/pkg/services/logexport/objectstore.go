@@ -0,0 +1,62 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"context"
+	"io"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// objectStore is where the archived logs are stored.
+type objectStore interface {
+	// Upload reads body until EOF and stores it as the object
+	Upload(ctx context.Context, key string, body io.Reader) error
+}
+
+type s3Store struct {
+	bucket   string
+	uploader *s3manager.Uploader
+}
+
+func newS3Store(endpoint string, region string, bucket string, accessKeyID string, secretAccessKey string, forcePathStyle bool) (*s3Store, error) {
+	cfg := &aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(forcePathStyle),
+	}
+	if len(endpoint) > 0 {
+		cfg.Endpoint = aws.String(endpoint)
+	}
+	if len(accessKeyID) > 0 {
+		cfg.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Store{
+		bucket:   bucket,
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func newS3StoreFromConfig() (*s3Store, error) {
+	cfg := config.Get().LogArchive
+	return newS3Store(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKeyID, cfg.SecretAccessKey, cfg.ForcePathStyle)
+}
+
+// Upload streams the body in multiple parts if it is larger than the part size.
+func (s *s3Store) Upload(ctx context.Context, key string, body io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return err
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetWriter writes every batch of logs as a row group compressed by gzip. All columns are
+// optional so that the NULL values of Nullable columns stay NULL. Time columns are stored as
+// timestamps in microseconds, integers as INT64, floats as DOUBLE and the others as UTF8 strings.
+type parquetWriter struct {
+	w       io.Writer
+	writer  *parquet.Writer
+	columns []parquetColumn
+}
+
+type parquetColumn struct {
+	name  string
+	index int
+	kind  parquet.Kind
+}
+
+func newParquetWriter(w io.Writer) *parquetWriter {
+	return &parquetWriter{w: w}
+}
+
+func (p *parquetWriter) Write(columns []string, logs []map[string]any) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	if p.writer == nil {
+		p.open(columns, logs)
+	}
+
+	rows := make([]parquet.Row, len(logs))
+	for i, log := range logs {
+		row := make(parquet.Row, len(p.columns))
+		for _, column := range p.columns {
+			value := log[column.name]
+			if value == nil {
+				row[column.index] = parquet.NullValue().Level(0, 0, column.index)
+				continue
+			}
+			row[column.index] = parquetValueOf(column.kind, value).Level(0, 1, column.index)
+		}
+		rows[i] = row
+	}
+	if _, err := p.writer.WriteRows(rows); err != nil {
+		return err
+	}
+	return p.writer.Flush()
+}
+
+// Close writes the file metadata, a file without logs has an empty schema.
+func (p *parquetWriter) Close() error {
+	if p.writer == nil {
+		p.open(nil, nil)
+	}
+	return p.writer.Close()
+}
+
+// open decides the types of the columns by the first non-NULL values of the first batch,
+// the scanned values of a column always have the same type. Columns without values are strings.
+func (p *parquetWriter) open(columns []string, logs []map[string]any) {
+	group := parquet.Group{}
+	for _, name := range columns {
+		var value any
+		for _, log := range logs {
+			if value = log[name]; value != nil {
+				break
+			}
+		}
+		var node parquet.Node
+		switch value.(type) {
+		case time.Time:
+			node = parquet.Timestamp(parquet.Microsecond)
+		case uint64:
+			node = parquet.Uint(64)
+		case int64, int32, uint32:
+			node = parquet.Int(64)
+		case float64, float32:
+			node = parquet.Leaf(parquet.DoubleType)
+		default:
+			node = parquet.String()
+		}
+		group[name] = parquet.Optional(node)
+	}
+
+	schema := parquet.NewSchema("schema", group)
+	for _, name := range columns {
+		leaf, _ := schema.Lookup(name)
+		p.columns = append(p.columns, parquetColumn{name: name, index: leaf.ColumnIndex, kind: leaf.Node.Type().Kind()})
+	}
+	p.writer = parquet.NewWriter(p.w, schema, parquet.Compression(&parquet.Gzip), parquet.CreatedBy("APO", "", ""))
+}
+
+func parquetValueOf(kind parquet.Kind, value any) parquet.Value {
+	switch kind {
+	case parquet.Int64:
+		var v int64
+		switch n := value.(type) {
+		case time.Time:
+			v = n.UnixMicro()
+		case uint64:
+			v = int64(n)
+		case int64:
+			v = n
+		case int32:
+			v = int64(n)
+		case uint32:
+			v = int64(n)
+		}
+		return parquet.Int64Value(v)
+	case parquet.Double:
+		var v float64
+		switch n := value.(type) {
+		case float64:
+			v = n
+		case float32:
+			v = float64(n)
+		}
+		return parquet.DoubleValue(v)
+	default:
+		return parquet.ByteArrayValue([]byte(formatValue(value)))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// CreateLogExport starts exporting the logs matching the query into a file in the background.
+	CreateLogExport(ctx core.Context, req *request.CreateLogExportRequest) (*database.LogExportJob, error)
+	// ListLogExports returns the exports of the current user.
+	ListLogExports(ctx core.Context) (*response.ListLogExportsResponse, error)
+	// GetLogExport returns the export with its progress.
+	GetLogExport(ctx core.Context, req *request.LogExportRequest) (*database.LogExportJob, error)
+	// GetLogExportFile returns the path of the exported file and the file name for downloading.
+	GetLogExportFile(ctx core.Context, req *request.LogExportRequest) (string, string, error)
+	// DeleteLogExport stops the export if it is running and removes the exported file.
+	DeleteLogExport(ctx core.Context, req *request.LogExportRequest) error
+	// FailInterruptedExports marks the exports left running by the last run of the server as failed
+	// and removes their partial files. It should be called once on startup before any export is created.
+	FailInterruptedExports(ctx core.Context)
+
+	CreateLogArchiveJob(ctx core.Context, req *request.CreateLogArchiveJobRequest) error
+	UpdateLogArchiveJob(ctx core.Context, req *request.UpdateLogArchiveJobRequest) error
+	DeleteLogArchiveJob(ctx core.Context, req *request.LogArchiveJobRequest) error
+	ListLogArchiveJobs(ctx core.Context) (*response.ListLogArchiveJobsResponse, error)
+	ListLogArchiveRecords(ctx core.Context, req *request.LogArchiveJobRequest) (*response.ListLogArchiveRecordsResponse, error)
+	// RunLogArchiveJob runs the archive job in the background regardless of its schedule.
+	RunLogArchiveJob(ctx core.Context, req *request.LogArchiveJobRequest) error
+
+	// KeepArchiving runs the due archive jobs and removes the expired exports periodically until ctx is done.
+	KeepArchiving(ctx context.Context, interval time.Duration)
+}
+
+type service struct {
+	logger *zap.Logger
+	chRepo clickhouse.Repo
+	dbRepo database.Repo
+	// store is nil if the object storage is not configured correctly
+	store objectStore
+
+	// id -> struct{}, the running exports deleted by users
+	canceledExports sync.Map
+	// id -> struct{}, the running archive jobs
+	runningArchives sync.Map
+}
+
+func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo) Service {
+	s := &service{
+		logger: logger,
+		chRepo: chRepo,
+		dbRepo: dbRepo,
+	}
+	store, err := newS3StoreFromConfig()
+	if err != nil {
+		logger.Error("failed to create the object storage client of log archive", zap.Error(err))
+	} else {
+		s.store = store
+	}
+	return s
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDaysBeforeExpire = 1
+	archiveDayLayout        = "2006-01-02"
+)
+
+func (s *service) CreateLogArchiveJob(ctx core.Context, req *request.CreateLogArchiveJobRequest) error {
+	job, err := archiveJobOf(req)
+	if err != nil {
+		return err
+	}
+	return s.dbRepo.CreateLogArchiveJob(ctx, job)
+}
+
+func (s *service) UpdateLogArchiveJob(ctx core.Context, req *request.UpdateLogArchiveJobRequest) error {
+	if _, err := s.getLogArchiveJob(ctx, req.ID); err != nil {
+		return err
+	}
+	job, err := archiveJobOf(&req.CreateLogArchiveJobRequest)
+	if err != nil {
+		return err
+	}
+	job.ID = req.ID
+	return s.dbRepo.UpdateLogArchiveJob(ctx, job)
+}
+
+// DeleteLogArchiveJob keeps the archived objects.
+func (s *service) DeleteLogArchiveJob(ctx core.Context, req *request.LogArchiveJobRequest) error {
+	if _, err := s.getLogArchiveJob(ctx, req.ID); err != nil {
+		return err
+	}
+	return s.dbRepo.DeleteLogArchiveJob(ctx, req.ID)
+}
+
+func (s *service) ListLogArchiveJobs(ctx core.Context) (*response.ListLogArchiveJobsResponse, error) {
+	jobs, err := s.dbRepo.ListLogArchiveJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListLogArchiveJobsResponse{Jobs: jobs}, nil
+}
+
+func (s *service) ListLogArchiveRecords(ctx core.Context, req *request.LogArchiveJobRequest) (*response.ListLogArchiveRecordsResponse, error) {
+	records, err := s.dbRepo.ListLogArchiveRecords(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListLogArchiveRecordsResponse{Records: records}, nil
+}
+
+func (s *service) RunLogArchiveJob(ctx core.Context, req *request.LogArchiveJobRequest) error {
+	job, err := s.getLogArchiveJob(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkObjectStore(); err != nil {
+		return core.Error(code.LogArchiveJobIllegalError, err.Error())
+	}
+	go s.runArchiveJob(core.EmptyCtx(), job)
+	return nil
+}
+
+func (s *service) KeepArchiving(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.archive(core.EmptyCtx(), time.Now())
+			s.cleanExports(core.EmptyCtx())
+		}
+	}
+}
+
+// archive runs the enabled jobs whose next scheduled time since the last run has come.
+func (s *service) archive(ctx core.Context, now time.Time) {
+	if s.checkObjectStore() != nil {
+		return
+	}
+	jobs, err := s.dbRepo.ListLogArchiveJobs(ctx)
+	if err != nil {
+		s.logger.Error("failed to list the log archive jobs", zap.Error(err))
+		return
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if !job.Enabled {
+			continue
+		}
+		schedule, err := cron.ParseStandard(job.Schedule)
+		if err != nil {
+			continue
+		}
+		lastRunAt := time.Unix(job.LastRunAt, 0)
+		if job.LastRunAt == 0 {
+			// never run, wait for the first scheduled time since it is updated
+			lastRunAt = time.Unix(job.UpdatedAt, 0)
+		}
+		if schedule.Next(lastRunAt).After(now) {
+			continue
+		}
+		s.runArchiveJob(ctx, job)
+	}
+}
+
+// runArchiveJob archives the partitions expiring soon and records the result of the run.
+func (s *service) runArchiveJob(ctx core.Context, job *database.LogArchiveJob) {
+	if _, running := s.runningArchives.LoadOrStore(job.ID, struct{}{}); running {
+		return
+	}
+	defer s.runningArchives.Delete(job.ID)
+
+	runAt := time.Now()
+	var runError string
+	if err := s.archiveJob(ctx, job, runAt); err != nil {
+		s.logger.Error("failed to archive logs", zap.String("job", job.Name), zap.Error(err))
+		runError = err.Error()
+	}
+	if err := s.dbRepo.UpdateLogArchiveJobRun(ctx, job.ID, runAt.Unix(), runError); err != nil {
+		s.logger.Error("failed to update the log archive job", zap.String("job", job.Name), zap.Error(err))
+	}
+}
+
+func (s *service) archiveJob(ctx core.Context, job *database.LogArchiveJob, now time.Time) error {
+	ttlDays, err := s.chRepo.GetLogTableTTLDays(ctx, job.DataBase, job.Table)
+	if err != nil {
+		return err
+	}
+	if ttlDays == 0 {
+		return fmt.Errorf("no TTL is set on %s.%s", job.DataBase, job.Table)
+	}
+	if job.DaysBeforeExpire >= ttlDays {
+		return fmt.Errorf("daysBeforeExpire %d must be less than the TTL %d days", job.DaysBeforeExpire, ttlDays)
+	}
+
+	days, err := s.chRepo.ListLogDays(ctx, job.DataBase, job.Table, expiringBefore(now, ttlDays, job.DaysBeforeExpire))
+	if err != nil {
+		return err
+	}
+	records, err := s.dbRepo.ListLogArchiveRecords(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	archived := make(map[string]struct{}, len(records))
+	for _, record := range records {
+		archived[record.Day] = struct{}{}
+	}
+
+	var errs []error
+	for _, day := range days {
+		if _, find := archived[day.Day.Format(archiveDayLayout)]; find {
+			continue
+		}
+		record, err := s.archiveDay(ctx, job, day.Day)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", day.Day.Format(archiveDayLayout), err))
+			continue
+		}
+		if err := s.dbRepo.CreateLogArchiveRecord(ctx, record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// archiveDay streams the logs of the day into the object storage.
+func (s *service) archiveDay(ctx core.Context, job *database.LogArchiveJob, day time.Time) (*database.LogArchiveRecord, error) {
+	record := &database.LogArchiveRecord{
+		JobID:     job.ID,
+		Day:       day.Format(archiveDayLayout),
+		ObjectKey: archiveObjectKey(job, day),
+	}
+
+	reader, writer := io.Pipe()
+	counter := &countingWriter{w: writer}
+	produced := make(chan error, 1)
+	go func() {
+		err := s.writeLogsOfDay(ctx, job, day, counter, &record.Rows)
+		writer.CloseWithError(err)
+		produced <- err
+	}()
+
+	err := s.store.Upload(context.Background(), record.ObjectKey, reader)
+	// stop the producer if the upload fails
+	reader.CloseWithError(err)
+	if produceErr := <-produced; produceErr != nil && err == nil {
+		err = produceErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	record.Size = counter.n
+	return record, nil
+}
+
+func (s *service) writeLogsOfDay(ctx core.Context, job *database.LogArchiveJob, day time.Time, w io.Writer, rows *int64) error {
+	writer, err := newCompressedLogWriter(job.Format, w)
+	if err != nil {
+		return err
+	}
+	err = s.chRepo.ExportLogsOfDay(ctx, job.DataBase, job.Table, day, exportBatchSize, func(columns []string, logs []map[string]any) error {
+		*rows += int64(len(logs))
+		return writer.Write(columns, logs)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func (s *service) checkObjectStore() error {
+	if !config.Get().LogArchive.Enable {
+		return errors.New("log archive is disabled")
+	}
+	if s.store == nil {
+		return errors.New("the object storage of log archive is not configured")
+	}
+	return nil
+}
+
+func (s *service) getLogArchiveJob(ctx core.Context, id int64) (*database.LogArchiveJob, error) {
+	job, err := s.dbRepo.GetLogArchiveJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, core.Error(code.LogArchiveJobNotExistError, fmt.Sprintf("log archive job %d not exists", id))
+	}
+	return job, nil
+}
+
+func archiveJobOf(req *request.CreateLogArchiveJobRequest) (*database.LogArchiveJob, error) {
+	if _, err := cron.ParseStandard(req.Schedule); err != nil {
+		return nil, core.Error(code.LogArchiveJobIllegalError, fmt.Sprintf("illegal schedule: %v", err))
+	}
+	if req.DaysBeforeExpire == 0 {
+		req.DaysBeforeExpire = defaultDaysBeforeExpire
+	}
+	return &database.LogArchiveJob{
+		Name:             req.Name,
+		DataBase:         req.DataBase,
+		Table:            req.TableName,
+		Format:           req.Format,
+		Schedule:         req.Schedule,
+		DaysBeforeExpire: req.DaysBeforeExpire,
+		Prefix:           req.Prefix,
+		Enabled:          req.Enabled,
+	}, nil
+}
+
+// expiringBefore returns the start of the first day (UTC) whose logs do not expire within daysBeforeExpire.
+// The partition of day D is dropped by TTL once D + 1 + ttlDays has come.
+func expiringBefore(now time.Time, ttlDays int, daysBeforeExpire int) time.Time {
+	today := now.UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, daysBeforeExpire-ttlDays)
+}
+
+func archiveObjectKey(job *database.LogArchiveJob, day time.Time) string {
+	return path.Join(job.Prefix, job.DataBase, job.Table, day.Format(archiveDayLayout)+fileExtension(job.Format, true))
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
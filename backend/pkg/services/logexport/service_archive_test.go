@@ -0,0 +1,148 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+// fakeS3 is a stand-in of MinIO which stores the objects put in path style.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut || r.URL.RawQuery != "" {
+		http.Error(w, "unsupported", http.StatusNotImplemented)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") {
+		http.Error(w, "unsigned", http.StatusForbidden)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.objects[r.URL.Path] = body
+	f.mu.Unlock()
+	w.Header().Set("ETag", `"etag"`)
+}
+
+type fakeChRepo struct {
+	clickhouse.Repo
+	days   []clickhouse.LogDay
+	before time.Time
+}
+
+func (f *fakeChRepo) GetLogTableTTLDays(core.Context, string, string) (int, error) {
+	return 7, nil
+}
+
+func (f *fakeChRepo) ListLogDays(_ core.Context, _ string, _ string, before time.Time) ([]clickhouse.LogDay, error) {
+	f.before = before
+	return f.days, nil
+}
+
+func (f *fakeChRepo) ExportLogsOfDay(_ core.Context, _ string, _ string, day time.Time, _ int, handle clickhouse.LogBatchHandler) error {
+	return handle([]string{"timestamp", "content"}, []map[string]any{
+		{"timestamp": day, "content": "first"},
+		{"timestamp": day.Add(time.Hour), "content": "second"},
+	})
+}
+
+type fakeDBRepo struct {
+	database.Repo
+	records []database.LogArchiveRecord
+}
+
+func (f *fakeDBRepo) ListLogArchiveRecords(core.Context, int64) ([]database.LogArchiveRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeDBRepo) CreateLogArchiveRecord(_ core.Context, record *database.LogArchiveRecord) error {
+	f.records = append(f.records, *record)
+	return nil
+}
+
+func TestArchiveJob(t *testing.T) {
+	storage := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(storage)
+	defer server.Close()
+	store, err := newS3Store(server.URL, "us-east-1", "archive", "minio", "minio123", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	chRepo := &fakeChRepo{days: []clickhouse.LogDay{{Day: day(1), Count: 2}, {Day: day(2), Count: 2}}}
+	dbRepo := &fakeDBRepo{records: []database.LogArchiveRecord{{JobID: 1, Day: "2025-01-01"}}}
+	s := &service{logger: zap.NewNop(), chRepo: chRepo, dbRepo: dbRepo, store: store}
+
+	job := &database.LogArchiveJob{ID: 1, DataBase: "apo", Table: "logs", Format: FormatNDJSON, DaysBeforeExpire: 1, Prefix: "apo-logs"}
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	if err := s.archiveJob(core.EmptyCtx(), job, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// TTL is 7 days, the logs of Jan 3 expire on Jan 11
+	if !chRepo.before.Equal(day(4)) {
+		t.Fatalf("before = %v", chRepo.before)
+	}
+	if len(storage.objects) != 1 {
+		t.Fatalf("objects = %v", storage.objects)
+	}
+	object, find := storage.objects["/archive/apo-logs/apo/logs/2025-01-02.ndjson.gz"]
+	if !find {
+		t.Fatalf("objects = %v", storage.objects)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(zr)
+	want := `{"content":"first","timestamp":"2025-01-02T00:00:00Z"}
+{"content":"second","timestamp":"2025-01-02T01:00:00Z"}
+`
+	if string(content) != want {
+		t.Fatalf("content = %s", content)
+	}
+
+	record := dbRepo.records[1]
+	if record.Day != "2025-01-02" || record.Rows != 2 || record.Size != int64(len(object)) || record.ObjectKey != "apo-logs/apo/logs/2025-01-02.ndjson.gz" {
+		t.Fatalf("record = %+v", record)
+	}
+
+	// DaysBeforeExpire must be less than TTL
+	job.DaysBeforeExpire = 7
+	if err := s.archiveJob(core.EmptyCtx(), job, now); err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestArchiveUploadFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+	store, _ := newS3Store(server.URL, "us-east-1", "archive", "minio", "minio123", true)
+
+	s := &service{logger: zap.NewNop(), chRepo: &fakeChRepo{}, dbRepo: &fakeDBRepo{}, store: store}
+	job := &database.LogArchiveJob{ID: 1, DataBase: "apo", Table: "logs", Format: FormatParquet}
+	if _, err := s.archiveDay(core.EmptyCtx(), job, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Fatal("expect error")
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultExportMaxRows        = 1000000
+	defaultExportRetentionHours = 24
+	// exportBatchSize is the number of logs written between two progress updates
+	exportBatchSize = 10000
+)
+
+var errExportCanceled = errors.New("the export is canceled")
+
+func (s *service) CreateLogExport(ctx core.Context, req *request.CreateLogExportRequest) (*database.LogExportJob, error) {
+	maxRows := config.Get().LogExport.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultExportMaxRows
+	}
+	if req.Limit <= 0 || req.Limit > maxRows {
+		req.Limit = maxRows
+	}
+	if err := os.MkdirAll(exportDir(), 0o755); err != nil {
+		return nil, err
+	}
+
+	job := &database.LogExportJob{
+		UserID:    ctx.UserID(),
+		DataBase:  req.DataBase,
+		Table:     req.TableName,
+		Query:     req.Query,
+		RawSQL:    req.RawSQL,
+		TimeField: req.TimeField,
+		LogField:  req.LogField,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Format:    req.Format,
+		Limit:     req.Limit,
+		Status:    database.LogExportRunning,
+		FileName:  fmt.Sprintf("%s-%s%s", req.TableName, time.Now().Format("20060102150405"), fileExtension(req.Format, false)),
+	}
+	if err := s.dbRepo.CreateLogExportJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	result := *job
+	go s.runExport(job)
+	return &result, nil
+}
+
+// runExport writes the logs into the file and records the progress after every batch.
+func (s *service) runExport(job *database.LogExportJob) {
+	ctx := core.EmptyCtx()
+	// the data scope of the requester applies to the exported logs
+	ctx.Set(core.UserIDKey, job.UserID)
+	err := s.export(ctx, job)
+	if _, canceled := s.canceledExports.LoadAndDelete(job.ID); canceled {
+		os.Remove(exportPath(job))
+		return
+	}
+
+	job.FinishedAt = time.Now().Unix()
+	if err != nil {
+		s.logger.Error("failed to export logs", zap.Int64("id", job.ID), zap.Error(err))
+		job.Status, job.Error = database.LogExportFailed, err.Error()
+		os.Remove(exportPath(job))
+	} else {
+		job.Status = database.LogExportSucceeded
+		if info, err := os.Stat(exportPath(job)); err == nil {
+			job.Size = info.Size()
+		}
+	}
+	if err := s.dbRepo.UpdateLogExportJob(ctx, job); err != nil {
+		s.logger.Error("failed to update the log export", zap.Int64("id", job.ID), zap.Error(err))
+	}
+}
+
+func (s *service) export(ctx core.Context, job *database.LogExportJob) error {
+	req := &request.LogQueryRequest{
+		StartTime: job.StartTime,
+		EndTime:   job.EndTime,
+		DataBase:  job.DataBase,
+		TableName: job.Table,
+		Query:     job.Query,
+		RawSQL:    job.RawSQL,
+		TimeField: job.TimeField,
+		LogField:  job.LogField,
+	}
+	_, count, err := s.chRepo.GetLogChart(ctx, req)
+	if err != nil {
+		return err
+	}
+	job.Total = min(count, int64(job.Limit))
+	if err := s.dbRepo.UpdateLogExportJob(ctx, job); err != nil {
+		return err
+	}
+
+	file, err := os.Create(exportPath(job))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	buffered := bufio.NewWriter(file)
+	writer, err := newLogWriter(job.Format, buffered)
+	if err != nil {
+		return err
+	}
+
+	err = s.chRepo.ExportLogs(ctx, req, job.Limit, exportBatchSize, func(columns []string, logs []map[string]any) error {
+		if _, canceled := s.canceledExports.Load(job.ID); canceled {
+			return errExportCanceled
+		}
+		if err := writer.Write(columns, logs); err != nil {
+			return err
+		}
+		job.Rows += int64(len(logs))
+		return s.dbRepo.UpdateLogExportJob(ctx, job)
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func (s *service) ListLogExports(ctx core.Context) (*response.ListLogExportsResponse, error) {
+	jobs, err := s.dbRepo.ListLogExportJobs(ctx, ctx.UserID())
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListLogExportsResponse{Exports: jobs}, nil
+}
+
+func (s *service) GetLogExport(ctx core.Context, req *request.LogExportRequest) (*database.LogExportJob, error) {
+	job, err := s.dbRepo.GetLogExportJob(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the exports are only visible to their creators
+	if job == nil || job.UserID != ctx.UserID() {
+		return nil, core.Error(code.LogExportNotExistError, fmt.Sprintf("log export %d not exists", req.ID))
+	}
+	return job, nil
+}
+
+func (s *service) GetLogExportFile(ctx core.Context, req *request.LogExportRequest) (string, string, error) {
+	job, err := s.GetLogExport(ctx, req)
+	if err != nil {
+		return "", "", err
+	}
+	if job.Status != database.LogExportSucceeded {
+		return "", "", core.Error(code.LogExportNotReadyError, fmt.Sprintf("log export %d is %s", job.ID, job.Status))
+	}
+	return exportPath(job), job.FileName, nil
+}
+
+func (s *service) DeleteLogExport(ctx core.Context, req *request.LogExportRequest) error {
+	job, err := s.GetLogExport(ctx, req)
+	if err != nil {
+		return err
+	}
+	if job.Status == database.LogExportRunning {
+		// the file is removed when the export stops
+		s.canceledExports.Store(job.ID, struct{}{})
+	} else {
+		os.Remove(exportPath(job))
+	}
+	return s.dbRepo.DeleteLogExportJob(ctx, job.ID)
+}
+
+func (s *service) FailInterruptedExports(ctx core.Context) {
+	// all the exports existing on startup, including the ones created in this second
+	jobs, err := s.dbRepo.ListLogExportJobsBefore(ctx, time.Now().Unix()+1)
+	if err != nil {
+		s.logger.Error("failed to list the interrupted log exports", zap.Error(err))
+		return
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Status != database.LogExportRunning {
+			continue
+		}
+		os.Remove(exportPath(job))
+		job.Status, job.Error = database.LogExportFailed, "the export is interrupted by the restart of the server"
+		job.FinishedAt = time.Now().Unix()
+		if err := s.dbRepo.UpdateLogExportJob(ctx, job); err != nil {
+			s.logger.Error("failed to update the interrupted log export", zap.Int64("id", job.ID), zap.Error(err))
+		}
+	}
+}
+
+// cleanExports removes the exports and their files after the retention.
+func (s *service) cleanExports(ctx core.Context) {
+	retention := config.Get().LogExport.RetentionHours
+	if retention <= 0 {
+		retention = defaultExportRetentionHours
+	}
+	jobs, err := s.dbRepo.ListLogExportJobsBefore(ctx, time.Now().Add(-time.Duration(retention)*time.Hour).Unix())
+	if err != nil {
+		s.logger.Error("failed to list the expired log exports", zap.Error(err))
+		return
+	}
+	for _, job := range jobs {
+		if job.Status == database.LogExportRunning {
+			s.canceledExports.Store(job.ID, struct{}{})
+		} else {
+			os.Remove(exportPath(&job))
+		}
+		if err := s.dbRepo.DeleteLogExportJob(ctx, job.ID); err != nil {
+			s.logger.Error("failed to delete the expired log export", zap.Int64("id", job.ID), zap.Error(err))
+		}
+	}
+}
+
+func exportDir() string {
+	if dir := config.Get().LogExport.Dir; len(dir) > 0 {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "log-export")
+}
+
+func exportPath(job *database.LogExportJob) string {
+	return filepath.Join(exportDir(), fmt.Sprintf("%d%s", job.ID, fileExtension(job.Format, false)))
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"os"
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+// exportChRepo records the user of the queries, which decides the data scope of the logs.
+type exportChRepo struct {
+	clickhouse.Repo
+	userIDs []int64
+}
+
+func (f *exportChRepo) GetLogChart(ctx core.Context, _ *request.LogQueryRequest) ([]map[string]any, int64, error) {
+	f.userIDs = append(f.userIDs, ctx.UserID())
+	return nil, 1, nil
+}
+
+func (f *exportChRepo) ExportLogs(ctx core.Context, _ *request.LogQueryRequest, _ int, _ int, handle clickhouse.LogBatchHandler) error {
+	f.userIDs = append(f.userIDs, ctx.UserID())
+	return handle([]string{"content"}, []map[string]any{{"content": "log"}})
+}
+
+type exportDBRepo struct {
+	database.Repo
+	job database.LogExportJob
+}
+
+func (f *exportDBRepo) UpdateLogExportJob(_ core.Context, job *database.LogExportJob) error {
+	f.job = *job
+	return nil
+}
+
+func (f *exportDBRepo) ListLogExportJobsBefore(_ core.Context, _ int64) ([]database.LogExportJob, error) {
+	return []database.LogExportJob{f.job}, nil
+}
+
+func TestRunExportAsRequester(t *testing.T) {
+	t.Setenv("APO_CONFIG", "../../../config/apo.yml")
+	t.Setenv("TMPDIR", t.TempDir())
+	if err := os.MkdirAll(exportDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	chRepo := &exportChRepo{}
+	dbRepo := &exportDBRepo{}
+	s := &service{logger: zap.NewNop(), chRepo: chRepo, dbRepo: dbRepo}
+
+	job := &database.LogExportJob{ID: 1, UserID: 42, DataBase: "apo", Table: "logs", Format: FormatNDJSON, Limit: 10}
+	s.runExport(job)
+
+	if dbRepo.job.Status != database.LogExportSucceeded {
+		t.Fatalf("job = %+v", dbRepo.job)
+	}
+	if len(chRepo.userIDs) != 2 {
+		t.Fatalf("queries = %v", chRepo.userIDs)
+	}
+	for _, userID := range chRepo.userIDs {
+		if userID != 42 {
+			t.Errorf("expected the logs queried as the requester, got user %d", userID)
+		}
+	}
+}
+
+func TestFailInterruptedExports(t *testing.T) {
+	t.Setenv("APO_CONFIG", "../../../config/apo.yml")
+	t.Setenv("TMPDIR", t.TempDir())
+	if err := os.MkdirAll(exportDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	job := database.LogExportJob{ID: 1, Format: FormatNDJSON, Status: database.LogExportRunning}
+	if err := os.WriteFile(exportPath(&job), []byte("partial"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dbRepo := &exportDBRepo{job: job}
+	s := &service{logger: zap.NewNop(), dbRepo: dbRepo}
+
+	s.FailInterruptedExports(core.EmptyCtx())
+
+	if dbRepo.job.Status != database.LogExportFailed || dbRepo.job.FinishedAt == 0 {
+		t.Errorf("expected the interrupted export failed, got %+v", dbRepo.job)
+	}
+	if _, err := os.Stat(exportPath(&job)); !os.IsNotExist(err) {
+		t.Errorf("expected the partial file removed, got %v", err)
+	}
+}
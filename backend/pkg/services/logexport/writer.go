@@ -0,0 +1,131 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	FormatNDJSON  = "ndjson"
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// logWriter writes the scanned logs in a file format, Close flushes the buffered data
+// but does not close the underlying writer.
+type logWriter interface {
+	// Write is called with the same columns for every batch
+	Write(columns []string, logs []map[string]any) error
+	Close() error
+}
+
+func newLogWriter(format string, w io.Writer) (logWriter, error) {
+	switch format {
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatParquet:
+		return newParquetWriter(w), nil
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+// newCompressedLogWriter compresses the text formats with gzip, parquet is compressed by pages.
+func newCompressedLogWriter(format string, w io.Writer) (logWriter, error) {
+	if format == FormatParquet {
+		return newLogWriter(format, w)
+	}
+	zw := gzip.NewWriter(w)
+	writer, err := newLogWriter(format, zw)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipLogWriter{logWriter: writer, zw: zw}, nil
+}
+
+func fileExtension(format string, compressed bool) string {
+	if compressed && format != FormatParquet {
+		return "." + format + ".gz"
+	}
+	return "." + format
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(_ []string, logs []map[string]any) error {
+	for _, log := range logs {
+		if err := w.encoder.Encode(log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(columns []string, logs []map[string]any) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(columns); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	record := make([]string, len(columns))
+	for _, log := range logs {
+		for i, column := range columns {
+			record[i] = formatValue(log[column])
+		}
+		if err := w.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type gzipLogWriter struct {
+	logWriter
+	zw *gzip.Writer
+}
+
+func (w *gzipLogWriter) Close() error {
+	if err := w.logWriter.Close(); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+var (
+	testColumns = []string{"timestamp", "content", "count", "cost"}
+	testTime    = time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC)
+)
+
+func testLogs(n int) []map[string]any {
+	logs := make([]map[string]any, n)
+	for i := range logs {
+		logs[i] = map[string]any{
+			"timestamp": testTime.Add(time.Duration(i) * time.Second),
+			"content":   strings.Repeat("x", i) + `, "quoted"`,
+			"count":     uint64(i),
+			"cost":      float64(i) / 2,
+		}
+	}
+	return logs
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, _ := newLogWriter(FormatCSV, &buf)
+	if err := writer.Write(testColumns, testLogs(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Write(testColumns, testLogs(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := `timestamp,content,count,cost
+2025-01-02T03:04:05.000006Z,", ""quoted""",0,0
+2025-01-02T03:04:06.000006Z,"x, ""quoted""",1,0.5
+2025-01-02T03:04:05.000006Z,", ""quoted""",0,0
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s", buf.String())
+	}
+}
+
+func TestCompressedNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, _ := newCompressedLogWriter(FormatNDJSON, &buf)
+	if err := writer.Write(testColumns, testLogs(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(zr)
+	want := `{"content":", \"quoted\"","cost":0,"count":0,"timestamp":"2025-01-02T03:04:05.000006Z"}
+{"content":"x, \"quoted\"","cost":0.5,"count":1,"timestamp":"2025-01-02T03:04:06.000006Z"}
+`
+	if string(data) != want {
+		t.Fatalf("got:\n%s", data)
+	}
+}
+
+// readParquet reads the file back by a parquet reader, the values of the rows are keyed by the column names.
+func readParquet(t *testing.T, data []byte) (*parquet.File, []map[string]parquet.Value) {
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.NumRows() == 0 {
+		return file, nil
+	}
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	var result []map[string]parquet.Value
+	rows := make([]parquet.Row, 10)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			values := map[string]parquet.Value{}
+			for _, value := range row {
+				values[file.Schema().Columns()[value.Column()][0]] = value
+			}
+			result = append(result, values)
+		}
+		if err == io.EOF {
+			return file, result
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, _ := newLogWriter(FormatParquet, &buf)
+	if err := writer.Write(testColumns, testLogs(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Write(testColumns, testLogs(20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, rows := readParquet(t, buf.Bytes())
+	if file.NumRows() != 23 || len(rows) != 23 {
+		t.Fatalf("num_rows = %d, rows = %d", file.NumRows(), len(rows))
+	}
+	if len(file.RowGroups()) != 2 || file.RowGroups()[1].NumRows() != 20 {
+		t.Fatalf("row groups = %d", len(file.RowGroups()))
+	}
+	for _, column := range testColumns {
+		leaf, ok := file.Schema().Lookup(column)
+		if !ok || leaf.MaxDefinitionLevel != 1 {
+			t.Fatalf("column %s = %+v", column, leaf)
+		}
+	}
+	timestamp, _ := file.Schema().Lookup("timestamp")
+	if logical := timestamp.Node.Type().LogicalType(); logical == nil || logical.Timestamp == nil {
+		t.Fatalf("timestamp = %v", timestamp.Node.Type())
+	}
+
+	last := rows[22]
+	if got := last["timestamp"].Int64(); got != testTime.Add(19*time.Second).UnixMicro() {
+		t.Fatalf("timestamp = %d", got)
+	}
+	if got := rows[5]["content"].String(); got != `xx, "quoted"` {
+		t.Fatalf("content = %s", got)
+	}
+	if got := last["count"].Int64(); got != 19 {
+		t.Fatalf("count = %d", got)
+	}
+	if got := rows[6]["cost"].Double(); got != 1.5 {
+		t.Fatalf("cost = %v", got)
+	}
+}
+
+func TestParquetNullValues(t *testing.T) {
+	logs := testLogs(3)
+	logs[0]["count"], logs[0]["cost"] = nil, nil
+	logs[1]["content"] = nil
+	var buf bytes.Buffer
+	writer, _ := newLogWriter(FormatParquet, &buf)
+	if err := writer.Write(testColumns, logs); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, rows := readParquet(t, buf.Bytes())
+	if count, _ := file.Schema().Lookup("count"); count.Node.Type().Kind() != parquet.Int64 {
+		t.Fatalf("count = %v", count.Node.Type())
+	}
+	if !rows[0]["count"].IsNull() || !rows[0]["cost"].IsNull() || !rows[1]["content"].IsNull() {
+		t.Fatalf("rows = %v", rows)
+	}
+	if rows[0]["content"].IsNull() || rows[1]["count"].IsNull() || rows[1]["count"].Int64() != 1 {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestEmptyParquet(t *testing.T) {
+	var buf bytes.Buffer
+	writer, _ := newLogWriter(FormatParquet, &buf)
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file, rows := readParquet(t, buf.Bytes())
+	if file.NumRows() != 0 || len(rows) != 0 || len(file.Schema().Fields()) != 0 {
+		t.Fatalf("num_rows = %d, fields = %v", file.NumRows(), file.Schema().Fields())
+	}
+}
+
+func TestParquetManyColumns(t *testing.T) {
+	columns := make([]string, 20)
+	log := map[string]any{}
+	for i := range columns {
+		columns[i] = strings.Repeat("c", i+1)
+		log[columns[i]] = int64(i)
+	}
+	var buf bytes.Buffer
+	writer, _ := newLogWriter(FormatParquet, &buf)
+	if err := writer.Write(columns, []map[string]any{log}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file, rows := readParquet(t, buf.Bytes())
+	if len(file.Schema().Fields()) != 20 || len(rows) != 1 {
+		t.Fatalf("fields = %d, rows = %d", len(file.Schema().Fields()), len(rows))
+	}
+	for i, column := range columns {
+		if got := rows[0][column].Int64(); got != int64(i) {
+			t.Fatalf("%s = %d", column, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// collector exposes the samples of the latest evaluations as gauges.
+type collector struct {
+	s *service
+}
+
+// Describe sends nothing, the log metrics are defined at runtime.
+func (c collector) Describe(chan<- *prometheus.Desc) {}
+
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	c.s.mu.RLock()
+	defer c.s.mu.RUnlock()
+
+	for _, state := range c.s.states {
+		labels := make([]string, 0, len(state.metric.GroupBy.Obj))
+		for _, field := range state.metric.GroupBy.Obj {
+			labels = append(labels, labelName(field))
+		}
+		desc := prometheus.NewDesc(state.metric.Name, state.metric.Description, labels, nil)
+		for _, sample := range state.samples {
+			if math.IsNaN(sample.Value) || len(sample.Labels) != len(labels) {
+				continue
+			}
+			metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, sample.Value, sample.Labels...)
+			if err != nil {
+				ch <- prometheus.NewInvalidMetric(desc, err)
+				continue
+			}
+			ch <- metric
+		}
+	}
+}
+
+func (s *service) MetricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector{s: s})
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
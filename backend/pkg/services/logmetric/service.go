@@ -0,0 +1,63 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	alertinput "github.com/CloudDetail/apo/backend/pkg/services/integration/alert"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// CreateLogMetric validates the log metric by evaluating it once before saving.
+	CreateLogMetric(ctx core.Context, req *request.CreateLogMetricRequest) error
+	UpdateLogMetric(ctx core.Context, req *request.UpdateLogMetricRequest) error
+	// DeleteLogMetric removes the log metric together with its alert rules.
+	DeleteLogMetric(ctx core.Context, req *request.DeleteLogMetricRequest) error
+	ListLogMetrics(ctx core.Context) (*response.ListLogMetricsResponse, error)
+
+	CreateLogAlertRule(ctx core.Context, req *request.CreateLogAlertRuleRequest) error
+	UpdateLogAlertRule(ctx core.Context, req *request.UpdateLogAlertRuleRequest) error
+	DeleteLogAlertRule(ctx core.Context, req *request.DeleteLogAlertRuleRequest) error
+	ListLogAlertRules(ctx core.Context, req *request.ListLogAlertRulesRequest) (*response.ListLogAlertRulesResponse, error)
+
+	// KeepEvaluating evaluates the due log metrics and their alert rules periodically until ctx is done.
+	KeepEvaluating(ctx context.Context, interval time.Duration)
+	// MetricsHandler exposes the latest values of the log metrics in Prometheus text format.
+	MetricsHandler() http.Handler
+}
+
+type service struct {
+	logger       *zap.Logger
+	chRepo       clickhouse.Repo
+	dbRepo       database.Repo
+	inputService alertinput.Service
+
+	// state of evaluations, only used by the evaluating instance
+	mu     sync.RWMutex
+	states map[int64]*metricState
+	alerts map[string]*alertState
+}
+
+func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, inputService alertinput.Service) Service {
+	return &service{
+		logger:       logger,
+		chRepo:       chRepo,
+		dbRepo:       dbRepo,
+		inputService: inputService,
+		states:       make(map[int64]*metricState),
+		alerts:       make(map[string]*alertState),
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+// LogAlertSourceName is the alert source of the events fired by log alert rules.
+const LogAlertSourceName = "APO_LOG_ALERT"
+
+var operators = map[string]func(value, threshold float64) bool{
+	">":  func(value, threshold float64) bool { return value > threshold },
+	">=": func(value, threshold float64) bool { return value >= threshold },
+	"<":  func(value, threshold float64) bool { return value < threshold },
+	"<=": func(value, threshold float64) bool { return value <= threshold },
+	"==": func(value, threshold float64) bool { return value == threshold },
+	"!=": func(value, threshold float64) bool { return value != threshold },
+}
+
+type metricState struct {
+	metric   database.LogMetric
+	lastEval time.Time
+	samples  []clickhouse.LogMetricSample
+}
+
+// alertState is the state of one group of a log alert rule.
+type alertState struct {
+	rule   database.LogAlertRule
+	metric string
+	labels map[string]string
+
+	// matched is the number of consecutive evaluations matched
+	matched int
+	firing  bool
+	since   time.Time
+	value   float64
+}
+
+// observe records the result of an evaluation, and returns whether the alert starts or stops firing.
+func (a *alertState) observe(matched bool, forEvaluations int, now time.Time) (fire bool, resolve bool) {
+	if !matched {
+		resolve = a.firing
+		a.matched = 0
+		a.firing = false
+		return false, resolve
+	}
+
+	a.matched++
+	if !a.firing && a.matched >= forEvaluations {
+		a.firing = true
+		a.since = now
+		return true, false
+	}
+	return false, false
+}
+
+func (s *service) KeepEvaluating(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.evaluate(core.EmptyCtx(), time.Now()); err != nil {
+			s.logger.Error("failed to evaluate log metrics", zap.Error(err))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// evaluate runs the due log metrics and their alert rules,
+// the window ends DelaySeconds before now to wait for the logs being ingested.
+func (s *service) evaluate(ctx core.Context, now time.Time) error {
+	metrics, err := s.dbRepo.ListLogMetrics(ctx)
+	if err != nil {
+		return err
+	}
+	rules, err := s.dbRepo.ListLogAlertRules(ctx, 0)
+	if err != nil {
+		return err
+	}
+	rulesOfMetric := make(map[int64][]database.LogAlertRule)
+	for _, rule := range rules {
+		if rule.Enabled {
+			rulesOfMetric[rule.MetricID] = append(rulesOfMetric[rule.MetricID], rule)
+		}
+	}
+
+	end := now.Add(-time.Duration(config.Get().LogMetric.DelaySeconds) * time.Second)
+	existed := make(map[int64]struct{}, len(metrics))
+	for i := range metrics {
+		metric := &metrics[i]
+		existed[metric.ID] = struct{}{}
+
+		s.mu.RLock()
+		state, find := s.states[metric.ID]
+		s.mu.RUnlock()
+		if find && state.metric.UpdatedAt == metric.UpdatedAt &&
+			now.Sub(state.lastEval) < time.Duration(metric.IntervalSeconds)*time.Second {
+			continue
+		}
+
+		samples, err := s.chRepo.QueryLogMetric(ctx, metricQuery(metric, end))
+		if err != nil {
+			s.logger.Warn("failed to evaluate log metric", zap.String("metric", metric.Name), zap.Error(err))
+			continue
+		}
+		s.mu.Lock()
+		s.states[metric.ID] = &metricState{metric: *metric, lastEval: now, samples: samples}
+		s.mu.Unlock()
+
+		s.evaluateRules(ctx, metric, rulesOfMetric[metric.ID], samples, now)
+	}
+
+	s.mu.Lock()
+	for id := range s.states {
+		if _, find := existed[id]; !find {
+			delete(s.states, id)
+		}
+	}
+	s.mu.Unlock()
+
+	// resolve the alerts of the rules removed or disabled
+	enabled := make(map[int64]database.LogAlertRule)
+	for _, rule := range rules {
+		if _, find := existed[rule.MetricID]; find && rule.Enabled {
+			enabled[rule.ID] = rule
+		}
+	}
+	for key, state := range s.alerts {
+		if rule, find := enabled[state.rule.ID]; find && rule.MetricID == state.rule.MetricID {
+			continue
+		}
+		if state.firing {
+			s.sendAlertEvent(ctx, state, alert.StatusResolved, now)
+		}
+		delete(s.alerts, key)
+	}
+	return nil
+}
+
+func (s *service) evaluateRules(ctx core.Context, metric *database.LogMetric, rules []database.LogAlertRule, samples []clickhouse.LogMetricSample, now time.Time) {
+	for _, rule := range rules {
+		compare := operators[rule.Operator]
+		if compare == nil {
+			continue
+		}
+
+		seen := make(map[string]struct{}, len(samples))
+		for _, sample := range samples {
+			if math.IsNaN(sample.Value) {
+				continue
+			}
+			key := alertKey(rule.ID, sample.Labels)
+			seen[key] = struct{}{}
+
+			state, find := s.alerts[key]
+			if !find {
+				state = &alertState{labels: groupLabels(metric, sample.Labels)}
+				s.alerts[key] = state
+			}
+			state.rule = rule
+			state.metric = metric.Name
+			state.value = sample.Value
+
+			fire, resolve := state.observe(compare(sample.Value, rule.Threshold), rule.ForEvaluations, now)
+			if fire {
+				s.sendAlertEvent(ctx, state, alert.StatusFiring, now)
+			} else if resolve {
+				s.sendAlertEvent(ctx, state, alert.StatusResolved, now)
+			}
+			if !state.firing && state.matched == 0 {
+				delete(s.alerts, key)
+			}
+		}
+
+		// the group has no logs in the window any more
+		for key, state := range s.alerts {
+			if state.rule.ID != rule.ID {
+				continue
+			}
+			if _, find := seen[key]; find {
+				continue
+			}
+			if state.firing {
+				s.sendAlertEvent(ctx, state, alert.StatusResolved, now)
+			}
+			delete(s.alerts, key)
+		}
+	}
+}
+
+func (s *service) sendAlertEvent(ctx core.Context, state *alertState, status string, now time.Time) {
+	tags := make(map[string]string, len(state.labels)+len(state.rule.Labels.Obj)+1)
+	for k, v := range state.labels {
+		tags[k] = v
+	}
+	for k, v := range state.rule.Labels.Obj {
+		tags[k] = v
+	}
+	tags["logMetric"] = state.metric
+
+	event := map[string]any{
+		"name":       state.rule.Name,
+		"group":      "app",
+		"tags":       tags,
+		"detail":     fmt.Sprintf("%s = %s, threshold %s %s", state.metric, formatValue(state.value), state.rule.Operator, formatValue(state.rule.Threshold)),
+		"severity":   state.rule.Severity,
+		"status":     status,
+		"createTime": state.since.UnixMilli(),
+		"updateTime": now.UnixMilli(),
+	}
+	if status == alert.StatusResolved {
+		event["endTime"] = now.UnixMilli()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to marshal log alert event", zap.Error(err))
+		return
+	}
+
+	source := alert.SourceFrom{SourceInfo: alert.SourceInfo{
+		SourceName: LogAlertSourceName,
+		SourceType: alert.JSONType,
+	}}
+	// the alert source is created by the first event, retry once
+	if err = s.inputService.ProcessAlertEvents(ctx, source, data); err != nil {
+		err = s.inputService.ProcessAlertEvents(ctx, source, data)
+	}
+	if err != nil {
+		s.logger.Error("failed to send log alert event", zap.String("rule", state.rule.Name), zap.Error(err))
+	}
+}
+
+func alertKey(ruleID int64, labels []string) string {
+	return strconv.FormatInt(ruleID, 10) + "\x00" + strings.Join(labels, "\x00")
+}
+
+func groupLabels(metric *database.LogMetric, values []string) map[string]string {
+	labels := make(map[string]string, len(values))
+	for i, field := range metric.GroupBy.Obj {
+		if i < len(values) {
+			labels[labelName(field)] = values[i]
+		}
+	}
+	return labels
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
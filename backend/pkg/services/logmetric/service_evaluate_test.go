@@ -0,0 +1,54 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertStateObserve(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	state := &alertState{}
+
+	steps := []struct {
+		matched bool
+		fire    bool
+		resolve bool
+	}{
+		{matched: true},
+		{matched: false},
+		{matched: true},
+		{matched: true},
+		{matched: true, fire: true},
+		{matched: true},
+		{matched: false, resolve: true},
+		{matched: false},
+	}
+	for i, step := range steps {
+		fire, resolve := state.observe(step.matched, 3, now.Add(time.Duration(i)*time.Minute))
+		if fire != step.fire || resolve != step.resolve {
+			t.Fatalf("step %d: fire = %v, resolve = %v", i, fire, resolve)
+		}
+	}
+	if state.firing || state.matched != 0 {
+		t.Fatalf("state = %+v", state)
+	}
+}
+
+func TestLabelName(t *testing.T) {
+	tests := map[string]string{
+		"level":        "level",
+		"labels.app":   "labels_app",
+		"tags.k8s-pod": "tags_k8s_pod",
+		"2xx":          "_2xx",
+		"":             "_",
+		"service:name": "service_name",
+	}
+	for field, want := range tests {
+		if got := labelName(field); got != want {
+			t.Errorf("labelName(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
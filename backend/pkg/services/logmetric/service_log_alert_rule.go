@@ -0,0 +1,94 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"fmt"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration/alert"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func (s *service) CreateLogAlertRule(ctx core.Context, req *request.CreateLogAlertRuleRequest) error {
+	rule := toLogAlertRule(req)
+	if err := s.validateLogAlertRule(ctx, rule); err != nil {
+		return err
+	}
+	return s.dbRepo.CreateLogAlertRule(ctx, rule)
+}
+
+func (s *service) UpdateLogAlertRule(ctx core.Context, req *request.UpdateLogAlertRuleRequest) error {
+	rule := toLogAlertRule(&req.CreateLogAlertRuleRequest)
+	rule.ID = req.ID
+	if err := s.validateLogAlertRule(ctx, rule); err != nil {
+		return err
+	}
+
+	oldRule, err := s.dbRepo.GetLogAlertRule(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if oldRule == nil {
+		return core.Error(code.LogAlertRuleNotExistError, "log alert rule not exists")
+	}
+	return s.dbRepo.UpdateLogAlertRule(ctx, rule)
+}
+
+func (s *service) DeleteLogAlertRule(ctx core.Context, req *request.DeleteLogAlertRuleRequest) error {
+	return s.dbRepo.DeleteLogAlertRule(ctx, req.ID)
+}
+
+func (s *service) ListLogAlertRules(ctx core.Context, req *request.ListLogAlertRulesRequest) (*response.ListLogAlertRulesResponse, error) {
+	rules, err := s.dbRepo.ListLogAlertRules(ctx, req.MetricID)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListLogAlertRulesResponse{Rules: rules}, nil
+}
+
+func toLogAlertRule(req *request.CreateLogAlertRuleRequest) *database.LogAlertRule {
+	rule := &database.LogAlertRule{
+		Name:           req.Name,
+		Description:    req.Description,
+		MetricID:       req.MetricID,
+		Operator:       req.Operator,
+		Threshold:      req.Threshold,
+		ForEvaluations: req.ForEvaluations,
+		Severity:       req.Severity,
+		Labels:         integration.JSONField[map[string]string]{Obj: req.Labels},
+		Enabled:        req.Enabled,
+	}
+	if rule.ForEvaluations == 0 {
+		rule.ForEvaluations = 1
+	}
+	if len(rule.Severity) == 0 {
+		rule.Severity = alert.SeverityWarnLevel
+	}
+	return rule
+}
+
+func (s *service) validateLogAlertRule(ctx core.Context, rule *database.LogAlertRule) error {
+	if _, find := operators[rule.Operator]; !find {
+		return core.Error(code.LogAlertRuleIllegalError, fmt.Sprintf("unknown operator %s", rule.Operator))
+	}
+	switch rule.Severity {
+	case alert.SeverityCriticalLevel, alert.SeverityErrorLevel, alert.SeverityWarnLevel, alert.SeverityInfoLevel:
+	default:
+		return core.Error(code.LogAlertRuleIllegalError, fmt.Sprintf("unknown severity %s", rule.Severity))
+	}
+
+	metric, err := s.dbRepo.GetLogMetric(ctx, rule.MetricID)
+	if err != nil {
+		return err
+	}
+	if metric == nil {
+		return core.Error(code.LogMetricNotExistError, "log metric not exists")
+	}
+	return nil
+}
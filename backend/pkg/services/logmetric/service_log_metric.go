@@ -0,0 +1,154 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetric
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/integration"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+const (
+	defaultIntervalSeconds = 60
+	minIntervalSeconds     = 10
+	maxWindowSeconds       = 24 * 3600
+)
+
+var (
+	metricNamePattern   = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	illegalLabelPattern = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+func (s *service) CreateLogMetric(ctx core.Context, req *request.CreateLogMetricRequest) error {
+	metric := toLogMetric(req)
+	if err := s.validateLogMetric(ctx, metric); err != nil {
+		return err
+	}
+	return s.dbRepo.CreateLogMetric(ctx, metric)
+}
+
+func (s *service) UpdateLogMetric(ctx core.Context, req *request.UpdateLogMetricRequest) error {
+	metric := toLogMetric(&req.CreateLogMetricRequest)
+	metric.ID = req.ID
+	if err := s.validateLogMetric(ctx, metric); err != nil {
+		return err
+	}
+
+	oldMetric, err := s.dbRepo.GetLogMetric(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if oldMetric == nil {
+		return core.Error(code.LogMetricNotExistError, "log metric not exists")
+	}
+	return s.dbRepo.UpdateLogMetric(ctx, metric)
+}
+
+func (s *service) DeleteLogMetric(ctx core.Context, req *request.DeleteLogMetricRequest) error {
+	return s.dbRepo.DeleteLogMetric(ctx, req.ID)
+}
+
+func (s *service) ListLogMetrics(ctx core.Context) (*response.ListLogMetricsResponse, error) {
+	metrics, err := s.dbRepo.ListLogMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListLogMetricsResponse{Metrics: metrics}, nil
+}
+
+func toLogMetric(req *request.CreateLogMetricRequest) *database.LogMetric {
+	metric := &database.LogMetric{
+		Name:            req.Name,
+		Description:     req.Description,
+		DataBase:        req.DataBase,
+		Table:           req.TableName,
+		TimeField:       req.TimeField,
+		LogField:        req.LogField,
+		Query:           req.Query,
+		Aggregation:     req.Aggregation,
+		Field:           req.Field,
+		GroupBy:         integration.JSONField[[]string]{Obj: req.GroupBy},
+		IntervalSeconds: req.IntervalSeconds,
+		WindowSeconds:   req.WindowSeconds,
+	}
+	if len(metric.TimeField) == 0 {
+		metric.TimeField = "timestamp"
+	}
+	if len(metric.LogField) == 0 {
+		metric.LogField = "content"
+	}
+	if metric.IntervalSeconds == 0 {
+		metric.IntervalSeconds = defaultIntervalSeconds
+	}
+	if metric.WindowSeconds == 0 {
+		metric.WindowSeconds = metric.IntervalSeconds
+	}
+	return metric
+}
+
+// validateLogMetric checks the definition, then evaluates it once to validate the table, fields and query.
+func (s *service) validateLogMetric(ctx core.Context, metric *database.LogMetric) error {
+	if !metricNamePattern.MatchString(metric.Name) {
+		return core.Error(code.LogMetricIllegalError, "name must be a valid Prometheus metric name")
+	}
+	if !clickhouse.IsValidLogMetricAggregation(metric.Aggregation) {
+		return core.Error(code.LogMetricIllegalError, fmt.Sprintf("unknown aggregation %s", metric.Aggregation))
+	}
+	if metric.Aggregation != clickhouse.LogMetricCount && len(metric.Field) == 0 {
+		return core.Error(code.LogMetricIllegalError, fmt.Sprintf("field is required by %s", metric.Aggregation))
+	}
+	if metric.IntervalSeconds < minIntervalSeconds {
+		return core.Error(code.LogMetricIllegalError, fmt.Sprintf("intervalSeconds must be at least %d", minIntervalSeconds))
+	}
+	if metric.WindowSeconds > maxWindowSeconds {
+		return core.Error(code.LogMetricIllegalError, fmt.Sprintf("windowSeconds must be at most %d", maxWindowSeconds))
+	}
+
+	labels := make(map[string]struct{}, len(metric.GroupBy.Obj))
+	for _, field := range metric.GroupBy.Obj {
+		label := labelName(field)
+		if _, exists := labels[label]; exists {
+			return core.Error(code.LogMetricIllegalError, fmt.Sprintf("duplicated label %s of groupBy", label))
+		}
+		labels[label] = struct{}{}
+	}
+
+	if _, err := s.chRepo.QueryLogMetric(ctx, metricQuery(metric, time.Now())); err != nil {
+		return core.Error(code.LogMetricIllegalError, err.Error())
+	}
+	return nil
+}
+
+// metricQuery returns the query of the window ending at end.
+func metricQuery(metric *database.LogMetric, end time.Time) *clickhouse.LogMetricQuery {
+	return &clickhouse.LogMetricQuery{
+		DataBase:    metric.DataBase,
+		TableName:   metric.Table,
+		TimeField:   metric.TimeField,
+		LogField:    metric.LogField,
+		Query:       metric.Query,
+		StartTime:   end.Add(-time.Duration(metric.WindowSeconds) * time.Second).UnixMicro(),
+		EndTime:     end.UnixMicro(),
+		Aggregation: metric.Aggregation,
+		Field:       metric.Field,
+		GroupBy:     metric.GroupBy.Obj,
+	}
+}
+
+// labelName converts the groupBy field into a Prometheus label name, e.g. labels.app -> labels_app
+func labelName(field string) string {
+	label := illegalLabelPattern.ReplaceAllString(field, "_")
+	if len(label) == 0 || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+	return label
+}
@@ -18,6 +18,8 @@ type Service interface {
 	GetSubjectFeature(ctx core.Context, req *request.GetSubjectFeatureRequest) (resp response.GetSubjectFeatureResponse, err error)
 	CheckApiPermission(ctx core.Context, userID int64, method string, path string) (ok bool, err error)
 	CheckRouterPermission(ctx core.Context, userID int64, router string) (bool, error)
+	// CheckFeaturePermission checks whether the user is granted the feature.
+	CheckFeaturePermission(ctx core.Context, userID int64, featureName string) (bool, error)
 }
 
 type service struct {
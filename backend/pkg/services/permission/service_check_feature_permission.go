@@ -0,0 +1,29 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package permission
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+)
+
+func (s *service) CheckFeaturePermission(ctx core.Context, userID int64, featureName string) (bool, error) {
+	featureID, err := s.dbRepo.GetFeatureByName(ctx, featureName)
+	if err != nil {
+		return false, err
+	}
+	if featureID <= 0 {
+		return false, nil
+	}
+
+	featureIDs, err := s.getUserFeatureIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range featureIDs {
+		if id == featureID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"context"
+	"time"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// ListRecordingRules returns the recording rules of the common PQL templates and their deploy status.
+	ListRecordingRules(ctx core.Context) (*response.ListRecordingRulesResponse, error)
+	// DeployRecordingRules writes the recording rules into the vmalert rule configmap.
+	DeployRecordingRules(ctx core.Context) error
+	// DeleteRecordingRules removes the recording rules and stops rewriting queries.
+	DeleteRecordingRules(ctx core.Context) error
+
+	// RefreshActiveRules enables query rewriting for the deployed rules whose recorded series exist.
+	RefreshActiveRules(ctx core.Context) error
+	// KeepRefreshing refreshes the active rules periodically until ctx is done.
+	KeepRefreshing(ctx context.Context, interval time.Duration)
+}
+
+type service struct {
+	logger   *zap.Logger
+	promRepo prometheus.Repo
+	dbRepo   database.Repo
+	k8sApi   kubernetes.Repo
+}
+
+func New(logger *zap.Logger, promRepo prometheus.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo) Service {
+	return &service{
+		logger:   logger,
+		promRepo: promRepo,
+		dbRepo:   dbRepo,
+		k8sApi:   k8sApi,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	prommodel "github.com/prometheus/common/model"
+	promfmt "github.com/prometheus/prometheus/model/rulefmt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	recordingRuleGroup       = "apo-recording-rules"
+	defaultRecordingRuleFile = "apo-recording-rules.yaml"
+)
+
+func ruleFile() string {
+	if file := config.Get().RecordingRule.RuleFile; len(file) > 0 {
+		return file
+	}
+	return defaultRecordingRuleFile
+}
+
+func (s *service) DeployRecordingRules(ctx core.Context) error {
+	rules := prometheus.DefaultRecordingRules(s.promRepo.GetRange())
+
+	content, err := marshalRecordingRules(rules)
+	if err != nil {
+		return err
+	}
+	if err = s.k8sApi.UpdateAlertRuleConfigFile(kubernetes.DefaultClusterID, ruleFile(), content); err != nil {
+		return err
+	}
+
+	deployed, err := s.dbRepo.ListRecordingRules(ctx)
+	if err != nil {
+		return err
+	}
+	deployedAt := make(map[string]database.RecordingRule, len(deployed))
+	for _, rule := range deployed {
+		deployedAt[rule.Record] = rule
+	}
+
+	now := time.Now().Unix()
+	toSave := make([]database.RecordingRule, 0, len(rules))
+	for _, rule := range rules {
+		record := database.RecordingRule{
+			Record:     rule.Record,
+			Metric:     string(rule.Metric),
+			Expr:       rule.Expr(),
+			DeployedAt: now,
+		}
+		// Recorded series are still complete if the expr is not changed
+		if old, find := deployedAt[rule.Record]; find && old.Expr == record.Expr {
+			record.DeployedAt = old.DeployedAt
+		}
+		toSave = append(toSave, record)
+	}
+	if err = s.dbRepo.SaveRecordingRules(ctx, toSave); err != nil {
+		return err
+	}
+	return s.RefreshActiveRules(ctx)
+}
+
+func (s *service) DeleteRecordingRules(ctx core.Context) error {
+	s.promRepo.SetRecordingRules(nil)
+	if err := s.k8sApi.UpdateAlertRuleConfigFile(kubernetes.DefaultClusterID, ruleFile(), nil); err != nil {
+		return err
+	}
+	return s.dbRepo.DeleteAllRecordingRules(ctx)
+}
+
+func marshalRecordingRules(rules []prometheus.RecordingRule) ([]byte, error) {
+	nodes := make([]promfmt.RuleNode, 0, len(rules))
+	for _, rule := range rules {
+		var node promfmt.RuleNode
+		node.Record.SetString(rule.Record)
+		node.Expr.SetString(rule.Expr())
+		nodes = append(nodes, node)
+	}
+
+	content := promfmt.RuleGroups{
+		Groups: []promfmt.RuleGroup{{
+			Name:     recordingRuleGroup,
+			Interval: prommodel.Duration(prometheus.RecordingRuleInterval),
+			Rules:    nodes,
+		}},
+	}
+	return yaml.Marshal(content)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+func (s *service) ListRecordingRules(ctx core.Context) (*response.ListRecordingRulesResponse, error) {
+	deployed, err := s.dbRepo.ListRecordingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deployedMap := make(map[string]database.RecordingRule, len(deployed))
+	for _, rule := range deployed {
+		deployedMap[rule.Record] = rule
+	}
+	activeMap := make(map[string]prometheus.ActiveRecordingRule)
+	for _, rule := range s.promRepo.GetRecordingRules() {
+		activeMap[rule.Record] = rule
+	}
+
+	rules := prometheus.DefaultRecordingRules(s.promRepo.GetRange())
+	resp := &response.ListRecordingRulesResponse{
+		RuleFile: ruleFile(),
+		Rules:    make([]response.RecordingRuleStatus, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		status := response.RecordingRuleStatus{
+			Record: rule.Record,
+			Metric: string(rule.Metric),
+			Labels: rule.Labels,
+			Expr:   rule.Expr(),
+		}
+		if d, find := deployedMap[rule.Record]; find {
+			status.Deployed = true
+			status.DeployedAt = d.DeployedAt
+			// Deploy again to apply the changed expr
+			status.Outdated = d.Expr != status.Expr
+		}
+		if a, find := activeMap[rule.Record]; find {
+			status.Active = true
+			status.ActiveSince = a.ActiveSince.Unix()
+		}
+		resp.Rules = append(resp.Rules, status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrule
+
+import (
+	"context"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/config"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"go.uber.org/zap"
+)
+
+func (s *service) KeepRefreshing(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RefreshActiveRules(core.EmptyCtx()); err != nil {
+			s.logger.Error("failed to refresh recording rules", zap.Error(err))
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *service) RefreshActiveRules(ctx core.Context) error {
+	if !config.Get().RecordingRule.Enable {
+		s.promRepo.SetRecordingRules(nil)
+		return nil
+	}
+
+	deployed, err := s.dbRepo.ListRecordingRules(ctx)
+	if err != nil {
+		return err
+	}
+	deployedAt := make(map[string]int64, len(deployed))
+	for _, rule := range deployed {
+		deployedAt[rule.Record] = rule.DeployedAt
+	}
+
+	now := time.Now()
+	var active []prometheus.ActiveRecordingRule
+	for _, rule := range prometheus.DefaultRecordingRules(s.promRepo.GetRange()) {
+		ts, find := deployedAt[rule.Record]
+		if !find {
+			continue
+		}
+		exists, err := s.promRepo.RecordedSeriesExists(ctx, rule.Record, now)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		active = append(active, prometheus.ActiveRecordingRule{
+			RecordingRule: rule,
+			// vmalert needs up to one interval to load the rule and write the first sample
+			ActiveSince: time.Unix(ts, 0).Add(2 * prometheus.RecordingRuleInterval),
+		})
+	}
+	s.promRepo.SetRecordingRules(active)
+	return nil
+}
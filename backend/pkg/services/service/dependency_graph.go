@@ -0,0 +1,248 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"sort"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+)
+
+// dependencyGraph is the directed call graph of the services and their db, mq and external peers.
+type dependencyGraph struct {
+	categories map[string]string
+	children   map[string][]string
+	parents    map[string][]string
+}
+
+func newDependencyGraph(nodes map[string]*model.ServiceToplogyNode) *dependencyGraph {
+	g := &dependencyGraph{
+		categories: make(map[string]string, len(nodes)),
+		children:   make(map[string][]string),
+		parents:    make(map[string][]string),
+	}
+	for name, node := range nodes {
+		g.addNode(name, node.Category)
+		for _, child := range node.Children {
+			g.addEdge(name, child)
+		}
+	}
+	return g
+}
+
+func (g *dependencyGraph) addNode(name string, category string) {
+	if _, find := g.categories[name]; !find || len(g.categories[name]) == 0 {
+		g.categories[name] = category
+	}
+}
+
+func (g *dependencyGraph) addEdge(parent string, child string) {
+	for _, c := range g.children[parent] {
+		if c == child {
+			return
+		}
+	}
+	g.addNode(parent, "")
+	g.addNode(child, "")
+	g.children[parent] = append(g.children[parent], child)
+	g.parents[child] = append(g.parents[child], parent)
+}
+
+// sortedNodes keeps the results stable as the nodes are stored in maps.
+func (g *dependencyGraph) sortedNodes() []string {
+	nodes := make([]string, 0, len(g.categories))
+	for node := range g.categories {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// upstream returns the nodes depending on the target transitively with their shortest distance.
+func (g *dependencyGraph) upstream(target string) map[string]int {
+	depths := map[string]int{}
+	queue := []string{target}
+	visited := map[string]bool{target: true}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []string
+		for _, node := range queue {
+			for _, parent := range g.parents[node] {
+				if visited[parent] {
+					continue
+				}
+				visited[parent] = true
+				depths[parent] = depth
+				next = append(next, parent)
+			}
+		}
+		queue = next
+	}
+	return depths
+}
+
+// cycles returns the strongly connected components forming call cycles, including the self calls.
+func (g *dependencyGraph) cycles() [][]string {
+	var (
+		index   = map[string]int{}
+		lowLink = map[string]int{}
+		onStack = map[string]bool{}
+		stack   []string
+		res     [][]string
+		counter int
+	)
+	var strongConnect func(node string)
+	strongConnect = func(node string) {
+		index[node], lowLink[node] = counter, counter
+		counter++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, child := range g.children[node] {
+			if _, visited := index[child]; !visited {
+				strongConnect(child)
+				lowLink[node] = min(lowLink[node], lowLink[child])
+			} else if onStack[child] {
+				lowLink[node] = min(lowLink[node], index[child])
+			}
+		}
+
+		if lowLink[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || g.callsItself(node) {
+			sort.Strings(component)
+			res = append(res, component)
+		}
+	}
+
+	for _, node := range g.sortedNodes() {
+		if _, visited := index[node]; !visited {
+			strongConnect(node)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i][0] < res[j][0] })
+	return res
+}
+
+func (g *dependencyGraph) callsItself(node string) bool {
+	for _, child := range g.children[node] {
+		if child == node {
+			return true
+		}
+	}
+	return false
+}
+
+// articulationPoints returns the nodes whose failure splits the graph, ignoring the call direction.
+func (g *dependencyGraph) articulationPoints() []string {
+	neighbors := make(map[string][]string, len(g.categories))
+	for parent, children := range g.children {
+		for _, child := range children {
+			if parent == child {
+				continue
+			}
+			neighbors[parent] = append(neighbors[parent], child)
+			neighbors[child] = append(neighbors[child], parent)
+		}
+	}
+
+	var (
+		discovery = map[string]int{}
+		low       = map[string]int{}
+		points    = map[string]bool{}
+		counter   int
+	)
+	var visit func(node string, parent string)
+	visit = func(node string, parent string) {
+		counter++
+		discovery[node], low[node] = counter, counter
+		childCount := 0
+		for _, next := range neighbors[node] {
+			if next == parent {
+				continue
+			}
+			if _, visited := discovery[next]; visited {
+				low[node] = min(low[node], discovery[next])
+				continue
+			}
+			childCount++
+			visit(next, node)
+			low[node] = min(low[node], low[next])
+			if len(parent) > 0 && low[next] >= discovery[node] {
+				points[node] = true
+			}
+		}
+		if len(parent) == 0 && childCount > 1 {
+			points[node] = true
+		}
+	}
+
+	for _, node := range g.sortedNodes() {
+		if _, visited := discovery[node]; !visited {
+			visit(node, "")
+		}
+	}
+	res := make([]string, 0, len(points))
+	for node := range points {
+		res = append(res, node)
+	}
+	sort.Strings(res)
+	return res
+}
+
+type endpointKey struct {
+	service  string
+	endpoint string
+}
+
+type criticalHop struct {
+	node         endpointKey
+	latency      float64
+	selfLatency  float64
+	contribution float64
+}
+
+// criticalPath follows the slowest downstream call from the entry. The self latency of a hop is
+// the part of its latency not spent in the next hop, the contribution is its ratio to the entry latency.
+func criticalPath(entry endpointKey, children map[endpointKey][]endpointKey, latency map[endpointKey]float64) []criticalHop {
+	var path []criticalHop
+	visited := map[endpointKey]bool{}
+	for node, find := entry, true; find && !visited[node]; {
+		visited[node] = true
+		hop := criticalHop{node: node, latency: latency[node], selfLatency: latency[node]}
+
+		var next endpointKey
+		find = false
+		for _, child := range children[node] {
+			if visited[child] || latency[child] <= 0 {
+				continue
+			}
+			if !find || latency[child] > latency[next] {
+				next, find = child, true
+			}
+		}
+		if find {
+			hop.selfLatency = max(hop.latency-latency[next], 0)
+		}
+		path = append(path, hop)
+		node = next
+	}
+
+	if total := latency[entry]; total > 0 {
+		for i := range path {
+			path[i].contribution = path[i].selfLatency / total
+		}
+	}
+	return path
+}
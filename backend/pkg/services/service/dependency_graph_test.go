@@ -0,0 +1,83 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestGraph(edges [][2]string) *dependencyGraph {
+	g := newDependencyGraph(nil)
+	for _, edge := range edges {
+		g.addEdge(edge[0], edge[1])
+	}
+	return g
+}
+
+func TestDependencyGraphUpstream(t *testing.T) {
+	g := newTestGraph([][2]string{
+		{"gateway", "order"}, {"gateway", "cart"}, {"order", "stock"}, {"cart", "stock"}, {"stock", "mysql"}, {"report", "mysql"},
+	})
+	got := g.upstream("mysql")
+	want := map[string]int{"stock": 1, "report": 1, "order": 2, "cart": 2, "gateway": 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("upstream() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraphCycles(t *testing.T) {
+	g := newTestGraph([][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "d"}, {"e", "f"},
+	})
+	got := g.cycles()
+	want := [][]string{{"a", "b", "c"}, {"d"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyGraphArticulationPoints(t *testing.T) {
+	// gateway reaches the backends only through order, redis is shared by order and cart
+	g := newTestGraph([][2]string{
+		{"gateway", "order"}, {"order", "stock"}, {"order", "cart"}, {"order", "redis"}, {"cart", "redis"}, {"stock", "mysql"},
+	})
+	got := g.articulationPoints()
+	want := []string{"order", "stock"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("articulationPoints() = %v, want %v", got, want)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	entry := endpointKey{"gateway", "/order"}
+	order := endpointKey{"order", "/create"}
+	stock := endpointKey{"stock", "/reserve"}
+	user := endpointKey{"user", "/get"}
+	mysql := endpointKey{"mysql", "SELECT"}
+	children := map[endpointKey][]endpointKey{
+		entry: {user, order},
+		order: {stock, mysql},
+	}
+	latency := map[endpointKey]float64{entry: 1000, order: 800, stock: 300, user: 100}
+
+	path := criticalPath(entry, children, latency)
+	if len(path) != 3 {
+		t.Fatalf("expected 3 hops, got %v", path)
+	}
+	expected := []struct {
+		node         endpointKey
+		selfLatency  float64
+		contribution float64
+	}{
+		{entry, 200, 0.2},
+		{order, 500, 0.5},
+		{stock, 300, 0.3},
+	}
+	for i, e := range expected {
+		if path[i].node != e.node || path[i].selfLatency != e.selfLatency || path[i].contribution != e.contribution {
+			t.Errorf("hop %d = %+v, want %+v", i, path[i], e)
+		}
+	}
+}
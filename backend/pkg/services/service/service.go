@@ -21,8 +21,18 @@ type Service interface {
 	GetServiceEndpointRelation(ctx core.Context, req *request.GetServiceEndpointRelationRequest) (*response.GetServiceEndpointRelationResponse, error)
 	// Get the upstream and downstream topology map
 	GetServiceEndpointTopology(ctx core.Context, req *request.GetServiceEndpointTopologyRequest) (*response.GetServiceEndpointTopologyResponse, error)
+	// Export the upstream and downstream topology map as DOT, Mermaid or JSON Graph Format
+	ExportServiceEndpointTopology(ctx core.Context, req *request.ExportServiceEndpointTopologyRequest) (*response.ExportTopologyResponse, error)
 	// Get the delay curve of the dependent service
 	GetDescendantMetrics(ctx core.Context, req *request.GetDescendantMetricsRequest) ([]response.GetDescendantMetricsResponse, error)
+	// Get the services and entry endpoints depending on a service or peer transitively
+	GetBlastRadius(ctx core.Context, req *request.GetBlastRadiusRequest) (*response.GetBlastRadiusResponse, error)
+	// Get the latency contribution of the nodes on the critical path of an entry endpoint
+	GetCriticalPath(ctx core.Context, req *request.GetCriticalPathRequest) (*response.GetCriticalPathResponse, error)
+	// Detect the call cycles in the dependency graph
+	DetectCycles(ctx core.Context, req *request.GetDependencyGraphRequest) (*response.DetectCyclesResponse, error)
+	// Get the nodes whose failure splits the dependency graph
+	GetSinglePointsOfFailure(ctx core.Context, req *request.GetDependencyGraphRequest) (*response.GetSinglePointsOfFailureResponse, error)
 	// Get the dependent node delay correlation.
 	GetDescendantRelevance(ctx core.Context, req *request.GetDescendantRelevanceRequest) ([]response.GetDescendantRelevanceResponse, error)
 	// Get Polaris metric analysis
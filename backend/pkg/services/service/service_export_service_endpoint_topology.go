@@ -0,0 +1,148 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/common"
+)
+
+func (s *service) ExportServiceEndpointTopology(ctx core.Context, req *request.ExportServiceEndpointTopologyRequest) (*response.ExportTopologyResponse, error) {
+	topology, err := s.GetServiceEndpointTopology(ctx, &req.GetServiceEndpointTopologyRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &common.TopologyGraph{Label: req.Service + " " + req.Endpoint}
+	current := endpointGraphNode(topology.Current)
+	graph.Nodes = append(graph.Nodes, current)
+	nodes := map[string]*common.TopologyGraphNode{current.ID: current}
+	// the services out of the group are not exported
+	for _, parent := range topology.Parents {
+		if parent.OutOfGroup {
+			continue
+		}
+		node := endpointGraphNode(parent)
+		if _, find := nodes[node.ID]; !find {
+			nodes[node.ID] = node
+			graph.Nodes = append(graph.Nodes, node)
+		}
+		graph.Edges = append(graph.Edges, &common.TopologyGraphEdge{Source: node.ID, Target: current.ID})
+	}
+	for _, child := range topology.Children {
+		if child.OutOfGroup {
+			continue
+		}
+		node := endpointGraphNode(child)
+		if _, find := nodes[node.ID]; !find {
+			nodes[node.ID] = node
+			graph.Nodes = append(graph.Nodes, node)
+		}
+		graph.Edges = append(graph.Edges, &common.TopologyGraphEdge{Source: current.ID, Target: node.ID})
+	}
+
+	if err := s.addCustomTopologyEdges(ctx, req.Service, req.EndTime, graph, current); err != nil {
+		return nil, err
+	}
+	if err := s.fillEndpointRED(ctx, &req.GetServiceEndpointTopologyRequest, graph); err != nil {
+		return nil, err
+	}
+	common.SortTopologyGraph(graph)
+	return common.ExportTopology(graph, req.Format, "topology")
+}
+
+func endpointGraphNode(node *model.TopologyNode) *common.TopologyGraphNode {
+	return &common.TopologyGraphNode{
+		ID:       node.Service + "|" + node.Endpoint,
+		Service:  node.Service,
+		Endpoint: node.Endpoint,
+		Group:    node.Group,
+	}
+}
+
+// addCustomTopologyEdges marks the edges of the service declared in the custom service topology,
+// the custom peers not traced are added as the service nodes.
+func (s *service) addCustomTopologyEdges(ctx core.Context, service string, endTime int64, graph *common.TopologyGraph, current *common.TopologyGraphNode) error {
+	customTopologies, err := s.dbRepo.ListActiveCustomServiceTopology(ctx, endTime)
+	if err != nil {
+		return err
+	}
+	for _, custom := range customTopologies {
+		var peer, peerType string
+		isParent := custom.RightNode == service
+		switch {
+		case isParent:
+			peer, peerType = custom.LeftNode, custom.LeftType
+		case custom.LeftNode == service:
+			peer, peerType = custom.RightNode, custom.RightType
+		default:
+			continue
+		}
+
+		marked := false
+		for _, edge := range graph.Edges {
+			source, target := edge.Source, edge.Target
+			if isParent && target == current.ID && serviceOf(graph, source) == peer ||
+				!isParent && source == current.ID && serviceOf(graph, target) == peer {
+				edge.IsCustom = true
+				marked = true
+			}
+		}
+		if marked {
+			continue
+		}
+		node := &common.TopologyGraphNode{ID: peer, Service: peer, Group: peerType, IsCustom: true}
+		graph.Nodes = append(graph.Nodes, node)
+		if isParent {
+			graph.Edges = append(graph.Edges, &common.TopologyGraphEdge{Source: node.ID, Target: current.ID, IsCustom: true})
+		} else {
+			graph.Edges = append(graph.Edges, &common.TopologyGraphEdge{Source: current.ID, Target: node.ID, IsCustom: true})
+		}
+	}
+	return nil
+}
+
+func serviceOf(graph *common.TopologyGraph, id string) string {
+	for _, node := range graph.Nodes {
+		if node.ID == id {
+			return node.Service
+		}
+	}
+	return ""
+}
+
+// fillEndpointRED annotates the traced endpoints with their RED metrics.
+func (s *service) fillEndpointRED(ctx core.Context, req *request.GetServiceEndpointTopologyRequest, graph *common.TopologyGraph) error {
+	var services, endpoints []string
+	for _, node := range graph.Nodes {
+		if node.Group == model.GROUP_SERVICE && len(node.Endpoint) > 0 {
+			services = append(services, node.Service)
+			endpoints = append(endpoints, node.Endpoint)
+		}
+	}
+	if len(services) == 0 {
+		return nil
+	}
+
+	filter := prometheus.NewFilter()
+	filter.RegexMatch(prometheus.ServiceNameKey, prometheus.RegexMultipleValue(services...))
+	filter.RegexMatch(prometheus.ContentKeyKey, prometheus.RegexMultipleValue(endpoints...))
+	if len(req.ClusterIDs) > 0 {
+		filter.RegexMatch(prometheus.ClusterIDKey, prometheus.RegexMultipleValue(req.ClusterIDs...))
+	}
+	red, err := common.QueryTopologyRED(ctx, s.promRepo, req.StartTime, req.EndTime,
+		prometheus.EndpointGranularity, filter,
+		func(labels *prometheus.Labels) string { return labels.SvcName + "|" + labels.ContentKey })
+	if err != nil {
+		return err
+	}
+	for _, node := range graph.Nodes {
+		node.RED = red[node.ID]
+	}
+	return nil
+}
@@ -192,7 +192,7 @@ func (s *service) InstanceLog(ctx core.Context, instances *InstanceMap, startTim
 			nodeName = append(nodeName, key.NodeName)
 		}
 		if len(key.PID) > 0 {
-			pid = append(pid)
+			pid = append(pid, key.PID)
 		}
 	}
 
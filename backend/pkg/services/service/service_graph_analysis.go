@@ -0,0 +1,171 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"sort"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/common"
+)
+
+func (s *service) GetBlastRadius(ctx core.Context, req *request.GetBlastRadiusRequest) (*response.GetBlastRadiusResponse, error) {
+	graph, err := s.dependencyGraph(ctx, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]response.DependentNode, 0)
+	for node, depth := range graph.upstream(req.Service) {
+		services = append(services, response.DependentNode{
+			Service:  node,
+			Category: graph.categories[node],
+			Depth:    depth,
+		})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Depth != services[j].Depth {
+			return services[i].Depth < services[j].Depth
+		}
+		return services[i].Service < services[j].Service
+	})
+
+	var entries []clickhouse.EntryNode
+	if len(req.Endpoint) > 0 {
+		entries, err = s.chRepo.ListEntryEndpoints(ctx, &request.GetServiceEntryEndpointsRequest{
+			StartTime: req.StartTime,
+			EndTime:   req.EndTime,
+			Service:   req.Service,
+			Endpoint:  req.Endpoint,
+		})
+	} else {
+		entries, err = s.chRepo.ListEntryEndpointsOfNode(ctx, req.StartTime, req.EndTime, req.Service)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &response.GetBlastRadiusResponse{
+		Services:       services,
+		EntryEndpoints: entries,
+	}, nil
+}
+
+func (s *service) GetCriticalPath(ctx core.Context, req *request.GetCriticalPathRequest) (*response.GetCriticalPathResponse, error) {
+	// Query the calling relationship of all descendant nodes, the same as the descendant metrics
+	relations, err := s.chRepo.ListDescendantRelations(ctx, &request.GetServiceEndpointTopologyRequest{
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Service:    req.Service,
+		Endpoint:   req.Endpoint,
+		ClusterIDs: req.ClusterIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := endpointKey{service: req.Service, endpoint: req.Endpoint}
+	groups := map[endpointKey]string{entry: model.GROUP_SERVICE}
+	children := make(map[endpointKey][]endpointKey)
+	services := []string{req.Service}
+	for _, relation := range relations {
+		parent := endpointKey{service: relation.ParentService, endpoint: relation.ParentEndpoint}
+		child := endpointKey{service: relation.Service, endpoint: relation.Endpoint}
+		children[parent] = append(children[parent], child)
+		groups[child] = relation.Group
+		if relation.Group == model.GROUP_SERVICE {
+			services = append(services, relation.Service)
+		}
+	}
+
+	filter := prometheus.NewFilter()
+	filter.RegexMatch(prometheus.ServiceNameKey, prometheus.RegexMultipleValue(services...))
+	if len(req.ClusterIDs) > 0 {
+		filter.RegexMatch(prometheus.ClusterIDKey, prometheus.RegexMultipleValue(req.ClusterIDs...))
+	}
+	red, err := common.QueryTopologyRED(ctx, s.promRepo, req.StartTime, req.EndTime,
+		prometheus.EndpointGranularity, filter,
+		func(labels *prometheus.Labels) string { return labels.SvcName + "|" + labels.ContentKey })
+	if err != nil {
+		return nil, err
+	}
+	// the latency of the db, mq and external calls are counted in their callers
+	latency := make(map[endpointKey]float64, len(groups))
+	for node, group := range groups {
+		if metrics, find := red[node.service+"|"+node.endpoint]; find && group == model.GROUP_SERVICE {
+			latency[node] = metrics.Latency
+		}
+	}
+
+	path := make([]response.CriticalPathNode, 0)
+	for _, hop := range criticalPath(entry, children, latency) {
+		path = append(path, response.CriticalPathNode{
+			Service:      hop.node.service,
+			Endpoint:     hop.node.endpoint,
+			Group:        groups[hop.node],
+			Latency:      hop.latency,
+			SelfLatency:  hop.selfLatency,
+			Contribution: hop.contribution,
+		})
+	}
+	return &response.GetCriticalPathResponse{
+		Latency: latency[entry],
+		Path:    path,
+	}, nil
+}
+
+func (s *service) DetectCycles(ctx core.Context, req *request.GetDependencyGraphRequest) (*response.DetectCyclesResponse, error) {
+	graph, err := s.dependencyGraph(ctx, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	cycles := graph.cycles()
+	if cycles == nil {
+		cycles = [][]string{}
+	}
+	return &response.DetectCyclesResponse{Cycles: cycles}, nil
+}
+
+func (s *service) GetSinglePointsOfFailure(ctx core.Context, req *request.GetDependencyGraphRequest) (*response.GetSinglePointsOfFailureResponse, error) {
+	graph, err := s.dependencyGraph(ctx, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]response.SinglePointOfFailure, 0)
+	for _, node := range graph.articulationPoints() {
+		nodes = append(nodes, response.SinglePointOfFailure{
+			Service:    node,
+			Category:   graph.categories[node],
+			Dependents: len(graph.upstream(node)),
+		})
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Dependents > nodes[j].Dependents })
+	return &response.GetSinglePointsOfFailureResponse{Nodes: nodes}, nil
+}
+
+// dependencyGraph builds the graph from the traced service relationship and the custom service topology.
+func (s *service) dependencyGraph(ctx core.Context, startTime int64, endTime int64) (*dependencyGraph, error) {
+	nodes, err := s.chRepo.ListServiceTopologys(ctx, &request.QueryTopologyRequest{
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph(nodes.Nodes)
+
+	customTopologies, err := s.dbRepo.ListActiveCustomServiceTopology(ctx, endTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, custom := range customTopologies {
+		graph.addNode(custom.LeftNode, custom.LeftType)
+		graph.addNode(custom.RightNode, custom.RightType)
+		graph.addEdge(custom.LeftNode, custom.RightNode)
+	}
+	return graph, nil
+}
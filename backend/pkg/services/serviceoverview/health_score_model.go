@@ -0,0 +1,199 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+const maxSignalScore = 3
+
+// builtinHealthSignals describes the signals used by ExposeRYGLightStatus when no model is configured.
+var builtinHealthSignals = []model.HealthSignal{
+	{Key: model.HealthSignalLatencyDoD, Thresholds: [3]float64{10, 20, 50}, Weight: 1, MissingScore: intPtr(3)},
+	{Key: model.HealthSignalErrorRateDoD, Thresholds: [3]float64{5, 10, 20}, Weight: 1, MissingScore: intPtr(3)},
+	{Key: model.HealthSignalLogErrorCountDoD, Thresholds: [3]float64{5, 10, 20}, Weight: 1, MissingScore: intPtr(3)},
+	{Key: model.HealthSignalAlert, Weight: 1},
+	{Key: model.HealthSignalReplica, Weight: 1},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// signalValue returns the value of threshold based signals, known is false for unsupported signals.
+func (s *RYGLightStatus) signalValue(key string) (value *float64, known bool) {
+	switch key {
+	case model.HealthSignalLatency:
+		if s.LatencyAvg == nil {
+			return nil, true
+		}
+		ms := *s.LatencyAvg / 1e3
+		return &ms, true
+	case model.HealthSignalErrorRate:
+		return s.ErrorRateAvg, true
+	case model.HealthSignalLogErrorCount:
+		return s.LogErrorCountAvg, true
+	case model.HealthSignalLatencyDoD:
+		return s.LatencyDoD, true
+	case model.HealthSignalErrorRateDoD:
+		return s.ErrorRateDoD, true
+	case model.HealthSignalLogErrorCountDoD:
+		return s.LogErrorCountDoD, true
+	case model.HealthSignalLatencyAnomaly:
+		return s.LatencyAnomaly, true
+	case model.HealthSignalErrorAnomaly:
+		return s.ErrorRateAnomaly, true
+	}
+	return nil, false
+}
+
+// ExposeRYGLightStatusWithModel scores the status with a configured model,
+// returns the result, the contribution of each signal and the signal deciding the color when aggregation is worst.
+// The default scoring is used when no signal of the model contributes, e.g. all signals are skipped for missing data.
+func (s *RYGLightStatus) ExposeRYGLightStatusWithModel(scoreModel *database.HealthScoreModel) (*response.RYGResult, []response.RYGSignalContribution, string) {
+	var res = &response.RYGResult{}
+	contributions := make([]response.RYGSignalContribution, 0, len(scoreModel.Signals))
+
+	for _, signal := range scoreModel.Signals {
+		contribution := response.RYGSignalContribution{
+			Key:        signal.Key,
+			Thresholds: signal.Thresholds,
+			Weight:     signal.Weight,
+			Score:      -1,
+		}
+
+		switch signal.Key {
+		case model.HealthSignalAlert:
+			contribution.Score = AlertScore(&s.AlertStatus, &s.AlertEventLevelCountMap)
+			contribution.Detail = getAlertScoreDetail(contribution.Score)
+		case model.HealthSignalReplica:
+			replicas := float64(len(s.Instances))
+			contribution.Value = &replicas
+			if len(s.Instances) < 2 {
+				contribution.Score = 0
+				contribution.Detail = "应用实例数小于2, 存在服务不可用风险"
+			} else {
+				contribution.Score = maxSignalScore
+				contribution.Detail = "应用实例数大于2, 服务有可用副本"
+			}
+		default:
+			value, _ := s.signalValue(signal.Key)
+			contribution.Value = value
+			contribution.Score = ScoreFromDoD(value, signal.Thresholds[0], signal.Thresholds[1], signal.Thresholds[2])
+			if contribution.Score >= 0 {
+				contribution.Detail = fmt.Sprintf("%s 为 %.2f", signal.Key, *value)
+			} else if signal.MissingScore != nil {
+				contribution.Score = *signal.MissingScore
+				contribution.Detail = fmt.Sprintf("未获取到 %s, 按 %d 分计算", signal.Key, *signal.MissingScore)
+			} else {
+				contribution.Skipped = true
+				contribution.Score = 0
+				contribution.Detail = fmt.Sprintf("未获取到 %s, 跳过检查", signal.Key)
+			}
+		}
+		contributions = append(contributions, contribution)
+	}
+
+	var determinedBy string
+	var percent float64
+	switch scoreModel.Aggregation {
+	case model.HealthAggregationWorst:
+		worst := -1
+		for i := range contributions {
+			if contributions[i].Skipped {
+				continue
+			}
+			if worst < 0 || contributions[i].Score < contributions[worst].Score {
+				worst = i
+			}
+		}
+		if worst < 0 {
+			return s.ExposeRYGLightStatus(), contributions, ""
+		}
+		percent = float64(contributions[worst].Score) * 100 / maxSignalScore
+		contributions[worst].Contribution = percent
+		determinedBy = contributions[worst].Key
+	default:
+		var totalWeight float64
+		for _, contribution := range contributions {
+			if !contribution.Skipped {
+				totalWeight += contribution.Weight * maxSignalScore
+			}
+		}
+		if totalWeight <= 0 {
+			return s.ExposeRYGLightStatus(), contributions, ""
+		}
+		for i := range contributions {
+			if contributions[i].Skipped {
+				continue
+			}
+			contributions[i].Contribution = contributions[i].Weight * float64(contributions[i].Score) * 100 / totalWeight
+			percent += contributions[i].Contribution
+		}
+	}
+
+	for _, contribution := range contributions {
+		if contribution.Skipped {
+			continue
+		}
+		res.Score += contribution.Score
+		res.ScoreDetail = append(res.ScoreDetail, response.RYGScoreDetail{
+			Key:    contribution.Key,
+			Score:  contribution.Score,
+			Detail: contribution.Detail,
+		})
+	}
+	res.PercentScore = int(math.Round(percent))
+	res.Status = statusFromPercent(res.PercentScore)
+	return res, contributions, determinedBy
+}
+
+func statusFromPercent(percentScore int) response.RYGStatus {
+	if percentScore >= 80 {
+		return response.GREEN
+	} else if percentScore >= 40 {
+		return response.YELLOW
+	}
+	return response.RED
+}
+
+func validateHealthSignals(aggregation string, signals []model.HealthSignal) error {
+	if aggregation != model.HealthAggregationWorst && aggregation != model.HealthAggregationWeighted {
+		return core.Error(code.HealthScoreModelIllegalError, "aggregation must be worst or weighted")
+	}
+	if len(signals) == 0 {
+		return core.Error(code.HealthScoreModelIllegalError, "at least one signal is required")
+	}
+
+	status := &RYGLightStatus{}
+	var totalWeight float64
+	for _, signal := range signals {
+		totalWeight += signal.Weight
+		if _, known := status.signalValue(signal.Key); !known &&
+			signal.Key != model.HealthSignalAlert && signal.Key != model.HealthSignalReplica {
+			return core.Error(code.HealthScoreModelIllegalError, fmt.Sprintf("unknown signal: %s", signal.Key))
+		}
+		if signal.Weight < 0 {
+			return core.Error(code.HealthScoreModelIllegalError, fmt.Sprintf("weight of %s must not be negative", signal.Key))
+		}
+		if signal.Thresholds[0] > signal.Thresholds[1] || signal.Thresholds[1] > signal.Thresholds[2] {
+			return core.Error(code.HealthScoreModelIllegalError, fmt.Sprintf("thresholds of %s must be ascending", signal.Key))
+		}
+		if signal.MissingScore != nil && (*signal.MissingScore < 0 || *signal.MissingScore > maxSignalScore) {
+			return core.Error(code.HealthScoreModelIllegalError, fmt.Sprintf("missingScore of %s must be between 0 and 3", signal.Key))
+		}
+	}
+	if aggregation == model.HealthAggregationWeighted && totalWeight <= 0 {
+		return core.Error(code.HealthScoreModelIllegalError, "sum of weights must be positive")
+	}
+	return nil
+}
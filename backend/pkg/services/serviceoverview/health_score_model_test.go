@@ -0,0 +1,88 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func TestExposeRYGLightStatusWithModel(t *testing.T) {
+	latencyDoD := 30.0  // score 1
+	errorRateDoD := 1.0 // score 3
+	status := &RYGLightStatus{
+		LatencyDoD:   &latencyDoD,
+		ErrorRateDoD: &errorRateDoD,
+	}
+	signals := model.HealthSignals{
+		{Key: model.HealthSignalLatencyDoD, Thresholds: [3]float64{10, 20, 50}, Weight: 2},
+		{Key: model.HealthSignalErrorRateDoD, Thresholds: [3]float64{5, 10, 20}, Weight: 1},
+		{Key: model.HealthSignalLogErrorCountDoD, Thresholds: [3]float64{5, 10, 20}, Weight: 1},
+	}
+
+	weighted := &database.HealthScoreModel{Aggregation: model.HealthAggregationWeighted, Signals: signals}
+	res, contributions, _ := status.ExposeRYGLightStatusWithModel(weighted)
+	// (2*1 + 1*3) / (3*3) = 55.6%
+	if res.PercentScore != 56 || res.Status != response.YELLOW {
+		t.Errorf("unexpected weighted result: %+v", res)
+	}
+	if !contributions[2].Skipped {
+		t.Errorf("expected signal without data to be skipped: %+v", contributions[2])
+	}
+
+	worst := &database.HealthScoreModel{Aggregation: model.HealthAggregationWorst, Signals: signals}
+	res, _, determinedBy := status.ExposeRYGLightStatusWithModel(worst)
+	if res.PercentScore != 33 || res.Status != response.RED || determinedBy != model.HealthSignalLatencyDoD {
+		t.Errorf("unexpected worst result: %+v, determined by %s", res, determinedBy)
+	}
+}
+
+func TestExposeRYGLightStatusWithoutContribution(t *testing.T) {
+	status := &RYGLightStatus{}
+	expected := status.ExposeRYGLightStatus()
+
+	skipped := model.HealthSignals{
+		{Key: model.HealthSignalLatencyDoD, Thresholds: [3]float64{10, 20, 50}, Weight: 1},
+	}
+	for _, aggregation := range []string{model.HealthAggregationWeighted, model.HealthAggregationWorst} {
+		res, _, _ := status.ExposeRYGLightStatusWithModel(&database.HealthScoreModel{Aggregation: aggregation, Signals: skipped})
+		if res.PercentScore != expected.PercentScore || res.Status != expected.Status {
+			t.Errorf("expected the default result for %s, got %+v", aggregation, res)
+		}
+	}
+
+	zeroWeight := model.HealthSignals{{Key: model.HealthSignalReplica, Weight: 0}}
+	res, _, _ := status.ExposeRYGLightStatusWithModel(&database.HealthScoreModel{Aggregation: model.HealthAggregationWeighted, Signals: zeroWeight})
+	if res.PercentScore != expected.PercentScore {
+		t.Errorf("expected the default result for zero weights, got %+v", res)
+	}
+}
+
+func TestValidateHealthSignals(t *testing.T) {
+	valid := []model.HealthSignal{{Key: model.HealthSignalAlert, Weight: 1}}
+	if err := validateHealthSignals(model.HealthAggregationWorst, valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateHealthSignals("avg", valid); err == nil {
+		t.Error("expected error for unknown aggregation")
+	}
+	unknown := []model.HealthSignal{{Key: "cpu", Weight: 1}}
+	if err := validateHealthSignals(model.HealthAggregationWeighted, unknown); err == nil {
+		t.Error("expected error for unknown signal")
+	}
+	descending := []model.HealthSignal{{Key: model.HealthSignalLatency, Thresholds: [3]float64{3, 2, 1}, Weight: 1}}
+	if err := validateHealthSignals(model.HealthAggregationWeighted, descending); err == nil {
+		t.Error("expected error for descending thresholds")
+	}
+	zeroWeight := []model.HealthSignal{{Key: model.HealthSignalAlert, Weight: 0}, {Key: model.HealthSignalReplica, Weight: 0}}
+	if err := validateHealthSignals(model.HealthAggregationWeighted, zeroWeight); err == nil {
+		t.Error("expected error for zero sum of weights")
+	}
+	if err := validateHealthSignals(model.HealthAggregationWorst, zeroWeight); err != nil {
+		t.Errorf("unexpected error for worst aggregation: %v", err)
+	}
+}
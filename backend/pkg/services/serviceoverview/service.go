@@ -14,6 +14,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/model/response"
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/anomaly"
 )
 
 var _ Service = (*service)(nil)
@@ -29,6 +30,13 @@ type Service interface {
 	GetServicesEndpointDataWithChart(ctx core.Context, startTime time.Time, endTime time.Time, step time.Duration, filter EndpointsFilter, sortRule request.SortType) (res []response.ServiceEndPointsRes, err error)
 
 	GetServicesRYGLightStatus(ctx core.Context, req *request.GetRygLightRequest) (response.ServiceRYGLightRes, error)
+	// ExplainRYGLight shows the contribution of each signal to the final color of the service
+	ExplainRYGLight(ctx core.Context, req *request.ExplainRygLightRequest) (*response.ExplainRYGLightResponse, error)
+
+	// Health score model of the red/yellow/green light, configured per data group
+	GetHealthScoreModel(ctx core.Context, req *request.GetHealthScoreModelRequest) (*response.GetHealthScoreModelResponse, error)
+	SetHealthScoreModel(ctx core.Context, req *request.SetHealthScoreModelRequest) error
+	DeleteHealthScoreModel(ctx core.Context, req *request.DeleteHealthScoreModelRequest) error
 	GetMonitorStatus(ctx core.Context, startTime time.Time, endTime time.Time) (response.GetMonitorStatusResponse, error)
 
 	GetAlertRelatedEntryData(ctx core.Context, startTime, endTime time.Time, namespaces []string, entry []response.AlertRelatedEntry) (res []response.AlertRelatedEntry, err error)
@@ -40,6 +48,8 @@ type service struct {
 	dbRepo   database.Repo
 	promRepo prometheus.Repo
 	chRepo   clickhouse.Repo
+
+	anomalyService anomaly.Service
 }
 
 func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, promRepo prometheus.Repo) Service {
@@ -48,6 +58,8 @@ func New(logger *zap.Logger, chRepo clickhouse.Repo, dbRepo database.Repo, promR
 		dbRepo:   dbRepo,
 		promRepo: promRepo,
 		chRepo:   chRepo,
+
+		anomalyService: anomaly.New(logger, promRepo, dbRepo),
 	}
 }
 
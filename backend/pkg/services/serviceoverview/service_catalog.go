@@ -0,0 +1,27 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"go.uber.org/zap"
+)
+
+// serviceCatalogs returns the catalogs of the services by name, the services are still shown if the catalogs are not available.
+func (s *service) serviceCatalogs(ctx core.Context, services []string) map[string]*database.ServiceCatalog {
+	res := make(map[string]*database.ServiceCatalog)
+	if len(services) == 0 {
+		return res
+	}
+	catalogs, err := s.dbRepo.ListServiceCatalogs(ctx, services, 0)
+	if err != nil {
+		s.logger.Error("failed to list service catalogs", zap.Error(err))
+		return res
+	}
+	for i := range catalogs {
+		res[catalogs[i].Service] = &catalogs[i]
+	}
+	return res
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/repository/database"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	prom "github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/anomaly"
 	"github.com/CloudDetail/apo/backend/pkg/services/common"
 	"go.uber.org/zap"
 )
@@ -66,6 +67,13 @@ func (s *service) GetServicesEndPointData(ctx core.Context, req *request.GetEndP
 
 	s.sortWithRule(ctx, sortRule, endpointsMap)
 
+	anomalyScores, err := s.anomalyService.ScoreEndpoints(ctx,
+		req.StartTime, req.EndTime,
+		prometheus.EndpointGranularity, pqlFilter)
+	if err != nil {
+		s.logger.Error("failed to score endpoints anomaly", zap.Error(err))
+	}
+
 	services := groupEndpointsByService(endpointsMap.MetricGroupList, 3)
 	var servicesResMsg []response.ServiceEndPointsRes
 	for _, service := range services {
@@ -77,6 +85,12 @@ func (s *service) GetServicesEndPointData(ctx core.Context, req *request.GetEndP
 		if serviceDetails == nil {
 			continue
 		}
+		for i := range serviceDetails {
+			serviceDetails[i].Anomaly = anomalyScores[anomaly.EndpointKey{
+				SvcName:    service.ServiceName,
+				ContentKey: serviceDetails[i].Endpoint,
+			}]
+		}
 
 		// endpoint namespaceList to remove weight
 		tmpSet := make(map[string]struct{})
@@ -100,6 +114,15 @@ func (s *service) GetServicesEndPointData(ctx core.Context, req *request.GetEndP
 
 		servicesResMsg = append(servicesResMsg, newServiceRes)
 	}
+
+	serviceNames := make([]string, 0, len(servicesResMsg))
+	for _, res := range servicesResMsg {
+		serviceNames = append(serviceNames, res.ServiceName)
+	}
+	catalogs := s.serviceCatalogs(ctx, serviceNames)
+	for i := range servicesResMsg {
+		servicesResMsg[i].Catalog = catalogs[servicesResMsg[i].ServiceName]
+	}
 	return servicesResMsg, err
 }
 
@@ -84,6 +84,15 @@ func (s *service) GetServicesEndpointDataWithChart(ctx core.Context,
 
 		servicesResMsg = append(servicesResMsg, newServiceRes)
 	}
+
+	serviceNames := make([]string, 0, len(servicesResMsg))
+	for _, res := range servicesResMsg {
+		serviceNames = append(serviceNames, res.ServiceName)
+	}
+	catalogs := s.serviceCatalogs(ctx, serviceNames)
+	for i := range servicesResMsg {
+		servicesResMsg[i].Catalog = catalogs[servicesResMsg[i].ServiceName]
+	}
 	return servicesResMsg, err
 }
 
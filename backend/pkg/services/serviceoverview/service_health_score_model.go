@@ -0,0 +1,106 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceoverview
+
+import (
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+// getHealthScoreModel returns the model of the data group, or the model of the default group (0) if not configured.
+// nil means the built-in scoring is used.
+func (s *service) getHealthScoreModel(ctx core.Context, groupID int64) (*database.HealthScoreModel, error) {
+	scoreModel, err := s.dbRepo.GetHealthScoreModel(ctx, groupID)
+	if err != nil || scoreModel != nil || groupID == 0 {
+		return scoreModel, err
+	}
+	return s.dbRepo.GetHealthScoreModel(ctx, 0)
+}
+
+func (s *service) GetHealthScoreModel(ctx core.Context, req *request.GetHealthScoreModelRequest) (*response.GetHealthScoreModelResponse, error) {
+	scoreModel, err := s.getHealthScoreModel(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if scoreModel == nil {
+		return &response.GetHealthScoreModelResponse{
+			Custom:      false,
+			Aggregation: model.HealthAggregationWeighted,
+			Signals:     builtinHealthSignals,
+		}, nil
+	}
+	return &response.GetHealthScoreModelResponse{
+		Custom:      true,
+		Aggregation: scoreModel.Aggregation,
+		Signals:     scoreModel.Signals,
+	}, nil
+}
+
+func (s *service) SetHealthScoreModel(ctx core.Context, req *request.SetHealthScoreModelRequest) error {
+	if err := validateHealthSignals(req.Aggregation, req.Signals); err != nil {
+		return err
+	}
+	return s.dbRepo.SaveHealthScoreModel(ctx, &database.HealthScoreModel{
+		GroupID:     req.GroupID,
+		Aggregation: req.Aggregation,
+		Signals:     req.Signals,
+	})
+}
+
+func (s *service) DeleteHealthScoreModel(ctx core.Context, req *request.DeleteHealthScoreModelRequest) error {
+	return s.dbRepo.DeleteHealthScoreModel(ctx, req.GroupID)
+}
+
+func (s *service) ExplainRYGLight(ctx core.Context, req *request.ExplainRygLightRequest) (*response.ExplainRYGLightResponse, error) {
+	filter := EndpointsFilter{ServiceName: req.ServiceName}
+	servicesMap, err := s.collectRYGLightStatus(ctx, filter, req.StartTime, req.EndTime, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status *RYGLightStatus
+	for svcKey, svcStatus := range servicesMap.MetricGroupMap {
+		if svcKey.SvcName == req.ServiceName {
+			status = svcStatus
+			break
+		}
+	}
+	if status == nil {
+		return nil, core.Error(code.RYGLightServiceNotFoundError, "service not found")
+	}
+
+	scoreModel, err := s.getHealthScoreModel(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.ExplainRYGLightResponse{ServiceName: req.ServiceName}
+	if scoreModel != nil {
+		result, contributions, determinedBy := status.ExposeRYGLightStatusWithModel(scoreModel)
+		resp.RYGResult = *result
+		resp.Custom = true
+		resp.Aggregation = scoreModel.Aggregation
+		resp.DeterminedBy = determinedBy
+		resp.Signals = contributions
+		return resp, nil
+	}
+
+	// built-in scoring, every signal has the same weight
+	resp.RYGResult = *status.ExposeRYGLightStatus()
+	resp.Aggregation = model.HealthAggregationWeighted
+	for _, detail := range resp.ScoreDetail {
+		resp.Signals = append(resp.Signals, response.RYGSignalContribution{
+			Key:          detail.Key,
+			Score:        detail.Score,
+			Weight:       1,
+			Contribution: float64(detail.Score) * 100 / response.MAX_RYG_SCORE,
+			Detail:       detail.Detail,
+		})
+	}
+	return resp, nil
+}
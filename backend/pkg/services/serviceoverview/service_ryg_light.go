@@ -14,27 +14,67 @@ import (
 	"github.com/CloudDetail/apo/backend/pkg/repository/clickhouse"
 	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
 	prom "github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	"github.com/CloudDetail/apo/backend/pkg/services/anomaly"
 	"github.com/CloudDetail/apo/backend/pkg/services/common"
 )
 
 func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRygLightRequest) (response.ServiceRYGLightRes, error) {
-	var servicesMap = &servicesRYGLightMap{
-		MetricGroupList: []*RYGLightStatus{},
-		MetricGroupMap:  map[prom.ServiceKey]*RYGLightStatus{},
-	}
-
-	startTime := time.UnixMicro(req.StartTime)
-	endTime := time.UnixMicro(req.EndTime)
 	filter := EndpointsFilter{
 		ContainsSvcName:      req.ServiceName,
 		ContainsEndpointName: req.EndpointName,
 		Namespace:            req.Namespace,
 	}
+	servicesMap, err := s.collectRYGLightStatus(ctx, filter, req.StartTime, req.EndTime, req.GroupID)
+	if err != nil {
+		return response.ServiceRYGLightRes{}, err
+	}
 
-	groupFilter, err := common.GetPQLFilterByGroupID(ctx, s.dbRepo, "apm", req.GroupID)
+	scoreModel, err := s.getHealthScoreModel(ctx, req.GroupID)
 	if err != nil {
 		return response.ServiceRYGLightRes{}, err
 	}
+
+	var resp = response.ServiceRYGLightRes{
+		ServiceList: []*response.ServiceRYGResult{},
+	}
+	for svcKey, status := range servicesMap.MetricGroupMap {
+		var result *response.RYGResult
+		if scoreModel != nil {
+			result, _, _ = status.ExposeRYGLightStatusWithModel(scoreModel)
+		} else {
+			result = status.ExposeRYGLightStatus()
+		}
+		resp.ServiceList = append(resp.ServiceList, &response.ServiceRYGResult{
+			ServiceName: svcKey.SvcName,
+			RYGResult:   *result,
+		})
+	}
+
+	serviceNames := make([]string, 0, len(resp.ServiceList))
+	for _, res := range resp.ServiceList {
+		serviceNames = append(serviceNames, res.ServiceName)
+	}
+	catalogs := s.serviceCatalogs(ctx, serviceNames)
+	for _, res := range resp.ServiceList {
+		res.Catalog = catalogs[res.ServiceName]
+	}
+	return resp, nil
+}
+
+// collectRYGLightStatus queries the signals of the red/yellow/green light for services matching the filter.
+func (s *service) collectRYGLightStatus(ctx core.Context, filter EndpointsFilter, startTS int64, endTS int64, groupID int64) (*servicesRYGLightMap, error) {
+	var servicesMap = &servicesRYGLightMap{
+		MetricGroupList: []*RYGLightStatus{},
+		MetricGroupMap:  map[prom.ServiceKey]*RYGLightStatus{},
+	}
+
+	startTime := time.UnixMicro(startTS)
+	endTime := time.UnixMicro(endTS)
+
+	groupFilter, err := common.GetPQLFilterByGroupID(ctx, s.dbRepo, "apm", groupID)
+	if err != nil {
+		return nil, err
+	}
 	filters := filter.ExtractPQLFilterStr()
 
 	pqlFilter := prometheus.And(groupFilter, filters)
@@ -42,7 +82,7 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 	// FIX for showing services without LatencyDay-over-Day Growth Rate
 	avgLatency, err := s.promRepo.QueryMetricsWithPQLFilter(ctx,
 		prom.PQLAvgLatencyWithPQLFilter,
-		req.StartTime, req.EndTime,
+		startTS, endTS,
 		prom.SVCGranularity, pqlFilter)
 	if err == nil {
 		servicesMap.MergeMetricResults(prom.AVG, prom.LATENCY, avgLatency)
@@ -50,7 +90,7 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 
 	avgLatencyDoD, err := s.promRepo.QueryMetricsWithPQLFilter(ctx,
 		prom.DayOnDayTemplate(prom.PQLAvgLatencyWithPQLFilter),
-		req.StartTime, req.EndTime,
+		startTS, endTS,
 		prom.SVCGranularity, pqlFilter)
 	if err == nil {
 		servicesMap.MergeMetricResults(prom.DOD, prom.LATENCY, avgLatencyDoD)
@@ -58,7 +98,7 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 
 	avgErrorRateDoD, err := s.promRepo.QueryMetricsWithPQLFilter(ctx,
 		prom.DayOnDayTemplate(prom.PQLAvgErrorRateWithPQLFilter),
-		req.StartTime, req.EndTime,
+		startTS, endTS,
 		prom.SVCGranularity, pqlFilter)
 	if err == nil {
 		servicesMap.MergeMetricResults(prom.DOD, prom.ERROR_RATE, avgErrorRateDoD)
@@ -66,14 +106,22 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 
 	avgLogErrorCountDoD, err := s.promRepo.QueryMetricsWithPQLFilter(ctx,
 		prom.DayOnDayTemplate(prom.PQLAvgLogErrorCountCombineEndpointsInfoWithPQLFilter),
-		req.StartTime, req.EndTime,
+		startTS, endTS,
 		prom.SVCGranularity, pqlFilter)
 	if err == nil {
 		servicesMap.MergeMetricResults(prom.DOD, prom.LOG_ERROR_COUNT, avgLogErrorCountDoD)
 	}
 
-	var resp = response.ServiceRYGLightRes{
-		ServiceList: []*response.ServiceRYGResult{},
+	anomalyScores, err := s.anomalyService.ScoreEndpoints(ctx,
+		startTS, endTS,
+		prom.SVCGranularity, pqlFilter)
+	if err == nil {
+		for svcKey, status := range servicesMap.MetricGroupMap {
+			if score, find := anomalyScores[anomaly.EndpointKey{SvcName: svcKey.SvcName}]; find {
+				status.LatencyAnomaly = score.Latency
+				status.ErrorRateAnomaly = score.ErrorRate
+			}
+		}
 	}
 
 	alertEventCount, _ := s.chRepo.GetAlertEventCountGroupByInstance(ctx,
@@ -83,8 +131,9 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 	)
 
 	for svcKey, status := range servicesMap.MetricGroupMap {
-		instances, err := s.promRepo.GetInstanceList(ctx, req.StartTime, req.EndTime, svcKey.SvcName, "")
+		instances, err := s.promRepo.GetInstanceList(ctx, startTS, endTS, svcKey.SvcName, "")
 		if err != nil {
+			delete(servicesMap.MetricGroupMap, svcKey)
 			continue
 		}
 
@@ -93,14 +142,9 @@ func (s *service) GetServicesRYGLightStatus(ctx core.Context, req *request.GetRy
 		if alertEventCount != nil {
 			status.AlertEventLevelCountMap = GroupAlertEventCountListByInstance(alertEventCount, status.Instances)
 		}
-
-		resp.ServiceList = append(resp.ServiceList, &response.ServiceRYGResult{
-			ServiceName: svcKey.SvcName,
-			RYGResult:   *status.ExposeRYGLightStatus(),
-		})
 	}
 
-	return resp, nil
+	return servicesMap, nil
 }
 
 // RYGLightStatus
@@ -115,6 +159,9 @@ type RYGLightStatus struct {
 	ErrorRateDoD     *float64 // Error Rate Day-over-Day Growth Rate
 	LogErrorCountDoD *float64 // log error Day-over-Day Growth Rate
 
+	LatencyAnomaly   *float64 // Latency anomaly score compared with the seasonal baseline
+	ErrorRateAnomaly *float64 // Error Rate anomaly score compared with the seasonal baseline
+
 	Instances []*model.ServiceInstance
 
 	// From Clickhouse
@@ -126,7 +173,15 @@ func (s *RYGLightStatus) ExposeRYGLightStatus() *response.RYGResult {
 	var res = &response.RYGResult{}
 
 	latencyScore := ScoreFromDoD(s.LatencyDoD, 10, 20, 50)
-	if latencyScore >= 0 {
+	if anomalyScore := ScoreFromDoD(s.LatencyAnomaly, 2, 3, 5); anomalyScore >= 0 {
+		// Prefer the seasonal baseline, which is less noisy than Day-over-Day after weekends and releases
+		res.Score += anomalyScore
+		res.ScoreDetail = append(res.ScoreDetail, response.RYGScoreDetail{
+			Key:    "latency",
+			Score:  anomalyScore,
+			Detail: fmt.Sprintf("latency 偏离历史基线 %.2f 倍标准差", *s.LatencyAnomaly),
+		})
+	} else if latencyScore >= 0 {
 		res.Score += latencyScore
 		res.ScoreDetail = append(res.ScoreDetail, response.RYGScoreDetail{
 			Key:    "latency",
@@ -143,7 +198,14 @@ func (s *RYGLightStatus) ExposeRYGLightStatus() *response.RYGResult {
 	}
 
 	errorRateScore := ScoreFromDoD(s.ErrorRateDoD, 5, 10, 20)
-	if errorRateScore >= 0 {
+	if anomalyScore := ScoreFromDoD(s.ErrorRateAnomaly, 2, 3, 5); anomalyScore >= 0 {
+		res.Score += anomalyScore
+		res.ScoreDetail = append(res.ScoreDetail, response.RYGScoreDetail{
+			Key:    "errorRate",
+			Score:  anomalyScore,
+			Detail: fmt.Sprintf("errorRate 偏离历史基线 %.2f 倍标准差", *s.ErrorRateAnomaly),
+		})
+	} else if errorRateScore >= 0 {
 		res.Score += errorRateScore
 		res.ScoreDetail = append(res.ScoreDetail, response.RYGScoreDetail{
 			Key:    "errorRate",
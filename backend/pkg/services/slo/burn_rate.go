@@ -0,0 +1,110 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+const (
+	severityCritical = "critical"
+	severityWarning  = "warning"
+)
+
+// burnRateWindow is a pair of windows used by multi-window burn-rate alerts.
+// Alert fires when both windows consume the error budget faster than
+// BudgetConsumed of the whole budget in the Long window.
+type burnRateWindow struct {
+	Long           time.Duration
+	Short          time.Duration
+	BudgetConsumed float64
+	Severity       string
+}
+
+var burnRateWindows = []burnRateWindow{
+	{Long: time.Hour, Short: 5 * time.Minute, BudgetConsumed: 0.02, Severity: severityCritical},
+	{Long: 6 * time.Hour, Short: 30 * time.Minute, BudgetConsumed: 0.05, Severity: severityCritical},
+	{Long: 24 * time.Hour, Short: 2 * time.Hour, BudgetConsumed: 0.1, Severity: severityWarning},
+	{Long: 72 * time.Hour, Short: 6 * time.Hour, BudgetConsumed: 0.1, Severity: severityWarning},
+}
+
+// burnRateFactor returns the burn rate which consumes BudgetConsumed of the budget in the Long window,
+// e.g. 14.4 for 2% budget in 1h of a 30 days SLO.
+func burnRateFactor(w burnRateWindow, windowDays int) float64 {
+	compliance := time.Duration(windowDays) * 24 * time.Hour
+	return w.BudgetConsumed * float64(compliance) / float64(w.Long)
+}
+
+// errorBudget returns the ratio of bad requests allowed by the SLO.
+func errorBudget(slo *database.SLO) float64 {
+	return 1 - slo.Target/100
+}
+
+func errorRatioTemplate(slo *database.SLO, promRange string) prometheus.PQLTemplate {
+	if slo.Type == database.SLOTypeLatency {
+		return prometheus.PQLLatencyErrorRatio(int64(slo.LatencyThreshold*float64(time.Millisecond)), promRange)
+	}
+	return prometheus.PQLAvailabilityErrorRatio
+}
+
+func sloFilter(slo *database.SLO) prometheus.PQLFilter {
+	return prometheus.EqualFilter(prometheus.ServiceNameKey, slo.ServiceName).
+		Equal(prometheus.ContentKeyKey, slo.Endpoint)
+}
+
+func errorRatioPQL(slo *database.SLO, promRange string, window time.Duration) string {
+	tpl := errorRatioTemplate(slo, promRange)
+	return tpl(prometheus.VecFromDuration(window), string(prometheus.EndpointGranularity), sloFilter(slo), "")
+}
+
+func sloAlertName(sloName string, severity string) string {
+	return fmt.Sprintf("SLO-%s-burn-rate-%s", sloName, severity)
+}
+
+// buildBurnRateRules generates one alert rule per severity,
+// window pairs of the same severity are combined with 'or'.
+func buildBurnRateRules(slo *database.SLO, promRange string) []request.AlertRule {
+	budget := errorBudget(slo)
+
+	var rules []request.AlertRule
+	for _, severity := range []string{severityCritical, severityWarning} {
+		var conditions []string
+		for _, w := range burnRateWindows {
+			if w.Severity != severity {
+				continue
+			}
+			threshold := strconv.FormatFloat(burnRateFactor(w, slo.WindowDays)*budget, 'g', 6, 64)
+			conditions = append(conditions, fmt.Sprintf("((%s) > %s and (%s) > %s)",
+				errorRatioPQL(slo, promRange, w.Long), threshold,
+				errorRatioPQL(slo, promRange, w.Short), threshold,
+			))
+		}
+
+		rules = append(rules, request.AlertRule{
+			Group: kubernetes.AppLabelVal,
+			Alert: sloAlertName(slo.Name, severity),
+			Expr:  strings.Join(conditions, " or "),
+			For:   "2m",
+			Labels: map[string]string{
+				"group":    kubernetes.AppLabelKey,
+				"severity": severity,
+				"slo":      slo.Name,
+			},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("SLO %s is burning error budget too fast", slo.Name),
+				"description": fmt.Sprintf("Endpoint %s of service %s consumes the error budget of SLO %s (target %g%% in %d days) too fast.",
+					slo.Endpoint, slo.ServiceName, slo.Name, slo.Target, slo.WindowDays),
+			},
+		})
+	}
+	return rules
+}
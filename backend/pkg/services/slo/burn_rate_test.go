@@ -0,0 +1,58 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+)
+
+func TestBurnRateFactor(t *testing.T) {
+	expected := []float64{14.4, 6, 3, 1}
+	for i, w := range burnRateWindows {
+		if got := burnRateFactor(w, 30); got < expected[i]-1e-9 || got > expected[i]+1e-9 {
+			t.Errorf("window %v: expected factor %v, got %v", w.Long, expected[i], got)
+		}
+	}
+}
+
+func TestBuildBurnRateRules(t *testing.T) {
+	slo := &database.SLO{
+		Name:        "checkout",
+		ServiceName: "shop",
+		Endpoint:    "POST /checkout",
+		Type:        database.SLOTypeAvailability,
+		Target:      99.9,
+		WindowDays:  30,
+	}
+	rules := buildBurnRateRules(slo, "vmrange")
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+
+	critical := rules[0]
+	if critical.Alert != "SLO-checkout-burn-rate-critical" || critical.Labels["severity"] != severityCritical {
+		t.Errorf("unexpected critical rule: %+v", critical)
+	}
+	for _, s := range []string{"[60m]", "[5m]", "[360m]", "[30m]", "> 0.0144 and", "> 0.006)", `content_key="POST /checkout"`} {
+		if !strings.Contains(critical.Expr, s) {
+			t.Errorf("expected %q in critical rule expr: %s", s, critical.Expr)
+		}
+	}
+
+	latencySLO := *slo
+	latencySLO.Type = database.SLOTypeLatency
+	latencySLO.LatencyThreshold = 500
+	rules = buildBurnRateRules(&latencySLO, "vmrange")
+	if !strings.Contains(rules[0].Expr, "histogram_share(500000000,") {
+		t.Errorf("expected latency threshold in ns: %s", rules[0].Expr)
+	}
+
+	rules = buildBurnRateRules(&latencySLO, "le")
+	if !strings.Contains(rules[0].Expr, `le=~"500000000|5e\\+08"`) {
+		t.Errorf("expected le matching both formats of the boundary: %s", rules[0].Expr)
+	}
+}
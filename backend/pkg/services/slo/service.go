@@ -0,0 +1,41 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+var _ Service = (*service)(nil)
+
+type Service interface {
+	// CreateSLO saves the SLO and generates its burn-rate alert rules if alert is enabled.
+	CreateSLO(ctx core.Context, req *request.CreateSLORequest) error
+	// UpdateSLO updates the SLO and regenerates its burn-rate alert rules.
+	UpdateSLO(ctx core.Context, req *request.UpdateSLORequest) error
+	// DeleteSLO removes the SLO together with its burn-rate alert rules.
+	DeleteSLO(ctx core.Context, req *request.DeleteSLORequest) error
+	ListSLO(ctx core.Context, req *request.ListSLORequest) (*response.ListSLOResponse, error)
+	// GetSLOStatus computes the SLI, error budget remaining and multi-window burn rates of the SLO.
+	GetSLOStatus(ctx core.Context, req *request.GetSLOStatusRequest) (*response.GetSLOStatusResponse, error)
+}
+
+type service struct {
+	promRepo prometheus.Repo
+	dbRepo   database.Repo
+	k8sApi   kubernetes.Repo
+}
+
+func New(promRepo prometheus.Repo, dbRepo database.Repo, k8sApi kubernetes.Repo) Service {
+	return &service{
+		promRepo: promRepo,
+		dbRepo:   dbRepo,
+		k8sApi:   k8sApi,
+	}
+}
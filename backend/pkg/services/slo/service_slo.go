@@ -0,0 +1,198 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+func (s *service) CreateSLO(ctx core.Context, req *request.CreateSLORequest) error {
+	slo := toSLO(req)
+	if err := validateSLO(slo); err != nil {
+		return err
+	}
+	if err := s.checkLatencyBucket(ctx, slo); err != nil {
+		return err
+	}
+
+	return s.saveWithAlertRules(ctx, nil, slo, func(txCtx core.Context) error {
+		return s.dbRepo.CreateSLO(txCtx, slo)
+	})
+}
+
+func (s *service) UpdateSLO(ctx core.Context, req *request.UpdateSLORequest) error {
+	slo := toSLO(&req.CreateSLORequest)
+	slo.ID = req.ID
+	if err := validateSLO(slo); err != nil {
+		return err
+	}
+	if err := s.checkLatencyBucket(ctx, slo); err != nil {
+		return err
+	}
+
+	oldSLO, err := s.dbRepo.GetSLO(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if oldSLO == nil {
+		return core.Error(code.SLONotExistError, "slo not exists")
+	}
+
+	return s.saveWithAlertRules(ctx, oldSLO, slo, func(txCtx core.Context) error {
+		return s.dbRepo.UpdateSLO(txCtx, slo)
+	})
+}
+
+func (s *service) DeleteSLO(ctx core.Context, req *request.DeleteSLORequest) error {
+	oldSLO, err := s.dbRepo.GetSLO(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if oldSLO == nil {
+		return nil
+	}
+
+	return s.saveWithAlertRules(ctx, oldSLO, nil, func(txCtx core.Context) error {
+		return s.dbRepo.DeleteSLO(txCtx, req.ID)
+	})
+}
+
+// checkLatencyBucket checks the latency threshold equals to a le boundary of the duration buckets,
+// as Prometheus can not compute the ratio of requests slower than a threshold between two boundaries.
+func (s *service) checkLatencyBucket(ctx core.Context, slo *database.SLO) error {
+	if slo.Type != database.SLOTypeLatency || s.promRepo.GetRange() == "vmrange" {
+		return nil
+	}
+
+	endTime := time.Now()
+	values, err := s.promRepo.LabelValues(ctx, string(prometheus.SPAN_TRACE_DURATION_BUCKET), "le",
+		endTime.Add(-24*time.Hour).UnixMicro(), endTime.UnixMicro())
+	if err != nil {
+		return err
+	}
+
+	thresholdNs := float64(int64(slo.LatencyThreshold * float64(time.Millisecond)))
+	var boundaries []float64
+	for _, value := range values {
+		le, err := strconv.ParseFloat(string(value), 64)
+		if err != nil || math.IsInf(le, 1) {
+			continue
+		}
+		if le == thresholdNs {
+			return nil
+		}
+		boundaries = append(boundaries, le)
+	}
+	if len(boundaries) == 0 {
+		return core.Error(code.SLOIllegalError, "no latency bucket is found, latency slo is not supported")
+	}
+
+	sort.Float64s(boundaries)
+	boundariesMs := make([]string, 0, len(boundaries))
+	for _, le := range boundaries {
+		boundariesMs = append(boundariesMs, strconv.FormatFloat(le/float64(time.Millisecond), 'f', -1, 64))
+	}
+	return core.Error(code.SLOIllegalError, fmt.Sprintf("latencyThreshold must be one of the bucket boundaries (ms): %s", strings.Join(boundariesMs, ", ")))
+}
+
+func (s *service) ListSLO(ctx core.Context, req *request.ListSLORequest) (*response.ListSLOResponse, error) {
+	slos, err := s.dbRepo.ListSLO(ctx, req.ServiceName, req.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListSLOResponse{SLOs: slos}, nil
+}
+
+// saveWithAlertRules saves the change of the slo and syncs its alert rules in a transaction.
+// If the rules fail to sync, the change is rolled back and the rules of oldSLO are restored.
+func (s *service) saveWithAlertRules(ctx core.Context, oldSLO *database.SLO, newSLO *database.SLO, save func(txCtx core.Context) error) error {
+	return s.dbRepo.Transaction(ctx, save, func(txCtx core.Context) error {
+		err := s.syncAlertRules(oldSLO, newSLO)
+		if err == nil {
+			return nil
+		}
+		// the rules of newSLO may be partially added, and the rules of oldSLO partially removed
+		restoreErr := s.syncAlertRules(newSLO, nil)
+		if restoreErr == nil {
+			restoreErr = s.syncAlertRules(oldSLO, oldSLO)
+		}
+		if restoreErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to restore the alert rules: %w", restoreErr))
+		}
+		return err
+	})
+}
+
+// syncAlertRules removes the burn-rate alert rules of oldSLO and generates the rules of newSLO.
+// Either of them can be nil.
+func (s *service) syncAlertRules(oldSLO *database.SLO, newSLO *database.SLO) error {
+	if oldSLO != nil && oldSLO.AlertEnabled {
+		for _, severity := range []string{severityCritical, severityWarning} {
+			err := s.k8sApi.DeleteAlertRule(kubernetes.DefaultClusterID, "", kubernetes.AppLabelVal, sloAlertName(oldSLO.Name, severity))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if newSLO == nil || !newSLO.AlertEnabled {
+		return nil
+	}
+	for _, rule := range buildBurnRateRules(newSLO, s.promRepo.GetRange()) {
+		if err := s.k8sApi.AddAlertRule(kubernetes.DefaultClusterID, "", rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toSLO(req *request.CreateSLORequest) *database.SLO {
+	return &database.SLO{
+		Name:             req.Name,
+		ServiceName:      req.ServiceName,
+		Endpoint:         req.Endpoint,
+		Type:             req.Type,
+		Target:           req.Target,
+		LatencyThreshold: req.LatencyThreshold,
+		WindowDays:       req.WindowDays,
+		AlertEnabled:     req.AlertEnabled,
+	}
+}
+
+func validateSLO(slo *database.SLO) error {
+	switch slo.Type {
+	case database.SLOTypeAvailability:
+	case database.SLOTypeLatency:
+		if slo.LatencyThreshold <= 0 {
+			return core.Error(code.SLOIllegalError, "latencyThreshold must be greater than 0 for latency slo")
+		}
+	default:
+		return core.Error(code.SLOIllegalError, "type must be availability or latency")
+	}
+
+	if slo.Target <= 0 || slo.Target >= 100 {
+		return core.Error(code.SLOIllegalError, "target must be between 0 and 100")
+	}
+
+	switch slo.WindowDays {
+	case 7, 28, 30:
+	default:
+		return core.Error(code.SLOIllegalError, "windowDays must be one of 7, 28, 30")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/kubernetes"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+	prommodel "github.com/prometheus/common/model"
+)
+
+// sloDBRepo runs the transaction on a copy of the slos, which are kept only if all functions succeed.
+type sloDBRepo struct {
+	database.Repo
+	slos    map[string]*database.SLO
+	pending map[string]*database.SLO
+}
+
+func (r *sloDBRepo) Transaction(ctx core.Context, funcs ...func(txCtx core.Context) error) error {
+	r.pending = map[string]*database.SLO{}
+	for name, slo := range r.slos {
+		r.pending[name] = slo
+	}
+	for _, f := range funcs {
+		if err := f(ctx); err != nil {
+			return err
+		}
+	}
+	r.slos = r.pending
+	return nil
+}
+
+func (r *sloDBRepo) CreateSLO(_ core.Context, slo *database.SLO) error {
+	r.pending[slo.Name] = slo
+	return nil
+}
+
+// sloK8sRepo fails to add the warning rules.
+type sloK8sRepo struct {
+	kubernetes.Repo
+	rules map[string]struct{}
+}
+
+func (r *sloK8sRepo) AddAlertRule(_ string, _ string, rule request.AlertRule) error {
+	if rule.Alert == sloAlertName("checkout", severityWarning) {
+		return errors.New("configmap is not writable")
+	}
+	r.rules[rule.Alert] = struct{}{}
+	return nil
+}
+
+func (r *sloK8sRepo) DeleteAlertRule(_ string, _ string, _ string, alert string) error {
+	delete(r.rules, alert)
+	return nil
+}
+
+type sloPromRepo struct {
+	prometheus.Repo
+}
+
+func (sloPromRepo) GetRange() string {
+	return "vmrange"
+}
+
+// sloLEPromRepo uses le buckets, which are formatted as floats.
+type sloLEPromRepo struct {
+	prometheus.Repo
+}
+
+func (sloLEPromRepo) GetRange() string {
+	return "le"
+}
+
+func (sloLEPromRepo) LabelValues(_ core.Context, _ string, _ string, _, _ int64) (prommodel.LabelValues, error) {
+	return prommodel.LabelValues{"1e+08", "5e+08", "1e+09", "+Inf"}, nil
+}
+
+func TestCheckLatencyBucket(t *testing.T) {
+	s := &service{promRepo: sloLEPromRepo{}}
+	slo := &database.SLO{Type: database.SLOTypeLatency, LatencyThreshold: 500}
+	if err := s.checkLatencyBucket(core.EmptyCtx(), slo); err != nil {
+		t.Errorf("unexpected error for a bucket boundary: %v", err)
+	}
+
+	slo.LatencyThreshold = 300
+	err := s.checkLatencyBucket(core.EmptyCtx(), slo)
+	if err == nil || !strings.Contains(err.Error(), "100, 500, 1000") {
+		t.Errorf("expected error listing the boundaries, got %v", err)
+	}
+
+	s.promRepo = sloPromRepo{}
+	if err := s.checkLatencyBucket(core.EmptyCtx(), slo); err != nil {
+		t.Errorf("unexpected error for vmrange: %v", err)
+	}
+}
+
+func TestCreateSLORollback(t *testing.T) {
+	dbRepo := &sloDBRepo{slos: map[string]*database.SLO{}}
+	k8sRepo := &sloK8sRepo{rules: map[string]struct{}{}}
+	s := &service{promRepo: sloPromRepo{}, dbRepo: dbRepo, k8sApi: k8sRepo}
+
+	err := s.CreateSLO(core.EmptyCtx(), &request.CreateSLORequest{
+		Name:         "checkout",
+		ServiceName:  "shop",
+		Endpoint:     "POST /checkout",
+		Type:         database.SLOTypeAvailability,
+		Target:       99.9,
+		WindowDays:   30,
+		AlertEnabled: true,
+	})
+	if err == nil {
+		t.Fatal("expected the failure of the alert rules")
+	}
+	if len(dbRepo.slos) != 0 {
+		t.Errorf("slos = %v", dbRepo.slos)
+	}
+	if len(k8sRepo.rules) != 0 {
+		t.Errorf("rules = %v", k8sRepo.rules)
+	}
+}
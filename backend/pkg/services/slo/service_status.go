@@ -0,0 +1,90 @@
+// Copyright 2025 CloudDetail
+// SPDX-License-Identifier: Apache-2.0
+
+package slo
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/CloudDetail/apo/backend/pkg/code"
+	core "github.com/CloudDetail/apo/backend/pkg/core"
+	"github.com/CloudDetail/apo/backend/pkg/model/request"
+	"github.com/CloudDetail/apo/backend/pkg/model/response"
+	"github.com/CloudDetail/apo/backend/pkg/repository/database"
+	"github.com/CloudDetail/apo/backend/pkg/repository/prometheus"
+)
+
+func (s *service) GetSLOStatus(ctx core.Context, req *request.GetSLOStatusRequest) (*response.GetSLOStatusResponse, error) {
+	slo, err := s.dbRepo.GetSLO(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if slo == nil {
+		return nil, core.Error(code.SLONotExistError, "slo not exists")
+	}
+
+	now := time.Now()
+	promRange := s.promRepo.GetRange()
+	budget := errorBudget(slo)
+	resp := &response.GetSLOStatusResponse{SLO: *slo}
+
+	errRatio, err := s.queryErrorRatio(ctx, slo, promRange, time.Duration(slo.WindowDays)*24*time.Hour, now)
+	if err != nil {
+		return nil, err
+	}
+	if errRatio != nil {
+		sli := (1 - *errRatio) * 100
+		remaining := 1 - *errRatio/budget
+		resp.SLI = &sli
+		resp.ErrorBudgetRemaining = &remaining
+	}
+
+	for _, window := range statusWindows() {
+		errRatio, err := s.queryErrorRatio(ctx, slo, promRange, window, now)
+		if err != nil {
+			return nil, err
+		}
+		burnRate := response.SLOBurnRate{Window: prometheus.VecFromDuration(window)}
+		if errRatio != nil {
+			rate := *errRatio / budget
+			burnRate.BurnRate = &rate
+		}
+		resp.BurnRates = append(resp.BurnRates, burnRate)
+	}
+	return resp, nil
+}
+
+// queryErrorRatio returns nil if there is no request in the window.
+func (s *service) queryErrorRatio(ctx core.Context, slo *database.SLO, promRange string, window time.Duration, now time.Time) (*float64, error) {
+	results, err := s.promRepo.QueryData(ctx, now, errorRatioPQL(slo, promRange, window))
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 || len(results[0].Values) == 0 {
+		return nil, nil
+	}
+	value := results[0].Values[0].Value
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+// statusWindows returns all windows used by burn-rate alerts in ascending order.
+func statusWindows() []time.Duration {
+	windowSet := map[time.Duration]struct{}{}
+	for _, w := range burnRateWindows {
+		windowSet[w.Long] = struct{}{}
+		windowSet[w.Short] = struct{}{}
+	}
+	windows := make([]time.Duration, 0, len(windowSet))
+	for window := range windowSet {
+		windows = append(windows, window)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i] < windows[j]
+	})
+	return windows
+}
@@ -0,0 +1,38 @@
+/**
+ * Copyright 2025 CloudDetail
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// 日志查询语法，例如 level:error AND pod:"order-7d9f"，语法见后端 log_query_parser.go
+
+/**
+ * 将值转为短语，转义其中的反斜杠和双引号
+ * @param {any} value
+ * @returns {string}
+ */
+export const quoteLogQueryValue = (value) =>
+  '"' + String(value).replace(/\\/g, '\\\\').replace(/"/g, '\\"') + '"'
+
+/**
+ * 字段等于值的查询条件，例如 pod:"order-7d9f"
+ * @param {string} field - 字段名
+ * @param {any} value - 字段值
+ * @returns {string}
+ */
+export const fieldQueryTerm = (field, value) => field + ':' + quoteLogQueryValue(value)
+
+/**
+ * 以 AND 追加查询条件，已包含该条件时返回原查询
+ * @param {string} query - 当前查询
+ * @param {string} term - 追加的条件
+ * @returns {string}
+ */
+export const appendLogQueryTerm = (query = '', term) => {
+  if (query.includes(term)) {
+    return query
+  }
+  if (query.trim().length === 0) {
+    return term
+  }
+  return query + ' AND ' + term
+}
@@ -10,6 +10,7 @@ import { useSearchParams } from 'react-router-dom'
 import { useLogsContext } from 'src/core/contexts/LogsContext'
 import { selectProcessedTimeRange } from 'src/core/store/reducers/timeRangeReducer'
 import { ISOToTimestamp } from 'src/core/utils/time'
+import { appendLogQueryTerm, fieldQueryTerm } from 'src/core/utils/logQuery'
 
 const IndexCollapseItem = ({ field }) => {
   const {
@@ -27,14 +28,9 @@ const IndexCollapseItem = ({ field }) => {
   const [loading, setLoading] = useState(false)
 
   const clickIndex = (index) => {
-    let newQueryPart = '`' + field + '` = ' + "'" + index.indexName + "'"
-    // 检查 query 是否已经包含 newQueryPart
-    if (!query.includes(newQueryPart)) {
-      let newQuery = query
-      if (newQuery.length > 0) {
-        newQuery += ' AND '
-      }
-      newQuery += newQueryPart
+    const newQuery = appendLogQueryTerm(query, fieldQueryTerm(field, index.indexName))
+    // 已经包含该条件时不更新
+    if (newQuery !== query) {
       updateQuery(newQuery) // 更新查询
     }
   }
@@ -7,34 +7,16 @@ import { Dropdown } from 'antd'
 import React from 'react'
 import { copyValue } from 'src/core/components/CopyButton'
 import { useLogsContext } from 'src/core/contexts/LogsContext'
+import { appendLogQueryTerm, fieldQueryTerm } from 'src/core/utils/logQuery'
 import { useTranslation } from 'react-i18next' // 引入i18n
 
 const LogTagDropDown = ({ objKey, value, children, trigger = ['click', 'contextMenu'] }) => {
   const { t } = useTranslation('oss/fullLogs')
   const { query, updateQuery } = useLogsContext()
   const addToQuery = () => {
-    let newQueryPart
-    switch (typeof value) {
-      case 'string':
-        newQueryPart = '`' + objKey + '` = ' + "'" + value + "'"
-        break
-      case 'number':
-        newQueryPart = '`' + objKey + '` = ' + value
-        break
-      case 'boolean':
-        newQueryPart = '`' + objKey + '` = ' + value
-        break
-      default:
-        newQueryPart = '`' + objKey + '` = -' + "'" + value + "'"
-        break
-    }
-    // 检查 query 是否已经包含 newQueryPart
-    if (!query.includes(newQueryPart)) {
-      let newQuery = query
-      if (newQuery.length > 0) {
-        newQuery += ' AND '
-      }
-      newQuery += newQueryPart
+    const newQuery = appendLogQueryTerm(query, fieldQueryTerm(objKey, value))
+    // 已经包含该条件时不更新
+    if (newQuery !== query) {
       updateQuery(newQuery) // 更新查询
     }
   }
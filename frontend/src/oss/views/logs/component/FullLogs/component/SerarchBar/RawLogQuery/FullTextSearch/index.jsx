@@ -6,6 +6,7 @@
 import { Button, Col, Form, Input, Popover, Row, Space } from 'antd'
 import React, { useEffect, useState } from 'react'
 import { useLogsContext } from 'src/core/contexts/LogsContext'
+import { appendLogQueryTerm, quoteLogQueryValue } from 'src/core/utils/logQuery'
 import { useTranslation } from 'react-i18next' // 引入i18n
 
 const FullTextSearch = () => {
@@ -15,11 +16,8 @@ const FullTextSearch = () => {
   const [inputValue, setInputValue] = useState()
 
   const clickSubmit = () => {
-    let newQuery = searchValue
-    if (newQuery.length > 0) {
-      newQuery += ' AND '
-    }
-    newQuery += '`content` LIKE ' + `'%` + inputValue + `%'`
+    // 短语检索日志内容
+    const newQuery = appendLogQueryTerm(searchValue, quoteLogQueryValue(inputValue))
     setSearchValue(newQuery)
     updateQuery(newQuery)
   }